@@ -0,0 +1,25 @@
+package bo
+
+import (
+	im_v1 "github.com/xinghe903/chatify/api/im/v1"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// PartitionKey 上行消息的Kafka分区键
+// 同一会话的消息落在同一分区，保证logic按发送顺序处理，单聊双方得到相同的值
+// 控制指令按其作用的会话分区，其余消息按发送者分区
+func PartitionKey(message *im_v1.BaseMessage) string {
+	switch message.MessageType {
+	case im_v1.MessageType_CHAT:
+		if message.TargetType == im_v1.TargetType_USER || message.TargetType == im_v1.TargetType_GROUP {
+			return im_v1.ConversationKey(message.TargetType, message.FromUserId, message.ToUserId)
+		}
+	case im_v1.MessageType_CONTROL:
+		var cmd im_v1.ControlCommand
+		if err := protojson.Unmarshal(message.Content, &cmd); err == nil && cmd.ConversationId != "" {
+			return im_v1.ConversationKey(cmd.ConversationType, message.FromUserId, cmd.ConversationId)
+		}
+	}
+	return "user:" + message.FromUserId
+}
@@ -0,0 +1,50 @@
+package bo
+
+import (
+	"testing"
+
+	im_v1 "github.com/xinghe903/chatify/api/im/v1"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func controlMessage(t *testing.T, from string, cmd *im_v1.ControlCommand) *im_v1.BaseMessage {
+	t.Helper()
+	content, err := protojson.Marshal(cmd)
+	if err != nil {
+		t.Fatalf("marshal control command: %v", err)
+	}
+	return &im_v1.BaseMessage{MessageType: im_v1.MessageType_CONTROL, FromUserId: from, Content: content}
+}
+
+// TestPartitionKey 同一会话的聊天消息和控制指令落在同一分区，单聊双方方向无关
+func TestPartitionKey(t *testing.T) {
+	chat := func(targetType im_v1.TargetType, from, to string) *im_v1.BaseMessage {
+		return &im_v1.BaseMessage{MessageType: im_v1.MessageType_CHAT, TargetType: targetType, FromUserId: from, ToUserId: to}
+	}
+	tests := []struct {
+		name    string
+		message *im_v1.BaseMessage
+		want    string
+	}{
+		{"single chat", chat(im_v1.TargetType_USER, "alice", "bob"), "u:alice:bob"},
+		{"single chat reply", chat(im_v1.TargetType_USER, "bob", "alice"), "u:alice:bob"},
+		{"group chat", chat(im_v1.TargetType_GROUP, "alice", "g1"), "g:g1"},
+		{"recall in single chat", controlMessage(t, "bob", &im_v1.ControlCommand{
+			ConversationType: im_v1.TargetType_USER, ConversationId: "alice",
+		}), "u:alice:bob"},
+		{"read receipt in group", controlMessage(t, "carol", &im_v1.ControlCommand{
+			ConversationType: im_v1.TargetType_GROUP, ConversationId: "g1",
+		}), "g:g1"},
+		{"control without conversation", controlMessage(t, "carol", &im_v1.ControlCommand{}), "user:carol"},
+		{"chat to system", chat(im_v1.TargetType_SYSTEM, "alice", "bob"), "user:alice"},
+		{"data report", &im_v1.BaseMessage{MessageType: im_v1.MessageType_DATAREPORT, FromUserId: "alice"}, "user:alice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PartitionKey(tt.message); got != tt.want {
+				t.Fatalf("PartitionKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -45,7 +45,9 @@ func NewKafkaProducer(cb *conf.Bootstrap, logger log.Logger) (biz.MqProducer, fu
 	config.Producer.Return.Errors = true               // 返回错误消息
 	config.Producer.Timeout = c.Timeout.AsDuration()   // 超时时间
 	config.Producer.Compression = sarama.CompressionSnappy
-	config.Producer.Partitioner = sarama.NewRandomPartitioner
+	// 相同key的消息写入同一分区，保证分区内有序
+	config.Producer.Partitioner = sarama.NewHashPartitioner
+	config.Net.MaxOpenRequests = 1 // 重试时不会打乱同一分区内的发送顺序
 	fmt.Printf("Kafka brokers: %v\n", c.Brokers)
 	// 创建异步生产者
 	producer, err := sarama.NewAsyncProducer(c.Brokers, config)
@@ -100,7 +102,8 @@ func (p *KafkaProducer) SendMessageWithUserState(ctx context.Context, message *b
 	if err != nil {
 		return fmt.Errorf("marshal message error: %w", err)
 	}
-	return p.SendMessage(ctx, KafkaTopicUserState, data)
+	// 同一用户的上下线事件按顺序处理
+	return p.SendMessage(ctx, KafkaTopicUserState, message.UserID, data)
 }
 
 func (p *KafkaProducer) SendMessageWithUserMessage(ctx context.Context, message *im_v1.BaseMessage) error {
@@ -109,24 +112,27 @@ func (p *KafkaProducer) SendMessageWithUserMessage(ctx context.Context, message
 	if err != nil {
 		return fmt.Errorf("marshal message error: %w", err)
 	}
-	return p.SendMessage(ctx, KafkaTopicUserMessage, data)
+	return p.SendMessage(ctx, KafkaTopicUserMessage, bo.PartitionKey(message), data)
 }
 
 // SendMessage 发送消息到Kafka
 // @param ctx context.Context 上下文
 // @param topic string Kafka主题
+// @param key string 分区键，为空时生成随机key，消息均匀分布到各分区
 // @param data []byte 消息数据
 // @return error 错误信息
-func (p *KafkaProducer) SendMessage(ctx context.Context, topic string, data []byte) error {
+func (p *KafkaProducer) SendMessage(ctx context.Context, topic, key string, data []byte) error {
 	if p.producer == nil {
 		return fmt.Errorf("kafka producer not initialized")
 	}
-	// 生成消息ID作为Key，用于防止重复消费
-	// 使用Sonyflake分布式ID生成器，确保全局唯一
-	msgID, err := p.snowflake.GenerateBase62()
-	if err != nil {
-		p.log.WithContext(ctx).Errorf("Generate message ID error: %v", err)
-		return fmt.Errorf("generate message ID error: %w", err)
+	if key == "" {
+		// 使用Sonyflake分布式ID生成器，确保全局唯一
+		msgID, err := p.snowflake.GenerateBase62()
+		if err != nil {
+			p.log.WithContext(ctx).Errorf("Generate message ID error: %v", err)
+			return fmt.Errorf("generate message ID error: %w", err)
+		}
+		key = msgID
 	}
 	// 导入OpenTelemetry的propagation
 	headers := make([]sarama.RecordHeader, 0)
@@ -141,10 +147,10 @@ func (p *KafkaProducer) SendMessage(ctx context.Context, topic string, data []by
 			})
 		}
 	}
-	// 创建Sarama消息，设置Key为消息ID
+	// 创建Sarama消息，按Key哈希选择分区
 	saramaMsg := &sarama.ProducerMessage{
 		Topic:   topic,
-		Key:     sarama.StringEncoder(msgID),
+		Key:     sarama.StringEncoder(key),
 		Value:   sarama.ByteEncoder(data),
 		Headers: headers,
 	}
@@ -109,6 +109,9 @@ func (s *AccessService) Dispatch(ctx context.Context, userId string, data []byte
 		s.connManager.SendToUser(ctx, userId, []byte(v1.ErrorInvalidMessage("json unmarshal error: %v", err).Error()))
 		return
 	}
+	// 序号由logic分配，发送者为连接的登录用户，忽略客户端传入的值
+	message.Seq = 0
+	message.FromUserId = userId
 	if err := s.dispatchMsg.DispatchMessage(ctx, &message); err != nil {
 		s.log.WithContext(ctx).Warnf("dispatch error: %v", err)
 		s.connManager.SendToUser(ctx, userId, []byte(err.Error()))
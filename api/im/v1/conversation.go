@@ -0,0 +1,13 @@
+package v1
+
+// ConversationKey 会话的唯一标识，单聊双方得到相同的值
+// access按它选择Kafka分区，logic按它分配会话序号，两处必须一致
+func ConversationKey(conversationType TargetType, userId, conversationId string) string {
+	if conversationType == TargetType_GROUP {
+		return "g:" + conversationId
+	}
+	if userId > conversationId {
+		userId, conversationId = conversationId, userId
+	}
+	return "u:" + userId + ":" + conversationId
+}
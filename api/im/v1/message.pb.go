@@ -73,6 +73,124 @@ func (MessageType) EnumDescriptor() ([]byte, []int) {
 	return file_im_v1_message_proto_rawDescGZIP(), []int{0}
 }
 
+// 聊天消息的内容类型，取值与 MessageContent 的 body 对应
+type ContentType int32
+
+const (
+	ContentType_CONTENT_TYPE_UNSPECIFIED ContentType = 0  // 非聊天消息或升级前保存的消息
+	ContentType_TEXT                     ContentType = 1  // 文本，包括旧客户端发送的纯文本
+	ContentType_IMAGE                    ContentType = 2  // 图片
+	ContentType_VOICE                    ContentType = 3  // 语音
+	ContentType_VIDEO                    ContentType = 4  // 视频
+	ContentType_FILE                     ContentType = 5  // 文件
+	ContentType_LOCATION                 ContentType = 6  // 位置
+	ContentType_CUSTOM                   ContentType = 10 // 自定义
+)
+
+// Enum value maps for ContentType.
+var (
+	ContentType_name = map[int32]string{
+		0:  "CONTENT_TYPE_UNSPECIFIED",
+		1:  "TEXT",
+		2:  "IMAGE",
+		3:  "VOICE",
+		4:  "VIDEO",
+		5:  "FILE",
+		6:  "LOCATION",
+		10: "CUSTOM",
+	}
+	ContentType_value = map[string]int32{
+		"CONTENT_TYPE_UNSPECIFIED": 0,
+		"TEXT":                     1,
+		"IMAGE":                    2,
+		"VOICE":                    3,
+		"VIDEO":                    4,
+		"FILE":                     5,
+		"LOCATION":                 6,
+		"CUSTOM":                   10,
+	}
+)
+
+func (x ContentType) Enum() *ContentType {
+	p := new(ContentType)
+	*p = x
+	return p
+}
+
+func (x ContentType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ContentType) Descriptor() protoreflect.EnumDescriptor {
+	return file_im_v1_message_proto_enumTypes[1].Descriptor()
+}
+
+func (ContentType) Type() protoreflect.EnumType {
+	return &file_im_v1_message_proto_enumTypes[1]
+}
+
+func (x ContentType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ContentType.Descriptor instead.
+func (ContentType) EnumDescriptor() ([]byte, []int) {
+	return file_im_v1_message_proto_rawDescGZIP(), []int{1}
+}
+
+// 消息投递优先级，push按优先级分队列投递，批量发送不阻塞高优先级消息
+type Priority int32
+
+const (
+	Priority_PRIORITY_UNSPECIFIED Priority = 0 // 由push按消息类型推断
+	Priority_PRIORITY_HIGH        Priority = 1 // 聊天、控制消息和面向单个用户的通知
+	Priority_PRIORITY_NORMAL      Priority = 2 // 一般系统推送
+	Priority_PRIORITY_LOW         Priority = 3 // 公告等批量推送
+)
+
+// Enum value maps for Priority.
+var (
+	Priority_name = map[int32]string{
+		0: "PRIORITY_UNSPECIFIED",
+		1: "PRIORITY_HIGH",
+		2: "PRIORITY_NORMAL",
+		3: "PRIORITY_LOW",
+	}
+	Priority_value = map[string]int32{
+		"PRIORITY_UNSPECIFIED": 0,
+		"PRIORITY_HIGH":        1,
+		"PRIORITY_NORMAL":      2,
+		"PRIORITY_LOW":         3,
+	}
+)
+
+func (x Priority) Enum() *Priority {
+	p := new(Priority)
+	*p = x
+	return p
+}
+
+func (x Priority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Priority) Descriptor() protoreflect.EnumDescriptor {
+	return file_im_v1_message_proto_enumTypes[2].Descriptor()
+}
+
+func (Priority) Type() protoreflect.EnumType {
+	return &file_im_v1_message_proto_enumTypes[2]
+}
+
+func (x Priority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Priority.Descriptor instead.
+func (Priority) EnumDescriptor() ([]byte, []int) {
+	return file_im_v1_message_proto_rawDescGZIP(), []int{2}
+}
+
 // 目标类型枚举
 type TargetType int32
 
@@ -110,11 +228,11 @@ func (x TargetType) String() string {
 }
 
 func (TargetType) Descriptor() protoreflect.EnumDescriptor {
-	return file_im_v1_message_proto_enumTypes[1].Descriptor()
+	return file_im_v1_message_proto_enumTypes[3].Descriptor()
 }
 
 func (TargetType) Type() protoreflect.EnumType {
-	return &file_im_v1_message_proto_enumTypes[1]
+	return &file_im_v1_message_proto_enumTypes[3]
 }
 
 func (x TargetType) Number() protoreflect.EnumNumber {
@@ -123,7 +241,7 @@ func (x TargetType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use TargetType.Descriptor instead.
 func (TargetType) EnumDescriptor() ([]byte, []int) {
-	return file_im_v1_message_proto_rawDescGZIP(), []int{1}
+	return file_im_v1_message_proto_rawDescGZIP(), []int{3}
 }
 
 // 基础消息结构
@@ -132,15 +250,22 @@ type BaseMessage struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	MsgId       string      `protobuf:"bytes,1,opt,name=msg_id,json=msgId,proto3" json:"msg_id,omitempty"`                                           // 消息ID 用户级别唯一
-	MessageType MessageType `protobuf:"varint,2,opt,name=message_type,json=messageType,proto3,enum=im.v1.MessageType" json:"message_type,omitempty"` // 消息类型
-	FromUserId  string      `protobuf:"bytes,3,opt,name=from_user_id,json=fromUserId,proto3" json:"from_user_id,omitempty"`                          // 发送者ID
-	TargetType  TargetType  `protobuf:"varint,4,opt,name=target_type,json=targetType,proto3,enum=im.v1.TargetType" json:"target_type,omitempty"`     // 目标类型
-	ToUserId    string      `protobuf:"bytes,5,opt,name=to_user_id,json=toUserId,proto3" json:"to_user_id,omitempty"`                                // 接收者
-	Content     []byte      `protobuf:"bytes,6,opt,name=content,proto3" json:"content,omitempty"`                                                    // 消息内容（二进制）
-	Timestamp   int64       `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                                               // 时间戳（单位: 秒）
-	ExpireTime  int64       `protobuf:"varint,10,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`                          // 过期时间戳（单位: 秒）
-	ContentId   string      `protobuf:"bytes,11,opt,name=content_id,json=contentId,proto3" json:"content_id,omitempty"`                              // 内容级别ID，用户聚合分析
+	MsgId       string      `protobuf:"bytes,1,opt,name=msg_id,json=msgId,proto3" json:"msg_id,omitempty"`                                            // 消息ID 用户级别唯一
+	MessageType MessageType `protobuf:"varint,2,opt,name=message_type,json=messageType,proto3,enum=im.v1.MessageType" json:"message_type,omitempty"`  // 消息类型
+	FromUserId  string      `protobuf:"bytes,3,opt,name=from_user_id,json=fromUserId,proto3" json:"from_user_id,omitempty"`                           // 发送者ID
+	TargetType  TargetType  `protobuf:"varint,4,opt,name=target_type,json=targetType,proto3,enum=im.v1.TargetType" json:"target_type,omitempty"`      // 目标类型
+	ToUserId    string      `protobuf:"bytes,5,opt,name=to_user_id,json=toUserId,proto3" json:"to_user_id,omitempty"`                                 // 接收者
+	Content     []byte      `protobuf:"bytes,6,opt,name=content,proto3" json:"content,omitempty"`                                                     // 消息内容（二进制）
+	Timestamp   int64       `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                                                // 时间戳（单位: 秒）
+	ExpireTime  int64       `protobuf:"varint,10,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`                           // 过期时间戳（单位: 秒）
+	ContentId   string      `protobuf:"bytes,11,opt,name=content_id,json=contentId,proto3" json:"content_id,omitempty"`                               // 内容级别ID，用户聚合分析
+	Transient   bool        `protobuf:"varint,12,opt,name=transient,proto3" json:"transient,omitempty"`                                               // 瞬时消息（如正在输入），用户离线时不保存
+	GroupId     string      `protobuf:"bytes,13,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`                                     // 群聊消息所属群ID，下发时 to_user_id 为实际接收者
+	Seq         int64       `protobuf:"varint,14,opt,name=seq,proto3" json:"seq,omitempty"`                                                           // 会话内的消息序号，由logic分配，单调递增，客户端据此发现缺失的消息
+	Priority    Priority    `protobuf:"varint,15,opt,name=priority,proto3,enum=im.v1.Priority" json:"priority,omitempty"`                             // 投递优先级
+	Mentioned   bool        `protobuf:"varint,16,opt,name=mentioned,proto3" json:"mentioned,omitempty"`                                               // 接收者在群聊消息中被@，免打扰的会话也应提醒
+	Silent      bool        `protobuf:"varint,17,opt,name=silent,proto3" json:"silent,omitempty"`                                                     // 接收者开启了会话免打扰或处于勿扰时段，消息照常保存和投递，客户端不提醒
+	ContentType ContentType `protobuf:"varint,18,opt,name=content_type,json=contentType,proto3,enum=im.v1.ContentType" json:"content_type,omitempty"` // 聊天消息的内容类型
 }
 
 func (x *BaseMessage) Reset() {
@@ -236,11 +361,60 @@ func (x *BaseMessage) GetContentId() string {
 	return ""
 }
 
+func (x *BaseMessage) GetTransient() bool {
+	if x != nil {
+		return x.Transient
+	}
+	return false
+}
+
+func (x *BaseMessage) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *BaseMessage) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *BaseMessage) GetPriority() Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Priority_PRIORITY_UNSPECIFIED
+}
+
+func (x *BaseMessage) GetMentioned() bool {
+	if x != nil {
+		return x.Mentioned
+	}
+	return false
+}
+
+func (x *BaseMessage) GetSilent() bool {
+	if x != nil {
+		return x.Silent
+	}
+	return false
+}
+
+func (x *BaseMessage) GetContentType() ContentType {
+	if x != nil {
+		return x.ContentType
+	}
+	return ContentType_CONTENT_TYPE_UNSPECIFIED
+}
+
 var File_im_v1_message_proto protoreflect.FileDescriptor
 
 var file_im_v1_message_proto_rawDesc = []byte{
 	0x0a, 0x13, 0x69, 0x6d, 0x2f, 0x76, 0x31, 0x2f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x69, 0x6d, 0x2e, 0x76, 0x31, 0x22, 0xc7, 0x02, 0x0a,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x69, 0x6d, 0x2e, 0x76, 0x31, 0x22, 0xac, 0x04, 0x0a,
 	0x0b, 0x42, 0x61, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x15, 0x0a, 0x06,
 	0x6d, 0x73, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x73,
 	0x67, 0x49, 0x64, 0x12, 0x35, 0x0a, 0x0c, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x74,
@@ -261,20 +435,48 @@ var file_im_v1_message_proto_rawDesc = []byte{
 	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x65, 0x78, 0x70,
 	0x69, 0x72, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x65,
 	0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e,
-	0x74, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x2a, 0x52, 0x0a, 0x0b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x18, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45,
-	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
-	0x44, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x43, 0x48, 0x41, 0x54, 0x10, 0x01, 0x12, 0x0e, 0x0a,
-	0x0a, 0x44, 0x41, 0x54, 0x41, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x02, 0x12, 0x0b, 0x0a,
-	0x07, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x4f, 0x4c, 0x10, 0x03, 0x2a, 0x4a, 0x0a, 0x0a, 0x54, 0x61,
-	0x72, 0x67, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x17, 0x54, 0x41, 0x52, 0x47,
-	0x45, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
-	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x55, 0x53, 0x45, 0x52, 0x10, 0x01, 0x12,
-	0x09, 0x0a, 0x05, 0x47, 0x52, 0x4f, 0x55, 0x50, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x59,
-	0x53, 0x54, 0x45, 0x4d, 0x10, 0x03, 0x42, 0x2b, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x78, 0x69, 0x6e, 0x67, 0x68, 0x65, 0x39, 0x30, 0x33, 0x2f, 0x63,
-	0x68, 0x61, 0x74, 0x69, 0x66, 0x79, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x69, 0x6d, 0x2f, 0x76, 0x31,
-	0x3b, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x74, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69,
+	0x65, 0x6e, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x69, 0x65, 0x6e, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12,
+	0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73, 0x65,
+	0x71, 0x12, 0x2b, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x0f, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x69, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x69, 0x6f,
+	0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x1c,
+	0x0a, 0x09, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x65, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x09, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x69, 0x6c, 0x65, 0x6e, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x69,
+	0x6c, 0x65, 0x6e, 0x74, 0x12, 0x35, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x69, 0x6d, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0b,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x2a, 0x52, 0x0a, 0x0b, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x18, 0x4d, 0x45,
+	0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x43, 0x48, 0x41, 0x54,
+	0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x41, 0x54, 0x41, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54,
+	0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x4f, 0x4c, 0x10, 0x03, 0x2a,
+	0x7a, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c,
+	0x0a, 0x18, 0x43, 0x4f, 0x4e, 0x54, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04,
+	0x54, 0x45, 0x58, 0x54, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x49, 0x4d, 0x41, 0x47, 0x45, 0x10,
+	0x02, 0x12, 0x09, 0x0a, 0x05, 0x56, 0x4f, 0x49, 0x43, 0x45, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05,
+	0x56, 0x49, 0x44, 0x45, 0x4f, 0x10, 0x04, 0x12, 0x08, 0x0a, 0x04, 0x46, 0x49, 0x4c, 0x45, 0x10,
+	0x05, 0x12, 0x0c, 0x0a, 0x08, 0x4c, 0x4f, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x06, 0x12,
+	0x0a, 0x0a, 0x06, 0x43, 0x55, 0x53, 0x54, 0x4f, 0x4d, 0x10, 0x0a, 0x2a, 0x5e, 0x0a, 0x08, 0x50,
+	0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x18, 0x0a, 0x14, 0x50, 0x52, 0x49, 0x4f, 0x52,
+	0x49, 0x54, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x11, 0x0a, 0x0d, 0x50, 0x52, 0x49, 0x4f, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x48, 0x49,
+	0x47, 0x48, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x50, 0x52, 0x49, 0x4f, 0x52, 0x49, 0x54, 0x59,
+	0x5f, 0x4e, 0x4f, 0x52, 0x4d, 0x41, 0x4c, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x50, 0x52, 0x49,
+	0x4f, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4c, 0x4f, 0x57, 0x10, 0x03, 0x2a, 0x4a, 0x0a, 0x0a, 0x54,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x17, 0x54, 0x41, 0x52,
+	0x47, 0x45, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x55, 0x53, 0x45, 0x52, 0x10, 0x01,
+	0x12, 0x09, 0x0a, 0x05, 0x47, 0x52, 0x4f, 0x55, 0x50, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x53,
+	0x59, 0x53, 0x54, 0x45, 0x4d, 0x10, 0x03, 0x42, 0x2b, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x78, 0x69, 0x6e, 0x67, 0x68, 0x65, 0x39, 0x30, 0x33, 0x2f,
+	0x63, 0x68, 0x61, 0x74, 0x69, 0x66, 0x79, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x69, 0x6d, 0x2f, 0x76,
+	0x31, 0x3b, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -289,21 +491,25 @@ func file_im_v1_message_proto_rawDescGZIP() []byte {
 	return file_im_v1_message_proto_rawDescData
 }
 
-var file_im_v1_message_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_im_v1_message_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
 var file_im_v1_message_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
 var file_im_v1_message_proto_goTypes = []any{
 	(MessageType)(0),    // 0: im.v1.MessageType
-	(TargetType)(0),     // 1: im.v1.TargetType
-	(*BaseMessage)(nil), // 2: im.v1.BaseMessage
+	(ContentType)(0),    // 1: im.v1.ContentType
+	(Priority)(0),       // 2: im.v1.Priority
+	(TargetType)(0),     // 3: im.v1.TargetType
+	(*BaseMessage)(nil), // 4: im.v1.BaseMessage
 }
 var file_im_v1_message_proto_depIdxs = []int32{
 	0, // 0: im.v1.BaseMessage.message_type:type_name -> im.v1.MessageType
-	1, // 1: im.v1.BaseMessage.target_type:type_name -> im.v1.TargetType
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	3, // 1: im.v1.BaseMessage.target_type:type_name -> im.v1.TargetType
+	2, // 2: im.v1.BaseMessage.priority:type_name -> im.v1.Priority
+	1, // 3: im.v1.BaseMessage.content_type:type_name -> im.v1.ContentType
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_im_v1_message_proto_init() }
@@ -316,7 +522,7 @@ func file_im_v1_message_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_im_v1_message_proto_rawDesc,
-			NumEnums:      2,
+			NumEnums:      4,
 			NumMessages:   1,
 			NumExtensions: 0,
 			NumServices:   0,
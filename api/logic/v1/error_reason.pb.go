@@ -24,14 +24,30 @@ const (
 type ErrorReason int32
 
 const (
-	ErrorReason_OK                 ErrorReason = 0
-	ErrorReason_MESSAGE_REPEAT     ErrorReason = 2010001 // 消息重复
-	ErrorReason_MESSAGE_EXPIRED    ErrorReason = 2010002 // 消息过期
-	ErrorReason_PERMISSION_DENIED  ErrorReason = 2010003 // 校验签名失败
-	ErrorReason_INTERNAL_ERROR     ErrorReason = 2010004 //  内部错误
-	ErrorReason_USER_NOT_FOUND     ErrorReason = 2010005 // 发送用户不存在
-	ErrorReason_TOO_MANY_TARGETS   ErrorReason = 2010006 //  目标用户数量过多
-	ErrorReason_INVOKE_PUSH_FAILED ErrorReason = 2010007 // 调用push service失败
+	ErrorReason_OK                       ErrorReason = 0
+	ErrorReason_MESSAGE_REPEAT           ErrorReason = 2010001 // 消息重复
+	ErrorReason_MESSAGE_EXPIRED          ErrorReason = 2010002 // 消息过期
+	ErrorReason_PERMISSION_DENIED        ErrorReason = 2010003 // 校验签名失败
+	ErrorReason_INTERNAL_ERROR           ErrorReason = 2010004 //  内部错误
+	ErrorReason_USER_NOT_FOUND           ErrorReason = 2010005 // 发送用户不存在
+	ErrorReason_TOO_MANY_TARGETS         ErrorReason = 2010006 //  目标用户数量过多
+	ErrorReason_INVOKE_PUSH_FAILED       ErrorReason = 2010007 // 调用push service失败
+	ErrorReason_INVALID_PARAMETER        ErrorReason = 2010008 // 请求参数错误
+	ErrorReason_JOB_NOT_FOUND            ErrorReason = 2010009 // 广播任务不存在
+	ErrorReason_JOB_STATUS_CONFLICT      ErrorReason = 2010010 // 广播任务当前状态不允许该操作
+	ErrorReason_SCHEDULE_NOT_FOUND       ErrorReason = 2010011 // 定时推送计划不存在
+	ErrorReason_SCHEDULE_STATUS_CONFLICT ErrorReason = 2010012 // 定时推送计划已执行完毕或已取消
+	ErrorReason_UNAUTHENTICATED          ErrorReason = 2010013 // 请求未携带登录用户
+	ErrorReason_CONVERSATION_FORBIDDEN   ErrorReason = 2010014 // 不是会话成员
+	ErrorReason_SENDER_MISMATCH          ErrorReason = 2010015 // 发送者与登录用户不一致
+	ErrorReason_QUOTA_EXCEEDED           ErrorReason = 2010016 // 超出发送配额
+	ErrorReason_SENDER_MUTED             ErrorReason = 2010017 // 发送者被临时禁言
+	ErrorReason_TEMPLATE_NOT_FOUND       ErrorReason = 2010018 // 推送模板不存在
+	ErrorReason_BOT_NOT_FOUND            ErrorReason = 2010019 // 机器人不存在
+	ErrorReason_WEBHOOK_NOT_FOUND        ErrorReason = 2010020 // webhook订阅不存在
+	ErrorReason_ATTACHMENT_NOT_FOUND     ErrorReason = 2010021 // 附件不存在
+	ErrorReason_UPLOAD_NOT_FOUND         ErrorReason = 2010022 // 附件上传不存在或已过期
+	ErrorReason_STORAGE_QUOTA_EXCEEDED   ErrorReason = 2010023 // 超出附件存储配额
 )
 
 // Enum value maps for ErrorReason.
@@ -45,16 +61,48 @@ var (
 		2010005: "USER_NOT_FOUND",
 		2010006: "TOO_MANY_TARGETS",
 		2010007: "INVOKE_PUSH_FAILED",
+		2010008: "INVALID_PARAMETER",
+		2010009: "JOB_NOT_FOUND",
+		2010010: "JOB_STATUS_CONFLICT",
+		2010011: "SCHEDULE_NOT_FOUND",
+		2010012: "SCHEDULE_STATUS_CONFLICT",
+		2010013: "UNAUTHENTICATED",
+		2010014: "CONVERSATION_FORBIDDEN",
+		2010015: "SENDER_MISMATCH",
+		2010016: "QUOTA_EXCEEDED",
+		2010017: "SENDER_MUTED",
+		2010018: "TEMPLATE_NOT_FOUND",
+		2010019: "BOT_NOT_FOUND",
+		2010020: "WEBHOOK_NOT_FOUND",
+		2010021: "ATTACHMENT_NOT_FOUND",
+		2010022: "UPLOAD_NOT_FOUND",
+		2010023: "STORAGE_QUOTA_EXCEEDED",
 	}
 	ErrorReason_value = map[string]int32{
-		"OK":                 0,
-		"MESSAGE_REPEAT":     2010001,
-		"MESSAGE_EXPIRED":    2010002,
-		"PERMISSION_DENIED":  2010003,
-		"INTERNAL_ERROR":     2010004,
-		"USER_NOT_FOUND":     2010005,
-		"TOO_MANY_TARGETS":   2010006,
-		"INVOKE_PUSH_FAILED": 2010007,
+		"OK":                       0,
+		"MESSAGE_REPEAT":           2010001,
+		"MESSAGE_EXPIRED":          2010002,
+		"PERMISSION_DENIED":        2010003,
+		"INTERNAL_ERROR":           2010004,
+		"USER_NOT_FOUND":           2010005,
+		"TOO_MANY_TARGETS":         2010006,
+		"INVOKE_PUSH_FAILED":       2010007,
+		"INVALID_PARAMETER":        2010008,
+		"JOB_NOT_FOUND":            2010009,
+		"JOB_STATUS_CONFLICT":      2010010,
+		"SCHEDULE_NOT_FOUND":       2010011,
+		"SCHEDULE_STATUS_CONFLICT": 2010012,
+		"UNAUTHENTICATED":          2010013,
+		"CONVERSATION_FORBIDDEN":   2010014,
+		"SENDER_MISMATCH":          2010015,
+		"QUOTA_EXCEEDED":           2010016,
+		"SENDER_MUTED":             2010017,
+		"TEMPLATE_NOT_FOUND":       2010018,
+		"BOT_NOT_FOUND":            2010019,
+		"WEBHOOK_NOT_FOUND":        2010020,
+		"ATTACHMENT_NOT_FOUND":     2010021,
+		"UPLOAD_NOT_FOUND":         2010022,
+		"STORAGE_QUOTA_EXCEEDED":   2010023,
 	}
 )
 
@@ -91,7 +139,7 @@ var file_logic_v1_error_reason_proto_rawDesc = []byte{
 	0x0a, 0x1b, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x72, 0x72, 0x6f, 0x72,
 	0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x6c,
 	0x6f, 0x67, 0x69, 0x63, 0x2e, 0x76, 0x31, 0x1a, 0x13, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2f,
-	0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2a, 0xef, 0x01, 0x0a,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2a, 0xe2, 0x05, 0x0a,
 	0x0b, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x0c, 0x0a, 0x02,
 	0x4f, 0x4b, 0x10, 0x00, 0x1a, 0x04, 0xa8, 0x45, 0xc8, 0x01, 0x12, 0x1a, 0x0a, 0x0e, 0x4d, 0x45,
 	0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x52, 0x45, 0x50, 0x45, 0x41, 0x54, 0x10, 0x91, 0xd7, 0x7a,
@@ -106,11 +154,42 @@ var file_logic_v1_error_reason_proto_rawDesc = []byte{
 	0x4f, 0x5f, 0x4d, 0x41, 0x4e, 0x59, 0x5f, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x53, 0x10, 0x96,
 	0xd7, 0x7a, 0x1a, 0x04, 0xa8, 0x45, 0xf4, 0x03, 0x12, 0x1e, 0x0a, 0x12, 0x49, 0x4e, 0x56, 0x4f,
 	0x4b, 0x45, 0x5f, 0x50, 0x55, 0x53, 0x48, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x97,
-	0xd7, 0x7a, 0x1a, 0x04, 0xa8, 0x45, 0xf4, 0x03, 0x1a, 0x04, 0xa0, 0x45, 0xf4, 0x03, 0x42, 0x2e,
-	0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x78, 0x69, 0x6e,
-	0x67, 0x68, 0x65, 0x39, 0x30, 0x33, 0x2f, 0x63, 0x68, 0x61, 0x74, 0x69, 0x66, 0x79, 0x2f, 0x61,
-	0x70, 0x69, 0x2f, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2f, 0x76, 0x31, 0x3b, 0x76, 0x31, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0xd7, 0x7a, 0x1a, 0x04, 0xa8, 0x45, 0xf4, 0x03, 0x12, 0x1d, 0x0a, 0x11, 0x49, 0x4e, 0x56, 0x41,
+	0x4c, 0x49, 0x44, 0x5f, 0x50, 0x41, 0x52, 0x41, 0x4d, 0x45, 0x54, 0x45, 0x52, 0x10, 0x98, 0xd7,
+	0x7a, 0x1a, 0x04, 0xa8, 0x45, 0xf4, 0x03, 0x12, 0x19, 0x0a, 0x0d, 0x4a, 0x4f, 0x42, 0x5f, 0x4e,
+	0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x99, 0xd7, 0x7a, 0x1a, 0x04, 0xa8, 0x45,
+	0xf4, 0x03, 0x12, 0x1f, 0x0a, 0x13, 0x4a, 0x4f, 0x42, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53,
+	0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x4c, 0x49, 0x43, 0x54, 0x10, 0x9a, 0xd7, 0x7a, 0x1a, 0x04, 0xa8,
+	0x45, 0xf4, 0x03, 0x12, 0x1e, 0x0a, 0x12, 0x53, 0x43, 0x48, 0x45, 0x44, 0x55, 0x4c, 0x45, 0x5f,
+	0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x9b, 0xd7, 0x7a, 0x1a, 0x04, 0xa8,
+	0x45, 0xf4, 0x03, 0x12, 0x24, 0x0a, 0x18, 0x53, 0x43, 0x48, 0x45, 0x44, 0x55, 0x4c, 0x45, 0x5f,
+	0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x4c, 0x49, 0x43, 0x54, 0x10,
+	0x9c, 0xd7, 0x7a, 0x1a, 0x04, 0xa8, 0x45, 0xf4, 0x03, 0x12, 0x1b, 0x0a, 0x0f, 0x55, 0x4e, 0x41,
+	0x55, 0x54, 0x48, 0x45, 0x4e, 0x54, 0x49, 0x43, 0x41, 0x54, 0x45, 0x44, 0x10, 0x9d, 0xd7, 0x7a,
+	0x1a, 0x04, 0xa8, 0x45, 0xf4, 0x03, 0x12, 0x22, 0x0a, 0x16, 0x43, 0x4f, 0x4e, 0x56, 0x45, 0x52,
+	0x53, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x46, 0x4f, 0x52, 0x42, 0x49, 0x44, 0x44, 0x45, 0x4e,
+	0x10, 0x9e, 0xd7, 0x7a, 0x1a, 0x04, 0xa8, 0x45, 0xf4, 0x03, 0x12, 0x1b, 0x0a, 0x0f, 0x53, 0x45,
+	0x4e, 0x44, 0x45, 0x52, 0x5f, 0x4d, 0x49, 0x53, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x10, 0x9f, 0xd7,
+	0x7a, 0x1a, 0x04, 0xa8, 0x45, 0xf4, 0x03, 0x12, 0x1a, 0x0a, 0x0e, 0x51, 0x55, 0x4f, 0x54, 0x41,
+	0x5f, 0x45, 0x58, 0x43, 0x45, 0x45, 0x44, 0x45, 0x44, 0x10, 0xa0, 0xd7, 0x7a, 0x1a, 0x04, 0xa8,
+	0x45, 0xf4, 0x03, 0x12, 0x18, 0x0a, 0x0c, 0x53, 0x45, 0x4e, 0x44, 0x45, 0x52, 0x5f, 0x4d, 0x55,
+	0x54, 0x45, 0x44, 0x10, 0xa1, 0xd7, 0x7a, 0x1a, 0x04, 0xa8, 0x45, 0xf4, 0x03, 0x12, 0x1e, 0x0a,
+	0x12, 0x54, 0x45, 0x4d, 0x50, 0x4c, 0x41, 0x54, 0x45, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f,
+	0x55, 0x4e, 0x44, 0x10, 0xa2, 0xd7, 0x7a, 0x1a, 0x04, 0xa8, 0x45, 0xf4, 0x03, 0x12, 0x19, 0x0a,
+	0x0d, 0x42, 0x4f, 0x54, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0xa3,
+	0xd7, 0x7a, 0x1a, 0x04, 0xa8, 0x45, 0xf4, 0x03, 0x12, 0x1d, 0x0a, 0x11, 0x57, 0x45, 0x42, 0x48,
+	0x4f, 0x4f, 0x4b, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0xa4, 0xd7,
+	0x7a, 0x1a, 0x04, 0xa8, 0x45, 0xf4, 0x03, 0x12, 0x20, 0x0a, 0x14, 0x41, 0x54, 0x54, 0x41, 0x43,
+	0x48, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10,
+	0xa5, 0xd7, 0x7a, 0x1a, 0x04, 0xa8, 0x45, 0xf4, 0x03, 0x12, 0x1c, 0x0a, 0x10, 0x55, 0x50, 0x4c,
+	0x4f, 0x41, 0x44, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0xa6, 0xd7,
+	0x7a, 0x1a, 0x04, 0xa8, 0x45, 0xf4, 0x03, 0x12, 0x22, 0x0a, 0x16, 0x53, 0x54, 0x4f, 0x52, 0x41,
+	0x47, 0x45, 0x5f, 0x51, 0x55, 0x4f, 0x54, 0x41, 0x5f, 0x45, 0x58, 0x43, 0x45, 0x45, 0x44, 0x45,
+	0x44, 0x10, 0xa7, 0xd7, 0x7a, 0x1a, 0x04, 0xa8, 0x45, 0xf4, 0x03, 0x1a, 0x04, 0xa0, 0x45, 0xf4,
+	0x03, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x78, 0x69, 0x6e, 0x67, 0x68, 0x65, 0x39, 0x30, 0x33, 0x2f, 0x63, 0x68, 0x61, 0x74, 0x69, 0x66,
+	0x79, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2f, 0x76, 0x31, 0x3b, 0x76,
+	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -120,3 +120,227 @@ func IsInvokePushFailed(err error) bool {
 func ErrorInvokePushFailed(format string, args ...interface{}) *errors.Error {
 	return errors.New(500, ErrorReason_INVOKE_PUSH_FAILED.String(), fmt.Sprintf(format, args...))
 }
+
+// 请求参数错误
+func IsInvalidParameter(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == ErrorReason_INVALID_PARAMETER.String() && e.Code == 500
+}
+
+// 请求参数错误
+func ErrorInvalidParameter(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, ErrorReason_INVALID_PARAMETER.String(), fmt.Sprintf(format, args...))
+}
+
+// 广播任务不存在
+func IsJobNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == ErrorReason_JOB_NOT_FOUND.String() && e.Code == 500
+}
+
+// 广播任务不存在
+func ErrorJobNotFound(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, ErrorReason_JOB_NOT_FOUND.String(), fmt.Sprintf(format, args...))
+}
+
+// 广播任务当前状态不允许该操作
+func IsJobStatusConflict(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == ErrorReason_JOB_STATUS_CONFLICT.String() && e.Code == 500
+}
+
+// 广播任务当前状态不允许该操作
+func ErrorJobStatusConflict(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, ErrorReason_JOB_STATUS_CONFLICT.String(), fmt.Sprintf(format, args...))
+}
+
+// 定时推送计划不存在
+func IsScheduleNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == ErrorReason_SCHEDULE_NOT_FOUND.String() && e.Code == 500
+}
+
+// 定时推送计划不存在
+func ErrorScheduleNotFound(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, ErrorReason_SCHEDULE_NOT_FOUND.String(), fmt.Sprintf(format, args...))
+}
+
+// 定时推送计划已执行完毕或已取消
+func IsScheduleStatusConflict(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == ErrorReason_SCHEDULE_STATUS_CONFLICT.String() && e.Code == 500
+}
+
+// 定时推送计划已执行完毕或已取消
+func ErrorScheduleStatusConflict(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, ErrorReason_SCHEDULE_STATUS_CONFLICT.String(), fmt.Sprintf(format, args...))
+}
+
+// 请求未携带登录用户
+func IsUnauthenticated(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == ErrorReason_UNAUTHENTICATED.String() && e.Code == 500
+}
+
+// 请求未携带登录用户
+func ErrorUnauthenticated(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, ErrorReason_UNAUTHENTICATED.String(), fmt.Sprintf(format, args...))
+}
+
+// 不是会话成员
+func IsConversationForbidden(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == ErrorReason_CONVERSATION_FORBIDDEN.String() && e.Code == 500
+}
+
+// 不是会话成员
+func ErrorConversationForbidden(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, ErrorReason_CONVERSATION_FORBIDDEN.String(), fmt.Sprintf(format, args...))
+}
+
+// 发送者与登录用户不一致
+func IsSenderMismatch(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == ErrorReason_SENDER_MISMATCH.String() && e.Code == 500
+}
+
+// 发送者与登录用户不一致
+func ErrorSenderMismatch(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, ErrorReason_SENDER_MISMATCH.String(), fmt.Sprintf(format, args...))
+}
+
+// 超出发送配额
+func IsQuotaExceeded(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == ErrorReason_QUOTA_EXCEEDED.String() && e.Code == 500
+}
+
+// 超出发送配额
+func ErrorQuotaExceeded(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, ErrorReason_QUOTA_EXCEEDED.String(), fmt.Sprintf(format, args...))
+}
+
+// 发送者被临时禁言
+func IsSenderMuted(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == ErrorReason_SENDER_MUTED.String() && e.Code == 500
+}
+
+// 发送者被临时禁言
+func ErrorSenderMuted(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, ErrorReason_SENDER_MUTED.String(), fmt.Sprintf(format, args...))
+}
+
+// 推送模板不存在
+func IsTemplateNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == ErrorReason_TEMPLATE_NOT_FOUND.String() && e.Code == 500
+}
+
+// 推送模板不存在
+func ErrorTemplateNotFound(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, ErrorReason_TEMPLATE_NOT_FOUND.String(), fmt.Sprintf(format, args...))
+}
+
+// 机器人不存在
+func IsBotNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == ErrorReason_BOT_NOT_FOUND.String() && e.Code == 500
+}
+
+// 机器人不存在
+func ErrorBotNotFound(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, ErrorReason_BOT_NOT_FOUND.String(), fmt.Sprintf(format, args...))
+}
+
+// webhook订阅不存在
+func IsWebhookNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == ErrorReason_WEBHOOK_NOT_FOUND.String() && e.Code == 500
+}
+
+// webhook订阅不存在
+func ErrorWebhookNotFound(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, ErrorReason_WEBHOOK_NOT_FOUND.String(), fmt.Sprintf(format, args...))
+}
+
+// 附件不存在
+func IsAttachmentNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == ErrorReason_ATTACHMENT_NOT_FOUND.String() && e.Code == 500
+}
+
+// 附件不存在
+func ErrorAttachmentNotFound(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, ErrorReason_ATTACHMENT_NOT_FOUND.String(), fmt.Sprintf(format, args...))
+}
+
+// 附件上传不存在或已过期
+func IsUploadNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == ErrorReason_UPLOAD_NOT_FOUND.String() && e.Code == 500
+}
+
+// 附件上传不存在或已过期
+func ErrorUploadNotFound(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, ErrorReason_UPLOAD_NOT_FOUND.String(), fmt.Sprintf(format, args...))
+}
+
+// 超出附件存储配额
+func IsStorageQuotaExceeded(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == ErrorReason_STORAGE_QUOTA_EXCEEDED.String() && e.Code == 500
+}
+
+// 超出附件存储配额
+func ErrorStorageQuotaExceeded(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, ErrorReason_STORAGE_QUOTA_EXCEEDED.String(), fmt.Sprintf(format, args...))
+}
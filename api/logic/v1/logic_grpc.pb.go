@@ -19,18 +19,133 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	LogicService_ValidateAndProcessMessage_FullMethodName = "/logic.v1.LogicService/ValidateAndProcessMessage"
-	LogicService_SendSystemPush_FullMethodName            = "/logic.v1.LogicService/SendSystemPush"
+	LogicService_ValidateAndProcessMessage_FullMethodName     = "/logic.v1.LogicService/ValidateAndProcessMessage"
+	LogicService_SendSystemPush_FullMethodName                = "/logic.v1.LogicService/SendSystemPush"
+	LogicService_ListSystemPushSchedules_FullMethodName       = "/logic.v1.LogicService/ListSystemPushSchedules"
+	LogicService_CancelSystemPushSchedule_FullMethodName      = "/logic.v1.LogicService/CancelSystemPushSchedule"
+	LogicService_CreatePushTemplate_FullMethodName            = "/logic.v1.LogicService/CreatePushTemplate"
+	LogicService_UpdatePushTemplate_FullMethodName            = "/logic.v1.LogicService/UpdatePushTemplate"
+	LogicService_GetPushTemplate_FullMethodName               = "/logic.v1.LogicService/GetPushTemplate"
+	LogicService_ListPushTemplates_FullMethodName             = "/logic.v1.LogicService/ListPushTemplates"
+	LogicService_DeletePushTemplate_FullMethodName            = "/logic.v1.LogicService/DeletePushTemplate"
+	LogicService_SetUserLocale_FullMethodName                 = "/logic.v1.LogicService/SetUserLocale"
+	LogicService_UpdateUserAttributes_FullMethodName          = "/logic.v1.LogicService/UpdateUserAttributes"
+	LogicService_GetUserAttributes_FullMethodName             = "/logic.v1.LogicService/GetUserAttributes"
+	LogicService_PreviewSegment_FullMethodName                = "/logic.v1.LogicService/PreviewSegment"
+	LogicService_GetDeliveryFunnel_FullMethodName             = "/logic.v1.LogicService/GetDeliveryFunnel"
+	LogicService_RegisterReportSchema_FullMethodName          = "/logic.v1.LogicService/RegisterReportSchema"
+	LogicService_ListReportSchemas_FullMethodName             = "/logic.v1.LogicService/ListReportSchemas"
+	LogicService_QueryDataReports_FullMethodName              = "/logic.v1.LogicService/QueryDataReports"
+	LogicService_CreateBot_FullMethodName                     = "/logic.v1.LogicService/CreateBot"
+	LogicService_DeleteBot_FullMethodName                     = "/logic.v1.LogicService/DeleteBot"
+	LogicService_SendBotMessage_FullMethodName                = "/logic.v1.LogicService/SendBotMessage"
+	LogicService_CreateWebhook_FullMethodName                 = "/logic.v1.LogicService/CreateWebhook"
+	LogicService_ListWebhooks_FullMethodName                  = "/logic.v1.LogicService/ListWebhooks"
+	LogicService_DeleteWebhook_FullMethodName                 = "/logic.v1.LogicService/DeleteWebhook"
+	LogicService_CreateAttachmentUpload_FullMethodName        = "/logic.v1.LogicService/CreateAttachmentUpload"
+	LogicService_UploadAttachmentChunk_FullMethodName         = "/logic.v1.LogicService/UploadAttachmentChunk"
+	LogicService_GetAttachmentUpload_FullMethodName           = "/logic.v1.LogicService/GetAttachmentUpload"
+	LogicService_CompleteAttachmentUpload_FullMethodName      = "/logic.v1.LogicService/CompleteAttachmentUpload"
+	LogicService_GetAttachment_FullMethodName                 = "/logic.v1.LogicService/GetAttachment"
+	LogicService_ListConversations_FullMethodName             = "/logic.v1.LogicService/ListConversations"
+	LogicService_GetHistory_FullMethodName                    = "/logic.v1.LogicService/GetHistory"
+	LogicService_SyncSince_FullMethodName                     = "/logic.v1.LogicService/SyncSince"
+	LogicService_DeleteHistoryMessages_FullMethodName         = "/logic.v1.LogicService/DeleteHistoryMessages"
+	LogicService_GetUnreadCount_FullMethodName                = "/logic.v1.LogicService/GetUnreadCount"
+	LogicService_GetNotificationPreferences_FullMethodName    = "/logic.v1.LogicService/GetNotificationPreferences"
+	LogicService_UpdateNotificationPreferences_FullMethodName = "/logic.v1.LogicService/UpdateNotificationPreferences"
+	LogicService_CreateBroadcastJob_FullMethodName            = "/logic.v1.LogicService/CreateBroadcastJob"
+	LogicService_GetBroadcastJob_FullMethodName               = "/logic.v1.LogicService/GetBroadcastJob"
+	LogicService_PauseBroadcastJob_FullMethodName             = "/logic.v1.LogicService/PauseBroadcastJob"
+	LogicService_ResumeBroadcastJob_FullMethodName            = "/logic.v1.LogicService/ResumeBroadcastJob"
+	LogicService_CancelBroadcastJob_FullMethodName            = "/logic.v1.LogicService/CancelBroadcastJob"
 )
 
 // LogicServiceClient is the client API for LogicService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type LogicServiceClient interface {
-	// 接收原始消息，返回是否允许发送
+	// 同步发送聊天消息，供服务端集成（机器人、业务后台）调用
+	// 与kafka上行链路执行相同的校验和路由，返回消息ID和会话序号
 	ValidateAndProcessMessage(ctx context.Context, in *ChatInputRequest, opts ...grpc.CallOption) (*ChatInputResponse, error)
 	// 系统主动推送（公告、通知）
 	SendSystemPush(ctx context.Context, in *SystemPushRequest, opts ...grpc.CallOption) (*SystemPushResponse, error)
+	// 查询定时推送计划
+	ListSystemPushSchedules(ctx context.Context, in *ListSystemPushSchedulesRequest, opts ...grpc.CallOption) (*ListSystemPushSchedulesResponse, error)
+	// 取消待执行的定时推送计划
+	CancelSystemPushSchedule(ctx context.Context, in *CancelSystemPushScheduleRequest, opts ...grpc.CallOption) (*CancelSystemPushScheduleResponse, error)
+	// 创建推送模板
+	CreatePushTemplate(ctx context.Context, in *CreatePushTemplateRequest, opts ...grpc.CallOption) (*PushTemplate, error)
+	// 更新推送模板，整体替换变量和多语言内容
+	UpdatePushTemplate(ctx context.Context, in *UpdatePushTemplateRequest, opts ...grpc.CallOption) (*PushTemplate, error)
+	// 查询推送模板
+	GetPushTemplate(ctx context.Context, in *GetPushTemplateRequest, opts ...grpc.CallOption) (*PushTemplate, error)
+	// 分页查询推送模板
+	ListPushTemplates(ctx context.Context, in *ListPushTemplatesRequest, opts ...grpc.CallOption) (*ListPushTemplatesResponse, error)
+	// 删除推送模板，引用该模板的定时推送计划到期后发送失败
+	DeletePushTemplate(ctx context.Context, in *DeletePushTemplateRequest, opts ...grpc.CallOption) (*DeletePushTemplateResponse, error)
+	// 设置当前用户的语言偏好，用于选择推送模板的语言版本
+	SetUserLocale(ctx context.Context, in *SetUserLocaleRequest, opts ...grpc.CallOption) (*SetUserLocaleResponse, error)
+	// 更新用户属性和标签，用于系统推送按分群选择接收者
+	UpdateUserAttributes(ctx context.Context, in *UpdateUserAttributesRequest, opts ...grpc.CallOption) (*UserAttributes, error)
+	// 查询用户属性和标签
+	GetUserAttributes(ctx context.Context, in *GetUserAttributesRequest, opts ...grpc.CallOption) (*UserAttributes, error)
+	// 预览分群命中的用户数，发送前确认推送范围
+	PreviewSegment(ctx context.Context, in *PreviewSegmentRequest, opts ...grpc.CallOption) (*PreviewSegmentResponse, error)
+	// 查询推送内容的送达漏斗，按小时或天汇总
+	GetDeliveryFunnel(ctx context.Context, in *GetDeliveryFunnelRequest, opts ...grpc.CallOption) (*GetDeliveryFunnelResponse, error)
+	// 注册数据上报结构，同名结构每次注册生成新版本，已注册的版本不可修改
+	RegisterReportSchema(ctx context.Context, in *RegisterReportSchemaRequest, opts ...grpc.CallOption) (*ReportSchema, error)
+	// 查询数据上报结构，name为空时返回全部结构的全部版本
+	ListReportSchemas(ctx context.Context, in *ListReportSchemasRequest, opts ...grpc.CallOption) (*ListReportSchemasResponse, error)
+	// 按用户和时间范围查询已存储的数据上报
+	QueryDataReports(ctx context.Context, in *QueryDataReportsRequest, opts ...grpc.CallOption) (*QueryDataReportsResponse, error)
+	// 创建机器人账号，token只在创建时返回一次
+	CreateBot(ctx context.Context, in *CreateBotRequest, opts ...grpc.CallOption) (*CreateBotResponse, error)
+	// 删除机器人账号，已签发的token立即失效
+	DeleteBot(ctx context.Context, in *DeleteBotRequest, opts ...grpc.CallOption) (*DeleteBotResponse, error)
+	// 机器人发送聊天消息，请求头 Authorization: Bot <token>
+	SendBotMessage(ctx context.Context, in *SendBotMessageRequest, opts ...grpc.CallOption) (*SendBotMessageResponse, error)
+	// 创建webhook订阅，签名密钥只在创建时返回一次
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error)
+	// 查询全部webhook订阅
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error)
+	// 删除webhook订阅，尚未投递的事件不再投递
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error)
+	// 创建附件上传，内容全部上传后在完成时按sha256去重
+	CreateAttachmentUpload(ctx context.Context, in *CreateAttachmentUploadRequest, opts ...grpc.CallOption) (*AttachmentUpload, error)
+	// 上传附件分片，同一分片可以重复上传，用于断点续传
+	UploadAttachmentChunk(ctx context.Context, in *UploadAttachmentChunkRequest, opts ...grpc.CallOption) (*UploadAttachmentChunkResponse, error)
+	// 查询附件上传进度，断点续传时只需上传缺少的分片
+	GetAttachmentUpload(ctx context.Context, in *GetAttachmentUploadRequest, opts ...grpc.CallOption) (*AttachmentUpload, error)
+	// 所有分片上传完成后合并为附件，内容相同的附件只保存一份
+	CompleteAttachmentUpload(ctx context.Context, in *CompleteAttachmentUploadRequest, opts ...grpc.CallOption) (*AttachmentUpload, error)
+	// 查询附件信息，返回带签名的下载地址
+	GetAttachment(ctx context.Context, in *GetAttachmentRequest, opts ...grpc.CallOption) (*Attachment, error)
+	// 分页查询当前用户的会话列表，置顶会话在前，其余按最后一条消息时间倒序
+	ListConversations(ctx context.Context, in *ListConversationsRequest, opts ...grpc.CallOption) (*ListConversationsResponse, error)
+	// 按消息ID游标分页查询会话历史消息，before_msg_id向前翻页，after_msg_id用于多端同步新消息
+	GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
+	// 按会话序号增量同步消息，用于补齐客户端缺失的消息
+	SyncSince(ctx context.Context, in *SyncSinceRequest, opts ...grpc.CallOption) (*SyncSinceResponse, error)
+	// 删除当前用户的历史消息，仅对自己不可见
+	DeleteHistoryMessages(ctx context.Context, in *DeleteHistoryMessagesRequest, opts ...grpc.CallOption) (*DeleteHistoryMessagesResponse, error)
+	// 查询当前用户的未读数，包括总数和各会话未读数
+	GetUnreadCount(ctx context.Context, in *GetUnreadCountRequest, opts ...grpc.CallOption) (*GetUnreadCountResponse, error)
+	// 查询当前用户的通知偏好，包括退订的推送类型、勿扰时段和免打扰的会话
+	GetNotificationPreferences(ctx context.Context, in *GetNotificationPreferencesRequest, opts ...grpc.CallOption) (*NotificationPreferences, error)
+	// 更新当前用户的通知偏好
+	UpdateNotificationPreferences(ctx context.Context, in *UpdateNotificationPreferencesRequest, opts ...grpc.CallOption) (*NotificationPreferences, error)
+	// 创建广播任务（超过1000个目标用户的系统推送）
+	CreateBroadcastJob(ctx context.Context, in *CreateBroadcastJobRequest, opts ...grpc.CallOption) (*BroadcastJobResponse, error)
+	// 查询广播任务进度
+	GetBroadcastJob(ctx context.Context, in *BroadcastJobRequest, opts ...grpc.CallOption) (*BroadcastJobResponse, error)
+	// 暂停广播任务
+	PauseBroadcastJob(ctx context.Context, in *BroadcastJobRequest, opts ...grpc.CallOption) (*BroadcastJobResponse, error)
+	// 恢复已暂停的广播任务
+	ResumeBroadcastJob(ctx context.Context, in *BroadcastJobRequest, opts ...grpc.CallOption) (*BroadcastJobResponse, error)
+	// 取消广播任务，未发送的分片不再投递
+	CancelBroadcastJob(ctx context.Context, in *BroadcastJobRequest, opts ...grpc.CallOption) (*BroadcastJobResponse, error)
 }
 
 type logicServiceClient struct {
@@ -61,83 +176,1338 @@ func (c *logicServiceClient) SendSystemPush(ctx context.Context, in *SystemPushR
 	return out, nil
 }
 
+func (c *logicServiceClient) ListSystemPushSchedules(ctx context.Context, in *ListSystemPushSchedulesRequest, opts ...grpc.CallOption) (*ListSystemPushSchedulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSystemPushSchedulesResponse)
+	err := c.cc.Invoke(ctx, LogicService_ListSystemPushSchedules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) CancelSystemPushSchedule(ctx context.Context, in *CancelSystemPushScheduleRequest, opts ...grpc.CallOption) (*CancelSystemPushScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelSystemPushScheduleResponse)
+	err := c.cc.Invoke(ctx, LogicService_CancelSystemPushSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) CreatePushTemplate(ctx context.Context, in *CreatePushTemplateRequest, opts ...grpc.CallOption) (*PushTemplate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PushTemplate)
+	err := c.cc.Invoke(ctx, LogicService_CreatePushTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) UpdatePushTemplate(ctx context.Context, in *UpdatePushTemplateRequest, opts ...grpc.CallOption) (*PushTemplate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PushTemplate)
+	err := c.cc.Invoke(ctx, LogicService_UpdatePushTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) GetPushTemplate(ctx context.Context, in *GetPushTemplateRequest, opts ...grpc.CallOption) (*PushTemplate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PushTemplate)
+	err := c.cc.Invoke(ctx, LogicService_GetPushTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) ListPushTemplates(ctx context.Context, in *ListPushTemplatesRequest, opts ...grpc.CallOption) (*ListPushTemplatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPushTemplatesResponse)
+	err := c.cc.Invoke(ctx, LogicService_ListPushTemplates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) DeletePushTemplate(ctx context.Context, in *DeletePushTemplateRequest, opts ...grpc.CallOption) (*DeletePushTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeletePushTemplateResponse)
+	err := c.cc.Invoke(ctx, LogicService_DeletePushTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) SetUserLocale(ctx context.Context, in *SetUserLocaleRequest, opts ...grpc.CallOption) (*SetUserLocaleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetUserLocaleResponse)
+	err := c.cc.Invoke(ctx, LogicService_SetUserLocale_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) UpdateUserAttributes(ctx context.Context, in *UpdateUserAttributesRequest, opts ...grpc.CallOption) (*UserAttributes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserAttributes)
+	err := c.cc.Invoke(ctx, LogicService_UpdateUserAttributes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) GetUserAttributes(ctx context.Context, in *GetUserAttributesRequest, opts ...grpc.CallOption) (*UserAttributes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserAttributes)
+	err := c.cc.Invoke(ctx, LogicService_GetUserAttributes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) PreviewSegment(ctx context.Context, in *PreviewSegmentRequest, opts ...grpc.CallOption) (*PreviewSegmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewSegmentResponse)
+	err := c.cc.Invoke(ctx, LogicService_PreviewSegment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) GetDeliveryFunnel(ctx context.Context, in *GetDeliveryFunnelRequest, opts ...grpc.CallOption) (*GetDeliveryFunnelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDeliveryFunnelResponse)
+	err := c.cc.Invoke(ctx, LogicService_GetDeliveryFunnel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) RegisterReportSchema(ctx context.Context, in *RegisterReportSchemaRequest, opts ...grpc.CallOption) (*ReportSchema, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportSchema)
+	err := c.cc.Invoke(ctx, LogicService_RegisterReportSchema_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) ListReportSchemas(ctx context.Context, in *ListReportSchemasRequest, opts ...grpc.CallOption) (*ListReportSchemasResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReportSchemasResponse)
+	err := c.cc.Invoke(ctx, LogicService_ListReportSchemas_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) QueryDataReports(ctx context.Context, in *QueryDataReportsRequest, opts ...grpc.CallOption) (*QueryDataReportsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryDataReportsResponse)
+	err := c.cc.Invoke(ctx, LogicService_QueryDataReports_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) CreateBot(ctx context.Context, in *CreateBotRequest, opts ...grpc.CallOption) (*CreateBotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateBotResponse)
+	err := c.cc.Invoke(ctx, LogicService_CreateBot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) DeleteBot(ctx context.Context, in *DeleteBotRequest, opts ...grpc.CallOption) (*DeleteBotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteBotResponse)
+	err := c.cc.Invoke(ctx, LogicService_DeleteBot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) SendBotMessage(ctx context.Context, in *SendBotMessageRequest, opts ...grpc.CallOption) (*SendBotMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendBotMessageResponse)
+	err := c.cc.Invoke(ctx, LogicService_SendBotMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateWebhookResponse)
+	err := c.cc.Invoke(ctx, LogicService_CreateWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhooksResponse)
+	err := c.cc.Invoke(ctx, LogicService_ListWebhooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteWebhookResponse)
+	err := c.cc.Invoke(ctx, LogicService_DeleteWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) CreateAttachmentUpload(ctx context.Context, in *CreateAttachmentUploadRequest, opts ...grpc.CallOption) (*AttachmentUpload, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AttachmentUpload)
+	err := c.cc.Invoke(ctx, LogicService_CreateAttachmentUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) UploadAttachmentChunk(ctx context.Context, in *UploadAttachmentChunkRequest, opts ...grpc.CallOption) (*UploadAttachmentChunkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadAttachmentChunkResponse)
+	err := c.cc.Invoke(ctx, LogicService_UploadAttachmentChunk_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) GetAttachmentUpload(ctx context.Context, in *GetAttachmentUploadRequest, opts ...grpc.CallOption) (*AttachmentUpload, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AttachmentUpload)
+	err := c.cc.Invoke(ctx, LogicService_GetAttachmentUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) CompleteAttachmentUpload(ctx context.Context, in *CompleteAttachmentUploadRequest, opts ...grpc.CallOption) (*AttachmentUpload, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AttachmentUpload)
+	err := c.cc.Invoke(ctx, LogicService_CompleteAttachmentUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) GetAttachment(ctx context.Context, in *GetAttachmentRequest, opts ...grpc.CallOption) (*Attachment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Attachment)
+	err := c.cc.Invoke(ctx, LogicService_GetAttachment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) ListConversations(ctx context.Context, in *ListConversationsRequest, opts ...grpc.CallOption) (*ListConversationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListConversationsResponse)
+	err := c.cc.Invoke(ctx, LogicService_ListConversations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetHistoryResponse)
+	err := c.cc.Invoke(ctx, LogicService_GetHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) SyncSince(ctx context.Context, in *SyncSinceRequest, opts ...grpc.CallOption) (*SyncSinceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SyncSinceResponse)
+	err := c.cc.Invoke(ctx, LogicService_SyncSince_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) DeleteHistoryMessages(ctx context.Context, in *DeleteHistoryMessagesRequest, opts ...grpc.CallOption) (*DeleteHistoryMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteHistoryMessagesResponse)
+	err := c.cc.Invoke(ctx, LogicService_DeleteHistoryMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) GetUnreadCount(ctx context.Context, in *GetUnreadCountRequest, opts ...grpc.CallOption) (*GetUnreadCountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUnreadCountResponse)
+	err := c.cc.Invoke(ctx, LogicService_GetUnreadCount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) GetNotificationPreferences(ctx context.Context, in *GetNotificationPreferencesRequest, opts ...grpc.CallOption) (*NotificationPreferences, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NotificationPreferences)
+	err := c.cc.Invoke(ctx, LogicService_GetNotificationPreferences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) UpdateNotificationPreferences(ctx context.Context, in *UpdateNotificationPreferencesRequest, opts ...grpc.CallOption) (*NotificationPreferences, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NotificationPreferences)
+	err := c.cc.Invoke(ctx, LogicService_UpdateNotificationPreferences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) CreateBroadcastJob(ctx context.Context, in *CreateBroadcastJobRequest, opts ...grpc.CallOption) (*BroadcastJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BroadcastJobResponse)
+	err := c.cc.Invoke(ctx, LogicService_CreateBroadcastJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) GetBroadcastJob(ctx context.Context, in *BroadcastJobRequest, opts ...grpc.CallOption) (*BroadcastJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BroadcastJobResponse)
+	err := c.cc.Invoke(ctx, LogicService_GetBroadcastJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) PauseBroadcastJob(ctx context.Context, in *BroadcastJobRequest, opts ...grpc.CallOption) (*BroadcastJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BroadcastJobResponse)
+	err := c.cc.Invoke(ctx, LogicService_PauseBroadcastJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) ResumeBroadcastJob(ctx context.Context, in *BroadcastJobRequest, opts ...grpc.CallOption) (*BroadcastJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BroadcastJobResponse)
+	err := c.cc.Invoke(ctx, LogicService_ResumeBroadcastJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicServiceClient) CancelBroadcastJob(ctx context.Context, in *BroadcastJobRequest, opts ...grpc.CallOption) (*BroadcastJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BroadcastJobResponse)
+	err := c.cc.Invoke(ctx, LogicService_CancelBroadcastJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LogicServiceServer is the server API for LogicService service.
 // All implementations must embed UnimplementedLogicServiceServer
 // for forward compatibility.
 type LogicServiceServer interface {
-	// 接收原始消息，返回是否允许发送
+	// 同步发送聊天消息，供服务端集成（机器人、业务后台）调用
+	// 与kafka上行链路执行相同的校验和路由，返回消息ID和会话序号
 	ValidateAndProcessMessage(context.Context, *ChatInputRequest) (*ChatInputResponse, error)
 	// 系统主动推送（公告、通知）
 	SendSystemPush(context.Context, *SystemPushRequest) (*SystemPushResponse, error)
+	// 查询定时推送计划
+	ListSystemPushSchedules(context.Context, *ListSystemPushSchedulesRequest) (*ListSystemPushSchedulesResponse, error)
+	// 取消待执行的定时推送计划
+	CancelSystemPushSchedule(context.Context, *CancelSystemPushScheduleRequest) (*CancelSystemPushScheduleResponse, error)
+	// 创建推送模板
+	CreatePushTemplate(context.Context, *CreatePushTemplateRequest) (*PushTemplate, error)
+	// 更新推送模板，整体替换变量和多语言内容
+	UpdatePushTemplate(context.Context, *UpdatePushTemplateRequest) (*PushTemplate, error)
+	// 查询推送模板
+	GetPushTemplate(context.Context, *GetPushTemplateRequest) (*PushTemplate, error)
+	// 分页查询推送模板
+	ListPushTemplates(context.Context, *ListPushTemplatesRequest) (*ListPushTemplatesResponse, error)
+	// 删除推送模板，引用该模板的定时推送计划到期后发送失败
+	DeletePushTemplate(context.Context, *DeletePushTemplateRequest) (*DeletePushTemplateResponse, error)
+	// 设置当前用户的语言偏好，用于选择推送模板的语言版本
+	SetUserLocale(context.Context, *SetUserLocaleRequest) (*SetUserLocaleResponse, error)
+	// 更新用户属性和标签，用于系统推送按分群选择接收者
+	UpdateUserAttributes(context.Context, *UpdateUserAttributesRequest) (*UserAttributes, error)
+	// 查询用户属性和标签
+	GetUserAttributes(context.Context, *GetUserAttributesRequest) (*UserAttributes, error)
+	// 预览分群命中的用户数，发送前确认推送范围
+	PreviewSegment(context.Context, *PreviewSegmentRequest) (*PreviewSegmentResponse, error)
+	// 查询推送内容的送达漏斗，按小时或天汇总
+	GetDeliveryFunnel(context.Context, *GetDeliveryFunnelRequest) (*GetDeliveryFunnelResponse, error)
+	// 注册数据上报结构，同名结构每次注册生成新版本，已注册的版本不可修改
+	RegisterReportSchema(context.Context, *RegisterReportSchemaRequest) (*ReportSchema, error)
+	// 查询数据上报结构，name为空时返回全部结构的全部版本
+	ListReportSchemas(context.Context, *ListReportSchemasRequest) (*ListReportSchemasResponse, error)
+	// 按用户和时间范围查询已存储的数据上报
+	QueryDataReports(context.Context, *QueryDataReportsRequest) (*QueryDataReportsResponse, error)
+	// 创建机器人账号，token只在创建时返回一次
+	CreateBot(context.Context, *CreateBotRequest) (*CreateBotResponse, error)
+	// 删除机器人账号，已签发的token立即失效
+	DeleteBot(context.Context, *DeleteBotRequest) (*DeleteBotResponse, error)
+	// 机器人发送聊天消息，请求头 Authorization: Bot <token>
+	SendBotMessage(context.Context, *SendBotMessageRequest) (*SendBotMessageResponse, error)
+	// 创建webhook订阅，签名密钥只在创建时返回一次
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error)
+	// 查询全部webhook订阅
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error)
+	// 删除webhook订阅，尚未投递的事件不再投递
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error)
+	// 创建附件上传，内容全部上传后在完成时按sha256去重
+	CreateAttachmentUpload(context.Context, *CreateAttachmentUploadRequest) (*AttachmentUpload, error)
+	// 上传附件分片，同一分片可以重复上传，用于断点续传
+	UploadAttachmentChunk(context.Context, *UploadAttachmentChunkRequest) (*UploadAttachmentChunkResponse, error)
+	// 查询附件上传进度，断点续传时只需上传缺少的分片
+	GetAttachmentUpload(context.Context, *GetAttachmentUploadRequest) (*AttachmentUpload, error)
+	// 所有分片上传完成后合并为附件，内容相同的附件只保存一份
+	CompleteAttachmentUpload(context.Context, *CompleteAttachmentUploadRequest) (*AttachmentUpload, error)
+	// 查询附件信息，返回带签名的下载地址
+	GetAttachment(context.Context, *GetAttachmentRequest) (*Attachment, error)
+	// 分页查询当前用户的会话列表，置顶会话在前，其余按最后一条消息时间倒序
+	ListConversations(context.Context, *ListConversationsRequest) (*ListConversationsResponse, error)
+	// 按消息ID游标分页查询会话历史消息，before_msg_id向前翻页，after_msg_id用于多端同步新消息
+	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
+	// 按会话序号增量同步消息，用于补齐客户端缺失的消息
+	SyncSince(context.Context, *SyncSinceRequest) (*SyncSinceResponse, error)
+	// 删除当前用户的历史消息，仅对自己不可见
+	DeleteHistoryMessages(context.Context, *DeleteHistoryMessagesRequest) (*DeleteHistoryMessagesResponse, error)
+	// 查询当前用户的未读数，包括总数和各会话未读数
+	GetUnreadCount(context.Context, *GetUnreadCountRequest) (*GetUnreadCountResponse, error)
+	// 查询当前用户的通知偏好，包括退订的推送类型、勿扰时段和免打扰的会话
+	GetNotificationPreferences(context.Context, *GetNotificationPreferencesRequest) (*NotificationPreferences, error)
+	// 更新当前用户的通知偏好
+	UpdateNotificationPreferences(context.Context, *UpdateNotificationPreferencesRequest) (*NotificationPreferences, error)
+	// 创建广播任务（超过1000个目标用户的系统推送）
+	CreateBroadcastJob(context.Context, *CreateBroadcastJobRequest) (*BroadcastJobResponse, error)
+	// 查询广播任务进度
+	GetBroadcastJob(context.Context, *BroadcastJobRequest) (*BroadcastJobResponse, error)
+	// 暂停广播任务
+	PauseBroadcastJob(context.Context, *BroadcastJobRequest) (*BroadcastJobResponse, error)
+	// 恢复已暂停的广播任务
+	ResumeBroadcastJob(context.Context, *BroadcastJobRequest) (*BroadcastJobResponse, error)
+	// 取消广播任务，未发送的分片不再投递
+	CancelBroadcastJob(context.Context, *BroadcastJobRequest) (*BroadcastJobResponse, error)
+	mustEmbedUnimplementedLogicServiceServer()
+}
+
+// UnimplementedLogicServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLogicServiceServer struct{}
+
+func (UnimplementedLogicServiceServer) ValidateAndProcessMessage(context.Context, *ChatInputRequest) (*ChatInputResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateAndProcessMessage not implemented")
+}
+func (UnimplementedLogicServiceServer) SendSystemPush(context.Context, *SystemPushRequest) (*SystemPushResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendSystemPush not implemented")
+}
+func (UnimplementedLogicServiceServer) ListSystemPushSchedules(context.Context, *ListSystemPushSchedulesRequest) (*ListSystemPushSchedulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSystemPushSchedules not implemented")
+}
+func (UnimplementedLogicServiceServer) CancelSystemPushSchedule(context.Context, *CancelSystemPushScheduleRequest) (*CancelSystemPushScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelSystemPushSchedule not implemented")
+}
+func (UnimplementedLogicServiceServer) CreatePushTemplate(context.Context, *CreatePushTemplateRequest) (*PushTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePushTemplate not implemented")
+}
+func (UnimplementedLogicServiceServer) UpdatePushTemplate(context.Context, *UpdatePushTemplateRequest) (*PushTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePushTemplate not implemented")
+}
+func (UnimplementedLogicServiceServer) GetPushTemplate(context.Context, *GetPushTemplateRequest) (*PushTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPushTemplate not implemented")
+}
+func (UnimplementedLogicServiceServer) ListPushTemplates(context.Context, *ListPushTemplatesRequest) (*ListPushTemplatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPushTemplates not implemented")
+}
+func (UnimplementedLogicServiceServer) DeletePushTemplate(context.Context, *DeletePushTemplateRequest) (*DeletePushTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePushTemplate not implemented")
+}
+func (UnimplementedLogicServiceServer) SetUserLocale(context.Context, *SetUserLocaleRequest) (*SetUserLocaleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetUserLocale not implemented")
+}
+func (UnimplementedLogicServiceServer) UpdateUserAttributes(context.Context, *UpdateUserAttributesRequest) (*UserAttributes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUserAttributes not implemented")
+}
+func (UnimplementedLogicServiceServer) GetUserAttributes(context.Context, *GetUserAttributesRequest) (*UserAttributes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserAttributes not implemented")
+}
+func (UnimplementedLogicServiceServer) PreviewSegment(context.Context, *PreviewSegmentRequest) (*PreviewSegmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewSegment not implemented")
+}
+func (UnimplementedLogicServiceServer) GetDeliveryFunnel(context.Context, *GetDeliveryFunnelRequest) (*GetDeliveryFunnelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeliveryFunnel not implemented")
+}
+func (UnimplementedLogicServiceServer) RegisterReportSchema(context.Context, *RegisterReportSchemaRequest) (*ReportSchema, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterReportSchema not implemented")
+}
+func (UnimplementedLogicServiceServer) ListReportSchemas(context.Context, *ListReportSchemasRequest) (*ListReportSchemasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReportSchemas not implemented")
+}
+func (UnimplementedLogicServiceServer) QueryDataReports(context.Context, *QueryDataReportsRequest) (*QueryDataReportsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryDataReports not implemented")
+}
+func (UnimplementedLogicServiceServer) CreateBot(context.Context, *CreateBotRequest) (*CreateBotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBot not implemented")
+}
+func (UnimplementedLogicServiceServer) DeleteBot(context.Context, *DeleteBotRequest) (*DeleteBotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBot not implemented")
+}
+func (UnimplementedLogicServiceServer) SendBotMessage(context.Context, *SendBotMessageRequest) (*SendBotMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendBotMessage not implemented")
+}
+func (UnimplementedLogicServiceServer) CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedLogicServiceServer) ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedLogicServiceServer) DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedLogicServiceServer) CreateAttachmentUpload(context.Context, *CreateAttachmentUploadRequest) (*AttachmentUpload, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAttachmentUpload not implemented")
+}
+func (UnimplementedLogicServiceServer) UploadAttachmentChunk(context.Context, *UploadAttachmentChunkRequest) (*UploadAttachmentChunkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadAttachmentChunk not implemented")
+}
+func (UnimplementedLogicServiceServer) GetAttachmentUpload(context.Context, *GetAttachmentUploadRequest) (*AttachmentUpload, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAttachmentUpload not implemented")
+}
+func (UnimplementedLogicServiceServer) CompleteAttachmentUpload(context.Context, *CompleteAttachmentUploadRequest) (*AttachmentUpload, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteAttachmentUpload not implemented")
+}
+func (UnimplementedLogicServiceServer) GetAttachment(context.Context, *GetAttachmentRequest) (*Attachment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAttachment not implemented")
+}
+func (UnimplementedLogicServiceServer) ListConversations(context.Context, *ListConversationsRequest) (*ListConversationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListConversations not implemented")
+}
+func (UnimplementedLogicServiceServer) GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHistory not implemented")
+}
+func (UnimplementedLogicServiceServer) SyncSince(context.Context, *SyncSinceRequest) (*SyncSinceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncSince not implemented")
+}
+func (UnimplementedLogicServiceServer) DeleteHistoryMessages(context.Context, *DeleteHistoryMessagesRequest) (*DeleteHistoryMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteHistoryMessages not implemented")
+}
+func (UnimplementedLogicServiceServer) GetUnreadCount(context.Context, *GetUnreadCountRequest) (*GetUnreadCountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUnreadCount not implemented")
+}
+func (UnimplementedLogicServiceServer) GetNotificationPreferences(context.Context, *GetNotificationPreferencesRequest) (*NotificationPreferences, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNotificationPreferences not implemented")
+}
+func (UnimplementedLogicServiceServer) UpdateNotificationPreferences(context.Context, *UpdateNotificationPreferencesRequest) (*NotificationPreferences, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateNotificationPreferences not implemented")
+}
+func (UnimplementedLogicServiceServer) CreateBroadcastJob(context.Context, *CreateBroadcastJobRequest) (*BroadcastJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBroadcastJob not implemented")
+}
+func (UnimplementedLogicServiceServer) GetBroadcastJob(context.Context, *BroadcastJobRequest) (*BroadcastJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBroadcastJob not implemented")
+}
+func (UnimplementedLogicServiceServer) PauseBroadcastJob(context.Context, *BroadcastJobRequest) (*BroadcastJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseBroadcastJob not implemented")
+}
+func (UnimplementedLogicServiceServer) ResumeBroadcastJob(context.Context, *BroadcastJobRequest) (*BroadcastJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeBroadcastJob not implemented")
+}
+func (UnimplementedLogicServiceServer) CancelBroadcastJob(context.Context, *BroadcastJobRequest) (*BroadcastJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelBroadcastJob not implemented")
+}
+func (UnimplementedLogicServiceServer) mustEmbedUnimplementedLogicServiceServer() {}
+func (UnimplementedLogicServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeLogicServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LogicServiceServer will
+// result in compilation errors.
+type UnsafeLogicServiceServer interface {
 	mustEmbedUnimplementedLogicServiceServer()
 }
 
-// UnimplementedLogicServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedLogicServiceServer struct{}
+func RegisterLogicServiceServer(s grpc.ServiceRegistrar, srv LogicServiceServer) {
+	// If the following call pancis, it indicates UnimplementedLogicServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&LogicService_ServiceDesc, srv)
+}
+
+func _LogicService_ValidateAndProcessMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChatInputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).ValidateAndProcessMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_ValidateAndProcessMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).ValidateAndProcessMessage(ctx, req.(*ChatInputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_SendSystemPush_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SystemPushRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).SendSystemPush(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_SendSystemPush_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).SendSystemPush(ctx, req.(*SystemPushRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_ListSystemPushSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSystemPushSchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).ListSystemPushSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_ListSystemPushSchedules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).ListSystemPushSchedules(ctx, req.(*ListSystemPushSchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_CancelSystemPushSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelSystemPushScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).CancelSystemPushSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_CancelSystemPushSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).CancelSystemPushSchedule(ctx, req.(*CancelSystemPushScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_CreatePushTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePushTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).CreatePushTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_CreatePushTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).CreatePushTemplate(ctx, req.(*CreatePushTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_UpdatePushTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePushTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).UpdatePushTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_UpdatePushTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).UpdatePushTemplate(ctx, req.(*UpdatePushTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_GetPushTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPushTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).GetPushTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_GetPushTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).GetPushTemplate(ctx, req.(*GetPushTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_ListPushTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPushTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).ListPushTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_ListPushTemplates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).ListPushTemplates(ctx, req.(*ListPushTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_DeletePushTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePushTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).DeletePushTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_DeletePushTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).DeletePushTemplate(ctx, req.(*DeletePushTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_SetUserLocale_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUserLocaleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).SetUserLocale(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_SetUserLocale_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).SetUserLocale(ctx, req.(*SetUserLocaleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_UpdateUserAttributes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserAttributesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).UpdateUserAttributes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_UpdateUserAttributes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).UpdateUserAttributes(ctx, req.(*UpdateUserAttributesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_GetUserAttributes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserAttributesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).GetUserAttributes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_GetUserAttributes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).GetUserAttributes(ctx, req.(*GetUserAttributesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_PreviewSegment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewSegmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).PreviewSegment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_PreviewSegment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).PreviewSegment(ctx, req.(*PreviewSegmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_GetDeliveryFunnel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeliveryFunnelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).GetDeliveryFunnel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_GetDeliveryFunnel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).GetDeliveryFunnel(ctx, req.(*GetDeliveryFunnelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_RegisterReportSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterReportSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).RegisterReportSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_RegisterReportSchema_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).RegisterReportSchema(ctx, req.(*RegisterReportSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_ListReportSchemas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReportSchemasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).ListReportSchemas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_ListReportSchemas_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).ListReportSchemas(ctx, req.(*ListReportSchemasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_QueryDataReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryDataReportsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).QueryDataReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_QueryDataReports_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).QueryDataReports(ctx, req.(*QueryDataReportsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_CreateBot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).CreateBot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_CreateBot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).CreateBot(ctx, req.(*CreateBotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_DeleteBot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).DeleteBot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_DeleteBot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).DeleteBot(ctx, req.(*DeleteBotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_SendBotMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendBotMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).SendBotMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_SendBotMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).SendBotMessage(ctx, req.(*SendBotMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_CreateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_ListWebhooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).ListWebhooks(ctx, req.(*ListWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_DeleteWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_CreateAttachmentUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAttachmentUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).CreateAttachmentUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_CreateAttachmentUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).CreateAttachmentUpload(ctx, req.(*CreateAttachmentUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_UploadAttachmentChunk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadAttachmentChunkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).UploadAttachmentChunk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_UploadAttachmentChunk_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).UploadAttachmentChunk(ctx, req.(*UploadAttachmentChunkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_GetAttachmentUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAttachmentUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).GetAttachmentUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_GetAttachmentUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).GetAttachmentUpload(ctx, req.(*GetAttachmentUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_CompleteAttachmentUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteAttachmentUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).CompleteAttachmentUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_CompleteAttachmentUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).CompleteAttachmentUpload(ctx, req.(*CompleteAttachmentUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_GetAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAttachmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).GetAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_GetAttachment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).GetAttachment(ctx, req.(*GetAttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-func (UnimplementedLogicServiceServer) ValidateAndProcessMessage(context.Context, *ChatInputRequest) (*ChatInputResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ValidateAndProcessMessage not implemented")
+func _LogicService_ListConversations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConversationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).ListConversations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_ListConversations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).ListConversations(ctx, req.(*ListConversationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedLogicServiceServer) SendSystemPush(context.Context, *SystemPushRequest) (*SystemPushResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SendSystemPush not implemented")
+
+func _LogicService_GetHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).GetHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_GetHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).GetHistory(ctx, req.(*GetHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedLogicServiceServer) mustEmbedUnimplementedLogicServiceServer() {}
-func (UnimplementedLogicServiceServer) testEmbeddedByValue()                      {}
 
-// UnsafeLogicServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to LogicServiceServer will
-// result in compilation errors.
-type UnsafeLogicServiceServer interface {
-	mustEmbedUnimplementedLogicServiceServer()
+func _LogicService_SyncSince_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncSinceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).SyncSince(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_SyncSince_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).SyncSince(ctx, req.(*SyncSinceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterLogicServiceServer(s grpc.ServiceRegistrar, srv LogicServiceServer) {
-	// If the following call pancis, it indicates UnimplementedLogicServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _LogicService_DeleteHistoryMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteHistoryMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&LogicService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(LogicServiceServer).DeleteHistoryMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_DeleteHistoryMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).DeleteHistoryMessages(ctx, req.(*DeleteHistoryMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _LogicService_ValidateAndProcessMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChatInputRequest)
+func _LogicService_GetUnreadCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUnreadCountRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LogicServiceServer).ValidateAndProcessMessage(ctx, in)
+		return srv.(LogicServiceServer).GetUnreadCount(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: LogicService_ValidateAndProcessMessage_FullMethodName,
+		FullMethod: LogicService_GetUnreadCount_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LogicServiceServer).ValidateAndProcessMessage(ctx, req.(*ChatInputRequest))
+		return srv.(LogicServiceServer).GetUnreadCount(ctx, req.(*GetUnreadCountRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LogicService_SendSystemPush_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SystemPushRequest)
+func _LogicService_GetNotificationPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNotificationPreferencesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LogicServiceServer).SendSystemPush(ctx, in)
+		return srv.(LogicServiceServer).GetNotificationPreferences(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: LogicService_SendSystemPush_FullMethodName,
+		FullMethod: LogicService_GetNotificationPreferences_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LogicServiceServer).SendSystemPush(ctx, req.(*SystemPushRequest))
+		return srv.(LogicServiceServer).GetNotificationPreferences(ctx, req.(*GetNotificationPreferencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_UpdateNotificationPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNotificationPreferencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).UpdateNotificationPreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_UpdateNotificationPreferences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).UpdateNotificationPreferences(ctx, req.(*UpdateNotificationPreferencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_CreateBroadcastJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBroadcastJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).CreateBroadcastJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_CreateBroadcastJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).CreateBroadcastJob(ctx, req.(*CreateBroadcastJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_GetBroadcastJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BroadcastJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).GetBroadcastJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_GetBroadcastJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).GetBroadcastJob(ctx, req.(*BroadcastJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_PauseBroadcastJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BroadcastJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).PauseBroadcastJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_PauseBroadcastJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).PauseBroadcastJob(ctx, req.(*BroadcastJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_ResumeBroadcastJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BroadcastJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).ResumeBroadcastJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_ResumeBroadcastJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).ResumeBroadcastJob(ctx, req.(*BroadcastJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogicService_CancelBroadcastJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BroadcastJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServiceServer).CancelBroadcastJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogicService_CancelBroadcastJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServiceServer).CancelBroadcastJob(ctx, req.(*BroadcastJobRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -157,6 +1527,158 @@ var LogicService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SendSystemPush",
 			Handler:    _LogicService_SendSystemPush_Handler,
 		},
+		{
+			MethodName: "ListSystemPushSchedules",
+			Handler:    _LogicService_ListSystemPushSchedules_Handler,
+		},
+		{
+			MethodName: "CancelSystemPushSchedule",
+			Handler:    _LogicService_CancelSystemPushSchedule_Handler,
+		},
+		{
+			MethodName: "CreatePushTemplate",
+			Handler:    _LogicService_CreatePushTemplate_Handler,
+		},
+		{
+			MethodName: "UpdatePushTemplate",
+			Handler:    _LogicService_UpdatePushTemplate_Handler,
+		},
+		{
+			MethodName: "GetPushTemplate",
+			Handler:    _LogicService_GetPushTemplate_Handler,
+		},
+		{
+			MethodName: "ListPushTemplates",
+			Handler:    _LogicService_ListPushTemplates_Handler,
+		},
+		{
+			MethodName: "DeletePushTemplate",
+			Handler:    _LogicService_DeletePushTemplate_Handler,
+		},
+		{
+			MethodName: "SetUserLocale",
+			Handler:    _LogicService_SetUserLocale_Handler,
+		},
+		{
+			MethodName: "UpdateUserAttributes",
+			Handler:    _LogicService_UpdateUserAttributes_Handler,
+		},
+		{
+			MethodName: "GetUserAttributes",
+			Handler:    _LogicService_GetUserAttributes_Handler,
+		},
+		{
+			MethodName: "PreviewSegment",
+			Handler:    _LogicService_PreviewSegment_Handler,
+		},
+		{
+			MethodName: "GetDeliveryFunnel",
+			Handler:    _LogicService_GetDeliveryFunnel_Handler,
+		},
+		{
+			MethodName: "RegisterReportSchema",
+			Handler:    _LogicService_RegisterReportSchema_Handler,
+		},
+		{
+			MethodName: "ListReportSchemas",
+			Handler:    _LogicService_ListReportSchemas_Handler,
+		},
+		{
+			MethodName: "QueryDataReports",
+			Handler:    _LogicService_QueryDataReports_Handler,
+		},
+		{
+			MethodName: "CreateBot",
+			Handler:    _LogicService_CreateBot_Handler,
+		},
+		{
+			MethodName: "DeleteBot",
+			Handler:    _LogicService_DeleteBot_Handler,
+		},
+		{
+			MethodName: "SendBotMessage",
+			Handler:    _LogicService_SendBotMessage_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _LogicService_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _LogicService_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _LogicService_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "CreateAttachmentUpload",
+			Handler:    _LogicService_CreateAttachmentUpload_Handler,
+		},
+		{
+			MethodName: "UploadAttachmentChunk",
+			Handler:    _LogicService_UploadAttachmentChunk_Handler,
+		},
+		{
+			MethodName: "GetAttachmentUpload",
+			Handler:    _LogicService_GetAttachmentUpload_Handler,
+		},
+		{
+			MethodName: "CompleteAttachmentUpload",
+			Handler:    _LogicService_CompleteAttachmentUpload_Handler,
+		},
+		{
+			MethodName: "GetAttachment",
+			Handler:    _LogicService_GetAttachment_Handler,
+		},
+		{
+			MethodName: "ListConversations",
+			Handler:    _LogicService_ListConversations_Handler,
+		},
+		{
+			MethodName: "GetHistory",
+			Handler:    _LogicService_GetHistory_Handler,
+		},
+		{
+			MethodName: "SyncSince",
+			Handler:    _LogicService_SyncSince_Handler,
+		},
+		{
+			MethodName: "DeleteHistoryMessages",
+			Handler:    _LogicService_DeleteHistoryMessages_Handler,
+		},
+		{
+			MethodName: "GetUnreadCount",
+			Handler:    _LogicService_GetUnreadCount_Handler,
+		},
+		{
+			MethodName: "GetNotificationPreferences",
+			Handler:    _LogicService_GetNotificationPreferences_Handler,
+		},
+		{
+			MethodName: "UpdateNotificationPreferences",
+			Handler:    _LogicService_UpdateNotificationPreferences_Handler,
+		},
+		{
+			MethodName: "CreateBroadcastJob",
+			Handler:    _LogicService_CreateBroadcastJob_Handler,
+		},
+		{
+			MethodName: "GetBroadcastJob",
+			Handler:    _LogicService_GetBroadcastJob_Handler,
+		},
+		{
+			MethodName: "PauseBroadcastJob",
+			Handler:    _LogicService_PauseBroadcastJob_Handler,
+		},
+		{
+			MethodName: "ResumeBroadcastJob",
+			Handler:    _LogicService_ResumeBroadcastJob_Handler,
+		},
+		{
+			MethodName: "CancelBroadcastJob",
+			Handler:    _LogicService_CancelBroadcastJob_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "logic/v1/logic.proto",
@@ -19,16 +19,168 @@ var _ = binding.EncodeURL
 
 const _ = http.SupportPackageIsVersion1
 
+const OperationLogicServiceCancelBroadcastJob = "/logic.v1.LogicService/CancelBroadcastJob"
+const OperationLogicServiceCancelSystemPushSchedule = "/logic.v1.LogicService/CancelSystemPushSchedule"
+const OperationLogicServiceCompleteAttachmentUpload = "/logic.v1.LogicService/CompleteAttachmentUpload"
+const OperationLogicServiceCreateAttachmentUpload = "/logic.v1.LogicService/CreateAttachmentUpload"
+const OperationLogicServiceCreateBot = "/logic.v1.LogicService/CreateBot"
+const OperationLogicServiceCreateBroadcastJob = "/logic.v1.LogicService/CreateBroadcastJob"
+const OperationLogicServiceCreatePushTemplate = "/logic.v1.LogicService/CreatePushTemplate"
+const OperationLogicServiceCreateWebhook = "/logic.v1.LogicService/CreateWebhook"
+const OperationLogicServiceDeleteBot = "/logic.v1.LogicService/DeleteBot"
+const OperationLogicServiceDeleteHistoryMessages = "/logic.v1.LogicService/DeleteHistoryMessages"
+const OperationLogicServiceDeletePushTemplate = "/logic.v1.LogicService/DeletePushTemplate"
+const OperationLogicServiceDeleteWebhook = "/logic.v1.LogicService/DeleteWebhook"
+const OperationLogicServiceGetAttachment = "/logic.v1.LogicService/GetAttachment"
+const OperationLogicServiceGetAttachmentUpload = "/logic.v1.LogicService/GetAttachmentUpload"
+const OperationLogicServiceGetBroadcastJob = "/logic.v1.LogicService/GetBroadcastJob"
+const OperationLogicServiceGetDeliveryFunnel = "/logic.v1.LogicService/GetDeliveryFunnel"
+const OperationLogicServiceGetHistory = "/logic.v1.LogicService/GetHistory"
+const OperationLogicServiceGetNotificationPreferences = "/logic.v1.LogicService/GetNotificationPreferences"
+const OperationLogicServiceGetPushTemplate = "/logic.v1.LogicService/GetPushTemplate"
+const OperationLogicServiceGetUnreadCount = "/logic.v1.LogicService/GetUnreadCount"
+const OperationLogicServiceGetUserAttributes = "/logic.v1.LogicService/GetUserAttributes"
+const OperationLogicServiceListConversations = "/logic.v1.LogicService/ListConversations"
+const OperationLogicServiceListPushTemplates = "/logic.v1.LogicService/ListPushTemplates"
+const OperationLogicServiceListReportSchemas = "/logic.v1.LogicService/ListReportSchemas"
+const OperationLogicServiceListSystemPushSchedules = "/logic.v1.LogicService/ListSystemPushSchedules"
+const OperationLogicServiceListWebhooks = "/logic.v1.LogicService/ListWebhooks"
+const OperationLogicServicePauseBroadcastJob = "/logic.v1.LogicService/PauseBroadcastJob"
+const OperationLogicServicePreviewSegment = "/logic.v1.LogicService/PreviewSegment"
+const OperationLogicServiceQueryDataReports = "/logic.v1.LogicService/QueryDataReports"
+const OperationLogicServiceRegisterReportSchema = "/logic.v1.LogicService/RegisterReportSchema"
+const OperationLogicServiceResumeBroadcastJob = "/logic.v1.LogicService/ResumeBroadcastJob"
+const OperationLogicServiceSendBotMessage = "/logic.v1.LogicService/SendBotMessage"
 const OperationLogicServiceSendSystemPush = "/logic.v1.LogicService/SendSystemPush"
+const OperationLogicServiceSetUserLocale = "/logic.v1.LogicService/SetUserLocale"
+const OperationLogicServiceSyncSince = "/logic.v1.LogicService/SyncSince"
+const OperationLogicServiceUpdateNotificationPreferences = "/logic.v1.LogicService/UpdateNotificationPreferences"
+const OperationLogicServiceUpdatePushTemplate = "/logic.v1.LogicService/UpdatePushTemplate"
+const OperationLogicServiceUpdateUserAttributes = "/logic.v1.LogicService/UpdateUserAttributes"
+const OperationLogicServiceUploadAttachmentChunk = "/logic.v1.LogicService/UploadAttachmentChunk"
 
 type LogicServiceHTTPServer interface {
+	// CancelBroadcastJob 取消广播任务，未发送的分片不再投递
+	CancelBroadcastJob(context.Context, *BroadcastJobRequest) (*BroadcastJobResponse, error)
+	// CancelSystemPushSchedule 取消待执行的定时推送计划
+	CancelSystemPushSchedule(context.Context, *CancelSystemPushScheduleRequest) (*CancelSystemPushScheduleResponse, error)
+	// CompleteAttachmentUpload 所有分片上传完成后合并为附件，内容相同的附件只保存一份
+	CompleteAttachmentUpload(context.Context, *CompleteAttachmentUploadRequest) (*AttachmentUpload, error)
+	// CreateAttachmentUpload 创建附件上传，内容全部上传后在完成时按sha256去重
+	CreateAttachmentUpload(context.Context, *CreateAttachmentUploadRequest) (*AttachmentUpload, error)
+	// CreateBot 创建机器人账号，token只在创建时返回一次
+	CreateBot(context.Context, *CreateBotRequest) (*CreateBotResponse, error)
+	// CreateBroadcastJob 创建广播任务（超过1000个目标用户的系统推送）
+	CreateBroadcastJob(context.Context, *CreateBroadcastJobRequest) (*BroadcastJobResponse, error)
+	// CreatePushTemplate 创建推送模板
+	CreatePushTemplate(context.Context, *CreatePushTemplateRequest) (*PushTemplate, error)
+	// CreateWebhook 创建webhook订阅，签名密钥只在创建时返回一次
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error)
+	// DeleteBot 删除机器人账号，已签发的token立即失效
+	DeleteBot(context.Context, *DeleteBotRequest) (*DeleteBotResponse, error)
+	// DeleteHistoryMessages 删除当前用户的历史消息，仅对自己不可见
+	DeleteHistoryMessages(context.Context, *DeleteHistoryMessagesRequest) (*DeleteHistoryMessagesResponse, error)
+	// DeletePushTemplate 删除推送模板，引用该模板的定时推送计划到期后发送失败
+	DeletePushTemplate(context.Context, *DeletePushTemplateRequest) (*DeletePushTemplateResponse, error)
+	// DeleteWebhook 删除webhook订阅，尚未投递的事件不再投递
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error)
+	// GetAttachment 查询附件信息，返回带签名的下载地址
+	GetAttachment(context.Context, *GetAttachmentRequest) (*Attachment, error)
+	// GetAttachmentUpload 查询附件上传进度，断点续传时只需上传缺少的分片
+	GetAttachmentUpload(context.Context, *GetAttachmentUploadRequest) (*AttachmentUpload, error)
+	// GetBroadcastJob 查询广播任务进度
+	GetBroadcastJob(context.Context, *BroadcastJobRequest) (*BroadcastJobResponse, error)
+	// GetDeliveryFunnel 查询推送内容的送达漏斗，按小时或天汇总
+	GetDeliveryFunnel(context.Context, *GetDeliveryFunnelRequest) (*GetDeliveryFunnelResponse, error)
+	// GetHistory 按消息ID游标分页查询会话历史消息，before_msg_id向前翻页，after_msg_id用于多端同步新消息
+	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
+	// GetNotificationPreferences 查询当前用户的通知偏好，包括退订的推送类型、勿扰时段和免打扰的会话
+	GetNotificationPreferences(context.Context, *GetNotificationPreferencesRequest) (*NotificationPreferences, error)
+	// GetPushTemplate 查询推送模板
+	GetPushTemplate(context.Context, *GetPushTemplateRequest) (*PushTemplate, error)
+	// GetUnreadCount 查询当前用户的未读数，包括总数和各会话未读数
+	GetUnreadCount(context.Context, *GetUnreadCountRequest) (*GetUnreadCountResponse, error)
+	// GetUserAttributes 查询用户属性和标签
+	GetUserAttributes(context.Context, *GetUserAttributesRequest) (*UserAttributes, error)
+	// ListConversations 分页查询当前用户的会话列表，置顶会话在前，其余按最后一条消息时间倒序
+	ListConversations(context.Context, *ListConversationsRequest) (*ListConversationsResponse, error)
+	// ListPushTemplates 分页查询推送模板
+	ListPushTemplates(context.Context, *ListPushTemplatesRequest) (*ListPushTemplatesResponse, error)
+	// ListReportSchemas 查询数据上报结构，name为空时返回全部结构的全部版本
+	ListReportSchemas(context.Context, *ListReportSchemasRequest) (*ListReportSchemasResponse, error)
+	// ListSystemPushSchedules 查询定时推送计划
+	ListSystemPushSchedules(context.Context, *ListSystemPushSchedulesRequest) (*ListSystemPushSchedulesResponse, error)
+	// ListWebhooks 查询全部webhook订阅
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error)
+	// PauseBroadcastJob 暂停广播任务
+	PauseBroadcastJob(context.Context, *BroadcastJobRequest) (*BroadcastJobResponse, error)
+	// PreviewSegment 预览分群命中的用户数，发送前确认推送范围
+	PreviewSegment(context.Context, *PreviewSegmentRequest) (*PreviewSegmentResponse, error)
+	// QueryDataReports 按用户和时间范围查询已存储的数据上报
+	QueryDataReports(context.Context, *QueryDataReportsRequest) (*QueryDataReportsResponse, error)
+	// RegisterReportSchema 注册数据上报结构，同名结构每次注册生成新版本，已注册的版本不可修改
+	RegisterReportSchema(context.Context, *RegisterReportSchemaRequest) (*ReportSchema, error)
+	// ResumeBroadcastJob 恢复已暂停的广播任务
+	ResumeBroadcastJob(context.Context, *BroadcastJobRequest) (*BroadcastJobResponse, error)
+	// SendBotMessage 机器人发送聊天消息，请求头 Authorization: Bot <token>
+	SendBotMessage(context.Context, *SendBotMessageRequest) (*SendBotMessageResponse, error)
 	// SendSystemPush 系统主动推送（公告、通知）
 	SendSystemPush(context.Context, *SystemPushRequest) (*SystemPushResponse, error)
+	// SetUserLocale 设置当前用户的语言偏好，用于选择推送模板的语言版本
+	SetUserLocale(context.Context, *SetUserLocaleRequest) (*SetUserLocaleResponse, error)
+	// SyncSince 按会话序号增量同步消息，用于补齐客户端缺失的消息
+	SyncSince(context.Context, *SyncSinceRequest) (*SyncSinceResponse, error)
+	// UpdateNotificationPreferences 更新当前用户的通知偏好
+	UpdateNotificationPreferences(context.Context, *UpdateNotificationPreferencesRequest) (*NotificationPreferences, error)
+	// UpdatePushTemplate 更新推送模板，整体替换变量和多语言内容
+	UpdatePushTemplate(context.Context, *UpdatePushTemplateRequest) (*PushTemplate, error)
+	// UpdateUserAttributes 更新用户属性和标签，用于系统推送按分群选择接收者
+	UpdateUserAttributes(context.Context, *UpdateUserAttributesRequest) (*UserAttributes, error)
+	// UploadAttachmentChunk 上传附件分片，同一分片可以重复上传，用于断点续传
+	UploadAttachmentChunk(context.Context, *UploadAttachmentChunkRequest) (*UploadAttachmentChunkResponse, error)
 }
 
 func RegisterLogicServiceHTTPServer(s *http.Server, srv LogicServiceHTTPServer) {
 	r := s.Route("/")
 	r.POST("/chatify/logic/v1/sendSystemPush", _LogicService_SendSystemPush0_HTTP_Handler(srv))
+	r.GET("/chatify/logic/v1/listSystemPushSchedules", _LogicService_ListSystemPushSchedules0_HTTP_Handler(srv))
+	r.POST("/chatify/logic/v1/cancelSystemPushSchedule", _LogicService_CancelSystemPushSchedule0_HTTP_Handler(srv))
+	r.POST("/chatify/logic/v1/createPushTemplate", _LogicService_CreatePushTemplate0_HTTP_Handler(srv))
+	r.POST("/chatify/logic/v1/updatePushTemplate", _LogicService_UpdatePushTemplate0_HTTP_Handler(srv))
+	r.GET("/chatify/logic/v1/getPushTemplate", _LogicService_GetPushTemplate0_HTTP_Handler(srv))
+	r.GET("/chatify/logic/v1/listPushTemplates", _LogicService_ListPushTemplates0_HTTP_Handler(srv))
+	r.POST("/chatify/logic/v1/deletePushTemplate", _LogicService_DeletePushTemplate0_HTTP_Handler(srv))
+	r.POST("/chatify/logic/v1/setUserLocale", _LogicService_SetUserLocale0_HTTP_Handler(srv))
+	r.POST("/chatify/logic/v1/updateUserAttributes", _LogicService_UpdateUserAttributes0_HTTP_Handler(srv))
+	r.GET("/chatify/logic/v1/getUserAttributes", _LogicService_GetUserAttributes0_HTTP_Handler(srv))
+	r.POST("/chatify/logic/v1/previewSegment", _LogicService_PreviewSegment0_HTTP_Handler(srv))
+	r.GET("/chatify/logic/v1/getDeliveryFunnel", _LogicService_GetDeliveryFunnel0_HTTP_Handler(srv))
+	r.POST("/chatify/logic/v1/registerReportSchema", _LogicService_RegisterReportSchema0_HTTP_Handler(srv))
+	r.GET("/chatify/logic/v1/listReportSchemas", _LogicService_ListReportSchemas0_HTTP_Handler(srv))
+	r.GET("/chatify/logic/v1/queryDataReports", _LogicService_QueryDataReports0_HTTP_Handler(srv))
+	r.POST("/chatify/logic/v1/createBot", _LogicService_CreateBot0_HTTP_Handler(srv))
+	r.POST("/chatify/logic/v1/deleteBot", _LogicService_DeleteBot0_HTTP_Handler(srv))
+	r.POST("/chatify/logic/v1/bot/sendMessage", _LogicService_SendBotMessage0_HTTP_Handler(srv))
+	r.POST("/chatify/logic/v1/createWebhook", _LogicService_CreateWebhook0_HTTP_Handler(srv))
+	r.GET("/chatify/logic/v1/listWebhooks", _LogicService_ListWebhooks0_HTTP_Handler(srv))
+	r.POST("/chatify/logic/v1/deleteWebhook", _LogicService_DeleteWebhook0_HTTP_Handler(srv))
+	r.POST("/chatify/logic/v1/createAttachmentUpload", _LogicService_CreateAttachmentUpload0_HTTP_Handler(srv))
+	r.POST("/chatify/logic/v1/uploadAttachmentChunk", _LogicService_UploadAttachmentChunk0_HTTP_Handler(srv))
+	r.GET("/chatify/logic/v1/getAttachmentUpload", _LogicService_GetAttachmentUpload0_HTTP_Handler(srv))
+	r.POST("/chatify/logic/v1/completeAttachmentUpload", _LogicService_CompleteAttachmentUpload0_HTTP_Handler(srv))
+	r.GET("/chatify/logic/v1/getAttachment", _LogicService_GetAttachment0_HTTP_Handler(srv))
+	r.GET("/chatify/logic/v1/listConversations", _LogicService_ListConversations0_HTTP_Handler(srv))
+	r.GET("/chatify/logic/v1/getHistory", _LogicService_GetHistory0_HTTP_Handler(srv))
+	r.GET("/chatify/logic/v1/syncSince", _LogicService_SyncSince0_HTTP_Handler(srv))
+	r.POST("/chatify/logic/v1/deleteHistoryMessages", _LogicService_DeleteHistoryMessages0_HTTP_Handler(srv))
+	r.GET("/chatify/logic/v1/getUnreadCount", _LogicService_GetUnreadCount0_HTTP_Handler(srv))
+	r.GET("/chatify/logic/v1/getNotificationPreferences", _LogicService_GetNotificationPreferences0_HTTP_Handler(srv))
+	r.POST("/chatify/logic/v1/updateNotificationPreferences", _LogicService_UpdateNotificationPreferences0_HTTP_Handler(srv))
+	r.POST("/chatify/logic/v1/createBroadcastJob", _LogicService_CreateBroadcastJob0_HTTP_Handler(srv))
+	r.GET("/chatify/logic/v1/getBroadcastJob", _LogicService_GetBroadcastJob0_HTTP_Handler(srv))
+	r.POST("/chatify/logic/v1/pauseBroadcastJob", _LogicService_PauseBroadcastJob0_HTTP_Handler(srv))
+	r.POST("/chatify/logic/v1/resumeBroadcastJob", _LogicService_ResumeBroadcastJob0_HTTP_Handler(srv))
+	r.POST("/chatify/logic/v1/cancelBroadcastJob", _LogicService_CancelBroadcastJob0_HTTP_Handler(srv))
 }
 
 func _LogicService_SendSystemPush0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
@@ -53,16 +205,1258 @@ func _LogicService_SendSystemPush0_HTTP_Handler(srv LogicServiceHTTPServer) func
 	}
 }
 
+func _LogicService_ListSystemPushSchedules0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListSystemPushSchedulesRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceListSystemPushSchedules)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListSystemPushSchedules(ctx, req.(*ListSystemPushSchedulesRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListSystemPushSchedulesResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_CancelSystemPushSchedule0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in CancelSystemPushScheduleRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceCancelSystemPushSchedule)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.CancelSystemPushSchedule(ctx, req.(*CancelSystemPushScheduleRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*CancelSystemPushScheduleResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_CreatePushTemplate0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in CreatePushTemplateRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceCreatePushTemplate)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.CreatePushTemplate(ctx, req.(*CreatePushTemplateRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*PushTemplate)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_UpdatePushTemplate0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in UpdatePushTemplateRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceUpdatePushTemplate)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.UpdatePushTemplate(ctx, req.(*UpdatePushTemplateRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*PushTemplate)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_GetPushTemplate0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetPushTemplateRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceGetPushTemplate)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetPushTemplate(ctx, req.(*GetPushTemplateRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*PushTemplate)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_ListPushTemplates0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListPushTemplatesRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceListPushTemplates)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListPushTemplates(ctx, req.(*ListPushTemplatesRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListPushTemplatesResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_DeletePushTemplate0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in DeletePushTemplateRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceDeletePushTemplate)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.DeletePushTemplate(ctx, req.(*DeletePushTemplateRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*DeletePushTemplateResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_SetUserLocale0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in SetUserLocaleRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceSetUserLocale)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.SetUserLocale(ctx, req.(*SetUserLocaleRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*SetUserLocaleResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_UpdateUserAttributes0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in UpdateUserAttributesRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceUpdateUserAttributes)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.UpdateUserAttributes(ctx, req.(*UpdateUserAttributesRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*UserAttributes)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_GetUserAttributes0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetUserAttributesRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceGetUserAttributes)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetUserAttributes(ctx, req.(*GetUserAttributesRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*UserAttributes)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_PreviewSegment0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in PreviewSegmentRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServicePreviewSegment)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.PreviewSegment(ctx, req.(*PreviewSegmentRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*PreviewSegmentResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_GetDeliveryFunnel0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetDeliveryFunnelRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceGetDeliveryFunnel)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetDeliveryFunnel(ctx, req.(*GetDeliveryFunnelRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GetDeliveryFunnelResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_RegisterReportSchema0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in RegisterReportSchemaRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceRegisterReportSchema)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.RegisterReportSchema(ctx, req.(*RegisterReportSchemaRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ReportSchema)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_ListReportSchemas0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListReportSchemasRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceListReportSchemas)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListReportSchemas(ctx, req.(*ListReportSchemasRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListReportSchemasResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_QueryDataReports0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in QueryDataReportsRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceQueryDataReports)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.QueryDataReports(ctx, req.(*QueryDataReportsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*QueryDataReportsResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_CreateBot0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in CreateBotRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceCreateBot)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.CreateBot(ctx, req.(*CreateBotRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*CreateBotResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_DeleteBot0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in DeleteBotRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceDeleteBot)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.DeleteBot(ctx, req.(*DeleteBotRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*DeleteBotResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_SendBotMessage0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in SendBotMessageRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceSendBotMessage)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.SendBotMessage(ctx, req.(*SendBotMessageRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*SendBotMessageResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_CreateWebhook0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in CreateWebhookRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceCreateWebhook)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.CreateWebhook(ctx, req.(*CreateWebhookRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*CreateWebhookResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_ListWebhooks0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListWebhooksRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceListWebhooks)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListWebhooks(ctx, req.(*ListWebhooksRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListWebhooksResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_DeleteWebhook0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in DeleteWebhookRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceDeleteWebhook)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*DeleteWebhookResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_CreateAttachmentUpload0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in CreateAttachmentUploadRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceCreateAttachmentUpload)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.CreateAttachmentUpload(ctx, req.(*CreateAttachmentUploadRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*AttachmentUpload)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_UploadAttachmentChunk0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in UploadAttachmentChunkRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceUploadAttachmentChunk)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.UploadAttachmentChunk(ctx, req.(*UploadAttachmentChunkRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*UploadAttachmentChunkResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_GetAttachmentUpload0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetAttachmentUploadRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceGetAttachmentUpload)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetAttachmentUpload(ctx, req.(*GetAttachmentUploadRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*AttachmentUpload)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_CompleteAttachmentUpload0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in CompleteAttachmentUploadRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceCompleteAttachmentUpload)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.CompleteAttachmentUpload(ctx, req.(*CompleteAttachmentUploadRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*AttachmentUpload)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_GetAttachment0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetAttachmentRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceGetAttachment)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetAttachment(ctx, req.(*GetAttachmentRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*Attachment)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_ListConversations0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListConversationsRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceListConversations)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListConversations(ctx, req.(*ListConversationsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListConversationsResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_GetHistory0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetHistoryRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceGetHistory)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetHistory(ctx, req.(*GetHistoryRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GetHistoryResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_SyncSince0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in SyncSinceRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceSyncSince)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.SyncSince(ctx, req.(*SyncSinceRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*SyncSinceResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_DeleteHistoryMessages0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in DeleteHistoryMessagesRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceDeleteHistoryMessages)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.DeleteHistoryMessages(ctx, req.(*DeleteHistoryMessagesRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*DeleteHistoryMessagesResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_GetUnreadCount0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetUnreadCountRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceGetUnreadCount)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetUnreadCount(ctx, req.(*GetUnreadCountRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GetUnreadCountResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_GetNotificationPreferences0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetNotificationPreferencesRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceGetNotificationPreferences)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetNotificationPreferences(ctx, req.(*GetNotificationPreferencesRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*NotificationPreferences)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_UpdateNotificationPreferences0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in UpdateNotificationPreferencesRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceUpdateNotificationPreferences)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.UpdateNotificationPreferences(ctx, req.(*UpdateNotificationPreferencesRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*NotificationPreferences)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_CreateBroadcastJob0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in CreateBroadcastJobRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceCreateBroadcastJob)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.CreateBroadcastJob(ctx, req.(*CreateBroadcastJobRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*BroadcastJobResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_GetBroadcastJob0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in BroadcastJobRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceGetBroadcastJob)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetBroadcastJob(ctx, req.(*BroadcastJobRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*BroadcastJobResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_PauseBroadcastJob0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in BroadcastJobRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServicePauseBroadcastJob)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.PauseBroadcastJob(ctx, req.(*BroadcastJobRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*BroadcastJobResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_ResumeBroadcastJob0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in BroadcastJobRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceResumeBroadcastJob)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ResumeBroadcastJob(ctx, req.(*BroadcastJobRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*BroadcastJobResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LogicService_CancelBroadcastJob0_HTTP_Handler(srv LogicServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in BroadcastJobRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLogicServiceCancelBroadcastJob)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.CancelBroadcastJob(ctx, req.(*BroadcastJobRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*BroadcastJobResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
 type LogicServiceHTTPClient interface {
+	CancelBroadcastJob(ctx context.Context, req *BroadcastJobRequest, opts ...http.CallOption) (rsp *BroadcastJobResponse, err error)
+	CancelSystemPushSchedule(ctx context.Context, req *CancelSystemPushScheduleRequest, opts ...http.CallOption) (rsp *CancelSystemPushScheduleResponse, err error)
+	CompleteAttachmentUpload(ctx context.Context, req *CompleteAttachmentUploadRequest, opts ...http.CallOption) (rsp *AttachmentUpload, err error)
+	CreateAttachmentUpload(ctx context.Context, req *CreateAttachmentUploadRequest, opts ...http.CallOption) (rsp *AttachmentUpload, err error)
+	CreateBot(ctx context.Context, req *CreateBotRequest, opts ...http.CallOption) (rsp *CreateBotResponse, err error)
+	CreateBroadcastJob(ctx context.Context, req *CreateBroadcastJobRequest, opts ...http.CallOption) (rsp *BroadcastJobResponse, err error)
+	CreatePushTemplate(ctx context.Context, req *CreatePushTemplateRequest, opts ...http.CallOption) (rsp *PushTemplate, err error)
+	CreateWebhook(ctx context.Context, req *CreateWebhookRequest, opts ...http.CallOption) (rsp *CreateWebhookResponse, err error)
+	DeleteBot(ctx context.Context, req *DeleteBotRequest, opts ...http.CallOption) (rsp *DeleteBotResponse, err error)
+	DeleteHistoryMessages(ctx context.Context, req *DeleteHistoryMessagesRequest, opts ...http.CallOption) (rsp *DeleteHistoryMessagesResponse, err error)
+	DeletePushTemplate(ctx context.Context, req *DeletePushTemplateRequest, opts ...http.CallOption) (rsp *DeletePushTemplateResponse, err error)
+	DeleteWebhook(ctx context.Context, req *DeleteWebhookRequest, opts ...http.CallOption) (rsp *DeleteWebhookResponse, err error)
+	GetAttachment(ctx context.Context, req *GetAttachmentRequest, opts ...http.CallOption) (rsp *Attachment, err error)
+	GetAttachmentUpload(ctx context.Context, req *GetAttachmentUploadRequest, opts ...http.CallOption) (rsp *AttachmentUpload, err error)
+	GetBroadcastJob(ctx context.Context, req *BroadcastJobRequest, opts ...http.CallOption) (rsp *BroadcastJobResponse, err error)
+	GetDeliveryFunnel(ctx context.Context, req *GetDeliveryFunnelRequest, opts ...http.CallOption) (rsp *GetDeliveryFunnelResponse, err error)
+	GetHistory(ctx context.Context, req *GetHistoryRequest, opts ...http.CallOption) (rsp *GetHistoryResponse, err error)
+	GetNotificationPreferences(ctx context.Context, req *GetNotificationPreferencesRequest, opts ...http.CallOption) (rsp *NotificationPreferences, err error)
+	GetPushTemplate(ctx context.Context, req *GetPushTemplateRequest, opts ...http.CallOption) (rsp *PushTemplate, err error)
+	GetUnreadCount(ctx context.Context, req *GetUnreadCountRequest, opts ...http.CallOption) (rsp *GetUnreadCountResponse, err error)
+	GetUserAttributes(ctx context.Context, req *GetUserAttributesRequest, opts ...http.CallOption) (rsp *UserAttributes, err error)
+	ListConversations(ctx context.Context, req *ListConversationsRequest, opts ...http.CallOption) (rsp *ListConversationsResponse, err error)
+	ListPushTemplates(ctx context.Context, req *ListPushTemplatesRequest, opts ...http.CallOption) (rsp *ListPushTemplatesResponse, err error)
+	ListReportSchemas(ctx context.Context, req *ListReportSchemasRequest, opts ...http.CallOption) (rsp *ListReportSchemasResponse, err error)
+	ListSystemPushSchedules(ctx context.Context, req *ListSystemPushSchedulesRequest, opts ...http.CallOption) (rsp *ListSystemPushSchedulesResponse, err error)
+	ListWebhooks(ctx context.Context, req *ListWebhooksRequest, opts ...http.CallOption) (rsp *ListWebhooksResponse, err error)
+	PauseBroadcastJob(ctx context.Context, req *BroadcastJobRequest, opts ...http.CallOption) (rsp *BroadcastJobResponse, err error)
+	PreviewSegment(ctx context.Context, req *PreviewSegmentRequest, opts ...http.CallOption) (rsp *PreviewSegmentResponse, err error)
+	QueryDataReports(ctx context.Context, req *QueryDataReportsRequest, opts ...http.CallOption) (rsp *QueryDataReportsResponse, err error)
+	RegisterReportSchema(ctx context.Context, req *RegisterReportSchemaRequest, opts ...http.CallOption) (rsp *ReportSchema, err error)
+	ResumeBroadcastJob(ctx context.Context, req *BroadcastJobRequest, opts ...http.CallOption) (rsp *BroadcastJobResponse, err error)
+	SendBotMessage(ctx context.Context, req *SendBotMessageRequest, opts ...http.CallOption) (rsp *SendBotMessageResponse, err error)
 	SendSystemPush(ctx context.Context, req *SystemPushRequest, opts ...http.CallOption) (rsp *SystemPushResponse, err error)
+	SetUserLocale(ctx context.Context, req *SetUserLocaleRequest, opts ...http.CallOption) (rsp *SetUserLocaleResponse, err error)
+	SyncSince(ctx context.Context, req *SyncSinceRequest, opts ...http.CallOption) (rsp *SyncSinceResponse, err error)
+	UpdateNotificationPreferences(ctx context.Context, req *UpdateNotificationPreferencesRequest, opts ...http.CallOption) (rsp *NotificationPreferences, err error)
+	UpdatePushTemplate(ctx context.Context, req *UpdatePushTemplateRequest, opts ...http.CallOption) (rsp *PushTemplate, err error)
+	UpdateUserAttributes(ctx context.Context, req *UpdateUserAttributesRequest, opts ...http.CallOption) (rsp *UserAttributes, err error)
+	UploadAttachmentChunk(ctx context.Context, req *UploadAttachmentChunkRequest, opts ...http.CallOption) (rsp *UploadAttachmentChunkResponse, err error)
+}
+
+type LogicServiceHTTPClientImpl struct {
+	cc *http.Client
+}
+
+func NewLogicServiceHTTPClient(client *http.Client) LogicServiceHTTPClient {
+	return &LogicServiceHTTPClientImpl{client}
+}
+
+func (c *LogicServiceHTTPClientImpl) CancelBroadcastJob(ctx context.Context, in *BroadcastJobRequest, opts ...http.CallOption) (*BroadcastJobResponse, error) {
+	var out BroadcastJobResponse
+	pattern := "/chatify/logic/v1/cancelBroadcastJob"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLogicServiceCancelBroadcastJob))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) CancelSystemPushSchedule(ctx context.Context, in *CancelSystemPushScheduleRequest, opts ...http.CallOption) (*CancelSystemPushScheduleResponse, error) {
+	var out CancelSystemPushScheduleResponse
+	pattern := "/chatify/logic/v1/cancelSystemPushSchedule"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLogicServiceCancelSystemPushSchedule))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) CompleteAttachmentUpload(ctx context.Context, in *CompleteAttachmentUploadRequest, opts ...http.CallOption) (*AttachmentUpload, error) {
+	var out AttachmentUpload
+	pattern := "/chatify/logic/v1/completeAttachmentUpload"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLogicServiceCompleteAttachmentUpload))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) CreateAttachmentUpload(ctx context.Context, in *CreateAttachmentUploadRequest, opts ...http.CallOption) (*AttachmentUpload, error) {
+	var out AttachmentUpload
+	pattern := "/chatify/logic/v1/createAttachmentUpload"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLogicServiceCreateAttachmentUpload))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) CreateBot(ctx context.Context, in *CreateBotRequest, opts ...http.CallOption) (*CreateBotResponse, error) {
+	var out CreateBotResponse
+	pattern := "/chatify/logic/v1/createBot"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLogicServiceCreateBot))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) CreateBroadcastJob(ctx context.Context, in *CreateBroadcastJobRequest, opts ...http.CallOption) (*BroadcastJobResponse, error) {
+	var out BroadcastJobResponse
+	pattern := "/chatify/logic/v1/createBroadcastJob"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLogicServiceCreateBroadcastJob))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
 }
 
-type LogicServiceHTTPClientImpl struct {
-	cc *http.Client
+func (c *LogicServiceHTTPClientImpl) CreatePushTemplate(ctx context.Context, in *CreatePushTemplateRequest, opts ...http.CallOption) (*PushTemplate, error) {
+	var out PushTemplate
+	pattern := "/chatify/logic/v1/createPushTemplate"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLogicServiceCreatePushTemplate))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
 }
 
-func NewLogicServiceHTTPClient(client *http.Client) LogicServiceHTTPClient {
-	return &LogicServiceHTTPClientImpl{client}
+func (c *LogicServiceHTTPClientImpl) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...http.CallOption) (*CreateWebhookResponse, error) {
+	var out CreateWebhookResponse
+	pattern := "/chatify/logic/v1/createWebhook"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLogicServiceCreateWebhook))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) DeleteBot(ctx context.Context, in *DeleteBotRequest, opts ...http.CallOption) (*DeleteBotResponse, error) {
+	var out DeleteBotResponse
+	pattern := "/chatify/logic/v1/deleteBot"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLogicServiceDeleteBot))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) DeleteHistoryMessages(ctx context.Context, in *DeleteHistoryMessagesRequest, opts ...http.CallOption) (*DeleteHistoryMessagesResponse, error) {
+	var out DeleteHistoryMessagesResponse
+	pattern := "/chatify/logic/v1/deleteHistoryMessages"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLogicServiceDeleteHistoryMessages))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) DeletePushTemplate(ctx context.Context, in *DeletePushTemplateRequest, opts ...http.CallOption) (*DeletePushTemplateResponse, error) {
+	var out DeletePushTemplateResponse
+	pattern := "/chatify/logic/v1/deletePushTemplate"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLogicServiceDeletePushTemplate))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...http.CallOption) (*DeleteWebhookResponse, error) {
+	var out DeleteWebhookResponse
+	pattern := "/chatify/logic/v1/deleteWebhook"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLogicServiceDeleteWebhook))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) GetAttachment(ctx context.Context, in *GetAttachmentRequest, opts ...http.CallOption) (*Attachment, error) {
+	var out Attachment
+	pattern := "/chatify/logic/v1/getAttachment"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationLogicServiceGetAttachment))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) GetAttachmentUpload(ctx context.Context, in *GetAttachmentUploadRequest, opts ...http.CallOption) (*AttachmentUpload, error) {
+	var out AttachmentUpload
+	pattern := "/chatify/logic/v1/getAttachmentUpload"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationLogicServiceGetAttachmentUpload))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) GetBroadcastJob(ctx context.Context, in *BroadcastJobRequest, opts ...http.CallOption) (*BroadcastJobResponse, error) {
+	var out BroadcastJobResponse
+	pattern := "/chatify/logic/v1/getBroadcastJob"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationLogicServiceGetBroadcastJob))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) GetDeliveryFunnel(ctx context.Context, in *GetDeliveryFunnelRequest, opts ...http.CallOption) (*GetDeliveryFunnelResponse, error) {
+	var out GetDeliveryFunnelResponse
+	pattern := "/chatify/logic/v1/getDeliveryFunnel"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationLogicServiceGetDeliveryFunnel))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...http.CallOption) (*GetHistoryResponse, error) {
+	var out GetHistoryResponse
+	pattern := "/chatify/logic/v1/getHistory"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationLogicServiceGetHistory))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) GetNotificationPreferences(ctx context.Context, in *GetNotificationPreferencesRequest, opts ...http.CallOption) (*NotificationPreferences, error) {
+	var out NotificationPreferences
+	pattern := "/chatify/logic/v1/getNotificationPreferences"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationLogicServiceGetNotificationPreferences))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) GetPushTemplate(ctx context.Context, in *GetPushTemplateRequest, opts ...http.CallOption) (*PushTemplate, error) {
+	var out PushTemplate
+	pattern := "/chatify/logic/v1/getPushTemplate"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationLogicServiceGetPushTemplate))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) GetUnreadCount(ctx context.Context, in *GetUnreadCountRequest, opts ...http.CallOption) (*GetUnreadCountResponse, error) {
+	var out GetUnreadCountResponse
+	pattern := "/chatify/logic/v1/getUnreadCount"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationLogicServiceGetUnreadCount))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) GetUserAttributes(ctx context.Context, in *GetUserAttributesRequest, opts ...http.CallOption) (*UserAttributes, error) {
+	var out UserAttributes
+	pattern := "/chatify/logic/v1/getUserAttributes"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationLogicServiceGetUserAttributes))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) ListConversations(ctx context.Context, in *ListConversationsRequest, opts ...http.CallOption) (*ListConversationsResponse, error) {
+	var out ListConversationsResponse
+	pattern := "/chatify/logic/v1/listConversations"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationLogicServiceListConversations))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) ListPushTemplates(ctx context.Context, in *ListPushTemplatesRequest, opts ...http.CallOption) (*ListPushTemplatesResponse, error) {
+	var out ListPushTemplatesResponse
+	pattern := "/chatify/logic/v1/listPushTemplates"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationLogicServiceListPushTemplates))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) ListReportSchemas(ctx context.Context, in *ListReportSchemasRequest, opts ...http.CallOption) (*ListReportSchemasResponse, error) {
+	var out ListReportSchemasResponse
+	pattern := "/chatify/logic/v1/listReportSchemas"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationLogicServiceListReportSchemas))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) ListSystemPushSchedules(ctx context.Context, in *ListSystemPushSchedulesRequest, opts ...http.CallOption) (*ListSystemPushSchedulesResponse, error) {
+	var out ListSystemPushSchedulesResponse
+	pattern := "/chatify/logic/v1/listSystemPushSchedules"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationLogicServiceListSystemPushSchedules))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...http.CallOption) (*ListWebhooksResponse, error) {
+	var out ListWebhooksResponse
+	pattern := "/chatify/logic/v1/listWebhooks"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationLogicServiceListWebhooks))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) PauseBroadcastJob(ctx context.Context, in *BroadcastJobRequest, opts ...http.CallOption) (*BroadcastJobResponse, error) {
+	var out BroadcastJobResponse
+	pattern := "/chatify/logic/v1/pauseBroadcastJob"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLogicServicePauseBroadcastJob))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) PreviewSegment(ctx context.Context, in *PreviewSegmentRequest, opts ...http.CallOption) (*PreviewSegmentResponse, error) {
+	var out PreviewSegmentResponse
+	pattern := "/chatify/logic/v1/previewSegment"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLogicServicePreviewSegment))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) QueryDataReports(ctx context.Context, in *QueryDataReportsRequest, opts ...http.CallOption) (*QueryDataReportsResponse, error) {
+	var out QueryDataReportsResponse
+	pattern := "/chatify/logic/v1/queryDataReports"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationLogicServiceQueryDataReports))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) RegisterReportSchema(ctx context.Context, in *RegisterReportSchemaRequest, opts ...http.CallOption) (*ReportSchema, error) {
+	var out ReportSchema
+	pattern := "/chatify/logic/v1/registerReportSchema"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLogicServiceRegisterReportSchema))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) ResumeBroadcastJob(ctx context.Context, in *BroadcastJobRequest, opts ...http.CallOption) (*BroadcastJobResponse, error) {
+	var out BroadcastJobResponse
+	pattern := "/chatify/logic/v1/resumeBroadcastJob"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLogicServiceResumeBroadcastJob))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) SendBotMessage(ctx context.Context, in *SendBotMessageRequest, opts ...http.CallOption) (*SendBotMessageResponse, error) {
+	var out SendBotMessageResponse
+	pattern := "/chatify/logic/v1/bot/sendMessage"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLogicServiceSendBotMessage))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
 }
 
 func (c *LogicServiceHTTPClientImpl) SendSystemPush(ctx context.Context, in *SystemPushRequest, opts ...http.CallOption) (*SystemPushResponse, error) {
@@ -77,3 +1471,81 @@ func (c *LogicServiceHTTPClientImpl) SendSystemPush(ctx context.Context, in *Sys
 	}
 	return &out, nil
 }
+
+func (c *LogicServiceHTTPClientImpl) SetUserLocale(ctx context.Context, in *SetUserLocaleRequest, opts ...http.CallOption) (*SetUserLocaleResponse, error) {
+	var out SetUserLocaleResponse
+	pattern := "/chatify/logic/v1/setUserLocale"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLogicServiceSetUserLocale))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) SyncSince(ctx context.Context, in *SyncSinceRequest, opts ...http.CallOption) (*SyncSinceResponse, error) {
+	var out SyncSinceResponse
+	pattern := "/chatify/logic/v1/syncSince"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationLogicServiceSyncSince))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) UpdateNotificationPreferences(ctx context.Context, in *UpdateNotificationPreferencesRequest, opts ...http.CallOption) (*NotificationPreferences, error) {
+	var out NotificationPreferences
+	pattern := "/chatify/logic/v1/updateNotificationPreferences"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLogicServiceUpdateNotificationPreferences))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) UpdatePushTemplate(ctx context.Context, in *UpdatePushTemplateRequest, opts ...http.CallOption) (*PushTemplate, error) {
+	var out PushTemplate
+	pattern := "/chatify/logic/v1/updatePushTemplate"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLogicServiceUpdatePushTemplate))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) UpdateUserAttributes(ctx context.Context, in *UpdateUserAttributesRequest, opts ...http.CallOption) (*UserAttributes, error) {
+	var out UserAttributes
+	pattern := "/chatify/logic/v1/updateUserAttributes"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLogicServiceUpdateUserAttributes))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *LogicServiceHTTPClientImpl) UploadAttachmentChunk(ctx context.Context, in *UploadAttachmentChunkRequest, opts ...http.CallOption) (*UploadAttachmentChunkResponse, error) {
+	var out UploadAttachmentChunkResponse
+	pattern := "/chatify/logic/v1/uploadAttachmentChunk"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLogicServiceUploadAttachmentChunk))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -31,6 +31,7 @@ const (
 	ErrorReason_ARCHIVE_MESSAGE_FAILED           ErrorReason = 2020004 // 存档消息失败
 	ErrorReason_GET_OFFLINE_MESSAGE_FAILED       ErrorReason = 2020005 // 获取离线消息失败
 	ErrorReason_MARK_MESSAGE_AS_DELIVERED_FAILED ErrorReason = 2020006 // 标记消息为已送达失败
+	ErrorReason_REVISE_MESSAGE_FAILED            ErrorReason = 2020007 // 撤回或编辑离线消息失败
 )
 
 // Enum value maps for ErrorReason.
@@ -43,6 +44,7 @@ var (
 		2020004: "ARCHIVE_MESSAGE_FAILED",
 		2020005: "GET_OFFLINE_MESSAGE_FAILED",
 		2020006: "MARK_MESSAGE_AS_DELIVERED_FAILED",
+		2020007: "REVISE_MESSAGE_FAILED",
 	}
 	ErrorReason_value = map[string]int32{
 		"OK":                               0,
@@ -52,6 +54,7 @@ var (
 		"ARCHIVE_MESSAGE_FAILED":           2020004,
 		"GET_OFFLINE_MESSAGE_FAILED":       2020005,
 		"MARK_MESSAGE_AS_DELIVERED_FAILED": 2020006,
+		"REVISE_MESSAGE_FAILED":            2020007,
 	}
 )
 
@@ -89,7 +92,7 @@ var file_offline_v1_error_reason_proto_rawDesc = []byte{
 	0x6f, 0x72, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
 	0x0a, 0x6f, 0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x13, 0x65, 0x72, 0x72,
 	0x6f, 0x72, 0x73, 0x2f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2a, 0xf0, 0x01, 0x0a, 0x0b, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x2a, 0x93, 0x02, 0x0a, 0x0b, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e,
 	0x12, 0x0c, 0x0a, 0x02, 0x4f, 0x4b, 0x10, 0x00, 0x1a, 0x04, 0xa8, 0x45, 0xc8, 0x01, 0x12, 0x1a,
 	0x0a, 0x0e, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x52, 0x45, 0x50, 0x45, 0x41, 0x54,
 	0x10, 0xa1, 0xa5, 0x7b, 0x1a, 0x04, 0xa8, 0x45, 0xf4, 0x03, 0x12, 0x18, 0x0a, 0x0c, 0x49, 0x4e,
@@ -103,11 +106,13 @@ var file_offline_v1_error_reason_proto_rawDesc = []byte{
 	0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0xa5, 0xa5, 0x7b, 0x1a, 0x04, 0xa8, 0x45, 0xf4, 0x03, 0x12,
 	0x2c, 0x0a, 0x20, 0x4d, 0x41, 0x52, 0x4b, 0x5f, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f,
 	0x41, 0x53, 0x5f, 0x44, 0x45, 0x4c, 0x49, 0x56, 0x45, 0x52, 0x45, 0x44, 0x5f, 0x46, 0x41, 0x49,
-	0x4c, 0x45, 0x44, 0x10, 0xa6, 0xa5, 0x7b, 0x1a, 0x04, 0xa8, 0x45, 0xf4, 0x03, 0x1a, 0x04, 0xa0,
-	0x45, 0xf4, 0x03, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x78, 0x69, 0x6e, 0x67, 0x68, 0x65, 0x39, 0x30, 0x33, 0x2f, 0x63, 0x68, 0x61, 0x74,
-	0x69, 0x66, 0x79, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6f, 0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x2f,
-	0x76, 0x31, 0x3b, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x4c, 0x45, 0x44, 0x10, 0xa6, 0xa5, 0x7b, 0x1a, 0x04, 0xa8, 0x45, 0xf4, 0x03, 0x12, 0x21, 0x0a,
+	0x15, 0x52, 0x45, 0x56, 0x49, 0x53, 0x45, 0x5f, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f,
+	0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0xa7, 0xa5, 0x7b, 0x1a, 0x04, 0xa8, 0x45, 0xf4, 0x03,
+	0x1a, 0x04, 0xa0, 0x45, 0xf4, 0x03, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x78, 0x69, 0x6e, 0x67, 0x68, 0x65, 0x39, 0x30, 0x33, 0x2f, 0x63,
+	0x68, 0x61, 0x74, 0x69, 0x66, 0x79, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6f, 0x66, 0x66, 0x6c, 0x69,
+	0x6e, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -106,3 +106,17 @@ func IsMarkMessageAsDeliveredFailed(err error) bool {
 func ErrorMarkMessageAsDeliveredFailed(format string, args ...interface{}) *errors.Error {
 	return errors.New(500, ErrorReason_MARK_MESSAGE_AS_DELIVERED_FAILED.String(), fmt.Sprintf(format, args...))
 }
+
+// 撤回或编辑离线消息失败
+func IsReviseMessageFailed(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == ErrorReason_REVISE_MESSAGE_FAILED.String() && e.Code == 500
+}
+
+// 撤回或编辑离线消息失败
+func ErrorReviseMessageFailed(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, ErrorReason_REVISE_MESSAGE_FAILED.String(), fmt.Sprintf(format, args...))
+}
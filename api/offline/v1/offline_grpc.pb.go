@@ -22,6 +22,7 @@ const (
 	OfflineService_RetrieveOfflineMessages_FullMethodName = "/offline.v1.OfflineService/RetrieveOfflineMessages"
 	OfflineService_AcknowledgeMessages_FullMethodName     = "/offline.v1.OfflineService/AcknowledgeMessages"
 	OfflineService_ArchiveMessages_FullMethodName         = "/offline.v1.OfflineService/ArchiveMessages"
+	OfflineService_ReviseMessages_FullMethodName          = "/offline.v1.OfflineService/ReviseMessages"
 )
 
 // OfflineServiceClient is the client API for OfflineService service.
@@ -34,6 +35,8 @@ type OfflineServiceClient interface {
 	AcknowledgeMessages(ctx context.Context, in *AckRequest, opts ...grpc.CallOption) (*AckResponse, error)
 	// 归档一批离线消息
 	ArchiveMessages(ctx context.Context, in *ArchiveRequest, opts ...grpc.CallOption) (*ArchiveResponse, error)
+	// 撤回或编辑尚未送达的离线消息（由 Logic 调用）
+	ReviseMessages(ctx context.Context, in *ReviseRequest, opts ...grpc.CallOption) (*ReviseResponse, error)
 }
 
 type offlineServiceClient struct {
@@ -74,6 +77,16 @@ func (c *offlineServiceClient) ArchiveMessages(ctx context.Context, in *ArchiveR
 	return out, nil
 }
 
+func (c *offlineServiceClient) ReviseMessages(ctx context.Context, in *ReviseRequest, opts ...grpc.CallOption) (*ReviseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviseResponse)
+	err := c.cc.Invoke(ctx, OfflineService_ReviseMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OfflineServiceServer is the server API for OfflineService service.
 // All implementations must embed UnimplementedOfflineServiceServer
 // for forward compatibility.
@@ -84,6 +97,8 @@ type OfflineServiceServer interface {
 	AcknowledgeMessages(context.Context, *AckRequest) (*AckResponse, error)
 	// 归档一批离线消息
 	ArchiveMessages(context.Context, *ArchiveRequest) (*ArchiveResponse, error)
+	// 撤回或编辑尚未送达的离线消息（由 Logic 调用）
+	ReviseMessages(context.Context, *ReviseRequest) (*ReviseResponse, error)
 	mustEmbedUnimplementedOfflineServiceServer()
 }
 
@@ -103,6 +118,9 @@ func (UnimplementedOfflineServiceServer) AcknowledgeMessages(context.Context, *A
 func (UnimplementedOfflineServiceServer) ArchiveMessages(context.Context, *ArchiveRequest) (*ArchiveResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ArchiveMessages not implemented")
 }
+func (UnimplementedOfflineServiceServer) ReviseMessages(context.Context, *ReviseRequest) (*ReviseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReviseMessages not implemented")
+}
 func (UnimplementedOfflineServiceServer) mustEmbedUnimplementedOfflineServiceServer() {}
 func (UnimplementedOfflineServiceServer) testEmbeddedByValue()                        {}
 
@@ -178,6 +196,24 @@ func _OfflineService_ArchiveMessages_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OfflineService_ReviseMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OfflineServiceServer).ReviseMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OfflineService_ReviseMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OfflineServiceServer).ReviseMessages(ctx, req.(*ReviseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OfflineService_ServiceDesc is the grpc.ServiceDesc for OfflineService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -197,6 +233,10 @@ var OfflineService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ArchiveMessages",
 			Handler:    _OfflineService_ArchiveMessages_Handler,
 		},
+		{
+			MethodName: "ReviseMessages",
+			Handler:    _OfflineService_ReviseMessages_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "offline/v1/offline.proto",
@@ -22,6 +22,7 @@ const _ = http.SupportPackageIsVersion1
 const OperationOfflineServiceAcknowledgeMessages = "/offline.v1.OfflineService/AcknowledgeMessages"
 const OperationOfflineServiceArchiveMessages = "/offline.v1.OfflineService/ArchiveMessages"
 const OperationOfflineServiceRetrieveOfflineMessages = "/offline.v1.OfflineService/RetrieveOfflineMessages"
+const OperationOfflineServiceReviseMessages = "/offline.v1.OfflineService/ReviseMessages"
 
 type OfflineServiceHTTPServer interface {
 	// AcknowledgeMessages 确认一批消息已送达（由 Push 调用）
@@ -30,6 +31,8 @@ type OfflineServiceHTTPServer interface {
 	ArchiveMessages(context.Context, *ArchiveRequest) (*ArchiveResponse, error)
 	// RetrieveOfflineMessages 主动拉取离线消息（用户上线时）
 	RetrieveOfflineMessages(context.Context, *RetrieveRequest) (*RetrieveResponse, error)
+	// ReviseMessages 撤回或编辑尚未送达的离线消息（由 Logic 调用）
+	ReviseMessages(context.Context, *ReviseRequest) (*ReviseResponse, error)
 }
 
 func RegisterOfflineServiceHTTPServer(s *http.Server, srv OfflineServiceHTTPServer) {
@@ -37,6 +40,7 @@ func RegisterOfflineServiceHTTPServer(s *http.Server, srv OfflineServiceHTTPServ
 	r.GET("/chatify/offline/v1/RetrieveOfflineMessages", _OfflineService_RetrieveOfflineMessages0_HTTP_Handler(srv))
 	r.POST("/chatify/offline/v1/AcknowledgeMessages", _OfflineService_AcknowledgeMessages0_HTTP_Handler(srv))
 	r.POST("/chatify/offline/v1/ArchiveMessages", _OfflineService_ArchiveMessages0_HTTP_Handler(srv))
+	r.POST("/chatify/offline/v1/ReviseMessages", _OfflineService_ReviseMessages0_HTTP_Handler(srv))
 }
 
 func _OfflineService_RetrieveOfflineMessages0_HTTP_Handler(srv OfflineServiceHTTPServer) func(ctx http.Context) error {
@@ -102,10 +106,33 @@ func _OfflineService_ArchiveMessages0_HTTP_Handler(srv OfflineServiceHTTPServer)
 	}
 }
 
+func _OfflineService_ReviseMessages0_HTTP_Handler(srv OfflineServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ReviseRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationOfflineServiceReviseMessages)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ReviseMessages(ctx, req.(*ReviseRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ReviseResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
 type OfflineServiceHTTPClient interface {
 	AcknowledgeMessages(ctx context.Context, req *AckRequest, opts ...http.CallOption) (rsp *AckResponse, err error)
 	ArchiveMessages(ctx context.Context, req *ArchiveRequest, opts ...http.CallOption) (rsp *ArchiveResponse, err error)
 	RetrieveOfflineMessages(ctx context.Context, req *RetrieveRequest, opts ...http.CallOption) (rsp *RetrieveResponse, err error)
+	ReviseMessages(ctx context.Context, req *ReviseRequest, opts ...http.CallOption) (rsp *ReviseResponse, err error)
 }
 
 type OfflineServiceHTTPClientImpl struct {
@@ -154,3 +181,16 @@ func (c *OfflineServiceHTTPClientImpl) RetrieveOfflineMessages(ctx context.Conte
 	}
 	return &out, nil
 }
+
+func (c *OfflineServiceHTTPClientImpl) ReviseMessages(ctx context.Context, in *ReviseRequest, opts ...http.CallOption) (*ReviseResponse, error) {
+	var out ReviseResponse
+	pattern := "/chatify/offline/v1/ReviseMessages"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationOfflineServiceReviseMessages))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
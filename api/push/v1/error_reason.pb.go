@@ -30,6 +30,7 @@ const (
 	ErrorReason_SAVE_MESSAGE_FAILED            ErrorReason = 2030003 // 保存消息失败
 	ErrorReason_ARCHIVE_OFFLINE_MESSAGE_FAILED ErrorReason = 2030004 // 离线消息归档失败
 	ErrorReason_UPDATE_MESSAGE_STATUS_FAILED   ErrorReason = 2030005 // 更新消息状态失败
+	ErrorReason_PUSH_QUEUE_BUSY                ErrorReason = 2030006 // 推送队列繁忙，等待入队超时
 )
 
 // Enum value maps for ErrorReason.
@@ -41,6 +42,7 @@ var (
 		2030003: "SAVE_MESSAGE_FAILED",
 		2030004: "ARCHIVE_OFFLINE_MESSAGE_FAILED",
 		2030005: "UPDATE_MESSAGE_STATUS_FAILED",
+		2030006: "PUSH_QUEUE_BUSY",
 	}
 	ErrorReason_value = map[string]int32{
 		"OK":                             0,
@@ -49,6 +51,7 @@ var (
 		"SAVE_MESSAGE_FAILED":            2030003,
 		"ARCHIVE_OFFLINE_MESSAGE_FAILED": 2030004,
 		"UPDATE_MESSAGE_STATUS_FAILED":   2030005,
+		"PUSH_QUEUE_BUSY":                2030006,
 	}
 )
 
@@ -85,7 +88,7 @@ var file_push_v1_error_reason_proto_rawDesc = []byte{
 	0x0a, 0x1a, 0x70, 0x75, 0x73, 0x68, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f,
 	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x70, 0x75,
 	0x73, 0x68, 0x2e, 0x76, 0x31, 0x1a, 0x13, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2f, 0x65, 0x72,
-	0x72, 0x6f, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2a, 0xd4, 0x01, 0x0a, 0x0b, 0x45,
+	0x72, 0x6f, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2a, 0xf1, 0x01, 0x0a, 0x0b, 0x45,
 	0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x0c, 0x0a, 0x02, 0x4f, 0x4b,
 	0x10, 0x00, 0x1a, 0x04, 0xa8, 0x45, 0xc8, 0x01, 0x12, 0x1b, 0x0a, 0x0f, 0x55, 0x53, 0x45, 0x52,
 	0x4e, 0x41, 0x4d, 0x45, 0x5f, 0x52, 0x45, 0x50, 0x45, 0x41, 0x54, 0x10, 0xb1, 0xf3, 0x7b, 0x1a,
@@ -98,11 +101,13 @@ var file_push_v1_error_reason_proto_rawDesc = []byte{
 	0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0xb4, 0xf3, 0x7b, 0x1a, 0x04, 0xa8, 0x45, 0xf4,
 	0x03, 0x12, 0x28, 0x0a, 0x1c, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x5f, 0x4d, 0x45, 0x53, 0x53,
 	0x41, 0x47, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45,
-	0x44, 0x10, 0xb5, 0xf3, 0x7b, 0x1a, 0x04, 0xa8, 0x45, 0xf4, 0x03, 0x1a, 0x04, 0xa0, 0x45, 0xf4,
-	0x03, 0x42, 0x2d, 0x5a, 0x2b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x78, 0x69, 0x6e, 0x67, 0x68, 0x65, 0x39, 0x30, 0x33, 0x2f, 0x63, 0x68, 0x61, 0x74, 0x69, 0x66,
-	0x79, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x75, 0x73, 0x68, 0x2f, 0x76, 0x31, 0x3b, 0x76, 0x31,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x44, 0x10, 0xb5, 0xf3, 0x7b, 0x1a, 0x04, 0xa8, 0x45, 0xf4, 0x03, 0x12, 0x1b, 0x0a, 0x0f, 0x50,
+	0x55, 0x53, 0x48, 0x5f, 0x51, 0x55, 0x45, 0x55, 0x45, 0x5f, 0x42, 0x55, 0x53, 0x59, 0x10, 0xb6,
+	0xf3, 0x7b, 0x1a, 0x04, 0xa8, 0x45, 0xf7, 0x03, 0x1a, 0x04, 0xa0, 0x45, 0xf4, 0x03, 0x42, 0x2d,
+	0x5a, 0x2b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x78, 0x69, 0x6e,
+	0x67, 0x68, 0x65, 0x39, 0x30, 0x33, 0x2f, 0x63, 0x68, 0x61, 0x74, 0x69, 0x66, 0x79, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x70, 0x75, 0x73, 0x68, 0x2f, 0x76, 0x31, 0x3b, 0x76, 0x31, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
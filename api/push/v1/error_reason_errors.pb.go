@@ -92,3 +92,17 @@ func IsUpdateMessageStatusFailed(err error) bool {
 func ErrorUpdateMessageStatusFailed(format string, args ...interface{}) *errors.Error {
 	return errors.New(500, ErrorReason_UPDATE_MESSAGE_STATUS_FAILED.String(), fmt.Sprintf(format, args...))
 }
+
+// 推送队列繁忙，等待入队超时
+func IsPushQueueBusy(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == ErrorReason_PUSH_QUEUE_BUSY.String() && e.Code == 503
+}
+
+// 推送队列繁忙，等待入队超时
+func ErrorPushQueueBusy(format string, args ...interface{}) *errors.Error {
+	return errors.New(503, ErrorReason_PUSH_QUEUE_BUSY.String(), fmt.Sprintf(format, args...))
+}
@@ -29,11 +29,15 @@ func wireApp(bootstrap *conf.Bootstrap, logger log.Logger) (*kratos.App, func(),
 	userRepo := data.NewUserRepo(dataData, logger)
 	sessionRepo := data.NewSessionRepo(dataData, logger)
 	auth := biz.NewAuth(logger, userRepo, sessionRepo, bootstrap)
-	authService := service.NewAuthService(logger, auth)
+	abuseConsumer, cleanup2 := data.NewAbuseConsumer(bootstrap, logger)
+	accountGuard, cleanup3 := biz.NewAccountGuard(logger, abuseConsumer, userRepo, sessionRepo)
+	authService := service.NewAuthService(logger, auth, accountGuard)
 	grpcServer := server.NewGRPCServer(bootstrap, authService, logger)
 	httpServer := server.NewHTTPServer(bootstrap, authService, logger)
 	app := newApp(logger, grpcServer, httpServer)
 	return app, func() {
+		cleanup3()
+		cleanup2()
 		cleanup()
 	}, nil
 }
@@ -0,0 +1,71 @@
+package biz
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/xinghe903/chatify/auth/internal/biz/bo"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// ErrInvalidAbuseEvent 滥用事件格式错误，重试也无法处理
+var ErrInvalidAbuseEvent = errors.New("invalid user abuse event")
+
+// AbuseEventHandler 用户滥用事件处理函数，返回错误时事件进入重试，ErrInvalidAbuseEvent直接进入死信队列
+type AbuseEventHandler func(ctx context.Context, value []byte) error
+
+// AbuseConsumer 用户滥用事件消费者接口
+type AbuseConsumer interface {
+	Start(ctx context.Context, handler AbuseEventHandler)
+}
+
+// AccountGuard 消费logic上报的滥用事件，锁定账号并清除登录会话
+type AccountGuard struct {
+	log         *log.Helper
+	consumer    AbuseConsumer
+	userRepo    UserRepo
+	sessionRepo SessionRepo
+}
+
+// NewAccountGuard 创建账号保护实例，并启动滥用事件消费协程
+func NewAccountGuard(logger log.Logger, consumer AbuseConsumer, userRepo UserRepo, sessionRepo SessionRepo) (*AccountGuard, func()) {
+	guard := &AccountGuard{
+		log:         log.NewHelper(logger),
+		consumer:    consumer,
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+	}
+	ctx, cancel := context.WithCancelCause(context.TODO())
+	guard.consumer.Start(ctx, guard.handle)
+	return guard, func() { cancel(errors.New("account guard context canceled")) }
+}
+
+// handle 锁定滥用账号，只处理活跃用户，重复事件不会改变已锁定或已注销的账号
+func (g *AccountGuard) handle(ctx context.Context, value []byte) error {
+	var event bo.UserAbuseEvent
+	if err := json.Unmarshal(value, &event); err != nil {
+		g.log.WithContext(ctx).Errorf("user abuse event json unmarshal error: %v", err)
+		return errors.Join(ErrInvalidAbuseEvent, err)
+	}
+	user, err := g.userRepo.GetByID(ctx, event.UserId)
+	if err != nil {
+		g.log.WithContext(ctx).Errorf("Failed to get user: %v. userId=%s", err, event.UserId)
+		return err
+	}
+	if user == nil || user.Status != bo.UserStatusActive {
+		return nil
+	}
+	user.Status = bo.UserStatusLocked
+	if err = g.userRepo.Update(ctx, user); err != nil {
+		g.log.WithContext(ctx).Errorf("Failed to lock user: %v. userId=%s", err, user.ID)
+		return err
+	}
+	if err = g.sessionRepo.Delete(ctx, user.ID); err != nil {
+		g.log.WithContext(ctx).Errorf("Failed to invalidate tokens: %v. userId=%s", err, user.ID)
+	}
+	g.log.WithContext(ctx).Infof("User locked for abuse. userId=%s, reason=%s, strikes=%d",
+		user.ID, event.Reason, event.Strikes)
+	return nil
+}
@@ -15,4 +15,4 @@ import (
 // }
 
 // ProviderSet is biz providers.
-var ProviderSet = wire.NewSet(NewAuth)
+var ProviderSet = wire.NewSet(NewAuth, NewAccountGuard)
@@ -0,0 +1,6 @@
+package bo
+
+import "github.com/xinghe903/chatify/pkg/event"
+
+// UserAbuseEvent logic服务检测到的用户滥用事件
+type UserAbuseEvent = event.UserAbuse
@@ -198,6 +198,7 @@ type Data struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Database      *Data_Database         `protobuf:"bytes,1,opt,name=database,proto3" json:"database,omitempty"`
 	Redis         *Data_Redis            `protobuf:"bytes,2,opt,name=redis,proto3" json:"redis,omitempty"`
+	Kafka         *Data_Kafka            `protobuf:"bytes,3,opt,name=kafka,proto3" json:"kafka,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -246,6 +247,13 @@ func (x *Data) GetRedis() *Data_Redis {
 	return nil
 }
 
+func (x *Data) GetKafka() *Data_Kafka {
+	if x != nil {
+		return x.Kafka
+	}
+	return nil
+}
+
 type Monitoring struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ServiceName   string                 `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
@@ -721,6 +729,58 @@ func (x *Data_Redis) GetWriteTimeout() *durationpb.Duration {
 	return nil
 }
 
+type Data_Kafka struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Brokers       []string               `protobuf:"bytes,1,rep,name=brokers,proto3" json:"brokers,omitempty"`
+	GroupId       string                 `protobuf:"bytes,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_Kafka) Reset() {
+	*x = Data_Kafka{}
+	mi := &file_conf_conf_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Kafka) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Kafka) ProtoMessage() {}
+
+func (x *Data_Kafka) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Kafka.ProtoReflect.Descriptor instead.
+func (*Data_Kafka) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 2}
+}
+
+func (x *Data_Kafka) GetBrokers() []string {
+	if x != nil {
+		return x.Brokers
+	}
+	return nil
+}
+
+func (x *Data_Kafka) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
 type Tracing_Jaeger struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Endpoint      string                 `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
@@ -731,7 +791,7 @@ type Tracing_Jaeger struct {
 
 func (x *Tracing_Jaeger) Reset() {
 	*x = Tracing_Jaeger{}
-	mi := &file_conf_conf_proto_msgTypes[12]
+	mi := &file_conf_conf_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -743,7 +803,7 @@ func (x *Tracing_Jaeger) String() string {
 func (*Tracing_Jaeger) ProtoMessage() {}
 
 func (x *Tracing_Jaeger) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[12]
+	mi := &file_conf_conf_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -783,7 +843,7 @@ type Metrics_Prometheus struct {
 
 func (x *Metrics_Prometheus) Reset() {
 	*x = Metrics_Prometheus{}
-	mi := &file_conf_conf_proto_msgTypes[13]
+	mi := &file_conf_conf_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -795,7 +855,7 @@ func (x *Metrics_Prometheus) String() string {
 func (*Metrics_Prometheus) ProtoMessage() {}
 
 func (x *Metrics_Prometheus) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[13]
+	mi := &file_conf_conf_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -851,10 +911,11 @@ const file_conf_conf_proto_rawDesc = "" +
 	"\x04GRPC\x12\x18\n" +
 	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
 	"\x04addr\x18\x02 \x01(\tR\x04addr\x123\n" +
-	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"\xdd\x02\n" +
+	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"\xc9\x03\n" +
 	"\x04Data\x125\n" +
 	"\bdatabase\x18\x01 \x01(\v2\x19.kratos.api.Data.DatabaseR\bdatabase\x12,\n" +
-	"\x05redis\x18\x02 \x01(\v2\x16.kratos.api.Data.RedisR\x05redis\x1a:\n" +
+	"\x05redis\x18\x02 \x01(\v2\x16.kratos.api.Data.RedisR\x05redis\x12,\n" +
+	"\x05kafka\x18\x03 \x01(\v2\x16.kratos.api.Data.KafkaR\x05kafka\x1a:\n" +
 	"\bDatabase\x12\x16\n" +
 	"\x06driver\x18\x01 \x01(\tR\x06driver\x12\x16\n" +
 	"\x06source\x18\x02 \x01(\tR\x06source\x1a\xb3\x01\n" +
@@ -862,7 +923,10 @@ const file_conf_conf_proto_rawDesc = "" +
 	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
 	"\x04addr\x18\x02 \x01(\tR\x04addr\x12<\n" +
 	"\fread_timeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\vreadTimeout\x12>\n" +
-	"\rwrite_timeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\fwriteTimeout\"\xbc\x01\n" +
+	"\rwrite_timeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\fwriteTimeout\x1a<\n" +
+	"\x05Kafka\x12\x18\n" +
+	"\abrokers\x18\x01 \x03(\tR\abrokers\x12\x19\n" +
+	"\bgroup_id\x18\x02 \x01(\tR\agroupId\"\xbc\x01\n" +
 	"\n" +
 	"Monitoring\x12!\n" +
 	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12-\n" +
@@ -887,7 +951,7 @@ const file_conf_conf_proto_rawDesc = "" +
 	"\n" +
 	"Prometheus\x12\x1a\n" +
 	"\bendpoint\x18\x01 \x01(\tR\bendpoint\x123\n" +
-	"\atimeout\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\atimeoutB\x19Z\x17auth/internal/conf;confb\x06proto3"
+	"\atimeout\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\atimeoutB6Z4github.com/xinghe903/chatify/auth/internal/conf;confb\x06proto3"
 
 var (
 	file_conf_conf_proto_rawDescOnce sync.Once
@@ -901,7 +965,7 @@ func file_conf_conf_proto_rawDescGZIP() []byte {
 	return file_conf_conf_proto_rawDescData
 }
 
-var file_conf_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_conf_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_conf_conf_proto_goTypes = []any{
 	(*Bootstrap)(nil),           // 0: kratos.api.Bootstrap
 	(*Auth)(nil),                // 1: kratos.api.Auth
@@ -915,9 +979,10 @@ var file_conf_conf_proto_goTypes = []any{
 	(*Server_GRPC)(nil),         // 9: kratos.api.Server.GRPC
 	(*Data_Database)(nil),       // 10: kratos.api.Data.Database
 	(*Data_Redis)(nil),          // 11: kratos.api.Data.Redis
-	(*Tracing_Jaeger)(nil),      // 12: kratos.api.Tracing.Jaeger
-	(*Metrics_Prometheus)(nil),  // 13: kratos.api.Metrics.Prometheus
-	(*durationpb.Duration)(nil), // 14: google.protobuf.Duration
+	(*Data_Kafka)(nil),          // 12: kratos.api.Data.Kafka
+	(*Tracing_Jaeger)(nil),      // 13: kratos.api.Tracing.Jaeger
+	(*Metrics_Prometheus)(nil),  // 14: kratos.api.Metrics.Prometheus
+	(*durationpb.Duration)(nil), // 15: google.protobuf.Duration
 }
 var file_conf_conf_proto_depIdxs = []int32{
 	2,  // 0: kratos.api.Bootstrap.server:type_name -> kratos.api.Server
@@ -928,22 +993,23 @@ var file_conf_conf_proto_depIdxs = []int32{
 	9,  // 5: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
 	10, // 6: kratos.api.Data.database:type_name -> kratos.api.Data.Database
 	11, // 7: kratos.api.Data.redis:type_name -> kratos.api.Data.Redis
-	5,  // 8: kratos.api.Monitoring.tracing:type_name -> kratos.api.Tracing
-	6,  // 9: kratos.api.Monitoring.logging:type_name -> kratos.api.Logging
-	7,  // 10: kratos.api.Monitoring.metrics:type_name -> kratos.api.Metrics
-	12, // 11: kratos.api.Tracing.jaeger:type_name -> kratos.api.Tracing.Jaeger
-	13, // 12: kratos.api.Metrics.prometheus:type_name -> kratos.api.Metrics.Prometheus
-	14, // 13: kratos.api.Server.HTTP.timeout:type_name -> google.protobuf.Duration
-	14, // 14: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
-	14, // 15: kratos.api.Data.Redis.read_timeout:type_name -> google.protobuf.Duration
-	14, // 16: kratos.api.Data.Redis.write_timeout:type_name -> google.protobuf.Duration
-	14, // 17: kratos.api.Tracing.Jaeger.timeout:type_name -> google.protobuf.Duration
-	14, // 18: kratos.api.Metrics.Prometheus.timeout:type_name -> google.protobuf.Duration
-	19, // [19:19] is the sub-list for method output_type
-	19, // [19:19] is the sub-list for method input_type
-	19, // [19:19] is the sub-list for extension type_name
-	19, // [19:19] is the sub-list for extension extendee
-	0,  // [0:19] is the sub-list for field type_name
+	12, // 8: kratos.api.Data.kafka:type_name -> kratos.api.Data.Kafka
+	5,  // 9: kratos.api.Monitoring.tracing:type_name -> kratos.api.Tracing
+	6,  // 10: kratos.api.Monitoring.logging:type_name -> kratos.api.Logging
+	7,  // 11: kratos.api.Monitoring.metrics:type_name -> kratos.api.Metrics
+	13, // 12: kratos.api.Tracing.jaeger:type_name -> kratos.api.Tracing.Jaeger
+	14, // 13: kratos.api.Metrics.prometheus:type_name -> kratos.api.Metrics.Prometheus
+	15, // 14: kratos.api.Server.HTTP.timeout:type_name -> google.protobuf.Duration
+	15, // 15: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
+	15, // 16: kratos.api.Data.Redis.read_timeout:type_name -> google.protobuf.Duration
+	15, // 17: kratos.api.Data.Redis.write_timeout:type_name -> google.protobuf.Duration
+	15, // 18: kratos.api.Tracing.Jaeger.timeout:type_name -> google.protobuf.Duration
+	15, // 19: kratos.api.Metrics.Prometheus.timeout:type_name -> google.protobuf.Duration
+	20, // [20:20] is the sub-list for method output_type
+	20, // [20:20] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_conf_conf_proto_init() }
@@ -957,7 +1023,7 @@ func file_conf_conf_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_conf_conf_proto_rawDesc), len(file_conf_conf_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   14,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
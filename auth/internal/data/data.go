@@ -18,7 +18,7 @@ import (
 )
 
 // ProviderSet is data providers.
-var ProviderSet = wire.NewSet(NewData, NewUserRepo, NewSessionRepo)
+var ProviderSet = wire.NewSet(NewData, NewUserRepo, NewSessionRepo, NewAbuseConsumer)
 
 // Data 数据层主结构
 type Data struct {
@@ -0,0 +1,128 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/xinghe903/chatify/auth/internal/biz"
+	"github.com/xinghe903/chatify/auth/internal/conf"
+
+	"github.com/xinghe903/chatify/pkg/event"
+	"github.com/xinghe903/chatify/pkg/kafka"
+
+	"github.com/IBM/sarama"
+	"github.com/go-kratos/kratos/v2/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const (
+	// KafkaTopicUserAbuse logic服务上报的用户滥用事件
+	KafkaTopicUserAbuse = event.TopicUserAbuse
+)
+
+var _ biz.AbuseConsumer = (*kafkaConsumer)(nil)
+
+type kafkaConsumer struct {
+	consumerGroup sarama.ConsumerGroup
+	retrier       *kafka.Retrier
+	log           *log.Helper
+}
+
+// NewAbuseConsumer 创建用户滥用事件消费者
+func NewAbuseConsumer(cb *conf.Bootstrap, logger log.Logger) (biz.AbuseConsumer, func()) {
+	kconf := cb.Data.Kafka
+	logg := log.NewHelper(logger)
+	config := sarama.NewConfig()
+	config.Consumer.Group.Rebalance.Strategy = sarama.BalanceStrategyRange
+	config.Consumer.Offsets.Initial = sarama.OffsetOldest
+	config.Consumer.Return.Errors = true
+	config.Consumer.Offsets.AutoCommit.Enable = true
+	config.Consumer.Offsets.AutoCommit.Interval = 1 * time.Second
+
+	consumerGroup, err := sarama.NewConsumerGroup(kconf.Brokers, kconf.GroupId, config)
+	if err != nil {
+		panic("创建消费者组失败" + err.Error())
+	}
+	// 处理失败的事件按默认策略进入重试主题，重试耗尽或事件格式错误时进入死信队列
+	retrier, err := kafka.NewRetrier(&kafka.RetrierConfig{
+		Brokers:      kconf.Brokers,
+		GroupId:      kconf.GroupId,
+		NonRetryable: biz.ErrInvalidAbuseEvent,
+	}, logger, KafkaTopicUserAbuse)
+	if err != nil {
+		panic("创建重试生产者失败" + err.Error())
+	}
+	cleanup := func() {
+		if err := consumerGroup.Close(); err != nil {
+			logg.Errorf("关闭消费者组失败: %v", err)
+		}
+		if err := retrier.Close(); err != nil {
+			logg.Errorf("关闭重试生产者失败: %v", err)
+		}
+	}
+	return &kafkaConsumer{
+		consumerGroup: consumerGroup,
+		retrier:       retrier,
+		log:           logg,
+	}, cleanup
+}
+
+func (k *kafkaConsumer) Start(ctx context.Context, handler biz.AbuseEventHandler) {
+	go func() {
+		h := consumerGroupHandler{handler: handler, retrier: k.retrier, log: k.log}
+		topics := k.retrier.Topics()
+		for {
+			if err := k.consumerGroup.Consume(ctx, topics, h); err != nil {
+				k.log.Errorf("consumer handler error: %v", err)
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			time.Sleep(2 * time.Second)
+		}
+	}()
+}
+
+type consumerGroupHandler struct {
+	log     *log.Helper
+	handler biz.AbuseEventHandler
+	retrier *kafka.Retrier
+}
+
+func (h consumerGroupHandler) Setup(_ sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (h consumerGroupHandler) Cleanup(_ sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+// ConsumeClaim 处理每个分区的消息，处理失败的事件转入重试主题或死信队列后再提交位移
+// 转发失败时不提交位移并结束本次会话，重新平衡后再次消费，锁定账号是幂等的
+func (h consumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for message := range claim.Messages() {
+		if !h.retrier.Owns(message) {
+			// 其他消费者组的重试或重放消息
+			session.MarkMessage(message, "")
+			continue
+		}
+		if !h.retrier.Wait(session.Context(), message) {
+			return nil
+		}
+		carrier := make(propagation.HeaderCarrier)
+		for _, header := range message.Headers {
+			carrier.Set(string(header.Key), string(header.Value))
+		}
+		ctx := otel.GetTextMapPropagator().Extract(context.Background(), carrier)
+		if err := h.handler(ctx, message.Value); err != nil {
+			h.log.WithContext(ctx).Errorf("处理消息失败: %v", err)
+			if ferr := h.retrier.Fail(ctx, message, err); ferr != nil {
+				h.log.WithContext(ctx).Errorf("转发失败消息失败: %v", ferr)
+				return ferr
+			}
+		}
+		session.MarkMessage(message, "")
+	}
+	return nil
+}
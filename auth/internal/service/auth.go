@@ -20,15 +20,17 @@ import (
 // AuthService 认证服务实现
 type AuthService struct {
 	v1.UnimplementedAuthServiceServer
-	log *log.Helper
-	uc  *biz.Auth
+	log   *log.Helper
+	uc    *biz.Auth
+	guard *biz.AccountGuard
 }
 
 // NewAuthService 创建认证服务
-func NewAuthService(logger log.Logger, uc *biz.Auth) *AuthService {
+func NewAuthService(logger log.Logger, uc *biz.Auth, guard *biz.AccountGuard) *AuthService {
 	return &AuthService{
-		log: log.NewHelper(log.With(logger)),
-		uc:  uc,
+		log:   log.NewHelper(log.With(logger)),
+		uc:    uc,
+		guard: guard, // 仅用作初始化滥用事件消费协程
 	}
 }
 
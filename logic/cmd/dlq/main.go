@@ -0,0 +1,225 @@
+// dlq 查看和重放Kafka死信队列
+//
+//	dlq -brokers kafka:9092 list -topic user_message -limit 20
+//	dlq -brokers kafka:9092 replay -topic user_message -partition 0 -offset 15 -count 10
+//
+// 死信消息按原始主题重新投递，logic、push和auth的消费者主题都可以使用
+// 重放的消息保留处理失败的消费者组，只有该组会再次处理，订阅同一主题的其他组直接跳过
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xinghe903/chatify/pkg/kafka"
+
+	"github.com/IBM/sarama"
+)
+
+// failureHeaders 重放时需要去掉的失败信息，重放后的消息重新开始计算重试次数
+// 消费者组保留，重放的消息只由处理失败的组消费
+var failureHeaders = map[string]bool{
+	kafka.HeaderRetryCount: true,
+	kafka.HeaderRetryAt:    true,
+	kafka.HeaderFailedAt:   true,
+	kafka.HeaderError:      true,
+}
+
+// deadLetter list输出的死信消息
+type deadLetter struct {
+	Partition int32             `json:"partition"`
+	Offset    int64             `json:"offset"`
+	Key       string            `json:"key"`
+	Timestamp time.Time         `json:"timestamp"`
+	Headers   map[string]string `json:"headers"`
+	Value     string            `json:"value"`
+}
+
+func main() {
+	brokers := flag.String("brokers", "localhost:19092", "kafka brokers, comma separated")
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+	var err error
+	switch flag.Arg(0) {
+	case "list":
+		err = list(strings.Split(*brokers, ","), flag.Args()[1:])
+	case "replay":
+		err = replay(strings.Split(*brokers, ","), flag.Args()[1:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: %s [-brokers addrs] list|replay [flags]\n", os.Args[0])
+	flag.PrintDefaults()
+}
+
+// list 输出每个分区最新的limit条死信消息，每行一个JSON
+func list(brokers, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	topic := fs.String("topic", "", "original topic, e.g. user_message")
+	limit := fs.Int64("limit", 20, "max messages per partition")
+	_ = fs.Parse(args)
+	if *topic == "" {
+		return fmt.Errorf("topic is required")
+	}
+	client, err := sarama.NewClient(brokers, sarama.NewConfig())
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		return err
+	}
+	defer consumer.Close()
+
+	dlqTopic := kafka.DeadLetterTopic(*topic)
+	partitions, err := client.Partitions(dlqTopic)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	for _, partition := range partitions {
+		oldest, err := client.GetOffset(dlqTopic, partition, sarama.OffsetOldest)
+		if err != nil {
+			return err
+		}
+		newest, err := client.GetOffset(dlqTopic, partition, sarama.OffsetNewest)
+		if err != nil {
+			return err
+		}
+		start := max(oldest, newest-*limit)
+		if err = consume(consumer, dlqTopic, partition, start, newest-start, func(message *sarama.ConsumerMessage) error {
+			return encoder.Encode(toDeadLetter(message))
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replay 把分区中从offset开始的count条死信消息投递回原始主题
+func replay(brokers, args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	topic := fs.String("topic", "", "original topic, e.g. user_message")
+	partition := fs.Int("partition", 0, "dead letter partition")
+	offset := fs.Int64("offset", -1, "first dead letter offset to replay")
+	count := fs.Int64("count", 1, "number of messages to replay")
+	_ = fs.Parse(args)
+	if *topic == "" || *offset < 0 || *count <= 0 {
+		return fmt.Errorf("topic, offset and count are required")
+	}
+	config := sarama.NewConfig()
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Return.Successes = true
+	config.Producer.Partitioner = sarama.NewHashPartitioner
+	client, err := sarama.NewClient(brokers, config)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		return err
+	}
+	defer consumer.Close()
+	producer, err := sarama.NewSyncProducerFromClient(client)
+	if err != nil {
+		return err
+	}
+	defer producer.Close()
+
+	dlqTopic := kafka.DeadLetterTopic(*topic)
+	newest, err := client.GetOffset(dlqTopic, int32(*partition), sarama.OffsetNewest)
+	if err != nil {
+		return err
+	}
+	replayed := 0
+	err = consume(consumer, dlqTopic, int32(*partition), *offset, min(*count, newest-*offset), func(message *sarama.ConsumerMessage) error {
+		target := *topic
+		headers := make([]sarama.RecordHeader, 0, len(message.Headers)+1)
+		for _, h := range message.Headers {
+			if h == nil || failureHeaders[string(h.Key)] {
+				continue
+			}
+			if string(h.Key) == kafka.HeaderOriginalTopic {
+				target = string(h.Value)
+			}
+			headers = append(headers, *h)
+		}
+		headers = append(headers, sarama.RecordHeader{
+			Key:   []byte(kafka.HeaderReplayed),
+			Value: []byte(dlqTopic + ":" + strconv.Itoa(int(message.Partition)) + ":" + strconv.FormatInt(message.Offset, 10)),
+		})
+		if _, _, err := producer.SendMessage(&sarama.ProducerMessage{
+			Topic:   target,
+			Key:     sarama.ByteEncoder(message.Key),
+			Value:   sarama.ByteEncoder(message.Value),
+			Headers: headers,
+		}); err != nil {
+			return fmt.Errorf("replay offset %d failed: %w", message.Offset, err)
+		}
+		replayed++
+		return nil
+	})
+	fmt.Printf("replayed %d messages from %s partition %d\n", replayed, dlqTopic, *partition)
+	return err
+}
+
+// consume 从指定位置顺序读取count条消息
+func consume(consumer sarama.Consumer, topic string, partition int32, offset, count int64, fn func(*sarama.ConsumerMessage) error) error {
+	if count <= 0 {
+		return nil
+	}
+	pc, err := consumer.ConsumePartition(topic, partition, offset)
+	if err != nil {
+		return err
+	}
+	defer pc.Close()
+	for i := int64(0); i < count; i++ {
+		select {
+		case message := <-pc.Messages():
+			if err = fn(message); err != nil {
+				return err
+			}
+		case cerr := <-pc.Errors():
+			return cerr
+		case <-time.After(10 * time.Second):
+			return fmt.Errorf("timed out reading %s partition %d", topic, partition)
+		}
+	}
+	return nil
+}
+
+func toDeadLetter(message *sarama.ConsumerMessage) *deadLetter {
+	headers := make(map[string]string, len(message.Headers))
+	for _, h := range message.Headers {
+		if h != nil {
+			headers[string(h.Key)] = string(h.Value)
+		}
+	}
+	return &deadLetter{
+		Partition: message.Partition,
+		Offset:    message.Offset,
+		Key:       string(message.Key),
+		Timestamp: message.Timestamp,
+		Headers:   headers,
+		Value:     string(message.Value),
+	}
+}
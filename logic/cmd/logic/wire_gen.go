@@ -28,29 +28,89 @@ func wireApp(bootstrap *conf.Bootstrap, logger log.Logger) (*kratos.App, func(),
 	}
 	discovery := data.NewDiscovery(client)
 	pushRepo, cleanup := data.NewPushServiceClient(bootstrap, logger, discovery)
-	logic := biz.NewLogic(logger, pushRepo, bootstrap)
-	consumer, cleanup2 := data.NewKafkaConsumer(bootstrap, logger)
+	mqProducer, cleanup2, err := data.NewKafkaProducer(bootstrap, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
 	dataData, cleanup3, err := data.NewData(bootstrap, logger)
 	if err != nil {
 		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
+	scheduleRepo := data.NewScheduleRepo(dataData, logger)
+	pushTemplateRepo := data.NewPushTemplateRepo(dataData, logger)
+	userLocaleRepo := data.NewUserLocaleRepo(dataData, logger)
+	userAttributeRepo := data.NewUserAttributeRepo(dataData, logger)
+	notificationPreferenceRepo := data.NewNotificationPreferenceRepo(dataData, logger)
+	conversationRepo := data.NewConversationRepo(dataData, logger)
+	preferences := biz.NewPreferences(logger, notificationPreferenceRepo, conversationRepo)
+	idempotencyRepo := data.NewIdempotencyRepo(dataData, bootstrap, logger)
+	logic := biz.NewLogic(logger, pushRepo, mqProducer, scheduleRepo, pushTemplateRepo, userLocaleRepo, userAttributeRepo, preferences, idempotencyRepo, bootstrap)
+	consumer, cleanup4 := data.NewKafkaConsumer(bootstrap, logger)
 	messageDedupRepo := data.NewMessageDedupRepo(dataData, logger)
-	mqProducer, cleanup4, err := data.NewKafkaProducer(bootstrap, logger)
+	groupRepo := data.NewGroupRepo(dataData, logger)
+	offlineRepo, cleanup5 := data.NewOfflineClient(bootstrap, logger, discovery)
+	chatRepo := data.NewChatRepo(dataData, logger)
+	readStateRepo := data.NewReadStateRepo(dataData, logger)
+	sequenceRepo := data.NewSequenceRepo(dataData, logger)
+	quotaRepo := data.NewQuotaRepo(dataData, logger)
+	dataReportConsumer, cleanup6 := data.NewDataReportConsumer(bootstrap, logger)
+	reportSchemaRepo := data.NewReportSchemaRepo(dataData, logger)
+	dataReportRepo := data.NewDataReportRepo(dataData, logger)
+	reporter, cleanup7 := biz.NewReporter(logger, dataReportConsumer, mqProducer, reportSchemaRepo, dataReportRepo)
+	webhookRepo := data.NewWebhookRepo(dataData, logger)
+	webhookSender := data.NewWebhookSender(bootstrap)
+	webhookConsumer, cleanup8 := data.NewWebhookConsumer(bootstrap, logger)
+	userStateConsumer, cleanup9 := data.NewUserStateConsumer(bootstrap, logger)
+	webhook, cleanup10 := biz.NewWebhook(logger, bootstrap, webhookRepo, webhookSender, mqProducer, webhookConsumer, userStateConsumer, messageDedupRepo)
+	attachmentRepo := data.NewAttachmentRepo(dataData, logger)
+	attachmentStorage, err := data.NewAttachmentStorage(bootstrap)
 	if err != nil {
+		cleanup10()
+		cleanup9()
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
 		cleanup3()
 		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	userMessageHandler, cleanup5 := biz.NewUserMessageHandler(logger, consumer, messageDedupRepo, mqProducer)
-	logicService := service.NewLogicService(logic, logger, userMessageHandler)
+	attachments, cleanup11 := biz.NewAttachments(logger, bootstrap, attachmentRepo, attachmentStorage)
+	userMessageHandler, cleanup12 := biz.NewUserMessageHandler(logger, consumer, messageDedupRepo, mqProducer, pushRepo, groupRepo, conversationRepo, offlineRepo, chatRepo, readStateRepo, sequenceRepo, quotaRepo, reporter, webhook, attachments, preferences, bootstrap)
+	broadcastRepo := data.NewBroadcastRepo(dataData, logger)
+	broadcastConsumer, cleanup13 := data.NewBroadcastConsumer(bootstrap, logger)
+	broadcast, cleanup14 := biz.NewBroadcast(logger, pushRepo, broadcastRepo, mqProducer, broadcastConsumer, preferences)
+	leaderElector := data.NewLeaderElector(client, logger)
+	pushScheduler, cleanup15 := biz.NewPushScheduler(logger, logic, scheduleRepo, leaderElector)
+	conversation := biz.NewConversation(logger, readStateRepo, conversationRepo, chatRepo, groupRepo)
+	funnelConsumer, cleanup16 := data.NewFunnelConsumer(bootstrap, logger)
+	funnelRepo := data.NewFunnelRepo(dataData, logger)
+	funnel, cleanup17 := biz.NewFunnel(logger, funnelConsumer, funnelRepo, messageDedupRepo)
+	botRepo := data.NewBotRepo(dataData, logger)
+	bots := biz.NewBots(logger, botRepo, userMessageHandler)
+	logicService := service.NewLogicService(logic, logger, userMessageHandler, broadcast, pushScheduler, conversation, funnel, reporter, webhook, bots, attachments, preferences)
 	httpServer := server.NewHTTPServer(bootstrap, logicService, logger)
 	grpcServer := server.NewGRPCServer(bootstrap, logicService, logger)
 	registrar := data.NewRegistry(client)
 	app := newApp(logger, httpServer, grpcServer, registrar)
 	return app, func() {
+		cleanup17()
+		cleanup16()
+		cleanup15()
+		cleanup14()
+		cleanup13()
+		cleanup12()
+		cleanup11()
+		cleanup10()
+		cleanup9()
+		cleanup8()
+		cleanup7()
+		cleanup6()
 		cleanup5()
 		cleanup4()
 		cleanup3()
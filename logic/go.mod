@@ -6,11 +6,12 @@ require (
 	github.com/IBM/sarama v1.46.3
 	github.com/go-kratos/kratos/contrib/registry/etcd/v2 v2.0.0-20250912104010-25b6c0fb9f38
 	github.com/go-kratos/kratos/v2 v2.9.1
+	github.com/go-sql-driver/mysql v1.8.1
 	github.com/google/wire v0.6.0
 	github.com/prometheus/client_golang v1.23.0
 	github.com/redis/go-redis/v9 v9.14.0
-	github.com/xinghe903/chatify/api v0.0.0-beta003
-	github.com/xinghe903/chatify/pkg v0.0.0-beta003
+	github.com/xinghe903/chatify/api v0.0.0-beta004
+	github.com/xinghe903/chatify/pkg v0.0.0-beta005
 	go.etcd.io/etcd/client/v3 v3.6.5
 	go.opentelemetry.io/otel v1.38.0
 	go.uber.org/automaxprocs v1.5.1
@@ -19,11 +20,6 @@ require (
 	gorm.io/gorm v1.31.0
 )
 
-replace (
-	pkg => ../pkg
-	push => ../push
-)
-
 require (
 	dario.cat/mergo v1.0.0 // indirect
 	filippo.io/edwards25519 v1.1.0 // indirect
@@ -43,7 +39,6 @@ require (
 	github.com/go-logr/stdr v1.2.2 // indirect
 	github.com/go-ole/go-ole v1.2.6 // indirect
 	github.com/go-playground/form/v4 v4.2.1 // indirect
-	github.com/go-sql-driver/mysql v1.8.1 // indirect
 	github.com/gogo/protobuf v1.3.2 // indirect
 	github.com/golang/protobuf v1.5.4 // indirect
 	github.com/golang/snappy v0.0.4 // indirect
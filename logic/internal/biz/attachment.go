@@ -0,0 +1,437 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+	"github.com/xinghe903/chatify/logic/internal/conf"
+
+	v1 "github.com/xinghe903/chatify/api/logic/v1"
+
+	"github.com/xinghe903/chatify/pkg/auth"
+	"github.com/xinghe903/chatify/pkg/auth/sign"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// ErrAttachmentURLInvalid 下载地址签名错误或已过期
+var ErrAttachmentURLInvalid = errors.New("attachment url is invalid or expired")
+
+// AttachmentRepo 附件和分片上传仓库接口
+type AttachmentRepo interface {
+	CreateUpload(ctx context.Context, upload *bo.AttachmentUpload) error
+	// GetUpload 查询上传会话及已上传的分片，不存在时返回nil
+	GetUpload(ctx context.Context, uploadId string) (*bo.AttachmentUpload, error)
+	// MarkChunkUploaded 记录分片已上传，返回已上传的分片数
+	MarkChunkUploaded(ctx context.Context, uploadId string, index int) (int, error)
+	// CompleteUpload 把上传会话标记为已完成并保存附件，会话已不是上传中状态时返回false
+	CompleteUpload(ctx context.Context, uploadId string, attachment *bo.Attachment) (bool, error)
+	// DeleteUpload 删除上传会话及分片记录
+	DeleteUpload(ctx context.Context, uploadId string) error
+	// ListExpiredUploads 查询过期仍未完成的上传
+	ListExpiredUploads(ctx context.Context, before time.Time, limit int) ([]*bo.AttachmentUpload, error)
+	// GetAttachment 查询附件，不存在时返回nil
+	GetAttachment(ctx context.Context, attachmentId string) (*bo.Attachment, error)
+	// GetStorageKey 按sha256查询已保存的内容，不存在时返回空
+	GetStorageKey(ctx context.Context, sha256 string) (string, error)
+	// UsedBytes 统计用户已占用的存储，包含附件和未过期的上传
+	UsedBytes(ctx context.Context, userId string, now time.Time) (int64, error)
+}
+
+// AttachmentStorage 附件存储后端接口
+// 分片先写入上传临时区，合并后按内容sha256保存为对象
+type AttachmentStorage interface {
+	// WriteChunk 保存上传分片，重复写入同一分片时覆盖
+	WriteChunk(ctx context.Context, uploadId string, index int, data []byte) error
+	// Compose 按序号合并所有分片，返回合并后内容的sha256和大小
+	Compose(ctx context.Context, uploadId string, chunkCount int) (string, int64, error)
+	// Commit 把合并后的内容保存为key对应的对象，对象已存在时直接覆盖
+	Commit(ctx context.Context, uploadId string, key string) error
+	// DeleteUpload 删除上传的分片和合并结果
+	DeleteUpload(ctx context.Context, uploadId string) error
+	// Open 读取对象
+	Open(ctx context.Context, key string) (io.ReadSeekCloser, error)
+}
+
+// Attachments 附件分片上传、去重和下载
+type Attachments struct {
+	log          *log.Helper
+	repo         AttachmentRepo
+	storage      AttachmentStorage
+	sonyFlake    *auth.Sonyflake
+	chunkSize    int64
+	maxFileSize  int64
+	userQuota    int64
+	uploadExpire time.Duration
+	urlExpire    time.Duration
+	publicURL    string
+	secret       string
+	composing    sync.Map // 正在完成的上传会话，uploadId -> chan struct{}，完成后关闭channel
+}
+
+// NewAttachments 创建附件服务，并启动过期上传的清理协程
+func NewAttachments(logger log.Logger, c *conf.Bootstrap, repo AttachmentRepo, storage AttachmentStorage) (*Attachments, func()) {
+	ac := c.GetAttachment()
+	a := &Attachments{
+		log:          log.NewHelper(logger),
+		repo:         repo,
+		storage:      storage,
+		sonyFlake:    auth.NewSonyflake(),
+		chunkSize:    ac.GetChunkSize(),
+		maxFileSize:  ac.GetMaxFileSize(),
+		userQuota:    ac.GetUserQuota(),
+		uploadExpire: ac.GetUploadExpire().AsDuration(),
+		urlExpire:    ac.GetUrlExpire().AsDuration(),
+		publicURL:    strings.TrimSuffix(ac.GetPublicUrl(), "/"),
+		secret:       ac.GetSecret(),
+	}
+	if a.chunkSize <= 0 || a.chunkSize > bo.MaxAttachmentChunkSize {
+		a.chunkSize = bo.DefaultAttachmentChunkSize
+	}
+	if a.maxFileSize <= 0 {
+		a.maxFileSize = bo.DefaultMaxAttachmentSize
+	}
+	if a.userQuota <= 0 {
+		a.userQuota = bo.DefaultAttachmentUserQuota
+	}
+	if a.uploadExpire <= 0 {
+		a.uploadExpire = bo.DefaultAttachmentUploadExpire
+	}
+	if a.urlExpire <= 0 {
+		a.urlExpire = bo.DefaultAttachmentURLExpire
+	}
+	if a.secret == "" {
+		a.secret = c.GetServer().GetSecret()
+	}
+	ctx, cancel := context.WithCancelCause(context.TODO())
+	go a.cleanupLoop(ctx)
+	return a, func() { cancel(errors.New("attachment context canceled")) }
+}
+
+// CreateUpload 创建分片上传
+// 即使声明的sha256已有保存的内容也必须上传全部分片，只凭sha256引用内容会让知道sha256的人取得他人的文件
+func (a *Attachments) CreateUpload(ctx context.Context, upload *bo.AttachmentUpload) (*bo.AttachmentUpload, error) {
+	userId := auth.GetUserID(ctx)
+	if userId == "" {
+		return nil, v1.ErrorUnauthenticated("user id not found in context")
+	}
+	upload.FileName = strings.TrimSpace(upload.FileName)
+	if upload.FileName == "" || utf8.RuneCountInString(upload.FileName) > bo.MaxContentNameRunes {
+		return nil, v1.ErrorInvalidParameter("file name length must be between 1 and %d", bo.MaxContentNameRunes)
+	}
+	if upload.Size <= 0 || upload.Size > a.maxFileSize {
+		return nil, v1.ErrorInvalidParameter("file size must be between 1 and %d", a.maxFileSize)
+	}
+	upload.Sha256 = strings.ToLower(strings.TrimSpace(upload.Sha256))
+	if upload.Sha256 != "" && !bo.IsSha256Hex(upload.Sha256) {
+		return nil, v1.ErrorInvalidParameter("invalid sha256 %q", upload.Sha256)
+	}
+	upload.MimeType = strings.ToLower(strings.TrimSpace(upload.MimeType))
+	upload.UserId = userId
+
+	used, err := a.repo.UsedBytes(ctx, userId, time.Now())
+	if err != nil {
+		a.log.WithContext(ctx).Errorf("failed to count used storage. userId=%s, error=%v", userId, err)
+		return nil, v1.ErrorInternalError("failed to count used storage")
+	}
+	if used+upload.Size > a.userQuota {
+		return nil, v1.ErrorStorageQuotaExceeded("storage quota exceeded quota=%d, used=%d, size=%d", a.userQuota, used, upload.Size)
+	}
+
+	uploadId, err := a.sonyFlake.GenerateBase62()
+	if err != nil {
+		a.log.WithContext(ctx).Errorf("failed to generate upload id: %v", err)
+		return nil, v1.ErrorInternalError("failed to generate upload id")
+	}
+	upload.UploadId = uploadId
+	upload.ChunkSize = a.chunkSize
+	upload.ChunkCount = int((upload.Size + a.chunkSize - 1) / a.chunkSize)
+	upload.Status = bo.AttachmentUploadPending
+	upload.UploadedChunks = []int{}
+	upload.ExpiresAt = time.Now().Add(a.uploadExpire)
+	if err = a.repo.CreateUpload(ctx, upload); err != nil {
+		a.log.WithContext(ctx).Errorf("failed to create upload. userId=%s, error=%v", userId, err)
+		return nil, v1.ErrorInternalError("failed to create upload")
+	}
+	a.log.WithContext(ctx).Infof("Created attachment upload. uploadId=%s, userId=%s, size=%d, chunks=%d",
+		upload.UploadId, userId, upload.Size, upload.ChunkCount)
+	return upload, nil
+}
+
+func (a *Attachments) newAttachment(upload *bo.AttachmentUpload, key string) (*bo.Attachment, error) {
+	attachmentId, err := bo.NewAttachmentId()
+	if err != nil {
+		return nil, err
+	}
+	return &bo.Attachment{
+		AttachmentId: attachmentId,
+		UserId:       upload.UserId,
+		FileName:     upload.FileName,
+		MimeType:     upload.MimeType,
+		Size:         upload.Size,
+		Sha256:       upload.Sha256,
+		StorageKey:   key,
+		CreatedAt:    time.Now(),
+	}, nil
+}
+
+// UploadChunk 上传分片，返回已上传的分片数
+func (a *Attachments) UploadChunk(ctx context.Context, uploadId string, index int, data []byte) (int, error) {
+	upload, err := a.pendingUpload(ctx, uploadId)
+	if err != nil {
+		return 0, err
+	}
+	if index < 0 || index >= upload.ChunkCount {
+		return 0, v1.ErrorInvalidParameter("chunk index out of range [0, %d)", upload.ChunkCount)
+	}
+	if want := upload.ChunkLength(index); int64(len(data)) != want {
+		return 0, v1.ErrorInvalidParameter("chunk %d length must be %d, got %d", index, want, len(data))
+	}
+	if err = a.storage.WriteChunk(ctx, uploadId, index, data); err != nil {
+		a.log.WithContext(ctx).Errorf("failed to write chunk. uploadId=%s, index=%d, error=%v", uploadId, index, err)
+		return 0, v1.ErrorInternalError("failed to write chunk")
+	}
+	count, err := a.repo.MarkChunkUploaded(ctx, uploadId, index)
+	if err != nil {
+		a.log.WithContext(ctx).Errorf("failed to mark chunk uploaded. uploadId=%s, index=%d, error=%v", uploadId, index, err)
+		return 0, v1.ErrorInternalError("failed to mark chunk uploaded")
+	}
+	return count, nil
+}
+
+// GetUpload 查询上传进度，已完成的上传同时返回附件
+func (a *Attachments) GetUpload(ctx context.Context, uploadId string) (*bo.AttachmentUpload, error) {
+	upload, err := a.ownUpload(ctx, uploadId)
+	if err != nil {
+		return nil, err
+	}
+	if upload.Status == bo.AttachmentUploadCompleted {
+		if upload.Attachment, err = a.getAttachment(ctx, upload.AttachmentId); err != nil {
+			return nil, err
+		}
+	}
+	return upload, nil
+}
+
+// CompleteUpload 合并分片并保存为附件
+// 合并后的内容按sha256去重，已保存过的内容只记录新附件，不重复保存
+func (a *Attachments) CompleteUpload(ctx context.Context, uploadId string) (*bo.AttachmentUpload, error) {
+	unlock, err := a.lockUpload(ctx, uploadId)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	upload, err := a.pendingUpload(ctx, uploadId)
+	if err != nil {
+		return nil, err
+	}
+	if len(upload.UploadedChunks) != upload.ChunkCount {
+		return nil, v1.ErrorInvalidParameter("upload is incomplete uploaded=%d, total=%d", len(upload.UploadedChunks), upload.ChunkCount)
+	}
+	sha256, size, err := a.storage.Compose(ctx, uploadId, upload.ChunkCount)
+	if err != nil {
+		a.log.WithContext(ctx).Errorf("failed to compose chunks. uploadId=%s, error=%v", uploadId, err)
+		return nil, v1.ErrorInternalError("failed to compose chunks")
+	}
+	if size != upload.Size {
+		return nil, v1.ErrorInvalidParameter("uploaded size mismatch expected=%d, actual=%d", upload.Size, size)
+	}
+	if upload.Sha256 != "" && upload.Sha256 != sha256 {
+		return nil, v1.ErrorInvalidParameter("uploaded sha256 mismatch expected=%s, actual=%s", upload.Sha256, sha256)
+	}
+	upload.Sha256 = sha256
+
+	key, err := a.repo.GetStorageKey(ctx, sha256)
+	if err != nil {
+		a.log.WithContext(ctx).Errorf("failed to get stored content. sha256=%s, error=%v", sha256, err)
+		return nil, v1.ErrorInternalError("failed to get stored content")
+	}
+	if key == "" {
+		key = bo.AttachmentStorageKey(sha256)
+		if err = a.storage.Commit(ctx, uploadId, key); err != nil {
+			a.log.WithContext(ctx).Errorf("failed to commit content. uploadId=%s, error=%v", uploadId, err)
+			return nil, v1.ErrorInternalError("failed to commit content")
+		}
+	}
+	attachment, err := a.newAttachment(upload, key)
+	if err != nil {
+		a.log.WithContext(ctx).Errorf("failed to generate attachment id: %v", err)
+		return nil, v1.ErrorInternalError("failed to generate attachment id")
+	}
+	ok, err := a.repo.CompleteUpload(ctx, uploadId, attachment)
+	if err != nil {
+		a.log.WithContext(ctx).Errorf("failed to complete upload. uploadId=%s, error=%v", uploadId, err)
+		return nil, v1.ErrorInternalError("failed to complete upload")
+	}
+	if !ok {
+		// 并发的完成请求已经处理
+		return a.GetUpload(ctx, uploadId)
+	}
+	if err = a.storage.DeleteUpload(ctx, uploadId); err != nil {
+		a.log.WithContext(ctx).Warnf("failed to delete upload chunks. uploadId=%s, error=%v", uploadId, err)
+	}
+	a.log.WithContext(ctx).Infof("Completed attachment upload. uploadId=%s, attachmentId=%s, sha256=%s",
+		uploadId, attachment.AttachmentId, sha256)
+	upload.Status = bo.AttachmentUploadCompleted
+	upload.AttachmentId = attachment.AttachmentId
+	upload.Attachment = attachment
+	return upload, nil
+}
+
+// GetAttachment 查询附件，返回带签名的下载地址和过期时间（单位: 秒）
+func (a *Attachments) GetAttachment(ctx context.Context, attachmentId string) (*bo.Attachment, string, int64, error) {
+	if auth.GetUserID(ctx) == "" {
+		return nil, "", 0, v1.ErrorUnauthenticated("user id not found in context")
+	}
+	attachment, err := a.getAttachment(ctx, attachmentId)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	downloadURL, expires := a.DownloadURL(attachment.AttachmentId)
+	return attachment, downloadURL, expires, nil
+}
+
+// DownloadURL 生成带签名的下载地址，返回地址和过期时间（单位: 秒）
+func (a *Attachments) DownloadURL(attachmentId string) (string, int64) {
+	expires := time.Now().Add(a.urlExpire).Unix()
+	query := url.Values{}
+	query.Set("attachment_id", attachmentId)
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", sign.SignPayload(a.secret, expires, []byte(attachmentId)))
+	return a.publicURL + bo.AttachmentDownloadPath + "?" + query.Encode(), expires
+}
+
+// OpenDownload 校验下载地址的签名和有效期后打开附件内容
+func (a *Attachments) OpenDownload(ctx context.Context, attachmentId string, expires int64, signature string) (*bo.Attachment, io.ReadSeekCloser, error) {
+	if expires < time.Now().Unix() || !sign.VerifyPayload(a.secret, expires, []byte(attachmentId), signature) {
+		return nil, nil, ErrAttachmentURLInvalid
+	}
+	attachment, err := a.getAttachment(ctx, attachmentId)
+	if err != nil {
+		return nil, nil, err
+	}
+	reader, err := a.storage.Open(ctx, attachment.StorageKey)
+	if err != nil {
+		a.log.WithContext(ctx).Errorf("failed to open attachment. attachmentId=%s, error=%v", attachmentId, err)
+		return nil, nil, v1.ErrorInternalError("failed to open attachment")
+	}
+	return attachment, reader, nil
+}
+
+// CheckAttachment 校验消息内容引用的附件存在
+func (a *Attachments) CheckAttachment(ctx context.Context, attachmentId string) error {
+	_, err := a.getAttachment(ctx, attachmentId)
+	return err
+}
+
+func (a *Attachments) getAttachment(ctx context.Context, attachmentId string) (*bo.Attachment, error) {
+	if !bo.IsAttachmentId(attachmentId) {
+		return nil, v1.ErrorAttachmentNotFound("attachment %s not found", attachmentId)
+	}
+	attachment, err := a.repo.GetAttachment(ctx, attachmentId)
+	if err != nil {
+		a.log.WithContext(ctx).Errorf("failed to get attachment. attachmentId=%s, error=%v", attachmentId, err)
+		return nil, v1.ErrorInternalError("failed to get attachment")
+	}
+	if attachment == nil {
+		return nil, v1.ErrorAttachmentNotFound("attachment %s not found", attachmentId)
+	}
+	return attachment, nil
+}
+
+// ownUpload 查询当前用户的上传会话
+func (a *Attachments) ownUpload(ctx context.Context, uploadId string) (*bo.AttachmentUpload, error) {
+	userId := auth.GetUserID(ctx)
+	if userId == "" {
+		return nil, v1.ErrorUnauthenticated("user id not found in context")
+	}
+	if uploadId == "" {
+		return nil, v1.ErrorInvalidParameter("upload id is empty")
+	}
+	upload, err := a.repo.GetUpload(ctx, uploadId)
+	if err != nil {
+		a.log.WithContext(ctx).Errorf("failed to get upload. uploadId=%s, error=%v", uploadId, err)
+		return nil, v1.ErrorInternalError("failed to get upload")
+	}
+	if upload == nil || upload.UserId != userId {
+		return nil, v1.ErrorUploadNotFound("upload %s not found", uploadId)
+	}
+	return upload, nil
+}
+
+// pendingUpload 查询当前用户未过期的上传中会话
+// lockUpload 串行处理同一上传会话的完成请求，避免并发合并时互相截断合并文件
+// 后到的请求等待前一个请求结束后再检查上传状态
+func (a *Attachments) lockUpload(ctx context.Context, uploadId string) (func(), error) {
+	done := make(chan struct{})
+	for {
+		held, loaded := a.composing.LoadOrStore(uploadId, done)
+		if !loaded {
+			return func() {
+				a.composing.Delete(uploadId)
+				close(done)
+			}, nil
+		}
+		select {
+		case <-held.(chan struct{}):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (a *Attachments) pendingUpload(ctx context.Context, uploadId string) (*bo.AttachmentUpload, error) {
+	upload, err := a.ownUpload(ctx, uploadId)
+	if err != nil {
+		return nil, err
+	}
+	if upload.Status != bo.AttachmentUploadPending {
+		return nil, v1.ErrorInvalidParameter("upload %s is already completed", uploadId)
+	}
+	if time.Now().After(upload.ExpiresAt) {
+		return nil, v1.ErrorUploadNotFound("upload %s expired", uploadId)
+	}
+	return upload, nil
+}
+
+func (a *Attachments) cleanupLoop(ctx context.Context) {
+	ticker := time.NewTicker(bo.AttachmentCleanupInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			a.cleanup(ctx)
+		}
+	}
+}
+
+// cleanup 删除过期未完成的上传及其分片
+func (a *Attachments) cleanup(ctx context.Context) {
+	uploads, err := a.repo.ListExpiredUploads(ctx, time.Now(), bo.AttachmentCleanupBatchSize)
+	if err != nil {
+		a.log.WithContext(ctx).Errorf("failed to list expired uploads: %v", err)
+		return
+	}
+	for _, upload := range uploads {
+		if err = a.storage.DeleteUpload(ctx, upload.UploadId); err != nil {
+			a.log.WithContext(ctx).Warnf("failed to delete expired upload chunks. uploadId=%s, error=%v", upload.UploadId, err)
+			continue
+		}
+		if err = a.repo.DeleteUpload(ctx, upload.UploadId); err != nil {
+			a.log.WithContext(ctx).Warnf("failed to delete expired upload. uploadId=%s, error=%v", upload.UploadId, err)
+		}
+	}
+	if len(uploads) > 0 {
+		a.log.WithContext(ctx).Infof("Cleaned up expired attachment uploads. count=%d", len(uploads))
+	}
+}
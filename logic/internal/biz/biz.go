@@ -3,4 +3,4 @@ package biz
 import "github.com/google/wire"
 
 // ProviderSet is biz providers.
-var ProviderSet = wire.NewSet(NewLogic, NewUserMessageHandler)
+var ProviderSet = wire.NewSet(NewLogic, NewUserMessageHandler, NewBroadcast, NewPushScheduler, NewConversation, NewFunnel, NewReporter, NewWebhook, NewBots, NewAttachments, NewPreferences)
@@ -0,0 +1,112 @@
+package bo
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+const (
+	// AttachmentIdPrefix 附件ID前缀
+	AttachmentIdPrefix = "att_"
+	// DefaultAttachmentChunkSize 默认分片大小
+	DefaultAttachmentChunkSize = 1 << 20
+	// MaxAttachmentChunkSize 分片大小上限，分片需要放在一个请求体中
+	MaxAttachmentChunkSize = 4 << 20
+	// DefaultMaxAttachmentSize 默认的单个附件大小上限
+	DefaultMaxAttachmentSize = 100 << 20
+	// DefaultAttachmentUserQuota 默认的用户存储配额
+	DefaultAttachmentUserQuota = 1 << 30
+	// DefaultAttachmentUploadExpire 未完成的上传默认保留时长
+	DefaultAttachmentUploadExpire = 24 * time.Hour
+	// DefaultAttachmentURLExpire 下载地址默认有效期
+	DefaultAttachmentURLExpire = time.Hour
+	// AttachmentCleanupInterval 清理过期上传的间隔
+	AttachmentCleanupInterval = 10 * time.Minute
+	// AttachmentCleanupBatchSize 每次清理的过期上传数
+	AttachmentCleanupBatchSize = 100
+	// AttachmentDownloadPath 附件下载地址的路径
+	AttachmentDownloadPath = "/chatify/logic/v1/downloadAttachment"
+)
+
+// AttachmentUploadStatus 附件上传状态
+type AttachmentUploadStatus string
+
+const (
+	AttachmentUploadPending   AttachmentUploadStatus = "pending"   // 上传中
+	AttachmentUploadCompleted AttachmentUploadStatus = "completed" // 已完成
+)
+
+// Attachment 附件，内容按sha256去重保存，多个附件可以引用同一份内容
+type Attachment struct {
+	AttachmentId string    `json:"attachment_id"`
+	UserId       string    `json:"user_id"` // 上传者
+	FileName     string    `json:"file_name"`
+	MimeType     string    `json:"mime_type"`
+	Size         int64     `json:"size"`
+	Sha256       string    `json:"sha256"`
+	StorageKey   string    `json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// AttachmentUpload 分片上传会话
+type AttachmentUpload struct {
+	UploadId       string                 `json:"upload_id"`
+	UserId         string                 `json:"user_id"`
+	FileName       string                 `json:"file_name"`
+	MimeType       string                 `json:"mime_type"`
+	Size           int64                  `json:"size"`
+	Sha256         string                 `json:"sha256"` // 上传者声明的sha256，为空时不校验
+	ChunkSize      int64                  `json:"chunk_size"`
+	ChunkCount     int                    `json:"chunk_count"`
+	Status         AttachmentUploadStatus `json:"status"`
+	AttachmentId   string                 `json:"attachment_id"` // 上传完成后的附件ID
+	UploadedChunks []int                  `json:"uploaded_chunks"`
+	ExpiresAt      time.Time              `json:"expires_at"`
+	Attachment     *Attachment            `json:"attachment,omitempty"`
+}
+
+// ChunkLength 返回第index个分片应有的长度，index越界时返回0
+func (u *AttachmentUpload) ChunkLength(index int) int64 {
+	if index < 0 || index >= u.ChunkCount {
+		return 0
+	}
+	if index == u.ChunkCount-1 {
+		return u.Size - u.ChunkSize*int64(index)
+	}
+	return u.ChunkSize
+}
+
+// NewAttachmentId 生成随机的附件ID，附件ID需要不可猜测
+func NewAttachmentId() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return AttachmentIdPrefix + hex.EncodeToString(buf), nil
+}
+
+// IsAttachmentId 判断是否为合法格式的附件ID
+func IsAttachmentId(id string) bool {
+	hexPart, ok := strings.CutPrefix(id, AttachmentIdPrefix)
+	if !ok || len(hexPart) != 32 {
+		return false
+	}
+	_, err := hex.DecodeString(hexPart)
+	return err == nil
+}
+
+// IsSha256Hex 判断是否为小写十六进制的sha256
+func IsSha256Hex(s string) bool {
+	if len(s) != 64 || strings.ToLower(s) != s {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+// AttachmentStorageKey 按内容sha256计算存储位置，相同内容只保存一份
+func AttachmentStorageKey(sha256 string) string {
+	return "objects/" + sha256[:2] + "/" + sha256
+}
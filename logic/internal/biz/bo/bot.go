@@ -0,0 +1,47 @@
+package bo
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+const (
+	// BotIdPrefix 机器人账号ID前缀，与auth服务的用户ID前缀 uid 区分
+	BotIdPrefix = "bot"
+	// BotAuthScheme 机器人接口请求头 Authorization: Bot <token>
+	BotAuthScheme = "Bot "
+	// MaxBotNameLength 机器人名称最大长度
+	MaxBotNameLength = 64
+)
+
+// Bot 机器人账号
+type Bot struct {
+	BotId     string    `json:"bot_id"`
+	Name      string    `json:"name"`
+	OwnerId   string    `json:"owner_id"`
+	TokenHash string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// IsBot 判断用户ID是否为机器人账号
+func IsBot(userId string) bool {
+	return strings.HasPrefix(userId, BotIdPrefix)
+}
+
+// HashBotToken 计算token摘要，token为高熵随机串，不需要加盐
+func HashBotToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// GenerateSecret 生成32字节随机密钥，用于机器人token和webhook签名密钥
+func GenerateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,153 @@
+package bo
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	im_v1 "github.com/xinghe903/chatify/api/im/v1"
+	v1 "github.com/xinghe903/chatify/api/logic/v1"
+)
+
+const (
+	// BroadcastChunkSize 广播任务每个分片的用户数，与单次推送上限保持一致
+	BroadcastChunkSize = MaxTargetUsers
+	// MaxBroadcastUsers 单个广播任务的目标用户数上限
+	MaxBroadcastUsers = 10000000
+	// BroadcastChunkLease 分片投递中状态的租期，超过租期未完成视为处理中断（如实例崩溃），重新置为待投递
+	BroadcastChunkLease = 5 * time.Minute
+	// BroadcastReclaimBatchSize 每次重置的超时分片数
+	BroadcastReclaimBatchSize = 100
+)
+
+// BroadcastJobStatus 广播任务状态
+type BroadcastJobStatus string
+
+const (
+	BroadcastJobStatusRunning   BroadcastJobStatus = "running"   // 执行中
+	BroadcastJobStatusPaused    BroadcastJobStatus = "paused"    // 已暂停
+	BroadcastJobStatusCanceled  BroadcastJobStatus = "canceled"  // 已取消
+	BroadcastJobStatusCompleted BroadcastJobStatus = "completed" // 已完成
+)
+
+// BroadcastChunkStatus 广播分片状态
+type BroadcastChunkStatus string
+
+const (
+	BroadcastChunkStatusPending    BroadcastChunkStatus = "pending"    // 待投递
+	BroadcastChunkStatusProcessing BroadcastChunkStatus = "processing" // 投递中
+	BroadcastChunkStatusSent       BroadcastChunkStatus = "sent"       // 已投递给push服务
+	BroadcastChunkStatusFailed     BroadcastChunkStatus = "failed"     // 投递失败
+	BroadcastChunkStatusCanceled   BroadcastChunkStatus = "canceled"   // 任务取消，不再投递
+)
+
+// BroadcastJob 广播任务业务对象
+type BroadcastJob struct {
+	JobId          string             `json:"job_id"`
+	Status         BroadcastJobStatus `json:"status"`
+	ContentId      string             `json:"content_id"`
+	Content        []byte             `json:"content"`
+	PushType       v1.PushType        `json:"push_type"`
+	FromUserId     string             `json:"from_user_id"`
+	Timestamp      int64              `json:"timestamp"`
+	ExpireTime     string             `json:"expire_time"`
+	TotalUsers     int64              `json:"total_users"`
+	TotalChunks    int32              `json:"total_chunks"`
+	FinishedChunks int32              `json:"finished_chunks"`
+	SentUsers      int64              `json:"sent_users"`
+	FailedUsers    int64              `json:"failed_users"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+}
+
+// BroadcastChunk 广播任务分片，每个分片对应一次push任务
+type BroadcastChunk struct {
+	JobId       string               `json:"job_id"`
+	ChunkIndex  int32                `json:"chunk_index"`
+	UserIds     []string             `json:"user_ids"`
+	Status      BroadcastChunkStatus `json:"status"`
+	TaskId      string               `json:"task_id"`
+	Description string               `json:"description"`
+	ClaimToken  string               `json:"claim_token"` // 抢占分片时生成，完成分片时校验
+}
+
+// BroadcastChunkTask 投递到Kafka的分片任务
+type BroadcastChunkTask struct {
+	JobId      string `json:"job_id"`
+	ChunkIndex int32  `json:"chunk_index"`
+}
+
+// NewBroadcastJob 根据CreateBroadcastJobRequest创建广播任务
+func NewBroadcastJob(req *v1.CreateBroadcastJobRequest) *BroadcastJob {
+	return &BroadcastJob{
+		Status:     BroadcastJobStatusRunning,
+		ContentId:  req.ContentId,
+		Content:    req.Content,
+		PushType:   req.PushType,
+		FromUserId: req.FromUserId,
+		Timestamp:  req.Timestamp,
+		ExpireTime: req.ExpireTime,
+	}
+}
+
+// ExpireAt 过期时间（单位: 秒），创建任务时已规范化为秒级时间戳，为空或无法解析时永不过期
+func (j *BroadcastJob) ExpireAt() int64 {
+	expireAt, err := strconv.ParseInt(j.ExpireTime, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return expireAt
+}
+
+// ToSystemPushRequest 将分片用户还原为系统推送请求，复用单次推送的消息构造逻辑
+func (j *BroadcastJob) ToSystemPushRequest(userIds []string) *v1.SystemPushRequest {
+	return &v1.SystemPushRequest{
+		ContentId:  j.ContentId,
+		Content:    j.Content,
+		Timestamp:  j.Timestamp,
+		PushType:   j.PushType,
+		FromUserId: j.FromUserId,
+		ToUserIds:  userIds,
+		ExpireTime: j.ExpireTime,
+		Priority:   im_v1.Priority_PRIORITY_LOW, // 广播任务为批量发送，不论推送类型都使用低优先级
+	}
+}
+
+// ParseRecipients 合并请求中的用户列表与上传文件中的用户，去重并保持原有顺序
+// 上传文件中的用户ID以换行、逗号或空白分隔
+func ParseRecipients(userIds []string, file []byte) []string {
+	fields := strings.FieldsFunc(string(file), func(r rune) bool {
+		return r == ',' || r == '\n' || r == '\r' || r == '\t' || r == ' '
+	})
+	seen := make(map[string]struct{}, len(userIds)+len(fields))
+	result := make([]string, 0, len(userIds)+len(fields))
+	for _, list := range [][]string{userIds, fields} {
+		for _, uid := range list {
+			uid = strings.TrimSpace(uid)
+			if uid == "" {
+				continue
+			}
+			if _, ok := seen[uid]; ok {
+				continue
+			}
+			seen[uid] = struct{}{}
+			result = append(result, uid)
+		}
+	}
+	return result
+}
+
+// SplitBroadcastChunks 按BroadcastChunkSize将用户切分为分片
+func SplitBroadcastChunks(jobId string, userIds []string) []*BroadcastChunk {
+	chunks := make([]*BroadcastChunk, 0, (len(userIds)+BroadcastChunkSize-1)/BroadcastChunkSize)
+	for start := 0; start < len(userIds); start += BroadcastChunkSize {
+		end := min(start+BroadcastChunkSize, len(userIds))
+		chunks = append(chunks, &BroadcastChunk{
+			JobId:      jobId,
+			ChunkIndex: int32(len(chunks)),
+			UserIds:    userIds[start:end],
+			Status:     BroadcastChunkStatusPending,
+		})
+	}
+	return chunks
+}
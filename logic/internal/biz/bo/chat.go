@@ -0,0 +1,125 @@
+package bo
+
+import (
+	"slices"
+	"time"
+
+	im_v1 "github.com/xinghe903/chatify/api/im/v1"
+)
+
+const (
+	// MessageType_CHAT 下发给客户端的聊天消息，取值与 im.v1.MessageType_CHAT 一致
+	MessageType_CHAT MessageType = MessageType(im_v1.MessageType_CHAT)
+	// DefaultRecallWindow 未配置时允许撤回的时长
+	DefaultRecallWindow = 2 * time.Minute
+	// DefaultEditWindow 未配置时允许编辑的时长
+	DefaultEditWindow = 15 * time.Minute
+	// DefaultHistoryLimit 历史消息默认分页大小
+	DefaultHistoryLimit = 20
+	// MaxHistoryLimit 历史消息最大分页大小
+	MaxHistoryLimit = 100
+	// MaxDeleteHistoryMsgIds 单次删除历史消息的最大数量
+	MaxDeleteHistoryMsgIds = 100
+)
+
+// ChatMessageStatus 聊天消息状态
+type ChatMessageStatus string
+
+const (
+	ChatMessageStatusNormal   ChatMessageStatus = "normal"   // 正常
+	ChatMessageStatusEdited   ChatMessageStatus = "edited"   // 已编辑
+	ChatMessageStatusRecalled ChatMessageStatus = "recalled" // 已撤回
+)
+
+// ChatMessage 聊天消息业务对象
+// MsgId为发送者上行的消息ID，下发给接收者的每条消息以它作为content_id
+type ChatMessage struct {
+	MsgId            string            `json:"msg_id"`
+	FromUserId       string            `json:"from_user_id"`
+	ConversationType im_v1.TargetType  `json:"conversation_type"`
+	ConversationId   string            `json:"conversation_id"` // 单聊为接收者ID，群聊为群ID
+	Content          []byte            `json:"content"`
+	ContentType      MessageType       `json:"content_type"` // 内容类型，升级前保存的消息为0
+	Timestamp        int64             `json:"timestamp"`
+	Status           ChatMessageStatus `json:"status"`
+	RevisedBy        string            `json:"revised_by"`
+	RevisedAt        int64             `json:"revised_at"`
+	SentAt           int64             `json:"sent_at"`                    // 服务端接收时间（单位: 毫秒）
+	Seq              int64             `json:"seq"`                        // 会话内的消息序号，单聊双方共用
+	DeliveredAt      int64             `json:"delivered_at"`               // 扇出给全部接收者的时间（单位: 毫秒），为0表示尚未完成投递
+	MentionUserIds   []string          `json:"mention_user_ids,omitempty"` // 被@的用户，取自内容，不单独保存
+	MentionAll       bool              `json:"mention_all,omitempty"`
+	CreatedAt        time.Time         `json:"created_at"`
+}
+
+// HistoryQuery 历史消息查询条件
+// Anchor为空时查询最新消息，After为true时查询晚于Anchor的消息，否则查询早于Anchor的消息
+type HistoryQuery struct {
+	UserId          string
+	ConversationKey string
+	Anchor          *ChatMessage
+	After           bool
+	Limit           int
+}
+
+// NewChatMessage 根据客户端上行消息创建聊天消息
+func NewChatMessage(baseMsg *im_v1.BaseMessage) *ChatMessage {
+	return &ChatMessage{
+		MsgId:            baseMsg.MsgId,
+		FromUserId:       baseMsg.FromUserId,
+		ConversationType: baseMsg.TargetType,
+		ConversationId:   baseMsg.ToUserId,
+		Content:          baseMsg.Content,
+		Timestamp:        baseMsg.Timestamp,
+		Status:           ChatMessageStatusNormal,
+	}
+}
+
+// ConversationKey 消息所属会话的唯一标识
+func (m *ChatMessage) ConversationKey() string {
+	return im_v1.ConversationKey(m.ConversationType, m.FromUserId, m.ConversationId)
+}
+
+// ToDelivery 生成下发给接收者的消息模板，MsgId和ToUserId由调用方按接收者填充
+// 单聊和被@的接收者为高优先级，群聊的其他接收者为普通优先级，避免大群扇出挤占单聊
+func (m *ChatMessage) ToDelivery(mentioned bool) *Message {
+	msg := &Message{
+		MessageType: MessageType_CHAT,
+		FromUserId:  m.FromUserId,
+		TargetType:  TargetType(m.ConversationType),
+		Content:     m.Content,
+		ContentType: m.ContentType,
+		Timestamp:   m.Timestamp,
+		ContentId:   m.MsgId,
+		Seq:         m.Seq,
+		Priority:    im_v1.Priority_PRIORITY_HIGH,
+		Mentioned:   mentioned,
+	}
+	if m.ConversationType == im_v1.TargetType_GROUP {
+		msg.GroupId = m.ConversationId
+		if !mentioned {
+			msg.Priority = im_v1.Priority_PRIORITY_NORMAL
+		}
+	}
+	return msg
+}
+
+// IsMentioned 判断userId是否被消息@，发送者自己不算
+func (m *ChatMessage) IsMentioned(userId string) bool {
+	if userId == m.FromUserId {
+		return false
+	}
+	return m.MentionAll || slices.Contains(m.MentionUserIds, userId)
+}
+
+// SplitMentioned 把接收者分为被@和未被@两组
+func (m *ChatMessage) SplitMentioned(recipients []string) (mentioned, others []string) {
+	for _, userId := range recipients {
+		if m.IsMentioned(userId) {
+			mentioned = append(mentioned, userId)
+		} else {
+			others = append(others, userId)
+		}
+	}
+	return mentioned, others
+}
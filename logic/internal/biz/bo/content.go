@@ -0,0 +1,385 @@
+package bo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	im_v1 "github.com/xinghe903/chatify/api/im/v1"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// MaxTextRunes 文本消息最大字符数
+	MaxTextRunes = 5000
+	// MaxMentionUsers 单条消息最多@的用户数
+	MaxMentionUsers = 100
+	// MaxContentURLLength 媒体地址最大长度
+	MaxContentURLLength = 2048
+	// MaxContentNameRunes 文件名、地点名称等最大字符数
+	MaxContentNameRunes = 255
+	// MaxCustomDataBytes 自定义消息业务数据最大字节数
+	MaxCustomDataBytes = 16 * 1024
+)
+
+// 各类型消息的摘要
+const (
+	ImagePreview    = "[图片]"
+	VoicePreview    = "[语音]"
+	VideoPreview    = "[视频]"
+	FilePreview     = "[文件]"
+	LocationPreview = "[位置]"
+)
+
+// ParseContent 解析聊天消息内容
+// 不能解析为消息内容对象的UTF-8内容按纯文本处理，兼容直接发送文本的旧客户端，纯文本也可以以{开头
+func ParseContent(content []byte) (*im_v1.MessageContent, error) {
+	c, _, err := parseContent(content)
+	return c, err
+}
+
+// parseContent 解析聊天消息内容，第二个返回值表示内容是否为纯文本
+func parseContent(content []byte) (*im_v1.MessageContent, bool, error) {
+	trimmed := bytes.TrimSpace(content)
+	if len(trimmed) == 0 {
+		return nil, false, errors.New("content is empty")
+	}
+	if trimmed[0] == '{' {
+		var c im_v1.MessageContent
+		if err := protojson.Unmarshal(content, &c); err == nil && c.Body != nil {
+			return &c, false, nil
+		}
+	}
+	if !utf8.Valid(content) {
+		return nil, false, errors.New("content must be UTF-8 text or a message content object")
+	}
+	return &im_v1.MessageContent{
+		Body: &im_v1.MessageContent_Text{Text: &im_v1.TextContent{Text: string(content)}},
+	}, true, nil
+}
+
+// NormalizeContent 按类型校验并规范化聊天消息内容，返回解析后的内容和规范化后的编码
+// 纯文本只做校验，原样返回，旧客户端收到的仍是自己能展示的文本
+func NormalizeContent(content []byte) (*im_v1.MessageContent, []byte, error) {
+	c, plain, err := parseContent(content)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = normalizeBody(c); err != nil {
+		return nil, nil, err
+	}
+	if plain {
+		return c, content, nil
+	}
+	data, err := protojson.Marshal(c)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal message content: %w", err)
+	}
+	// protojson的输出带有随机空白，压缩后保证相同内容的编码一致
+	var buf bytes.Buffer
+	if err = json.Compact(&buf, data); err != nil {
+		return nil, nil, fmt.Errorf("failed to compact message content: %w", err)
+	}
+	return c, buf.Bytes(), nil
+}
+
+// ContentAttachmentId 返回消息内容引用的附件ID，没有引用附件时返回空
+func ContentAttachmentId(c *im_v1.MessageContent) string {
+	switch b := c.GetBody().(type) {
+	case *im_v1.MessageContent_Image:
+		return b.Image.GetAttachmentId()
+	case *im_v1.MessageContent_Voice:
+		return b.Voice.GetAttachmentId()
+	case *im_v1.MessageContent_Video:
+		return b.Video.GetAttachmentId()
+	case *im_v1.MessageContent_File:
+		return b.File.GetAttachmentId()
+	}
+	return ""
+}
+
+// ContentMentions 返回文本消息@的用户和是否@所有人
+func ContentMentions(c *im_v1.MessageContent) ([]string, bool) {
+	text := c.GetText()
+	return text.GetMentionUserIds(), text.GetMentionAll()
+}
+
+// SameMentions 判断两条消息内容@的用户和是否@所有人相同，不考虑顺序
+// 规范化后的内容中@的用户已去重
+func SameMentions(a, b *im_v1.MessageContent) bool {
+	aUserIds, aAll := ContentMentions(a)
+	bUserIds, bAll := ContentMentions(b)
+	if aAll != bAll || len(aUserIds) != len(bUserIds) {
+		return false
+	}
+	for _, userId := range aUserIds {
+		if !slices.Contains(bUserIds, userId) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContentType 返回消息内容对应的消息类型
+func ContentType(c *im_v1.MessageContent) MessageType {
+	switch c.GetBody().(type) {
+	case *im_v1.MessageContent_Text:
+		return MessageType_TEXT
+	case *im_v1.MessageContent_Image:
+		return MessageType_IMAGE
+	case *im_v1.MessageContent_Voice:
+		return MessageType_VOICE
+	case *im_v1.MessageContent_Video:
+		return MessageType_VIDEO
+	case *im_v1.MessageContent_File:
+		return MessageType_FILE
+	case *im_v1.MessageContent_Location:
+		return MessageType_LOCATION
+	default:
+		return MessageType_CUSTOM
+	}
+}
+
+// ContentPreview 生成会话列表和通知展示的摘要
+func ContentPreview(c *im_v1.MessageContent) string {
+	var preview string
+	switch b := c.GetBody().(type) {
+	case *im_v1.MessageContent_Text:
+		preview = b.Text.GetText()
+	case *im_v1.MessageContent_Image:
+		preview = ImagePreview
+	case *im_v1.MessageContent_Voice:
+		preview = VoicePreview
+	case *im_v1.MessageContent_Video:
+		preview = VideoPreview
+	case *im_v1.MessageContent_File:
+		preview = joinPreview(FilePreview, b.File.GetName())
+	case *im_v1.MessageContent_Location:
+		name := b.Location.GetName()
+		if name == "" {
+			name = b.Location.GetAddress()
+		}
+		preview = joinPreview(LocationPreview, name)
+	case *im_v1.MessageContent_Custom:
+		preview = b.Custom.GetPreview()
+	}
+	if preview == "" {
+		return BinaryPreview
+	}
+	return truncateRunes(preview, MaxPreviewRunes)
+}
+
+func joinPreview(prefix, name string) string {
+	if name == "" {
+		return prefix
+	}
+	return prefix + " " + name
+}
+
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
+func normalizeBody(c *im_v1.MessageContent) error {
+	switch b := c.GetBody().(type) {
+	case *im_v1.MessageContent_Text:
+		return normalizeText(b.Text)
+	case *im_v1.MessageContent_Image:
+		return normalizeImage(b.Image)
+	case *im_v1.MessageContent_Voice:
+		return normalizeVoice(b.Voice)
+	case *im_v1.MessageContent_Video:
+		return normalizeVideo(b.Video)
+	case *im_v1.MessageContent_File:
+		return normalizeFile(b.File)
+	case *im_v1.MessageContent_Location:
+		return normalizeLocation(b.Location)
+	case *im_v1.MessageContent_Custom:
+		return normalizeCustom(b.Custom)
+	default:
+		return errors.New("content body is empty")
+	}
+}
+
+func normalizeText(t *im_v1.TextContent) error {
+	if t == nil || strings.TrimSpace(t.Text) == "" {
+		return errors.New("text is empty")
+	}
+	if n := utf8.RuneCountInString(t.Text); n > MaxTextRunes {
+		return fmt.Errorf("text too long limit=%d, input=%d", MaxTextRunes, n)
+	}
+	mentions := make([]string, 0, len(t.MentionUserIds))
+	for _, userId := range t.MentionUserIds {
+		userId = strings.TrimSpace(userId)
+		if userId != "" && !slices.Contains(mentions, userId) {
+			mentions = append(mentions, userId)
+		}
+	}
+	if len(mentions) > MaxMentionUsers {
+		return fmt.Errorf("too many mentions limit=%d, input=%d", MaxMentionUsers, len(mentions))
+	}
+	t.MentionUserIds = mentions
+	return nil
+}
+
+func normalizeImage(i *im_v1.ImageContent) error {
+	if i == nil {
+		return errors.New("image is empty")
+	}
+	if err := normalizeMedia(&i.Url, &i.AttachmentId); err != nil {
+		return fmt.Errorf("image %w", err)
+	}
+	if err := normalizeURL(&i.ThumbnailUrl, false); err != nil {
+		return fmt.Errorf("image thumbnail url: %w", err)
+	}
+	if i.Width <= 0 || i.Height <= 0 {
+		return errors.New("image width and height must be positive")
+	}
+	if i.Size < 0 {
+		return errors.New("image size must not be negative")
+	}
+	i.Format = strings.ToLower(strings.TrimSpace(i.Format))
+	return nil
+}
+
+func normalizeVoice(v *im_v1.VoiceContent) error {
+	if v == nil {
+		return errors.New("voice is empty")
+	}
+	if err := normalizeMedia(&v.Url, &v.AttachmentId); err != nil {
+		return fmt.Errorf("voice %w", err)
+	}
+	if v.Duration <= 0 {
+		return errors.New("voice duration must be positive")
+	}
+	if v.Size < 0 {
+		return errors.New("voice size must not be negative")
+	}
+	return nil
+}
+
+func normalizeVideo(v *im_v1.VideoContent) error {
+	if v == nil {
+		return errors.New("video is empty")
+	}
+	if err := normalizeMedia(&v.Url, &v.AttachmentId); err != nil {
+		return fmt.Errorf("video %w", err)
+	}
+	if err := normalizeURL(&v.ThumbnailUrl, false); err != nil {
+		return fmt.Errorf("video thumbnail url: %w", err)
+	}
+	if v.Duration <= 0 {
+		return errors.New("video duration must be positive")
+	}
+	if v.Width < 0 || v.Height < 0 || v.Size < 0 {
+		return errors.New("video width, height and size must not be negative")
+	}
+	return nil
+}
+
+func normalizeFile(f *im_v1.FileContent) error {
+	if f == nil {
+		return errors.New("file is empty")
+	}
+	if err := normalizeMedia(&f.Url, &f.AttachmentId); err != nil {
+		return fmt.Errorf("file %w", err)
+	}
+	f.Name = strings.TrimSpace(f.Name)
+	if f.Name == "" {
+		return errors.New("file name is empty")
+	}
+	if err := checkNameLength("file name", f.Name); err != nil {
+		return err
+	}
+	if f.Size < 0 {
+		return errors.New("file size must not be negative")
+	}
+	f.MimeType = strings.ToLower(strings.TrimSpace(f.MimeType))
+	return nil
+}
+
+func normalizeLocation(l *im_v1.LocationContent) error {
+	if l == nil {
+		return errors.New("location is empty")
+	}
+	if math.IsNaN(l.Latitude) || l.Latitude < -90 || l.Latitude > 90 {
+		return fmt.Errorf("latitude out of range: %v", l.Latitude)
+	}
+	if math.IsNaN(l.Longitude) || l.Longitude < -180 || l.Longitude > 180 {
+		return fmt.Errorf("longitude out of range: %v", l.Longitude)
+	}
+	l.Name = strings.TrimSpace(l.Name)
+	l.Address = strings.TrimSpace(l.Address)
+	if err := checkNameLength("location name", l.Name); err != nil {
+		return err
+	}
+	return checkNameLength("location address", l.Address)
+}
+
+func normalizeCustom(c *im_v1.CustomContent) error {
+	if c == nil {
+		return errors.New("custom content is empty")
+	}
+	c.Type = strings.TrimSpace(c.Type)
+	if c.Type == "" {
+		return errors.New("custom type is empty")
+	}
+	if len(c.Data) > MaxCustomDataBytes {
+		return fmt.Errorf("custom data too large limit=%d, input=%d", MaxCustomDataBytes, len(c.Data))
+	}
+	if c.Data != "" && !json.Valid([]byte(c.Data)) {
+		return errors.New("custom data must be valid JSON")
+	}
+	return checkNameLength("custom preview", c.Preview)
+}
+
+// normalizeMedia 校验媒体地址和附件ID，两者至少设置一个
+func normalizeMedia(rawURL, attachmentId *string) error {
+	*attachmentId = strings.TrimSpace(*attachmentId)
+	if *attachmentId != "" && !IsAttachmentId(*attachmentId) {
+		return fmt.Errorf("attachment id: invalid %q", *attachmentId)
+	}
+	if err := normalizeURL(rawURL, *attachmentId == ""); err != nil {
+		return fmt.Errorf("url: %w", err)
+	}
+	return nil
+}
+
+// normalizeURL 去除首尾空白并校验为http(s)地址
+func normalizeURL(raw *string, required bool) error {
+	*raw = strings.TrimSpace(*raw)
+	if *raw == "" {
+		if required {
+			return errors.New("is empty")
+		}
+		return nil
+	}
+	if len(*raw) > MaxContentURLLength {
+		return fmt.Errorf("too long limit=%d, input=%d", MaxContentURLLength, len(*raw))
+	}
+	u, err := url.Parse(*raw)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("must be an http(s) url")
+	}
+	return nil
+}
+
+func checkNameLength(field, value string) error {
+	if n := utf8.RuneCountInString(value); n > MaxContentNameRunes {
+		return fmt.Errorf("%s too long limit=%d, input=%d", field, MaxContentNameRunes, n)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package bo
+
+import im_v1 "github.com/xinghe903/chatify/api/im/v1"
+
+const (
+	// MaxReceiptMsgIds 单条回执指令最多携带的消息ID数量
+	MaxReceiptMsgIds = 1000
+	// MessageType_CONTROL 下发给客户端的控制消息，取值与 im.v1.MessageType_CONTROL 一致
+	MessageType_CONTROL MessageType = MessageType(im_v1.MessageType_CONTROL)
+)
+
+// GroupMemberRole 群成员角色
+type GroupMemberRole string
+
+const (
+	GroupMemberRoleOwner  GroupMemberRole = "owner"  // 群主
+	GroupMemberRoleAdmin  GroupMemberRole = "admin"  // 管理员
+	GroupMemberRoleMember GroupMemberRole = "member" // 普通成员
+)
+
+// GroupMember 群成员业务对象
+type GroupMember struct {
+	GroupId string          `json:"group_id"`
+	UserId  string          `json:"user_id"`
+	Role    GroupMemberRole `json:"role"`
+}
+
+// IsAdmin 是否为群主或管理员
+func (m *GroupMember) IsAdmin() bool {
+	return m.Role == GroupMemberRoleOwner || m.Role == GroupMemberRoleAdmin
+}
@@ -0,0 +1,63 @@
+package bo
+
+import (
+	im_v1 "github.com/xinghe903/chatify/api/im/v1"
+)
+
+const (
+	// DefaultConversationPageSize 会话列表默认分页大小
+	DefaultConversationPageSize = 20
+	// MaxConversationPageSize 会话列表最大分页大小
+	MaxConversationPageSize = 100
+	// MaxPreviewRunes 消息摘要最大字符数
+	MaxPreviewRunes = 64
+	// RecalledPreview 最后一条消息被撤回后的摘要
+	RecalledPreview = "[消息已撤回]"
+	// BinaryPreview 无法作为文本展示的消息摘要
+	BinaryPreview = "[消息]"
+)
+
+// Conversation 用户会话业务对象，会话ID为该用户视角：单聊为对方用户ID，群聊为群ID
+type Conversation struct {
+	UserId           string           `json:"user_id"`
+	ConversationType im_v1.TargetType `json:"conversation_type"`
+	ConversationId   string           `json:"conversation_id"`
+	LastMsgId        string           `json:"last_msg_id"`
+	LastSenderId     string           `json:"last_sender_id"`
+	LastPreview      string           `json:"last_preview"`
+	LastMessageAt    int64            `json:"last_message_at"`
+	ReadMsgId        string           `json:"read_msg_id"`
+	ReadAt           int64            `json:"read_at"`
+	UnreadCount      int64            `json:"unread_count"`
+	Pinned           bool             `json:"pinned"`
+	Muted            bool             `json:"muted"`
+	MuteUntil        int64            `json:"mute_until"`
+	MentionedAt      int64            `json:"mentioned_at"` // 最近一条@该用户的消息时间（单位: 毫秒）
+}
+
+// IsMentioned 判断是否有未读的@该用户的消息
+func (c *Conversation) IsMentioned() bool {
+	return c.MentionedAt > c.ReadAt
+}
+
+// IsMuted 判断免打扰在now（单位: 秒）时是否生效
+func (c *Conversation) IsMuted(now int64) bool {
+	return c.Muted && (c.MuteUntil == 0 || c.MuteUntil > now)
+}
+
+// MessagePreview 生成消息摘要，按内容类型生成，无法解析的内容使用占位文本
+func MessagePreview(content []byte) string {
+	c, err := ParseContent(content)
+	if err != nil {
+		return BinaryPreview
+	}
+	return ContentPreview(c)
+}
+
+// PeerConversationId 返回userId视角下消息所属会话的ID
+func (m *ChatMessage) PeerConversationId(userId string) string {
+	if m.ConversationType == im_v1.TargetType_USER && m.FromUserId != userId {
+		return m.FromUserId
+	}
+	return m.ConversationId
+}
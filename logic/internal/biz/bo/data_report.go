@@ -1,7 +1,228 @@
 package bo
 
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxReportBatchSize 一条上报消息最多携带的上报数
+	MaxReportBatchSize = 100
+	// MaxReportDataSize 单条上报data的最大长度
+	MaxReportDataSize = 8 * 1024
+	// MaxReportClockSkew 允许客户端时间超前服务端的最大值
+	MaxReportClockSkew = 5 * time.Minute
+	// MaxReportAge 客户端缓存后补报的最长时间
+	MaxReportAge = 7 * 24 * time.Hour
+	// MaxReportSchemaNameLength 上报结构名称最大长度
+	MaxReportSchemaNameLength = 64
+	// MaxReportSchemaFields 上报结构最多声明的字段数
+	MaxReportSchemaFields = 64
+	// DefaultReportPageSize 查询上报的默认分页大小
+	DefaultReportPageSize = 100
+	// MaxReportPageSize 查询上报的最大分页大小
+	MaxReportPageSize = 1000
+	// MaxReportQueryRange 查询上报的最大时间范围
+	MaxReportQueryRange = 31 * 24 * time.Hour
+)
+
+var reportSchemaName = regexp.MustCompile(`^[a-z][a-z0-9_.]*$`)
+
+// ReportFieldType 上报字段类型，与接口枚举值一致
+type ReportFieldType int32
+
+const (
+	ReportFieldString  ReportFieldType = 1
+	ReportFieldNumber  ReportFieldType = 2
+	ReportFieldInteger ReportFieldType = 3
+	ReportFieldBool    ReportFieldType = 4
+	ReportFieldObject  ReportFieldType = 5
+	ReportFieldArray   ReportFieldType = 6
+)
+
+// ReportField 上报结构中的字段
+type ReportField struct {
+	Name     string          `json:"name"`
+	Type     ReportFieldType `json:"type"`
+	Required bool            `json:"required"`
+}
+
+// ReportSchema 数据上报结构，同名结构按版本区分，注册后不可修改
+type ReportSchema struct {
+	Name                  string         `json:"name"`
+	Version               int32          `json:"version"`
+	Fields                []*ReportField `json:"fields"`
+	AllowAdditionalFields bool           `json:"allow_additional_fields"`
+	CreatedAt             time.Time      `json:"created_at"`
+}
+
+// Validate 校验结构定义
+func (s *ReportSchema) Validate() error {
+	if len(s.Name) > MaxReportSchemaNameLength || !reportSchemaName.MatchString(s.Name) {
+		return fmt.Errorf("invalid schema name %q", s.Name)
+	}
+	if len(s.Fields) > MaxReportSchemaFields {
+		return fmt.Errorf("too many fields limit=%d, input=%d", MaxReportSchemaFields, len(s.Fields))
+	}
+	names := make(map[string]struct{}, len(s.Fields))
+	for _, field := range s.Fields {
+		if field == nil || !variableName.MatchString(field.Name) {
+			return fmt.Errorf("invalid field name")
+		}
+		if field.Type < ReportFieldString || field.Type > ReportFieldArray {
+			return fmt.Errorf("invalid type of field %s", field.Name)
+		}
+		if _, ok := names[field.Name]; ok {
+			return fmt.Errorf("duplicate field %s", field.Name)
+		}
+		names[field.Name] = struct{}{}
+	}
+	return nil
+}
+
+// Check 按结构校验上报data，data必须是JSON对象
+func (s *ReportSchema) Check(data json.RawMessage) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var values map[string]any
+	if err := decoder.Decode(&values); err != nil || values == nil {
+		return fmt.Errorf("data must be a json object")
+	}
+	for _, field := range s.Fields {
+		value, ok := values[field.Name]
+		if !ok || value == nil {
+			if field.Required {
+				return fmt.Errorf("missing required field %s", field.Name)
+			}
+			continue
+		}
+		if !field.Type.match(value) {
+			return fmt.Errorf("field %s has wrong type", field.Name)
+		}
+		delete(values, field.Name)
+	}
+	if !s.AllowAdditionalFields {
+		for name := range values {
+			return fmt.Errorf("undeclared field %s", name)
+		}
+	}
+	return nil
+}
+
+func (t ReportFieldType) match(value any) bool {
+	switch v := value.(type) {
+	case string:
+		return t == ReportFieldString
+	case json.Number:
+		if t == ReportFieldNumber {
+			return true
+		}
+		_, err := strconv.ParseInt(v.String(), 10, 64)
+		return t == ReportFieldInteger && err == nil
+	case bool:
+		return t == ReportFieldBool
+	case map[string]any:
+		return t == ReportFieldObject
+	case []any:
+		return t == ReportFieldArray
+	}
+	return false
+}
+
+// DataReport 一条数据上报，version为0时使用结构的最新版本
 type DataReport struct {
-	UserId    string `json:"user_id"`
-	Timestamp int64  `json:"timestamp"`
-	Data      string `json:"data"`
+	ReportId  string          `json:"report_id,omitempty"` // 由logic生成，用于存储去重
+	Schema    string          `json:"schema"`
+	Version   int32           `json:"version"`
+	Timestamp int64           `json:"timestamp"` // 单位: 秒
+	Data      json.RawMessage `json:"data"`
+}
+
+// DataReportBatch 客户端一条上报消息，可以携带多条上报
+// 也兼容只有一条上报时直接把schema、version、timestamp、data写在顶层的格式
+type DataReportBatch struct {
+	BatchId string        `json:"batch_id"`
+	UserId  string        `json:"user_id"`
+	Reports []*DataReport `json:"reports"`
+}
+
+// ParseDataReportBatch 解析上报消息内容
+func ParseDataReportBatch(content []byte) (*DataReportBatch, error) {
+	var raw struct {
+		DataReportBatch
+		DataReport
+	}
+	if err := json.Unmarshal(content, &raw); err != nil {
+		return nil, err
+	}
+	batch := &raw.DataReportBatch
+	if len(batch.Reports) == 0 && raw.Schema != "" {
+		batch.Reports = []*DataReport{&raw.DataReport}
+	}
+	return batch, nil
+}
+
+// Validate 校验上报的公共字段，时间戳为0时使用服务端时间
+func (r *DataReport) Validate(now time.Time) error {
+	if r.Schema == "" {
+		return fmt.Errorf("schema is empty")
+	}
+	if r.Version < 0 {
+		return fmt.Errorf("invalid version %d", r.Version)
+	}
+	if len(r.Data) == 0 || len(r.Data) > MaxReportDataSize {
+		return fmt.Errorf("data length must be between 1 and %d", MaxReportDataSize)
+	}
+	if r.Timestamp == 0 {
+		r.Timestamp = now.Unix()
+	}
+	if r.Timestamp > now.Add(MaxReportClockSkew).Unix() || r.Timestamp < now.Add(-MaxReportAge).Unix() {
+		return fmt.Errorf("timestamp %d out of range", r.Timestamp)
+	}
+	return nil
+}
+
+// StoredDataReport 已存储的数据上报
+type StoredDataReport struct {
+	ReportId   string    `json:"report_id"`
+	UserId     string    `json:"user_id"`
+	Schema     string    `json:"schema"`
+	Version    int32     `json:"version"`
+	Timestamp  int64     `json:"timestamp"`
+	Data       string    `json:"data"`
+	ReceivedAt time.Time `json:"received_at"`
+}
+
+// DataReportQuery 按用户和时间范围查询上报，游标为上一页最后一条的(timestamp, report_id)
+type DataReportQuery struct {
+	UserId         string
+	Schema         string
+	StartTime      int64 // 包含，单位: 秒
+	EndTime        int64 // 不包含，单位: 秒
+	AfterTimestamp int64
+	AfterReportId  string
+	Limit          int
+}
+
+// EncodeReportPageToken 生成查询上报的分页游标
+func EncodeReportPageToken(report *StoredDataReport) string {
+	return strconv.FormatInt(report.Timestamp, 10) + ":" + report.ReportId
+}
+
+// DecodeReportPageToken 解析查询上报的分页游标
+func DecodeReportPageToken(token string) (int64, string, error) {
+	timestamp, reportId, ok := strings.Cut(token, ":")
+	if !ok || reportId == "" {
+		return 0, "", fmt.Errorf("invalid page token")
+	}
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid page token")
+	}
+	return ts, reportId, nil
 }
@@ -0,0 +1,139 @@
+package bo
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/xinghe903/chatify/pkg/event"
+)
+
+const (
+	// SystemContentIdPrefix 系统推送和广播的content_id前缀，只有系统推送参与漏斗统计
+	SystemContentIdPrefix = event.SystemContentIdPrefix
+	// FunnelBucketSize 漏斗计数的存储粒度
+	FunnelBucketSize = time.Hour
+	// FunnelEventDedupPrefix 漏斗事件消费去重标识前缀，与消息ID区分
+	FunnelEventDedupPrefix = "funnel:"
+	// MaxFunnelQueryRange 漏斗查询的最大时间范围
+	MaxFunnelQueryRange = 90 * 24 * time.Hour
+)
+
+// FunnelStage 送达漏斗阶段
+type FunnelStage = event.FunnelStage
+
+const (
+	FunnelStageTargeted        = event.FunnelStageTargeted
+	FunnelStageSent            = event.FunnelStageSent
+	FunnelStageArchivedOffline = event.FunnelStageArchivedOffline
+	FunnelStageDelivered       = event.FunnelStageDelivered
+	FunnelStageRead            = event.FunnelStageRead
+)
+
+// DeliveryEvent 漏斗事件，push按内容汇总计数，回执事件每个用户一条
+type DeliveryEvent = event.Delivery
+
+// DeliveryEventBatch 一条Kafka消息携带的漏斗事件，event_id用于消费去重
+type DeliveryEventBatch = event.DeliveryBatch
+
+// FunnelCount 某个内容在一个时间段内某个阶段的计数
+type FunnelCount struct {
+	ContentId   string      `json:"content_id"`
+	BucketStart int64       `json:"bucket_start"` // 单位: 秒
+	Stage       FunnelStage `json:"stage"`
+	Count       int64       `json:"count"`
+}
+
+// FunnelCounts 各阶段的计数
+type FunnelCounts struct {
+	Targeted        int64 `json:"targeted"`
+	Sent            int64 `json:"sent"`
+	ArchivedOffline int64 `json:"archived_offline"`
+	Delivered       int64 `json:"delivered"`
+	Read            int64 `json:"read"`
+}
+
+// Add 按阶段累加计数，未知阶段忽略
+func (c *FunnelCounts) Add(stage FunnelStage, count int64) {
+	switch stage {
+	case FunnelStageTargeted:
+		c.Targeted += count
+	case FunnelStageSent:
+		c.Sent += count
+	case FunnelStageArchivedOffline:
+		c.ArchivedOffline += count
+	case FunnelStageDelivered:
+		c.Delivered += count
+	case FunnelStageRead:
+		c.Read += count
+	}
+}
+
+// FunnelBucket 一个时间段的漏斗计数
+type FunnelBucket struct {
+	BucketStart int64         `json:"bucket_start"`
+	Counts      *FunnelCounts `json:"counts"`
+}
+
+// IsFunnelStage 判断是否为已知的漏斗阶段
+func IsFunnelStage(stage FunnelStage) bool {
+	switch stage {
+	case FunnelStageTargeted, FunnelStageSent, FunnelStageArchivedOffline, FunnelStageDelivered, FunnelStageRead:
+		return true
+	}
+	return false
+}
+
+// FunnelBucketStart 计算时间所在存储时间段的开始时间
+func FunnelBucketStart(timestamp int64) int64 {
+	return timestamp - timestamp%int64(FunnelBucketSize/time.Second)
+}
+
+// MergeFunnelCounts 把事件按内容、时间段和阶段合并，减少数据库写入次数
+func MergeFunnelCounts(events []*DeliveryEvent) []*FunnelCount {
+	type key struct {
+		contentId   string
+		bucketStart int64
+		stage       FunnelStage
+	}
+	merged := make(map[key]*FunnelCount, len(events))
+	for _, event := range events {
+		k := key{event.ContentId, FunnelBucketStart(event.Timestamp), event.Stage}
+		if count, ok := merged[k]; ok {
+			count.Count += event.Count
+			continue
+		}
+		merged[k] = &FunnelCount{ContentId: k.contentId, BucketStart: k.bucketStart, Stage: k.stage, Count: event.Count}
+	}
+	counts := make([]*FunnelCount, 0, len(merged))
+	for _, count := range merged {
+		counts = append(counts, count)
+	}
+	return counts
+}
+
+// AggregateFunnel 把存储时间段的计数按粒度汇总，返回总计和按时间正序的时间段
+// granularity为存储粒度的整数倍，按UTC对齐
+func AggregateFunnel(counts []*FunnelCount, granularity time.Duration) (*FunnelCounts, []*FunnelBucket) {
+	step := int64(max(granularity, FunnelBucketSize) / time.Second)
+	total := &FunnelCounts{}
+	buckets := make(map[int64]*FunnelBucket)
+	for _, count := range counts {
+		start := count.BucketStart - count.BucketStart%step
+		bucket, ok := buckets[start]
+		if !ok {
+			bucket = &FunnelBucket{BucketStart: start, Counts: &FunnelCounts{}}
+			buckets[start] = bucket
+		}
+		bucket.Counts.Add(count.Stage, count.Count)
+		total.Add(count.Stage, count.Count)
+	}
+	result := make([]*FunnelBucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		result = append(result, bucket)
+	}
+	slices.SortFunc(result, func(a, b *FunnelBucket) int {
+		return cmp.Compare(a.BucketStart, b.BucketStart)
+	})
+	return total, result
+}
@@ -0,0 +1,51 @@
+package bo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	v1 "github.com/xinghe903/chatify/api/logic/v1"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// MaxIdempotencyKeyLength 幂等键最大长度
+	MaxIdempotencyKeyLength = 128
+	// DefaultIdempotencyWindow 未配置时保存首次请求结果的时长
+	DefaultIdempotencyWindow = 24 * time.Hour
+	// DefaultIdempotencyTimeWindow 未配置时签名时间戳允许的偏差
+	DefaultIdempotencyTimeWindow = 5 * time.Minute
+	// DefaultIdempotencyProcessingTTL 未配置时处理中状态的占用时长
+	DefaultIdempotencyProcessingTTL = 5 * time.Minute
+)
+
+// IdempotencyRecord 幂等请求的处理记录，处理中时只有请求摘要，完成后保存结果
+type IdempotencyRecord struct {
+	Fingerprint string `json:"fingerprint"` // 请求内容摘要，相同的幂等键用于不同的请求时拒绝
+	Done        bool   `json:"done"`
+	ScheduleId  string `json:"schedule_id,omitempty"`
+	TaskId      string `json:"task_id,omitempty"`
+	ContentId   string `json:"content_id,omitempty"`
+	SentUsers   int64  `json:"sent_users,omitempty"`
+	FailedUsers int64  `json:"failed_users,omitempty"` // 部分批次失败时仍保存结果，重试不会重发已成功的批次
+}
+
+// SystemPushIdempotencyKey 系统推送的幂等键按发送者隔离，不同发送者可以使用相同的key
+func SystemPushIdempotencyKey(fromUserId, key string) string {
+	return "system_push:" + fromUserId + ":" + key
+}
+
+// SystemPushFingerprint 计算系统推送请求的内容摘要，不包含幂等参数本身
+func SystemPushFingerprint(req *v1.SystemPushRequest) (string, error) {
+	clone := proto.Clone(req).(*v1.SystemPushRequest)
+	clone.Idempotency = nil
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(clone)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal system push request: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -1,6 +1,10 @@
 package bo
 
 import (
+	"fmt"
+	"strconv"
+	"time"
+
 	im_v1 "github.com/xinghe903/chatify/api/im/v1"
 	v1 "github.com/xinghe903/chatify/api/logic/v1"
 )
@@ -9,7 +13,7 @@ const (
 	MaxTargetUsers = 1000
 )
 
-// MessageType 消息类型枚举
+// MessageType 消息类型枚举，TEXT到CUSTOM的取值与 im.v1.ContentType 一致
 type MessageType int32
 
 const (
@@ -32,17 +36,33 @@ const (
 	TargetType_GROUP TargetType = 2 // 群组
 )
 
+// PushResult 立即发送的结果，分批发送时部分批次失败不影响其余批次
+type PushResult struct {
+	TaskId      string // 第一个发送成功的批次的任务ID
+	ContentId   string
+	SentUsers   int64
+	FailedUsers int64
+	LastError   string // 最后一个失败批次的错误
+}
+
 // Message 消息业务对象
 type Message struct {
-	MsgId       string      `protobuf:"bytes,1,opt,name=msg_id,json=msgId,proto3" json:"msg_id,omitempty"`
-	MessageType MessageType `protobuf:"varint,2,opt,name=message_type,json=messageType,proto3,enum=im.v1.MessageType" json:"message_type,omitempty"`
-	FromUserId  string      `protobuf:"bytes,3,opt,name=from_user_id,json=fromUserId,proto3" json:"from_user_id,omitempty"`
-	TargetType  TargetType  `protobuf:"varint,4,opt,name=target_type,json=targetType,proto3,enum=im.v1.TargetType" json:"target_type,omitempty"`
-	ToUserId    string      `protobuf:"bytes,5,opt,name=to_user_id,json=toUserId,proto3" json:"to_user_id,omitempty"`
-	Content     []byte      `protobuf:"bytes,6,opt,name=content,proto3" json:"content,omitempty"`
-	Timestamp   int64       `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	ExpireTime  int64       `protobuf:"varint,10,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
-	ContentId   string      `protobuf:"bytes,11,opt,name=content_id,json=contentId,proto3" json:"content_id,omitempty"`
+	MsgId       string         `protobuf:"bytes,1,opt,name=msg_id,json=msgId,proto3" json:"msg_id,omitempty"`
+	MessageType MessageType    `protobuf:"varint,2,opt,name=message_type,json=messageType,proto3,enum=im.v1.MessageType" json:"message_type,omitempty"`
+	FromUserId  string         `protobuf:"bytes,3,opt,name=from_user_id,json=fromUserId,proto3" json:"from_user_id,omitempty"`
+	TargetType  TargetType     `protobuf:"varint,4,opt,name=target_type,json=targetType,proto3,enum=im.v1.TargetType" json:"target_type,omitempty"`
+	ToUserId    string         `protobuf:"bytes,5,opt,name=to_user_id,json=toUserId,proto3" json:"to_user_id,omitempty"`
+	Content     []byte         `protobuf:"bytes,6,opt,name=content,proto3" json:"content,omitempty"`
+	Timestamp   int64          `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	ExpireTime  int64          `protobuf:"varint,10,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	ContentId   string         `protobuf:"bytes,11,opt,name=content_id,json=contentId,proto3" json:"content_id,omitempty"`
+	Transient   bool           `protobuf:"varint,12,opt,name=transient,proto3" json:"transient,omitempty"`
+	GroupId     string         `protobuf:"bytes,13,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Seq         int64          `protobuf:"varint,14,opt,name=seq,proto3" json:"seq,omitempty"`
+	Priority    im_v1.Priority `protobuf:"varint,15,opt,name=priority,proto3,enum=im.v1.Priority" json:"priority,omitempty"`
+	Mentioned   bool           `protobuf:"varint,16,opt,name=mentioned,proto3" json:"mentioned,omitempty"`
+	Silent      bool           `protobuf:"varint,17,opt,name=silent,proto3" json:"silent,omitempty"`
+	ContentType MessageType    `protobuf:"varint,18,opt,name=content_type,json=contentType,proto3,enum=im.v1.ContentType" json:"content_type,omitempty"`
 }
 
 // NewMessage 根据SystemPushRequest创建Message对象
@@ -63,9 +83,46 @@ func NewMessage(req *v1.SystemPushRequest) *Message {
 		FromUserId:  req.FromUserId,
 		TargetType:  TargetType_USER,
 		Timestamp:   req.Timestamp,
-		ExpireTime:  req.Timestamp,
 		ContentId:   req.ContentId,
+		Priority:    PushPriority(req),
+	}
+}
+
+// PushPriority 系统推送的投递优先级，未指定时公告为低优先级，通知为高优先级
+func PushPriority(req *v1.SystemPushRequest) im_v1.Priority {
+	if req.Priority != im_v1.Priority_PRIORITY_UNSPECIFIED {
+		return req.Priority
+	}
+	switch req.PushType {
+	case v1.PushType_NOTICE:
+		return im_v1.Priority_PRIORITY_LOW
+	case v1.PushType_INFORM:
+		return im_v1.Priority_PRIORITY_HIGH
+	default:
+		return im_v1.Priority_PRIORITY_NORMAL
+	}
+}
+
+// ParseExpireTime 解析推送请求的过期时间，返回秒级时间戳，为空时返回0表示永不过期
+// 支持时长（如 30m、24h，从now开始计算）、RFC3339时间和秒级时间戳，过期时间必须晚于now
+func ParseExpireTime(expireTime string, now time.Time) (int64, error) {
+	if expireTime == "" {
+		return 0, nil
+	}
+	var expireAt int64
+	if d, err := time.ParseDuration(expireTime); err == nil {
+		expireAt = now.Add(d).Unix()
+	} else if t, err := time.Parse(time.RFC3339, expireTime); err == nil {
+		expireAt = t.Unix()
+	} else if ts, err := strconv.ParseInt(expireTime, 10, 64); err == nil {
+		expireAt = ts
+	} else {
+		return 0, fmt.Errorf("invalid expire time %q", expireTime)
+	}
+	if expireAt <= now.Unix() {
+		return 0, fmt.Errorf("expire time %q is not after now", expireTime)
 	}
+	return expireAt, nil
 }
 
 // NewMessagesByUserIDs 根据用户ID列表创建多条消息
@@ -91,8 +148,15 @@ func (m *Message) ToBaseMessage() *im_v1.BaseMessage {
 		Timestamp:   m.Timestamp,
 		ExpireTime:  m.ExpireTime,
 		ContentId:   m.ContentId,
+		Transient:   m.Transient,
+		GroupId:     m.GroupId,
+		Seq:         m.Seq,
+		Priority:    m.Priority,
+		Mentioned:   m.Mentioned,
+		Silent:      m.Silent,
 		MessageType: im_v1.MessageType(m.MessageType),
 		TargetType:  im_v1.TargetType(m.TargetType),
+		ContentType: im_v1.ContentType(m.ContentType),
 	}
 }
 
@@ -0,0 +1,165 @@
+package bo
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	// 运行镜像中没有时区数据，内嵌后勿扰时段的时区才能解析
+	_ "time/tzdata"
+
+	im_v1 "github.com/xinghe903/chatify/api/im/v1"
+	v1 "github.com/xinghe903/chatify/api/logic/v1"
+)
+
+const (
+	// MaxConversationMutes 单次最多更新的会话免打扰数
+	MaxConversationMutes = 100
+)
+
+// SuppressReason 系统推送被过滤的原因
+type SuppressReason string
+
+const (
+	// SuppressReasonOptOut 用户退订了该类型的推送
+	SuppressReasonOptOut SuppressReason = "opt_out"
+)
+
+// DndWindow 勿扰时段，Start晚于End时跨越零点，End不包含
+type DndWindow struct {
+	Enabled  bool   `json:"enabled"`
+	Start    string `json:"start"`    // 开始时间，格式 HH:MM
+	End      string `json:"end"`      // 结束时间，格式 HH:MM
+	Timezone string `json:"timezone"` // IANA时区，为空时使用UTC
+}
+
+// NotificationPreferences 用户通知偏好
+type NotificationPreferences struct {
+	UserId          string        `json:"user_id"`
+	OptOutPushTypes []v1.PushType `json:"opt_out_push_types"`
+	Dnd             DndWindow     `json:"dnd"`
+}
+
+// NotificationPreferencesUpdate 通知偏好更新，为nil的部分保持不变
+type NotificationPreferencesUpdate struct {
+	UserId          string
+	OptOutPushTypes []v1.PushType // 非nil时整体覆盖
+	Dnd             *DndWindow
+	Mutes           []*ConversationMute
+}
+
+// ConversationMute 会话免打扰设置
+type ConversationMute struct {
+	ConversationType im_v1.TargetType `json:"conversation_type"`
+	ConversationId   string           `json:"conversation_id"`
+	Muted            bool             `json:"muted"`
+	MuteUntil        int64            `json:"mute_until"` // 免打扰截止时间（单位: 秒），0表示一直有效
+}
+
+// PushSuppression 被过滤的系统推送记录
+type PushSuppression struct {
+	ContentId string         `json:"content_id"`
+	UserId    string         `json:"user_id"`
+	PushType  v1.PushType    `json:"push_type"`
+	Reason    SuppressReason `json:"reason"`
+}
+
+// OptedOut 判断是否退订了该类型的推送
+func (p *NotificationPreferences) OptedOut(pushType v1.PushType) bool {
+	return p != nil && slices.Contains(p.OptOutPushTypes, pushType)
+}
+
+// InQuietHours 判断now是否处于勿扰时段，时区无效时按UTC计算
+func (p *NotificationPreferences) InQuietHours(now time.Time) bool {
+	if p == nil || !p.Dnd.Enabled {
+		return false
+	}
+	start, err := ParseClock(p.Dnd.Start)
+	if err != nil {
+		return false
+	}
+	end, err := ParseClock(p.Dnd.End)
+	if err != nil || start == end {
+		return false
+	}
+	if loc, err := time.LoadLocation(p.Dnd.Timezone); err == nil {
+		now = now.In(loc)
+	} else {
+		now = now.UTC()
+	}
+	minute := now.Hour()*60 + now.Minute()
+	if start < end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
+
+// Normalize 校验更新内容，去除重复的推送类型
+func (u *NotificationPreferencesUpdate) Normalize() error {
+	if u.UserId == "" {
+		return fmt.Errorf("user id is empty")
+	}
+	if u.OptOutPushTypes != nil {
+		types := make([]v1.PushType, 0, len(u.OptOutPushTypes))
+		for _, pushType := range u.OptOutPushTypes {
+			if _, ok := v1.PushType_name[int32(pushType)]; !ok || pushType == v1.PushType_PUSH_TYPE_UNSPECIFIED {
+				return fmt.Errorf("invalid push type %d", pushType)
+			}
+			if !slices.Contains(types, pushType) {
+				types = append(types, pushType)
+			}
+		}
+		slices.Sort(types)
+		u.OptOutPushTypes = types
+	}
+	// 关闭勿扰时段时可以不填写时间
+	if d := u.Dnd; d != nil && (d.Enabled || d.Start != "" || d.End != "") {
+		start, err := ParseClock(d.Start)
+		if err != nil {
+			return fmt.Errorf("dnd start: %w", err)
+		}
+		end, err := ParseClock(d.End)
+		if err != nil {
+			return fmt.Errorf("dnd end: %w", err)
+		}
+		if d.Enabled && start == end {
+			return fmt.Errorf("dnd start and end must differ")
+		}
+		d.Start, d.End = FormatClock(start), FormatClock(end)
+	}
+	if d := u.Dnd; d != nil {
+		d.Timezone = strings.TrimSpace(d.Timezone)
+		if _, err := time.LoadLocation(d.Timezone); err != nil {
+			return fmt.Errorf("invalid dnd timezone %q", d.Timezone)
+		}
+	}
+	if len(u.Mutes) > MaxConversationMutes {
+		return fmt.Errorf("too many conversation mutes limit=%d, input=%d", MaxConversationMutes, len(u.Mutes))
+	}
+	for _, mute := range u.Mutes {
+		if mute.ConversationType != im_v1.TargetType_USER && mute.ConversationType != im_v1.TargetType_GROUP {
+			return fmt.Errorf("invalid conversation type %d", mute.ConversationType)
+		}
+		if mute.ConversationId == "" {
+			return fmt.Errorf("conversation id is empty")
+		}
+		if mute.MuteUntil < 0 {
+			return fmt.Errorf("invalid mute_until %d", mute.MuteUntil)
+		}
+	}
+	return nil
+}
+
+// ParseClock 解析 HH:MM 格式的时间，返回当天第几分钟
+func ParseClock(clock string) (int, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expect HH:MM", clock)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// FormatClock 把当天第几分钟格式化为 HH:MM
+func FormatClock(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
@@ -0,0 +1,44 @@
+package bo
+
+import (
+	"time"
+
+	"github.com/xinghe903/chatify/pkg/event"
+)
+
+const (
+	// DefaultQuotaWindow 未配置时配额的滑动窗口时长
+	DefaultQuotaWindow = time.Minute
+	// DefaultChatLimit 未配置时窗口内最多发送的聊天消息数
+	DefaultChatLimit = 60
+	// DefaultControlLimit 未配置时窗口内最多发送的控制指令数
+	DefaultControlLimit = 300
+	// DefaultTargetLimit 未配置时窗口内最多发送的不同会话数
+	DefaultTargetLimit = 30
+	// DefaultSpamThreshold 未配置时触发临时禁言的垃圾分
+	DefaultSpamThreshold = 20
+	// DefaultMuteDuration 未配置时临时禁言的时长
+	DefaultMuteDuration = 10 * time.Minute
+	// DefaultLockStrikes 未配置时触发锁定账号的禁言次数
+	DefaultLockStrikes = 3
+	// StrikeWindow 禁言次数的统计周期
+	StrikeWindow = 24 * time.Hour
+	// RepeatSpamWeight 窗口内每重复发送一次相同内容增加的垃圾分
+	RepeatSpamWeight = 2
+	// FanoutSpamWeight 不同会话数超过配额一半后，每发往一个新会话增加的垃圾分
+	FanoutSpamWeight = 1
+)
+
+// Quota 发送配额与反垃圾参数
+type Quota struct {
+	Window        time.Duration
+	ChatLimit     int
+	ControlLimit  int
+	TargetLimit   int
+	SpamThreshold int64
+	MuteDuration  time.Duration
+	LockStrikes   int64
+}
+
+// UserAbuseEvent 用户滥用事件，由auth服务消费并锁定账号
+type UserAbuseEvent = event.UserAbuse
@@ -0,0 +1,20 @@
+package bo
+
+import im_v1 "github.com/xinghe903/chatify/api/im/v1"
+
+// ReadCursor 用户在会话中的已读位置
+// 会话ID始终为该用户视角：单聊为对方用户ID，群聊为群ID
+type ReadCursor struct {
+	UserId           string           `json:"user_id"`
+	ConversationType im_v1.TargetType `json:"conversation_type"`
+	ConversationId   string           `json:"conversation_id"`
+	MsgId            string           `json:"msg_id"`
+	ReadAt           int64            `json:"read_at"` // 已读消息的服务端接收时间（单位: 毫秒），用于比较先后
+}
+
+// UnreadCount 会话未读数
+type UnreadCount struct {
+	ConversationType im_v1.TargetType `json:"conversation_type"`
+	ConversationId   string           `json:"conversation_id"`
+	Unread           int64            `json:"unread"`
+}
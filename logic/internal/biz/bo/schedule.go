@@ -0,0 +1,114 @@
+package bo
+
+import (
+	"fmt"
+	"time"
+
+	v1 "github.com/xinghe903/chatify/api/logic/v1"
+)
+
+const (
+	// ScheduleScanInterval 调度器扫描到期计划的间隔
+	ScheduleScanInterval = 5 * time.Second
+	// ScheduleScanBatch 每次扫描的最大计划数
+	ScheduleScanBatch = 100
+	// DefaultSchedulePageSize 计划列表默认分页大小
+	DefaultSchedulePageSize = 20
+	// MaxSchedulePageSize 计划列表最大分页大小
+	MaxSchedulePageSize = 100
+	// ScheduleFireLease 待发送周期的租期，超过租期仍未记录结果视为发送中断（如实例崩溃），由leader重新发送
+	ScheduleFireLease = 5 * time.Minute
+)
+
+// PushScheduleStatus 定时推送计划状态
+type PushScheduleStatus string
+
+const (
+	PushScheduleStatusPending  PushScheduleStatus = "pending"  // 待执行
+	PushScheduleStatusFinished PushScheduleStatus = "finished" // 已执行完毕
+	PushScheduleStatusCanceled PushScheduleStatus = "canceled" // 已取消
+)
+
+// PushSchedule 定时推送计划业务对象
+type PushSchedule struct {
+	ScheduleId  string                `json:"schedule_id"`
+	Status      PushScheduleStatus    `json:"status"`
+	Request     *v1.SystemPushRequest `json:"request"`
+	NextFireAt  int64                 `json:"next_fire_at"`
+	FiredCount  int32                 `json:"fired_count"`
+	LastFiredAt int64                 `json:"last_fired_at"`
+	LastTaskId  string                `json:"last_task_id"`
+	LastError   string                `json:"last_error"`
+	CreatedAt   time.Time             `json:"created_at"`
+}
+
+// PushScheduleFireStatus 计划单个周期的发送状态
+type PushScheduleFireStatus string
+
+const (
+	PushScheduleFireStatusPending PushScheduleFireStatus = "pending" // 已抢占，待发送或发送中
+	PushScheduleFireStatusSent    PushScheduleFireStatus = "sent"    // 已发送，可能部分批次失败
+	PushScheduleFireStatusFailed  PushScheduleFireStatus = "failed"  // 发送失败
+)
+
+// PushScheduleFire 计划的一个发送周期，抢占计划时与执行进度一起写入，发送后记录结果
+type PushScheduleFire struct {
+	ScheduleId string                 `json:"schedule_id"`
+	FireAt     int64                  `json:"fire_at"` // 本周期的计划发送时间
+	Status     PushScheduleFireStatus `json:"status"`
+	TaskId     string                 `json:"task_id"`
+	LastError  string                 `json:"last_error"`
+}
+
+// PushTaskId 由计划ID和周期时间确定的push任务ID，同一周期重新发送时任务ID不变
+func (f *PushScheduleFire) PushTaskId() string {
+	return fmt.Sprintf("task%s-%d", f.ScheduleId, f.FireAt)
+}
+
+// NewPushSchedule 根据系统推送请求创建计划，首次执行时间不早于当前时间
+func NewPushSchedule(req *v1.SystemPushRequest, now int64) *PushSchedule {
+	return &PushSchedule{
+		Status:     PushScheduleStatusPending,
+		Request:    req,
+		NextFireAt: max(req.SendAt, now),
+	}
+}
+
+// IsScheduled 判断推送请求是否需要进入定时计划
+func IsScheduled(req *v1.SystemPushRequest, now int64) bool {
+	return req.Recurrence != nil || req.SendAt > now
+}
+
+// Advance 在一次发送后计算下一次发送时间
+// 调度器停机期间错过的周期不会补发，直接跳到当前时间之后的下一个周期
+// 返回false表示计划已结束
+func (s *PushSchedule) Advance(now int64) bool {
+	r := s.Request.Recurrence
+	if r == nil || r.Frequency == v1.RecurrenceFrequency_RECURRENCE_FREQUENCY_UNSPECIFIED {
+		return false
+	}
+	if r.Count > 0 && s.FiredCount >= r.Count {
+		return false
+	}
+	interval := max(int(r.Interval), 1)
+	next := time.Unix(s.NextFireAt, 0)
+	for next.Unix() <= now {
+		switch r.Frequency {
+		case v1.RecurrenceFrequency_HOURLY:
+			next = next.Add(time.Duration(interval) * time.Hour)
+		case v1.RecurrenceFrequency_DAILY:
+			next = next.AddDate(0, 0, interval)
+		case v1.RecurrenceFrequency_WEEKLY:
+			next = next.AddDate(0, 0, 7*interval)
+		case v1.RecurrenceFrequency_MONTHLY:
+			next = next.AddDate(0, interval, 0)
+		default:
+			return false
+		}
+	}
+	if r.Until > 0 && next.Unix() > r.Until {
+		return false
+	}
+	s.NextFireAt = next.Unix()
+	return true
+}
@@ -0,0 +1,384 @@
+package bo
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxSegmentLength 分群表达式最大长度
+	MaxSegmentLength = 4096
+	// MaxSegmentPredicates 分群表达式最多包含的条件数
+	MaxSegmentPredicates = 64
+	// MaxSegmentDepth 分群表达式括号最大嵌套层数
+	MaxSegmentDepth = 8
+	// MaxSegmentUsers 单次分群推送的用户数上限，超过时应使用广播任务
+	MaxSegmentUsers = 10000
+	// SegmentPreviewSampleSize 预览分群时返回的用户数
+	SegmentPreviewSampleSize = 10
+	// MaxUserTags 单个用户最多拥有的标签数
+	MaxUserTags = 100
+	// MaxTagLength 标签最大长度
+	MaxTagLength = 64
+	// MaxPlatformLength 平台名称最大长度
+	MaxPlatformLength = 32
+)
+
+var (
+	userTag      = regexp.MustCompile(`^[a-z0-9][a-z0-9_.:-]*$`)
+	platformName = regexp.MustCompile(`^[a-z0-9_]+$`)
+)
+
+// UserAttributes 用户属性，用于分群
+type UserAttributes struct {
+	UserId       string   `json:"user_id"`
+	Platform     string   `json:"platform"`
+	Locale       string   `json:"locale"`
+	RegisteredAt int64    `json:"registered_at"` // 单位: 秒
+	Tags         []string `json:"tags"`
+}
+
+// UserAttributesUpdate 用户属性更新，为空的字段保持不变
+type UserAttributesUpdate struct {
+	UserId       string
+	Platform     string
+	Locale       string
+	RegisteredAt int64
+	AddTags      []string
+	RemoveTags   []string
+}
+
+// Normalize 校验并规范化更新内容，同一标签同时新增和删除时以删除为准
+func (u *UserAttributesUpdate) Normalize() error {
+	if u.UserId == "" {
+		return fmt.Errorf("user id is empty")
+	}
+	var err error
+	if u.Platform != "" {
+		if u.Platform, err = NormalizePlatform(u.Platform); err != nil {
+			return err
+		}
+	}
+	if u.Locale != "" {
+		if u.Locale, err = NormalizeLocale(u.Locale); err != nil {
+			return err
+		}
+	}
+	if u.RegisteredAt < 0 {
+		return fmt.Errorf("invalid registered_at %d", u.RegisteredAt)
+	}
+	if u.RemoveTags, err = normalizeTags(u.RemoveTags); err != nil {
+		return err
+	}
+	if u.AddTags, err = normalizeTags(u.AddTags); err != nil {
+		return err
+	}
+	u.AddTags = slices.DeleteFunc(u.AddTags, func(tag string) bool {
+		return slices.Contains(u.RemoveTags, tag)
+	})
+	return nil
+}
+
+// NormalizeTag 校验标签并转为小写
+func NormalizeTag(tag string) (string, error) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if len(tag) > MaxTagLength || !userTag.MatchString(tag) {
+		return "", fmt.Errorf("invalid tag %q", tag)
+	}
+	return tag, nil
+}
+
+// NormalizePlatform 校验平台名称并转为小写
+func NormalizePlatform(platform string) (string, error) {
+	platform = strings.ToLower(strings.TrimSpace(platform))
+	if len(platform) > MaxPlatformLength || !platformName.MatchString(platform) {
+		return "", fmt.Errorf("invalid platform %q", platform)
+	}
+	return platform, nil
+}
+
+func normalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		t, err := NormalizeTag(tag)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(normalized, t) {
+			normalized = append(normalized, t)
+		}
+	}
+	return normalized, nil
+}
+
+// SegmentField 分群可用的用户属性
+type SegmentField string
+
+const (
+	SegmentFieldPlatform     SegmentField = "platform"
+	SegmentFieldLocale       SegmentField = "locale"
+	SegmentFieldTag          SegmentField = "tag"
+	SegmentFieldRegisteredAt SegmentField = "registered_at"
+)
+
+// SegmentOp 分群条件运算符
+type SegmentOp string
+
+const (
+	SegmentOpEq SegmentOp = "="
+	SegmentOpNe SegmentOp = "!="
+	SegmentOpLt SegmentOp = "<"
+	SegmentOpLe SegmentOp = "<="
+	SegmentOpGt SegmentOp = ">"
+	SegmentOpGe SegmentOp = ">="
+)
+
+// SegmentLogic 分群条件组合方式
+type SegmentLogic string
+
+const (
+	SegmentAnd SegmentLogic = "and"
+	SegmentOr  SegmentLogic = "or"
+)
+
+// Segment 分群表达式节点，Predicate不为空时为属性条件，否则为Children的AND或OR组合
+type Segment struct {
+	Logic     SegmentLogic
+	Children  []*Segment
+	Predicate *SegmentPredicate
+}
+
+// SegmentPredicate 分群属性条件
+type SegmentPredicate struct {
+	Field SegmentField
+	Op    SegmentOp
+	Value string // platform、locale、tag的规范化取值
+	Time  int64  // registered_at的秒级时间戳
+}
+
+// ParseSegment 解析分群表达式
+func ParseSegment(expr string) (*Segment, error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, fmt.Errorf("segment is empty")
+	}
+	if len(expr) > MaxSegmentLength {
+		return nil, fmt.Errorf("segment is too long limit=%d, input=%d", MaxSegmentLength, len(expr))
+	}
+	tokens, err := tokenizeSegment(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &segmentParser{tokens: tokens}
+	segment, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+	return segment, nil
+}
+
+type segmentTokenKind int
+
+const (
+	segmentWord segmentTokenKind = iota
+	segmentString
+	segmentOperator
+	segmentLParen
+	segmentRParen
+)
+
+type segmentToken struct {
+	kind segmentTokenKind
+	text string
+}
+
+// tokenizeSegment 把表达式拆分为单词、带引号的字符串、运算符和括号
+func tokenizeSegment(expr string) ([]segmentToken, error) {
+	var tokens []segmentToken
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, segmentToken{kind: segmentLParen, text: "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, segmentToken{kind: segmentRParen, text: ")"})
+			i++
+		case c == '"':
+			end := i + 1
+			for end < len(expr) && expr[end] != '"' {
+				if expr[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(expr) {
+				return nil, fmt.Errorf("unterminated string at %d", i)
+			}
+			text, err := strconv.Unquote(expr[i : end+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at %d", i)
+			}
+			tokens = append(tokens, segmentToken{kind: segmentString, text: text})
+			i = end + 1
+		case c == '=' || c == '!' || c == '<' || c == '>':
+			op := string(c)
+			if i+1 < len(expr) && expr[i+1] == '=' {
+				op += "="
+			}
+			if op == "!" || op == "==" {
+				return nil, fmt.Errorf("invalid operator at %d", i)
+			}
+			tokens = append(tokens, segmentToken{kind: segmentOperator, text: op})
+			i += len(op)
+		default:
+			end := i
+			for end < len(expr) && isSegmentWordChar(expr[end]) {
+				end++
+			}
+			if end == i {
+				return nil, fmt.Errorf("unexpected character %q at %d", c, i)
+			}
+			tokens = append(tokens, segmentToken{kind: segmentWord, text: expr[i:end]})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+func isSegmentWordChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '_' || c == '.' || c == ':' || c == '-'
+}
+
+// segmentParser 按 or := and (OR and)*，and := primary (AND primary)*，primary := ( or ) | predicate 解析
+type segmentParser struct {
+	tokens     []segmentToken
+	pos        int
+	predicates int
+}
+
+func (p *segmentParser) peekKeyword(keyword string) bool {
+	return p.pos < len(p.tokens) && p.tokens[p.pos].kind == segmentWord && strings.EqualFold(p.tokens[p.pos].text, keyword)
+}
+
+func (p *segmentParser) parseOr(depth int) (*Segment, error) {
+	return p.parseLogic(depth, SegmentOr, p.parseAnd)
+}
+
+func (p *segmentParser) parseAnd(depth int) (*Segment, error) {
+	return p.parseLogic(depth, SegmentAnd, p.parsePrimary)
+}
+
+func (p *segmentParser) parseLogic(depth int, logic SegmentLogic, next func(int) (*Segment, error)) (*Segment, error) {
+	first, err := next(depth)
+	if err != nil {
+		return nil, err
+	}
+	children := []*Segment{first}
+	for p.peekKeyword(string(logic)) {
+		p.pos++
+		child, err := next(depth)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+	}
+	if len(children) == 1 {
+		return first, nil
+	}
+	return &Segment{Logic: logic, Children: children}, nil
+}
+
+func (p *segmentParser) parsePrimary(depth int) (*Segment, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("unexpected end of segment")
+	}
+	if p.tokens[p.pos].kind == segmentLParen {
+		if depth >= MaxSegmentDepth {
+			return nil, fmt.Errorf("segment is nested too deep limit=%d", MaxSegmentDepth)
+		}
+		p.pos++
+		segment, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != segmentRParen {
+			return nil, fmt.Errorf("missing )")
+		}
+		p.pos++
+		return segment, nil
+	}
+	if p.pos+3 > len(p.tokens) {
+		return nil, fmt.Errorf("incomplete condition")
+	}
+	field, op, value := p.tokens[p.pos], p.tokens[p.pos+1], p.tokens[p.pos+2]
+	if field.kind != segmentWord {
+		return nil, fmt.Errorf("unexpected %q", field.text)
+	}
+	if op.kind != segmentOperator {
+		return nil, fmt.Errorf("missing operator after %s", field.text)
+	}
+	if value.kind != segmentWord && value.kind != segmentString {
+		return nil, fmt.Errorf("missing value after %s %s", field.text, op.text)
+	}
+	p.pos += 3
+	if p.predicates++; p.predicates > MaxSegmentPredicates {
+		return nil, fmt.Errorf("too many conditions limit=%d", MaxSegmentPredicates)
+	}
+	predicate, err := newSegmentPredicate(SegmentField(strings.ToLower(field.text)), SegmentOp(op.text), value.text)
+	if err != nil {
+		return nil, err
+	}
+	return &Segment{Predicate: predicate}, nil
+}
+
+// newSegmentPredicate 校验属性和运算符，并按属性规范化取值
+func newSegmentPredicate(field SegmentField, op SegmentOp, value string) (*SegmentPredicate, error) {
+	predicate := &SegmentPredicate{Field: field, Op: op}
+	var err error
+	switch field {
+	case SegmentFieldPlatform:
+		predicate.Value, err = NormalizePlatform(value)
+	case SegmentFieldLocale:
+		predicate.Value, err = NormalizeLocale(value)
+		if err == nil && predicate.Value == "" {
+			err = fmt.Errorf("locale is empty")
+		}
+	case SegmentFieldTag:
+		predicate.Value, err = NormalizeTag(value)
+	case SegmentFieldRegisteredAt:
+		predicate.Time, err = parseSegmentTime(value)
+	default:
+		return nil, fmt.Errorf("unknown field %q", field)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if field != SegmentFieldRegisteredAt && op != SegmentOpEq && op != SegmentOpNe {
+		return nil, fmt.Errorf("operator %s is not supported by %s", op, field)
+	}
+	return predicate, nil
+}
+
+// parseSegmentTime 解析日期（按UTC零点）、RFC3339时间或秒级时间戳
+func parseSegmentTime(value string) (int64, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t.Unix(), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.Unix(), nil
+	}
+	if ts, err := strconv.ParseInt(value, 10, 64); err == nil && ts >= 0 {
+		return ts, nil
+	}
+	return 0, fmt.Errorf("invalid time %q", value)
+}
@@ -0,0 +1,168 @@
+package bo
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxTemplateNameLength 模板名称最大长度
+	MaxTemplateNameLength = 128
+	// MaxTemplateVariables 模板最多声明的变量数
+	MaxTemplateVariables = 32
+	// MaxTemplateContentLength 单个语言内容的最大长度
+	MaxTemplateContentLength = 16 * 1024
+	// MaxLocaleLength 语言标签最大长度
+	MaxLocaleLength = 35
+	// DefaultTemplatePageSize 模板列表默认分页大小
+	DefaultTemplatePageSize = 20
+	// MaxTemplatePageSize 模板列表最大分页大小
+	MaxTemplatePageSize = 100
+)
+
+var (
+	// templatePlaceholder 模板变量占位符 {{name}}，允许名称两侧有空格
+	templatePlaceholder = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+	variableName        = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	localeTag           = regexp.MustCompile(`^[A-Za-z]{2,8}(-[A-Za-z0-9]{1,8})*$`)
+)
+
+// PushTemplate 推送模板业务对象
+type PushTemplate struct {
+	TemplateId    string            `json:"template_id"`
+	Name          string            `json:"name"`
+	Variables     []string          `json:"variables"`
+	DefaultLocale string            `json:"default_locale"`
+	Contents      map[string]string `json:"contents"` // key为规范化的语言标签
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+}
+
+// NormalizeLocale 规范化语言标签，语言小写，地区等子标签大写，如 zh-cn 转为 zh-CN
+// 下划线按连字符处理，空字符串原样返回
+func NormalizeLocale(locale string) (string, error) {
+	locale = strings.ReplaceAll(strings.TrimSpace(locale), "_", "-")
+	if locale == "" {
+		return "", nil
+	}
+	if len(locale) > MaxLocaleLength || !localeTag.MatchString(locale) {
+		return "", fmt.Errorf("invalid locale %q", locale)
+	}
+	parts := strings.Split(locale, "-")
+	parts[0] = strings.ToLower(parts[0])
+	for i := 1; i < len(parts); i++ {
+		parts[i] = strings.ToUpper(parts[i])
+	}
+	return strings.Join(parts, "-"), nil
+}
+
+// Validate 校验并规范化模板，内容中引用的变量必须已声明
+func (t *PushTemplate) Validate() error {
+	t.Name = strings.TrimSpace(t.Name)
+	if t.Name == "" || len(t.Name) > MaxTemplateNameLength {
+		return fmt.Errorf("name length must be between 1 and %d", MaxTemplateNameLength)
+	}
+	if len(t.Variables) > MaxTemplateVariables {
+		return fmt.Errorf("too many variables limit=%d, input=%d", MaxTemplateVariables, len(t.Variables))
+	}
+	for _, name := range t.Variables {
+		if !variableName.MatchString(name) {
+			return fmt.Errorf("invalid variable name %q", name)
+		}
+	}
+	slices.Sort(t.Variables)
+	t.Variables = slices.Compact(t.Variables)
+	if len(t.Contents) == 0 {
+		return fmt.Errorf("contents is empty")
+	}
+	contents := make(map[string]string, len(t.Contents))
+	for locale, content := range t.Contents {
+		normalized, err := NormalizeLocale(locale)
+		if err != nil {
+			return err
+		}
+		if normalized == "" {
+			return fmt.Errorf("content locale is empty")
+		}
+		if _, ok := contents[normalized]; ok {
+			return fmt.Errorf("duplicate locale %s", normalized)
+		}
+		if content == "" || len(content) > MaxTemplateContentLength {
+			return fmt.Errorf("content length of %s must be between 1 and %d", normalized, MaxTemplateContentLength)
+		}
+		for _, name := range placeholders(content) {
+			if !slices.Contains(t.Variables, name) {
+				return fmt.Errorf("content of %s references undeclared variable %s", normalized, name)
+			}
+		}
+		// 去掉合法占位符后仍有 {{ 说明占位符格式错误
+		if strings.Contains(templatePlaceholder.ReplaceAllString(content, ""), "{{") {
+			return fmt.Errorf("content of %s has malformed placeholder", normalized)
+		}
+		contents[normalized] = content
+	}
+	t.Contents = contents
+	defaultLocale, err := NormalizeLocale(t.DefaultLocale)
+	if err != nil {
+		return err
+	}
+	if _, ok := t.Contents[defaultLocale]; !ok {
+		return fmt.Errorf("default locale %q has no content", t.DefaultLocale)
+	}
+	t.DefaultLocale = defaultLocale
+	return nil
+}
+
+// MissingVariables 返回未提供值的声明变量
+func (t *PushTemplate) MissingVariables(global, user map[string]string) []string {
+	var missing []string
+	for _, name := range t.Variables {
+		if _, ok := user[name]; ok {
+			continue
+		}
+		if _, ok := global[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// Content 选择语言版本，依次匹配完整标签、逐级去掉子标签后的标签，最后使用默认语言
+func (t *PushTemplate) Content(locale string) string {
+	for locale != "" {
+		if content, ok := t.Contents[locale]; ok {
+			return content
+		}
+		index := strings.LastIndex(locale, "-")
+		if index < 0 {
+			break
+		}
+		locale = locale[:index]
+	}
+	return t.Contents[t.DefaultLocale]
+}
+
+// Render 按接收者的语言渲染模板，用户变量覆盖同名的共用变量
+func (t *PushTemplate) Render(locale string, global, user map[string]string) []byte {
+	content := templatePlaceholder.ReplaceAllStringFunc(t.Content(locale), func(placeholder string) string {
+		name := templatePlaceholder.FindStringSubmatch(placeholder)[1]
+		if value, ok := user[name]; ok {
+			return value
+		}
+		return global[name]
+	})
+	return []byte(content)
+}
+
+// placeholders 返回内容中引用的变量名
+func placeholders(content string) []string {
+	matches := templatePlaceholder.FindAllStringSubmatch(content, -1)
+	names := make([]string, 0, len(matches))
+	for _, match := range matches {
+		names = append(names, match[1])
+	}
+	return names
+}
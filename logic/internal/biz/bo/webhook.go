@@ -0,0 +1,138 @@
+package bo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// WebhookEventType webhook事件类型
+type WebhookEventType string
+
+// 群成员由群组服务写入，logic无法感知成员变更，不提供群成员变更事件
+const (
+	WebhookEventBotMessage  WebhookEventType = "bot.message"  // 发给机器人的聊天消息
+	WebhookEventUserOnline  WebhookEventType = "user.online"  // 用户上线
+	WebhookEventUserOffline WebhookEventType = "user.offline" // 用户下线
+)
+
+const (
+	// MaxWebhookURLLength 订阅地址最大长度
+	MaxWebhookURLLength = 512
+	// DefaultWebhookTimeout 单次投递的默认超时
+	DefaultWebhookTimeout = 5 * time.Second
+	// DefaultWebhookRefreshInterval 订阅缓存的默认刷新间隔
+	DefaultWebhookRefreshInterval = 30 * time.Second
+	// MaxWebhookErrorBodyLength 投递失败时记录的响应体最大长度
+	MaxWebhookErrorBodyLength = 256
+	// WebhookDeliveryDedupPrefix webhook投递消费去重标识前缀，与消息ID区分
+	WebhookDeliveryDedupPrefix = "webhook:"
+	// UserStateDedupPrefix 用户上下线事件消费去重标识前缀，同一连接的上线和下线各处理一次
+	UserStateDedupPrefix = "user_state:"
+)
+
+// webhook投递请求头
+const (
+	WebhookHeaderEvent     = "X-Chatify-Event"
+	WebhookHeaderDelivery  = "X-Chatify-Delivery"
+	WebhookHeaderTimestamp = "X-Chatify-Timestamp" // 单位: 毫秒
+	WebhookHeaderSignature = "X-Chatify-Signature"
+)
+
+// IsWebhookEventType 判断是否为已知的事件类型
+func IsWebhookEventType(eventType WebhookEventType) bool {
+	switch eventType {
+	case WebhookEventBotMessage, WebhookEventUserOnline, WebhookEventUserOffline:
+		return true
+	}
+	return false
+}
+
+// WebhookSubscription webhook订阅
+type WebhookSubscription struct {
+	WebhookId  string             `json:"webhook_id"`
+	URL        string             `json:"url"`
+	EventTypes []WebhookEventType `json:"event_types"`
+	BotId      string             `json:"bot_id"` // 不为空时只接收发给该机器人的消息
+	Secret     string             `json:"-"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
+
+// Validate 校验订阅
+func (s *WebhookSubscription) Validate() error {
+	if len(s.URL) > MaxWebhookURLLength {
+		return fmt.Errorf("url is too long")
+	}
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid url %q", s.URL)
+	}
+	if len(s.EventTypes) == 0 {
+		return fmt.Errorf("event types is empty")
+	}
+	for _, eventType := range s.EventTypes {
+		if !IsWebhookEventType(eventType) {
+			return fmt.Errorf("unknown event type %q", eventType)
+		}
+	}
+	slices.Sort(s.EventTypes)
+	s.EventTypes = slices.Compact(s.EventTypes)
+	if s.BotId != "" && !IsBot(s.BotId) {
+		return fmt.Errorf("invalid bot id %q", s.BotId)
+	}
+	return nil
+}
+
+// Match 判断订阅是否接收该事件
+func (s *WebhookSubscription) Match(event *WebhookEvent) bool {
+	if !slices.Contains(s.EventTypes, event.EventType) {
+		return false
+	}
+	return s.BotId == "" || event.EventType != WebhookEventBotMessage || s.BotId == event.BotId
+}
+
+// WebhookEvent 投递给订阅方的事件，Data随事件类型不同
+type WebhookEvent struct {
+	EventId   string           `json:"event_id"`
+	EventType WebhookEventType `json:"event_type"`
+	Timestamp int64            `json:"timestamp"` // 单位: 毫秒
+	BotId     string           `json:"bot_id,omitempty"`
+	Data      any              `json:"data"`
+}
+
+// WebhookBotMessage bot.message 事件数据
+type WebhookBotMessage struct {
+	MsgId          string `json:"msg_id"`
+	FromUserId     string `json:"from_user_id"`
+	TargetType     string `json:"target_type"`     // USER 或 GROUP
+	ConversationId string `json:"conversation_id"` // 单聊为发送者ID，群聊为群ID，机器人回复时作为to_id
+	Seq            int64  `json:"seq"`
+	Content        string `json:"content"`   // protojson编码的 im.v1.MessageContent
+	Preview        string `json:"preview"`   // 消息摘要，用于通知展示
+	Timestamp      int64  `json:"timestamp"` // 单位: 秒
+}
+
+// WebhookUserState user.online/user.offline 事件数据
+type WebhookUserState struct {
+	UserId         string `json:"user_id"`
+	ConnectionId   string `json:"connection_id"`
+	ConnectionTime int64  `json:"connection_time"`
+}
+
+// WebhookDelivery 一个订阅的一次投递，通过Kafka重试主题实现退避重试
+type WebhookDelivery struct {
+	DeliveryId string          `json:"delivery_id"`
+	WebhookId  string          `json:"webhook_id"`
+	Event      json.RawMessage `json:"event"`
+}
+
+// UserStateMessage access发布的用户上下线消息
+type UserStateMessage struct {
+	Id             string `json:"id"`
+	UserID         string `json:"user_id"`
+	State          string `json:"state"` // online/offline
+	ConnectionTime int64  `json:"connection_time"`
+	ConnectionId   string `json:"connection_id"`
+}
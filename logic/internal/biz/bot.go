@@ -0,0 +1,118 @@
+package biz
+
+import (
+	"context"
+	"strings"
+
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+
+	im_v1 "github.com/xinghe903/chatify/api/im/v1"
+	v1 "github.com/xinghe903/chatify/api/logic/v1"
+
+	"github.com/xinghe903/chatify/pkg/auth"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// BotRepo 机器人账号仓库接口
+type BotRepo interface {
+	CreateBot(ctx context.Context, bot *bo.Bot) error
+	// GetBotByToken 按token摘要查询机器人，不存在时返回nil
+	GetBotByToken(ctx context.Context, tokenHash string) (*bo.Bot, error)
+	// DeleteBot 删除机器人，返回机器人是否存在
+	DeleteBot(ctx context.Context, botId string) (bool, error)
+}
+
+// Bots 机器人账号管理和机器人发送消息
+type Bots struct {
+	log       *log.Helper
+	repo      BotRepo
+	handler   *UserMessageHandler
+	sonyFlake *auth.Sonyflake
+}
+
+// NewBots 创建机器人账号管理
+func NewBots(logger log.Logger, repo BotRepo, handler *UserMessageHandler) *Bots {
+	return &Bots{
+		log:       log.NewHelper(logger),
+		repo:      repo,
+		handler:   handler,
+		sonyFlake: auth.NewSonyflake(),
+	}
+}
+
+// CreateBot 创建机器人账号，返回只展示一次的token
+func (b *Bots) CreateBot(ctx context.Context, name, ownerId string) (*bo.Bot, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > bo.MaxBotNameLength {
+		return nil, "", v1.ErrorInvalidParameter("name length must be between 1 and %d", bo.MaxBotNameLength)
+	}
+	if ownerId == "" {
+		return nil, "", v1.ErrorInvalidParameter("owner id is empty")
+	}
+	botId, err := b.sonyFlake.GenerateBase62()
+	if err != nil {
+		b.log.WithContext(ctx).Errorf("failed to generate bot id: %v", err)
+		return nil, "", v1.ErrorInternalError("failed to generate bot id: %v", err)
+	}
+	token, err := bo.GenerateSecret()
+	if err != nil {
+		b.log.WithContext(ctx).Errorf("failed to generate bot token: %v", err)
+		return nil, "", v1.ErrorInternalError("failed to generate bot token")
+	}
+	bot := &bo.Bot{
+		BotId:     bo.BotIdPrefix + botId,
+		Name:      name,
+		OwnerId:   ownerId,
+		TokenHash: bo.HashBotToken(token),
+	}
+	if err = b.repo.CreateBot(ctx, bot); err != nil {
+		b.log.WithContext(ctx).Errorf("failed to create bot: %v", err)
+		return nil, "", v1.ErrorInternalError("failed to create bot")
+	}
+	b.log.WithContext(ctx).Infof("Created bot. BotID: %s, Owner: %s", bot.BotId, ownerId)
+	return bot, token, nil
+}
+
+// DeleteBot 删除机器人账号
+func (b *Bots) DeleteBot(ctx context.Context, botId string) error {
+	if botId == "" {
+		return v1.ErrorInvalidParameter("bot id is empty")
+	}
+	ok, err := b.repo.DeleteBot(ctx, botId)
+	if err != nil {
+		b.log.WithContext(ctx).Errorf("failed to delete bot. BotID: %s, error: %v", botId, err)
+		return v1.ErrorInternalError("failed to delete bot")
+	}
+	if !ok {
+		return v1.ErrorBotNotFound("bot not found. bot_id=%s", botId)
+	}
+	b.log.WithContext(ctx).Infof("Deleted bot. BotID: %s", botId)
+	return nil
+}
+
+// SendMessage 校验机器人token后以机器人身份发送聊天消息
+func (b *Bots) SendMessage(ctx context.Context, authorization string, baseMsg *im_v1.BaseMessage) (*bo.ChatMessage, error) {
+	token, ok := strings.CutPrefix(authorization, bo.BotAuthScheme)
+	if !ok || token == "" {
+		return nil, v1.ErrorUnauthenticated("bot token not found in authorization header")
+	}
+	bot, err := b.repo.GetBotByToken(ctx, bo.HashBotToken(token))
+	if err != nil {
+		b.log.WithContext(ctx).Errorf("failed to get bot by token: %v", err)
+		return nil, v1.ErrorInternalError("failed to authenticate bot")
+	}
+	if bot == nil {
+		return nil, v1.ErrorUnauthenticated("invalid bot token")
+	}
+	if baseMsg.MsgId == "" {
+		msgId, err := b.sonyFlake.GenerateBase62()
+		if err != nil {
+			b.log.WithContext(ctx).Errorf("failed to generate msg id: %v", err)
+			return nil, v1.ErrorInternalError("failed to generate msg id")
+		}
+		baseMsg.MsgId = msgId
+	}
+	baseMsg.FromUserId = bot.BotId
+	return b.handler.SendChatMessage(auth.NewContext(ctx, bot.BotId, bot.Name), baseMsg)
+}
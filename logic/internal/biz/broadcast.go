@@ -0,0 +1,362 @@
+package biz
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+
+	v1 "github.com/xinghe903/chatify/api/logic/v1"
+
+	"github.com/xinghe903/chatify/pkg/auth"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// BroadcastConsumer 广播分片消费者，与用户消息消费者区分不同的topic
+type BroadcastConsumer interface {
+	Consumer
+}
+
+// BroadcastRepo 广播任务仓库接口
+type BroadcastRepo interface {
+	// CreateJob 创建广播任务及其全部分片，并回填任务的创建时间
+	CreateJob(ctx context.Context, job *bo.BroadcastJob, chunks []*bo.BroadcastChunk) error
+	// GetJob 查询广播任务，不存在时返回nil
+	GetJob(ctx context.Context, jobId string) (*bo.BroadcastJob, error)
+	// UpdateJobStatus 仅当任务处于from中的状态时更新为to，返回是否更新成功
+	UpdateJobStatus(ctx context.Context, jobId string, from []bo.BroadcastJobStatus, to bo.BroadcastJobStatus) (bool, error)
+	// GetChunk 查询分片，不存在时返回nil
+	GetChunk(ctx context.Context, jobId string, chunkIndex int32) (*bo.BroadcastChunk, error)
+	// ListChunkIndexes 查询指定状态的分片序号
+	ListChunkIndexes(ctx context.Context, jobId string, status bo.BroadcastChunkStatus) ([]int32, error)
+	// ClaimChunk 将待投递分片标记为投递中，返回本次抢占的令牌，未抢占成功时返回空字符串
+	ClaimChunk(ctx context.Context, jobId string, chunkIndex int32) (string, error)
+	// ResetStaleChunks 将执行中和暂停的任务里早于staleBefore进入投递中的分片重置为待投递，返回执行中任务被重置的分片
+	ResetStaleChunks(ctx context.Context, staleBefore time.Time, limit int) ([]*bo.BroadcastChunkTask, error)
+	// FinishChunk 记录分片投递结果并累加任务进度，全部分片完成后任务置为完成
+	// 分片已不再由chunk.ClaimToken持有时不做任何更新，返回false
+	FinishChunk(ctx context.Context, chunk *bo.BroadcastChunk, sentUsers, failedUsers int64) (bool, error)
+	// CancelPendingChunks 将所有待投递分片标记为取消
+	CancelPendingChunks(ctx context.Context, jobId string) error
+}
+
+// Broadcast 广播任务业务逻辑
+// 大批量的系统推送被切分为若干分片，通过Kafka异步投递给push服务
+type Broadcast struct {
+	log         *log.Helper
+	pushClient  PushRepo
+	repo        BroadcastRepo
+	mqProducer  MqProducer
+	consumer    BroadcastConsumer
+	preferences *Preferences
+	sonyFlake   *auth.Sonyflake
+}
+
+// NewBroadcast 创建广播任务业务逻辑实例，并启动分片消费协程
+func NewBroadcast(
+	logger log.Logger,
+	pushClient PushRepo,
+	repo BroadcastRepo,
+	mqProducer MqProducer,
+	consumer BroadcastConsumer,
+	preferences *Preferences,
+) (*Broadcast, func()) {
+	b := &Broadcast{
+		log:         log.NewHelper(logger),
+		pushClient:  pushClient,
+		repo:        repo,
+		mqProducer:  mqProducer,
+		consumer:    consumer,
+		preferences: preferences,
+		sonyFlake:   auth.NewSonyflake(),
+	}
+	ctx, cancel := context.WithCancelCause(context.TODO())
+	b.consumer.Start(ctx, nil, b.HandleChunk())
+	go b.reclaimLoop(ctx)
+	return b, func() { cancel(errors.New("broadcast consumer context canceled")) }
+}
+
+func (b *Broadcast) reclaimLoop(ctx context.Context) {
+	ticker := time.NewTicker(bo.BroadcastChunkLease / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			b.reclaim(ctx)
+		}
+	}
+}
+
+// reclaim 重置租期已过的投递中分片，执行中任务的分片重新投递，暂停任务的分片在恢复时投递
+func (b *Broadcast) reclaim(ctx context.Context) {
+	tasks, err := b.repo.ResetStaleChunks(ctx, time.Now().Add(-bo.BroadcastChunkLease), bo.BroadcastReclaimBatchSize)
+	if err != nil {
+		// 出错前已重置的分片仍然需要重新投递
+		b.log.WithContext(ctx).Errorf("failed to reset stale broadcast chunks: %v", err)
+	}
+	for _, task := range tasks {
+		if err = b.mqProducer.SendMessageWithBroadcastChunk(ctx, task); err != nil {
+			// 分片已是待投递状态，下次恢复任务时重新投递
+			b.log.WithContext(ctx).Errorf("failed to redispatch broadcast chunk. jobID=%s, chunk=%d, error=%v", task.JobId, task.ChunkIndex, err)
+		}
+	}
+	if len(tasks) > 0 {
+		b.log.WithContext(ctx).Infof("Redispatched stale broadcast chunks. count=%d", len(tasks))
+	}
+}
+
+// CreateBroadcastJob 创建广播任务并投递全部分片
+func (b *Broadcast) CreateBroadcastJob(ctx context.Context, req *v1.CreateBroadcastJobRequest) (*bo.BroadcastJob, error) {
+	userIds := bo.ParseRecipients(req.ToUserIds, req.RecipientFile)
+	if len(userIds) == 0 {
+		return nil, v1.ErrorInvalidParameter("target users is empty")
+	}
+	if len(userIds) > bo.MaxBroadcastUsers {
+		b.log.WithContext(ctx).Errorf("too many broadcast users limit=%d, input=%d", bo.MaxBroadcastUsers, len(userIds))
+		return nil, v1.ErrorTooManyTargets("too many target users limit=%d, input=%d",
+			bo.MaxBroadcastUsers, len(userIds))
+	}
+	expireAt, err := bo.ParseExpireTime(req.ExpireTime, time.Now())
+	if err != nil {
+		return nil, v1.ErrorInvalidParameter("%v", err)
+	}
+	job := bo.NewBroadcastJob(req)
+	// 时长从创建任务时开始计算，统一保存为秒级时间戳
+	if expireAt > 0 {
+		job.ExpireTime = strconv.FormatInt(expireAt, 10)
+	}
+	if job.JobId, err = b.sonyFlake.GenerateBase62(); err != nil {
+		b.log.WithContext(ctx).Errorf("failed to generate job id: %v", err)
+		return nil, v1.ErrorInternalError("failed to generate job id: %v", err)
+	}
+	job.JobId = "job" + job.JobId
+	var contentId string
+	if contentId, err = b.sonyFlake.GenerateBase62(); err != nil {
+		b.log.WithContext(ctx).Errorf("failed to generate content id: %v", err)
+		return nil, v1.ErrorInternalError("failed to generate content id: %v", err)
+	}
+	job.ContentId = bo.SystemContentIdPrefix + contentId
+	chunks := bo.SplitBroadcastChunks(job.JobId, userIds)
+	job.TotalUsers = int64(len(userIds))
+	job.TotalChunks = int32(len(chunks))
+	if err = b.repo.CreateJob(ctx, job, chunks); err != nil {
+		b.log.WithContext(ctx).Errorf("failed to create broadcast job: %v", err)
+		return nil, v1.ErrorInternalError("failed to create broadcast job")
+	}
+	b.log.WithContext(ctx).Infof("Created broadcast job. jobID=%s, users=%d, chunks=%d", job.JobId, job.TotalUsers, job.TotalChunks)
+
+	indexes := make([]int32, 0, len(chunks))
+	for _, chunk := range chunks {
+		indexes = append(indexes, chunk.ChunkIndex)
+	}
+	if err = b.dispatchChunks(ctx, job.JobId, indexes); err != nil {
+		// 投递中断时暂停任务，未投递的分片保持待投递状态，恢复任务时重新投递
+		b.log.WithContext(ctx).Errorf("failed to dispatch broadcast chunks, pause job. jobID=%s, error=%v", job.JobId, err)
+		if _, perr := b.repo.UpdateJobStatus(ctx, job.JobId,
+			[]bo.BroadcastJobStatus{bo.BroadcastJobStatusRunning}, bo.BroadcastJobStatusPaused); perr != nil {
+			b.log.WithContext(ctx).Errorf("failed to pause broadcast job. jobID=%s, error=%v", job.JobId, perr)
+		}
+		job.Status = bo.BroadcastJobStatusPaused
+	}
+	return job, nil
+}
+
+// GetBroadcastJob 查询广播任务进度
+func (b *Broadcast) GetBroadcastJob(ctx context.Context, jobId string) (*bo.BroadcastJob, error) {
+	if jobId == "" {
+		return nil, v1.ErrorInvalidParameter("job id is empty")
+	}
+	job, err := b.repo.GetJob(ctx, jobId)
+	if err != nil {
+		b.log.WithContext(ctx).Errorf("failed to get broadcast job. jobID=%s, error=%v", jobId, err)
+		return nil, v1.ErrorInternalError("failed to get broadcast job")
+	}
+	if job == nil {
+		return nil, v1.ErrorJobNotFound("broadcast job not found. job_id=%s", jobId)
+	}
+	return job, nil
+}
+
+// PauseBroadcastJob 暂停广播任务，已在投递中的分片会继续完成
+func (b *Broadcast) PauseBroadcastJob(ctx context.Context, jobId string) (*bo.BroadcastJob, error) {
+	if err := b.transit(ctx, jobId,
+		[]bo.BroadcastJobStatus{bo.BroadcastJobStatusRunning}, bo.BroadcastJobStatusPaused); err != nil {
+		return nil, err
+	}
+	b.log.WithContext(ctx).Infof("Paused broadcast job. jobID=%s", jobId)
+	return b.GetBroadcastJob(ctx, jobId)
+}
+
+// ResumeBroadcastJob 恢复广播任务，并重新投递所有待投递分片
+// 处理中断的分片在租期过后由reclaim重置为待投递
+func (b *Broadcast) ResumeBroadcastJob(ctx context.Context, jobId string) (*bo.BroadcastJob, error) {
+	if err := b.transit(ctx, jobId,
+		[]bo.BroadcastJobStatus{bo.BroadcastJobStatusPaused}, bo.BroadcastJobStatusRunning); err != nil {
+		return nil, err
+	}
+	indexes, err := b.repo.ListChunkIndexes(ctx, jobId, bo.BroadcastChunkStatusPending)
+	if err != nil {
+		b.log.WithContext(ctx).Errorf("failed to list pending chunks. jobID=%s, error=%v", jobId, err)
+		return nil, v1.ErrorInternalError("failed to list pending chunks")
+	}
+	if err = b.dispatchChunks(ctx, jobId, indexes); err != nil {
+		b.log.WithContext(ctx).Errorf("failed to dispatch broadcast chunks. jobID=%s, error=%v", jobId, err)
+		return nil, v1.ErrorInternalError("failed to dispatch broadcast chunks")
+	}
+	b.log.WithContext(ctx).Infof("Resumed broadcast job. jobID=%s, pendingChunks=%d", jobId, len(indexes))
+	return b.GetBroadcastJob(ctx, jobId)
+}
+
+// CancelBroadcastJob 取消广播任务，待投递的分片不再发送
+func (b *Broadcast) CancelBroadcastJob(ctx context.Context, jobId string) (*bo.BroadcastJob, error) {
+	if err := b.transit(ctx, jobId,
+		[]bo.BroadcastJobStatus{bo.BroadcastJobStatusRunning, bo.BroadcastJobStatusPaused},
+		bo.BroadcastJobStatusCanceled); err != nil {
+		return nil, err
+	}
+	if err := b.repo.CancelPendingChunks(ctx, jobId); err != nil {
+		// 任务已是取消状态，消费端会跳过剩余分片，这里只记录日志
+		b.log.WithContext(ctx).Errorf("failed to cancel pending chunks. jobID=%s, error=%v", jobId, err)
+	}
+	b.log.WithContext(ctx).Infof("Canceled broadcast job. jobID=%s", jobId)
+	return b.GetBroadcastJob(ctx, jobId)
+}
+
+// HandleChunk 消费广播分片，调用push服务完成推送
+func (b *Broadcast) HandleChunk() MessageHandler {
+	return func(ctx context.Context, key string, value []byte) error {
+		var task bo.BroadcastChunkTask
+		if err := json.Unmarshal(value, &task); err != nil {
+			b.log.WithContext(ctx).Errorf("broadcast chunk json unmarshal error: %v", err)
+			return errors.Join(ErrNonRetryable, err)
+		}
+		job, err := b.repo.GetJob(ctx, task.JobId)
+		if err != nil {
+			b.log.WithContext(ctx).Errorf("failed to get broadcast job. jobID=%s, error=%v", task.JobId, err)
+			return err
+		}
+		if job == nil {
+			b.log.WithContext(ctx).Warnf("broadcast job not found, skip chunk. jobID=%s, chunk=%d", task.JobId, task.ChunkIndex)
+			return nil
+		}
+		if job.Status != bo.BroadcastJobStatusRunning {
+			// 暂停的任务分片保持待投递状态，恢复时重新投递；取消或完成的任务直接跳过
+			b.log.WithContext(ctx).Debugf("broadcast job is %s, skip chunk. jobID=%s, chunk=%d", job.Status, task.JobId, task.ChunkIndex)
+			return nil
+		}
+		token, err := b.repo.ClaimChunk(ctx, task.JobId, task.ChunkIndex)
+		if err != nil {
+			b.log.WithContext(ctx).Errorf("failed to claim broadcast chunk. jobID=%s, chunk=%d, error=%v", task.JobId, task.ChunkIndex, err)
+			return err
+		}
+		if token == "" {
+			// 分片已被处理（重复投递），跳过
+			b.log.WithContext(ctx).Debugf("broadcast chunk already handled. jobID=%s, chunk=%d", task.JobId, task.ChunkIndex)
+			return nil
+		}
+		chunk, err := b.repo.GetChunk(ctx, task.JobId, task.ChunkIndex)
+		if err != nil || chunk == nil {
+			// 分片已是投递中状态，重试消息会被跳过，由reclaim在租期过后重新投递
+			b.log.WithContext(ctx).Errorf("failed to get broadcast chunk. jobID=%s, chunk=%d, error=%v", task.JobId, task.ChunkIndex, err)
+			return errors.Join(err, errors.New("failed to get broadcast chunk"))
+		}
+		chunk.ClaimToken = token
+		return b.sendChunk(ctx, job, chunk)
+	}
+}
+
+// sendChunk 将一个分片作为一次push任务发送
+func (b *Broadcast) sendChunk(ctx context.Context, job *bo.BroadcastJob, chunk *bo.BroadcastChunk) error {
+	req := job.ToSystemPushRequest(chunk.UserIds)
+	// 退订了该类型推送的用户不再发送，不计入发送人数
+	messages, suppressions, err := b.preferences.FilterSystemPush(ctx, req.PushType, bo.NewMessagesByUserIDs(req), time.Now())
+	if err != nil {
+		b.log.WithContext(ctx).Errorf("failed to check notification preferences. jobID=%s, chunk=%d, error=%v", job.JobId, chunk.ChunkIndex, err)
+		chunk.Status = bo.BroadcastChunkStatusFailed
+		chunk.Description = err.Error()
+		return b.finishChunk(ctx, chunk, 0, int64(len(chunk.UserIds)))
+	}
+	b.preferences.RecordSuppressions(ctx, job.ContentId, suppressions)
+	if len(messages) == 0 {
+		chunk.Status = bo.BroadcastChunkStatusSent
+		return b.finishChunk(ctx, chunk, 0, 0)
+	}
+	expireAt := job.ExpireAt()
+	for _, message := range messages {
+		message.ContentId = job.ContentId
+		message.ExpireTime = expireAt
+		if message.MsgId, err = b.sonyFlake.GenerateBase62(); err != nil {
+			break
+		}
+		message.MsgId = "msg" + message.MsgId
+	}
+	if err == nil {
+		if chunk.TaskId, err = b.sonyFlake.GenerateBase62(); err == nil {
+			chunk.TaskId = "task" + chunk.TaskId
+			err = b.pushClient.SendMessage(ctx, chunk.TaskId, messages)
+		}
+	}
+	userCount := int64(len(messages))
+	if err != nil {
+		b.log.WithContext(ctx).Errorf("failed to send broadcast chunk. jobID=%s, chunk=%d, error=%v", job.JobId, chunk.ChunkIndex, err)
+		chunk.Status = bo.BroadcastChunkStatusFailed
+		chunk.Description = err.Error()
+		return b.finishChunk(ctx, chunk, 0, userCount)
+	}
+	sendDeliveryEvents(ctx, b.mqProducer, b.log, newTargetedEvent(job.ContentId, len(messages)))
+	chunk.Status = bo.BroadcastChunkStatusSent
+	b.log.WithContext(ctx).Infof("Sent broadcast chunk. jobID=%s, chunk=%d, taskID=%s, len=%d", job.JobId, chunk.ChunkIndex, chunk.TaskId, userCount)
+	return b.finishChunk(ctx, chunk, userCount, 0)
+}
+
+// finishChunk 记录分片结果，分片在投递期间超时被重新抢占时丢弃本次结果，由新的持有者计入进度
+func (b *Broadcast) finishChunk(ctx context.Context, chunk *bo.BroadcastChunk, sentUsers, failedUsers int64) error {
+	finished, err := b.repo.FinishChunk(ctx, chunk, sentUsers, failedUsers)
+	if err != nil {
+		b.log.WithContext(ctx).Errorf("failed to finish broadcast chunk. jobID=%s, chunk=%d, error=%v", chunk.JobId, chunk.ChunkIndex, err)
+		return err
+	}
+	if !finished {
+		b.log.WithContext(ctx).Warnf("broadcast chunk was reclaimed, drop result. jobID=%s, chunk=%d, taskID=%s", chunk.JobId, chunk.ChunkIndex, chunk.TaskId)
+	}
+	return nil
+}
+
+// dispatchChunks 将分片任务投递到Kafka
+func (b *Broadcast) dispatchChunks(ctx context.Context, jobId string, indexes []int32) error {
+	for _, index := range indexes {
+		if err := b.mqProducer.SendMessageWithBroadcastChunk(ctx, &bo.BroadcastChunkTask{
+			JobId:      jobId,
+			ChunkIndex: index,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// transit 变更任务状态，不满足前置状态时返回对应错误
+func (b *Broadcast) transit(ctx context.Context, jobId string, from []bo.BroadcastJobStatus, to bo.BroadcastJobStatus) error {
+	if jobId == "" {
+		return v1.ErrorInvalidParameter("job id is empty")
+	}
+	ok, err := b.repo.UpdateJobStatus(ctx, jobId, from, to)
+	if err != nil {
+		b.log.WithContext(ctx).Errorf("failed to update broadcast job status. jobID=%s, error=%v", jobId, err)
+		return v1.ErrorInternalError("failed to update broadcast job status")
+	}
+	if ok {
+		return nil
+	}
+	job, err := b.GetBroadcastJob(ctx, jobId)
+	if err != nil {
+		return err
+	}
+	return v1.ErrorJobStatusConflict("broadcast job is %s, can not change to %s", job.Status, to)
+}
@@ -0,0 +1,424 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+
+	"github.com/xinghe903/chatify/pkg/auth"
+
+	im_v1 "github.com/xinghe903/chatify/api/im/v1"
+	v1 "github.com/xinghe903/chatify/api/logic/v1"
+)
+
+var (
+	ErrInvalidChatMessage   = errors.New("invalid chat message")
+	ErrChatMessageNotFound  = errors.New("chat message not found")
+	ErrRevisionNotAllowed   = errors.New("operator is not allowed to revise the message")
+	ErrRevisionWindowExpire = errors.New("message revision window expired")
+	ErrInvalidMention       = errors.New("invalid mention")
+)
+
+// ChatRepo 聊天消息仓库接口
+type ChatRepo interface {
+	// SaveMessage 保存聊天消息，msg_id已存在时不覆盖，用已保存的记录填充message，返回是否新保存
+	SaveMessage(ctx context.Context, message *bo.ChatMessage) (bool, error)
+	// GetMessage 查询聊天消息，不存在时返回nil
+	GetMessage(ctx context.Context, msgId string) (*bo.ChatMessage, error)
+	// MarkDelivered 记录消息已扇出给全部接收者，重试的消息不再重复投递
+	MarkDelivered(ctx context.Context, msgId string, deliveredAt int64) error
+	// ReviseMessage 撤回或编辑消息，已撤回的消息不能再修改，返回是否修改成功
+	ReviseMessage(ctx context.Context, msgId string, status bo.ChatMessageStatus, content []byte, operatorId string) (bool, error)
+	// CountUnread 统计用户在会话中晚于since收到的未撤回消息数，会话ID为该用户视角
+	CountUnread(ctx context.Context, userId string, conversationType im_v1.TargetType, conversationId string, since time.Time) (int64, error)
+	// ListHistory 按游标查询会话历史消息，结果按时间正序排列
+	ListHistory(ctx context.Context, query *bo.HistoryQuery) ([]*bo.ChatMessage, error)
+	// ListSince 查询会话中序号大于sinceSeq的消息，排除用户已删除的消息，结果按序号正序排列
+	ListSince(ctx context.Context, userId, conversationKey string, sinceSeq int64, limit int) ([]*bo.ChatMessage, error)
+	// DeleteForUser 删除消息，仅对该用户不可见
+	DeleteForUser(ctx context.Context, userId string, msgIds []string) error
+}
+
+// SequenceRepo 会话序号仓库接口
+type SequenceRepo interface {
+	// NextSeq 为会话分配下一个序号，序号从1开始单调递增
+	NextSeq(ctx context.Context, conversationKey string) (int64, error)
+}
+
+// SendChatMessage 同步发送聊天消息
+// 调用方必须是登录用户本人，msg_id重复时返回首次发送的结果
+func (h *UserMessageHandler) SendChatMessage(ctx context.Context, baseMsg *im_v1.BaseMessage) (*bo.ChatMessage, error) {
+	userId := auth.GetUserID(ctx)
+	if userId == "" {
+		return nil, v1.ErrorUnauthenticated("user id not found in context")
+	}
+	if baseMsg == nil {
+		return nil, v1.ErrorInvalidParameter("message is empty")
+	}
+	if baseMsg.FromUserId != userId {
+		return nil, v1.ErrorSenderMismatch("from user id %s does not match caller %s", baseMsg.FromUserId, userId)
+	}
+	if baseMsg.MessageType != im_v1.MessageType_CHAT {
+		return nil, v1.ErrorInvalidParameter("only chat message is supported")
+	}
+	if baseMsg.MsgId == "" {
+		msgId, err := h.sonyFlake.GenerateBase62()
+		if err != nil {
+			h.log.WithContext(ctx).Errorf("failed to generate message id. error=%v", err)
+			return nil, v1.ErrorInternalError("failed to generate message id")
+		}
+		baseMsg.MsgId = "msg" + msgId
+	} else if isNew, err := h.dedupRepo.CheckAndSetDedup(ctx, baseMsg.MsgId); err != nil {
+		h.log.WithContext(ctx).Errorf("检查消息去重失败: msgId=%s, error=%v", baseMsg.MsgId, err)
+	} else if !isNew {
+		return h.sentChatMessage(ctx, baseMsg)
+	}
+	if baseMsg.Timestamp == 0 {
+		baseMsg.Timestamp = time.Now().Unix()
+	}
+
+	message, err := h.sendChat(ctx, baseMsg)
+	if err != nil {
+		// 允许调用方使用相同的msg_id重试
+		if rerr := h.dedupRepo.ReleaseDedup(ctx, baseMsg.MsgId); rerr != nil {
+			h.log.WithContext(ctx).Errorf("failed to release dedup. msgId=%s, error=%v", baseMsg.MsgId, rerr)
+		}
+	}
+	switch {
+	case err == nil:
+		return message, nil
+	case errors.Is(err, ErrInvalidTargetType), errors.Is(err, ErrInvalidChatMessage):
+		return nil, v1.ErrorInvalidParameter("%v", err)
+	case errors.Is(err, ErrQuotaExceeded):
+		return nil, v1.ErrorQuotaExceeded("send quota exceeded")
+	case errors.Is(err, ErrSenderMuted):
+		return nil, v1.ErrorSenderMuted("sender %s is temporarily muted", userId)
+	case errors.Is(err, ErrNotGroupMember):
+		return nil, v1.ErrorConversationForbidden("user %s is not a member of group %s", userId, baseMsg.ToUserId)
+	default:
+		return nil, v1.ErrorInternalError("failed to send chat message")
+	}
+}
+
+// sentChatMessage 返回重复msg_id已保存的消息，仍在处理中时返回消息重复
+func (h *UserMessageHandler) sentChatMessage(ctx context.Context, baseMsg *im_v1.BaseMessage) (*bo.ChatMessage, error) {
+	message, err := h.chatRepo.GetMessage(ctx, baseMsg.MsgId)
+	if err != nil {
+		h.log.WithContext(ctx).Errorf("failed to get chat message. msgId=%s, error=%v", baseMsg.MsgId, err)
+		return nil, v1.ErrorInternalError("failed to get chat message")
+	}
+	if message == nil || message.FromUserId != baseMsg.FromUserId {
+		return nil, v1.ErrorMessageRepeat("message %s is already being processed", baseMsg.MsgId)
+	}
+	return message, nil
+}
+
+// chat 处理kafka上行的聊天消息
+func (h *UserMessageHandler) chat(ctx context.Context, baseMsg *im_v1.BaseMessage) error {
+	_, err := h.sendChat(ctx, baseMsg)
+	return err
+}
+
+// normalizeContent 校验并规范化消息内容，内容引用的附件必须已上传完成
+// @只能用于群聊，被@的用户必须是群成员，@所有人需要群主或管理员权限
+// sender为群聊中发送者的成员信息，单聊时为nil；内容不合法时返回的错误包含invalid
+func (h *UserMessageHandler) normalizeContent(ctx context.Context, raw []byte, sender *bo.GroupMember, invalid error) (*im_v1.MessageContent, []byte, error) {
+	c, content, err := bo.NormalizeContent(raw)
+	if err != nil {
+		return nil, nil, errors.Join(invalid, err)
+	}
+	if attachmentId := bo.ContentAttachmentId(c); attachmentId != "" {
+		if err = h.attachments.CheckAttachment(ctx, attachmentId); err != nil {
+			if v1.IsAttachmentNotFound(err) {
+				return nil, nil, errors.Join(invalid, err)
+			}
+			return nil, nil, err
+		}
+	}
+	if err = h.checkMentions(ctx, c, sender); err != nil {
+		if errors.Is(err, ErrInvalidMention) {
+			return nil, nil, errors.Join(invalid, err)
+		}
+		return nil, nil, err
+	}
+	return c, content, nil
+}
+
+// checkMentions 校验消息内容中的@
+func (h *UserMessageHandler) checkMentions(ctx context.Context, c *im_v1.MessageContent, sender *bo.GroupMember) error {
+	userIds, all := bo.ContentMentions(c)
+	if len(userIds) == 0 && !all {
+		return nil
+	}
+	if sender == nil {
+		return errors.Join(ErrInvalidMention, errors.New("mentions are only supported in group chats"))
+	}
+	if all && !sender.IsAdmin() {
+		return errors.Join(ErrInvalidMention, errors.New("only group owner or admin can mention all"))
+	}
+	if len(userIds) == 0 {
+		return nil
+	}
+	members, err := h.groupRepo.ListMembers(ctx, sender.GroupId)
+	if err != nil {
+		return err
+	}
+	memberIds := make(map[string]struct{}, len(members))
+	for _, member := range members {
+		memberIds[member.UserId] = struct{}{}
+	}
+	for _, userId := range userIds {
+		if _, ok := memberIds[userId]; !ok {
+			return errors.Join(ErrInvalidMention, fmt.Errorf("mentioned user %s is not a group member", userId))
+		}
+	}
+	return nil
+}
+
+// sendChat 校验并发送聊天消息
+// 分配会话序号并保存消息后按会话成员扇出，每个接收者一条独立的下发消息
+func (h *UserMessageHandler) sendChat(ctx context.Context, baseMsg *im_v1.BaseMessage) (*bo.ChatMessage, error) {
+	if baseMsg.TargetType != im_v1.TargetType_USER && baseMsg.TargetType != im_v1.TargetType_GROUP {
+		return nil, ErrInvalidTargetType
+	}
+	if baseMsg.MsgId == "" || baseMsg.FromUserId == "" || baseMsg.ToUserId == "" {
+		return nil, errors.Join(ErrInvalidChatMessage, errors.New("msg id, from user id and to user id are required"))
+	}
+	if baseMsg.TargetType == im_v1.TargetType_USER && baseMsg.ToUserId == baseMsg.FromUserId {
+		return nil, errors.Join(ErrInvalidChatMessage, errors.New("cannot send message to self"))
+	}
+	var sender *bo.GroupMember
+	if baseMsg.TargetType == im_v1.TargetType_GROUP {
+		member, err := h.groupRepo.GetMember(ctx, baseMsg.ToUserId, baseMsg.FromUserId)
+		if err != nil {
+			return nil, err
+		}
+		if member == nil {
+			return nil, ErrNotGroupMember
+		}
+		sender = member
+	}
+
+	c, content, err := h.normalizeContent(ctx, baseMsg.Content, sender, ErrInvalidChatMessage)
+	if err != nil {
+		return nil, err
+	}
+	baseMsg.Content = content
+
+	message := bo.NewChatMessage(baseMsg)
+	message.ContentType = bo.ContentType(c)
+	message.MentionUserIds, message.MentionAll = bo.ContentMentions(c)
+	if message, err = h.saveChat(ctx, message); err != nil {
+		return nil, err
+	}
+	if message.DeliveredAt > 0 {
+		// 客户端重试已投递的消息，每个接收者已经收到过一条下发消息
+		h.log.WithContext(ctx).Infof("Chat message already delivered, skip delivery. msgId=%s", message.MsgId)
+		return message, nil
+	}
+	recipients, err := h.conversationRecipients(ctx, message.ConversationType, message.ConversationId, message.FromUserId)
+	if err != nil {
+		return nil, err
+	}
+	// 机器人没有长连接，发给机器人的消息通过webhook投递
+	users, bots := splitBots(recipients)
+	silent, err := h.preferences.SilentRecipients(ctx, message, users, time.Now())
+	if err != nil {
+		// 查询失败时按正常消息提醒，不影响投递
+		h.log.WithContext(ctx).Warnf("failed to check notification preferences. msgId=%s, error=%v", baseMsg.MsgId, err)
+	}
+	if err = h.deliverChat(ctx, message, users, silent); err != nil {
+		h.log.WithContext(ctx).Errorf("failed to deliver chat message. msgId=%s, error=%v", baseMsg.MsgId, err)
+		return nil, err
+	}
+	message.DeliveredAt = time.Now().UnixMilli()
+	if err = h.chatRepo.MarkDelivered(ctx, message.MsgId, message.DeliveredAt); err != nil {
+		// 记录失败时重试的消息会再次扇出，客户端可以按content_id去重
+		h.log.WithContext(ctx).Warnf("failed to mark chat message delivered. msgId=%s, error=%v", baseMsg.MsgId, err)
+	}
+	h.webhook.PublishBotMessage(ctx, message, bots)
+	if err = h.conversationRepo.AppendMessage(ctx, message, recipients); err != nil {
+		// 未读数会在下一次已读回执时重新统计
+		h.log.WithContext(ctx).Warnf("failed to update conversations. msgId=%s, error=%v", baseMsg.MsgId, err)
+	}
+	h.log.WithContext(ctx).Debugf("Delivered chat message. msgId=%s, recipients=%d", baseMsg.MsgId, len(recipients))
+	return message, nil
+}
+
+// saveChat 计入配额、分配序号并保存消息
+// 消息保存后投递失败时会从重试队列再次处理，此时沿用已保存的消息继续投递，不重复计入配额和分配序号
+// 已保存的消息如果已完成投递，返回的消息DeliveredAt不为0
+func (h *UserMessageHandler) saveChat(ctx context.Context, message *bo.ChatMessage) (*bo.ChatMessage, error) {
+	saved, err := h.chatRepo.GetMessage(ctx, message.MsgId)
+	if err != nil {
+		h.log.WithContext(ctx).Errorf("failed to get chat message. msgId=%s, error=%v", message.MsgId, err)
+		return nil, err
+	}
+	if saved != nil {
+		if err = sameChat(saved, message); err != nil {
+			return nil, err
+		}
+		h.log.WithContext(ctx).Infof("Chat message already saved, continue delivery. msgId=%s, seq=%d", message.MsgId, saved.Seq)
+		saved.MentionUserIds, saved.MentionAll = message.MentionUserIds, message.MentionAll
+		return saved, nil
+	}
+	if err = h.admitChat(ctx, message); err != nil {
+		h.log.WithContext(ctx).Warnf("reject chat message. msgId=%s, sender=%s, error=%v", message.MsgId, message.FromUserId, err)
+		return nil, err
+	}
+	seq, err := h.sequenceRepo.NextSeq(ctx, message.ConversationKey())
+	if err != nil {
+		h.log.WithContext(ctx).Errorf("failed to allocate seq. msgId=%s, error=%v", message.MsgId, err)
+		return nil, err
+	}
+	message.Seq = seq
+	incoming := *message
+	created, err := h.chatRepo.SaveMessage(ctx, message)
+	if err != nil {
+		h.log.WithContext(ctx).Errorf("failed to save chat message. msgId=%s, error=%v", message.MsgId, err)
+		return nil, err
+	}
+	// 并发处理同一条消息时另一方先保存成功，message已替换为已保存的记录
+	if !created {
+		if err = sameChat(message, &incoming); err != nil {
+			return nil, err
+		}
+	}
+	return message, nil
+}
+
+// sameChat 校验已保存的消息与重试的消息属于同一发送者和会话
+func sameChat(saved, message *bo.ChatMessage) error {
+	if saved.FromUserId != message.FromUserId || saved.ConversationType != message.ConversationType || saved.ConversationId != message.ConversationId {
+		return errors.Join(ErrInvalidChatMessage, errors.New("msg id is used by another message"))
+	}
+	return nil
+}
+
+// deliverChat 按是否被@和是否静默把接收者分组投递，静默的消息照常保存和投递但客户端不提醒
+func (h *UserMessageHandler) deliverChat(ctx context.Context, message *bo.ChatMessage, users []string, silent map[string]bool) error {
+	mentioned, others := message.SplitMentioned(users)
+	for _, group := range []struct {
+		mentioned  bool
+		recipients []string
+	}{{true, mentioned}, {false, others}} {
+		var loud, quiet []string
+		for _, userId := range group.recipients {
+			if silent[userId] {
+				quiet = append(quiet, userId)
+			} else {
+				loud = append(loud, userId)
+			}
+		}
+		if err := h.deliver(ctx, message.ToDelivery(group.mentioned), loud); err != nil {
+			return err
+		}
+		template := message.ToDelivery(group.mentioned)
+		template.Silent = true
+		if err := h.deliver(ctx, template, quiet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// revise 校验并执行撤回或编辑
+// 原发送者或群主/管理员可以在时间窗口内操作，修改记录后同步到尚未投递的离线消息
+func (h *UserMessageHandler) revise(ctx context.Context, cmd *im_v1.ControlCommand, msgId string, status bo.ChatMessageStatus, content []byte) error {
+	message, err := h.chatRepo.GetMessage(ctx, msgId)
+	if err != nil {
+		return err
+	}
+	if message == nil || message.ConversationType != cmd.ConversationType {
+		return ErrChatMessageNotFound
+	}
+	switch cmd.ConversationType {
+	case im_v1.TargetType_USER:
+		if message.FromUserId != cmd.OperatorId || message.ConversationId != cmd.ConversationId {
+			return ErrRevisionNotAllowed
+		}
+	case im_v1.TargetType_GROUP:
+		if message.ConversationId != cmd.ConversationId {
+			return ErrChatMessageNotFound
+		}
+		if message.FromUserId != cmd.OperatorId {
+			member, err := h.groupRepo.GetMember(ctx, cmd.ConversationId, cmd.OperatorId)
+			if err != nil {
+				return err
+			}
+			if member == nil || !member.IsAdmin() {
+				return ErrRevisionNotAllowed
+			}
+		}
+	}
+	if status == bo.ChatMessageStatusEdited {
+		if err = checkEdit(message, content); err != nil {
+			return err
+		}
+	}
+	window := h.editWindow
+	if status == bo.ChatMessageStatusRecalled {
+		window = h.recallWindow
+	}
+	if time.Since(message.CreatedAt) > window {
+		return ErrRevisionWindowExpire
+	}
+
+	ok, err := h.chatRepo.ReviseMessage(ctx, msgId, status, content, cmd.OperatorId)
+	if err != nil {
+		h.log.WithContext(ctx).Errorf("failed to revise chat message. msgId=%s, error=%v", msgId, err)
+		return err
+	}
+	if !ok {
+		// 消息已被撤回
+		return ErrRevisionNotAllowed
+	}
+	preview := bo.RecalledPreview
+	if status != bo.ChatMessageStatusRecalled {
+		preview = bo.MessagePreview(content)
+	}
+	if err = h.conversationRepo.ReviseLastMessage(ctx, msgId, preview); err != nil {
+		h.log.WithContext(ctx).Warnf("failed to revise conversation preview. msgId=%s, error=%v", msgId, err)
+	}
+	if err = h.offlineRepo.ReviseMessages(ctx, msgId, status == bo.ChatMessageStatusRecalled, content); err != nil {
+		h.log.WithContext(ctx).Errorf("failed to revise offline messages. msgId=%s, error=%v", msgId, err)
+		return err
+	}
+	h.log.WithContext(ctx).Infof("Revised chat message. msgId=%s, status=%s, operator=%s", msgId, status, cmd.OperatorId)
+	return nil
+}
+
+// checkEdit 编辑不能改变内容类型和@的用户，@只在发送时提醒被@的用户
+// 升级前保存的消息没有记录类型，不限制类型
+func checkEdit(message *bo.ChatMessage, content []byte) error {
+	c, err := bo.ParseContent(content)
+	if err != nil {
+		return errors.Join(ErrInvalidControlCommand, err)
+	}
+	if message.ContentType != 0 && bo.ContentType(c) != message.ContentType {
+		return errors.Join(ErrInvalidControlCommand, errors.New("edit can not change the content type"))
+	}
+	// 已保存的内容都经过校验，解析失败时按没有@处理
+	saved, _ := bo.ParseContent(message.Content)
+	if !bo.SameMentions(saved, c) {
+		return errors.Join(ErrInvalidControlCommand, errors.New("edit can not change mentions"))
+	}
+	return nil
+}
+
+// splitBots 把接收者分为普通用户和机器人
+func splitBots(recipients []string) ([]string, []string) {
+	users := make([]string, 0, len(recipients))
+	var bots []string
+	for _, userId := range recipients {
+		if bo.IsBot(userId) {
+			bots = append(bots, userId)
+			continue
+		}
+		users = append(users, userId)
+	}
+	return users, bots
+}
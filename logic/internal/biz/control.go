@@ -0,0 +1,259 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+
+	im_v1 "github.com/xinghe903/chatify/api/im/v1"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	ErrInvalidControlCommand = errors.New("invalid control command")
+	ErrNotGroupMember        = errors.New("operator is not a member of the group")
+)
+
+// GroupRepo 群成员仓库接口
+// 群关系由群组服务维护，logic只读取成员信息用于鉴权和消息路由
+type GroupRepo interface {
+	// GetMember 查询群成员，不存在时返回nil
+	GetMember(ctx context.Context, groupId, userId string) (*bo.GroupMember, error)
+	ListMembers(ctx context.Context, groupId string) ([]*bo.GroupMember, error)
+}
+
+// ConversationRepo 会话仓库接口，会话ID均为所属用户视角
+type ConversationRepo interface {
+	// SaveMute 保存会话免打扰设置
+	SaveMute(ctx context.Context, userId string, conversationType im_v1.TargetType, conversationId string, muted bool, muteUntil int64) error
+	// SavePinned 保存会话置顶设置
+	SavePinned(ctx context.Context, userId string, conversationType im_v1.TargetType, conversationId string, pinned bool) error
+	// AppendMessage 用新消息更新发送者和接收者的会话摘要，为接收者增加未读数并记录被@的时间
+	AppendMessage(ctx context.Context, message *bo.ChatMessage, recipients []string) error
+	// ReviseLastMessage 最后一条消息被撤回或编辑后更新会话摘要
+	ReviseLastMessage(ctx context.Context, msgId, preview string) error
+	// ListConversations 分页查询会话，置顶在前，其余按最后一条消息时间倒序
+	ListConversations(ctx context.Context, userId string, offset, limit int) ([]*bo.Conversation, int64, error)
+	// ListMuted 查询用户在now（单位: 秒）时免打扰生效中的会话
+	ListMuted(ctx context.Context, userId string, now int64) ([]*bo.ConversationMute, error)
+	// ListMutedUsers 查询接收者中在now（单位: 秒）时对消息所属会话开启了免打扰的用户
+	ListMutedUsers(ctx context.Context, message *bo.ChatMessage, recipients []string, now int64) ([]string, error)
+}
+
+// OfflineRepo offline服务接口
+type OfflineRepo interface {
+	// AcknowledgeMessages 确认离线消息已送达
+	AcknowledgeMessages(ctx context.Context, userId string, msgIds []string) error
+	// ReviseMessages 撤回或编辑尚未投递的离线消息，contentId为原始消息ID
+	ReviseMessages(ctx context.Context, contentId string, recalled bool, content []byte) error
+}
+
+// control 处理控制类消息
+// 校验指令后更新对应的会话状态，再把指令转发给会话中受影响的用户
+func (h *UserMessageHandler) control(ctx context.Context, baseMsg *im_v1.BaseMessage) error {
+	if baseMsg.TargetType != im_v1.TargetType_SYSTEM {
+		return ErrInvalidTargetType
+	}
+	if err := h.checkRate(ctx, baseMsg.FromUserId, baseMsg.MsgId, im_v1.MessageType_CONTROL, h.quota.ControlLimit); err != nil {
+		return err
+	}
+	var cmd im_v1.ControlCommand
+	if err := protojson.Unmarshal(baseMsg.Content, &cmd); err != nil {
+		h.log.WithContext(ctx).Errorf("control command unmarshal error. msgId=%s, error=%v", baseMsg.MsgId, err)
+		return errors.Join(ErrInvalidControlCommand, err)
+	}
+	cmd.OperatorId = baseMsg.FromUserId
+	if err := h.validateControl(ctx, &cmd); err != nil {
+		h.log.WithContext(ctx).Warnf("reject control command. msgId=%s, operator=%s, error=%v", baseMsg.MsgId, cmd.OperatorId, err)
+		return err
+	}
+
+	transient := false
+	switch c := cmd.Command.(type) {
+	case *im_v1.ControlCommand_Mute:
+		// 免打扰和置顶只影响自己，不需要转发
+		return h.conversationRepo.SaveMute(ctx, cmd.OperatorId, cmd.ConversationType, cmd.ConversationId,
+			c.Mute.Muted, c.Mute.MuteUntil)
+	case *im_v1.ControlCommand_Pin:
+		return h.conversationRepo.SavePinned(ctx, cmd.OperatorId, cmd.ConversationType, cmd.ConversationId,
+			c.Pin.Pinned)
+	case *im_v1.ControlCommand_ReadReceipt:
+		msgIds := c.ReadReceipt.MsgIds
+		if err := h.markRead(ctx, &cmd, msgIds[len(msgIds)-1]); err != nil {
+			h.log.WithContext(ctx).Warnf("failed to mark read. operator=%s, error=%v", cmd.OperatorId, err)
+			return err
+		}
+		sendDeliveryEvents(ctx, h.mqProducer, h.log, newReceiptEvents(bo.FunnelStageRead, cmd.OperatorId, c.ReadReceipt.ContentIds)...)
+	case *im_v1.ControlCommand_DeliveryReceipt:
+		sendDeliveryEvents(ctx, h.mqProducer, h.log, newReceiptEvents(bo.FunnelStageDelivered, cmd.OperatorId, c.DeliveryReceipt.ContentIds)...)
+		if err := h.offlineRepo.AcknowledgeMessages(ctx, cmd.OperatorId, c.DeliveryReceipt.MsgIds); err != nil {
+			// 离线消息确认失败不影响回执转发，客户端重新拉取时会再次确认
+			h.log.WithContext(ctx).Warnf("failed to acknowledge offline messages. operator=%s, error=%v", cmd.OperatorId, err)
+		}
+	case *im_v1.ControlCommand_Typing:
+		transient = true
+	case *im_v1.ControlCommand_Recall:
+		if err := h.revise(ctx, &cmd, c.Recall.MsgId, bo.ChatMessageStatusRecalled, nil); err != nil {
+			h.log.WithContext(ctx).Warnf("reject recall. msgId=%s, operator=%s, error=%v", c.Recall.MsgId, cmd.OperatorId, err)
+			return err
+		}
+	case *im_v1.ControlCommand_Edit:
+		if err := h.revise(ctx, &cmd, c.Edit.MsgId, bo.ChatMessageStatusEdited, c.Edit.Content); err != nil {
+			h.log.WithContext(ctx).Warnf("reject edit. msgId=%s, operator=%s, error=%v", c.Edit.MsgId, cmd.OperatorId, err)
+			return err
+		}
+	}
+
+	recipients, err := h.conversationRecipients(ctx, cmd.ConversationType, cmd.ConversationId, cmd.OperatorId)
+	if err != nil {
+		return err
+	}
+	return h.routeControl(ctx, &cmd, recipients, transient)
+}
+
+// validateControl 校验控制指令的会话和参数，规范化编辑后的消息内容
+func (h *UserMessageHandler) validateControl(ctx context.Context, cmd *im_v1.ControlCommand) error {
+	if cmd.ConversationId == "" {
+		return errors.Join(ErrInvalidControlCommand, errors.New("conversation id is empty"))
+	}
+	var operator *bo.GroupMember
+	switch cmd.ConversationType {
+	case im_v1.TargetType_USER:
+		if cmd.ConversationId == cmd.OperatorId {
+			return errors.Join(ErrInvalidControlCommand, errors.New("conversation id is the operator itself"))
+		}
+	case im_v1.TargetType_GROUP:
+		member, err := h.groupRepo.GetMember(ctx, cmd.ConversationId, cmd.OperatorId)
+		if err != nil {
+			return err
+		}
+		if member == nil {
+			return ErrNotGroupMember
+		}
+		operator = member
+	default:
+		return errors.Join(ErrInvalidControlCommand, ErrInvalidTargetType)
+	}
+
+	switch c := cmd.Command.(type) {
+	case *im_v1.ControlCommand_Recall:
+		if c.Recall.MsgId == "" {
+			return errors.Join(ErrInvalidControlCommand, errors.New("recall msg id is empty"))
+		}
+	case *im_v1.ControlCommand_Edit:
+		if c.Edit.MsgId == "" || len(c.Edit.Content) == 0 {
+			return errors.Join(ErrInvalidControlCommand, errors.New("edit msg id and content are required"))
+		}
+		_, content, err := h.normalizeContent(ctx, c.Edit.Content, operator, ErrInvalidControlCommand)
+		if err != nil {
+			return err
+		}
+		c.Edit.Content = content
+	case *im_v1.ControlCommand_ReadReceipt:
+		return validateReceipt(c.ReadReceipt)
+	case *im_v1.ControlCommand_DeliveryReceipt:
+		return validateReceipt(c.DeliveryReceipt)
+	case *im_v1.ControlCommand_Typing, *im_v1.ControlCommand_Pin:
+	case *im_v1.ControlCommand_Mute:
+		if c.Mute.MuteUntil < 0 {
+			return errors.Join(ErrInvalidControlCommand, errors.New("mute until must not be negative"))
+		}
+	default:
+		return errors.Join(ErrInvalidControlCommand, errors.New("command is empty"))
+	}
+	return nil
+}
+
+func validateReceipt(receipt *im_v1.ReceiptCommand) error {
+	if receipt == nil || len(receipt.MsgIds) == 0 {
+		return errors.Join(ErrInvalidControlCommand, errors.New("receipt msg ids is empty"))
+	}
+	if len(receipt.MsgIds) > bo.MaxReceiptMsgIds || len(receipt.ContentIds) > bo.MaxReceiptMsgIds {
+		return errors.Join(ErrInvalidControlCommand, errors.New("too many receipt msg ids"))
+	}
+	return nil
+}
+
+// conversationRecipients 计算会话中需要接收消息的用户，不包含操作者自身
+func (h *UserMessageHandler) conversationRecipients(ctx context.Context, conversationType im_v1.TargetType, conversationId, operatorId string) ([]string, error) {
+	if conversationType == im_v1.TargetType_USER {
+		return []string{conversationId}, nil
+	}
+	members, err := h.groupRepo.ListMembers(ctx, conversationId)
+	if err != nil {
+		h.log.WithContext(ctx).Errorf("failed to list group members. groupId=%s, error=%v", conversationId, err)
+		return nil, err
+	}
+	recipients := make([]string, 0, len(members))
+	for _, member := range members {
+		if member.UserId != operatorId {
+			recipients = append(recipients, member.UserId)
+		}
+	}
+	return recipients, nil
+}
+
+// routeControl 通过push服务把指令下发给接收者
+// 单聊时会话ID改写为发起者，保证接收方看到的是自己视角的会话
+func (h *UserMessageHandler) routeControl(ctx context.Context, cmd *im_v1.ControlCommand, recipients []string, transient bool) error {
+	if len(recipients) == 0 {
+		return nil
+	}
+	out := proto.Clone(cmd).(*im_v1.ControlCommand)
+	if out.ConversationType == im_v1.TargetType_USER {
+		out.ConversationId = cmd.OperatorId
+	}
+	content, err := protojson.Marshal(out)
+	if err != nil {
+		return errors.Join(err, errors.New("failed to marshal control command"))
+	}
+	template := &bo.Message{
+		MessageType: bo.MessageType_CONTROL,
+		FromUserId:  cmd.OperatorId,
+		TargetType:  bo.TargetType(cmd.ConversationType),
+		Content:     content,
+		Timestamp:   time.Now().Unix(),
+		Transient:   transient,
+		Priority:    im_v1.Priority_PRIORITY_HIGH,
+	}
+	if cmd.ConversationType == im_v1.TargetType_GROUP {
+		template.GroupId = cmd.ConversationId
+	}
+	if err = h.deliver(ctx, template, recipients); err != nil {
+		h.log.WithContext(ctx).Errorf("failed to route control command. operator=%s, error=%v", cmd.OperatorId, err)
+		return err
+	}
+	h.log.WithContext(ctx).Debugf("Routed control command. operator=%s, conversation=%s, recipients=%d",
+		cmd.OperatorId, cmd.ConversationId, len(recipients))
+	return nil
+}
+
+// deliver 按模板为每个接收者生成独立消息ID，分批调用push服务下发
+func (h *UserMessageHandler) deliver(ctx context.Context, template *bo.Message, recipients []string) error {
+	for start := 0; start < len(recipients); start += bo.MaxTargetUsers {
+		batch := recipients[start:min(start+bo.MaxTargetUsers, len(recipients))]
+		messages := make([]*bo.Message, 0, len(batch))
+		for _, userId := range batch {
+			msgId, err := h.sonyFlake.GenerateBase62()
+			if err != nil {
+				return errors.Join(err, errors.New("failed to generate message id"))
+			}
+			msg := *template
+			msg.MsgId = "msg" + msgId
+			msg.ToUserId = userId
+			messages = append(messages, &msg)
+		}
+		taskId, err := h.sonyFlake.GenerateBase62()
+		if err != nil {
+			return errors.Join(err, errors.New("failed to generate task id"))
+		}
+		if err = h.pushClient.SendMessage(ctx, "task"+taskId, messages); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package biz
+
+import (
+	"context"
+
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+
+	"github.com/xinghe903/chatify/pkg/auth"
+
+	im_v1 "github.com/xinghe903/chatify/api/im/v1"
+	v1 "github.com/xinghe903/chatify/api/logic/v1"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// ReadStateRepo 已读位置和未读数仓库接口
+type ReadStateRepo interface {
+	// AdvanceCursor 已读位置只前进不后退，返回是否发生了更新
+	AdvanceCursor(ctx context.Context, cursor *bo.ReadCursor) (bool, error)
+	// SetUnread 用重新统计的结果覆盖未读数
+	SetUnread(ctx context.Context, userId string, conversationType im_v1.TargetType, conversationId string, unread int64) error
+	// ListUnread 查询用户所有未读数大于0的会话
+	ListUnread(ctx context.Context, userId string) ([]*bo.UnreadCount, error)
+}
+
+// Conversation 会话相关的查询
+type Conversation struct {
+	log              *log.Helper
+	readStateRepo    ReadStateRepo
+	conversationRepo ConversationRepo
+	chatRepo         ChatRepo
+	groupRepo        GroupRepo
+}
+
+// NewConversation 创建会话业务实例
+func NewConversation(
+	logger log.Logger,
+	readStateRepo ReadStateRepo,
+	conversationRepo ConversationRepo,
+	chatRepo ChatRepo,
+	groupRepo GroupRepo,
+) *Conversation {
+	return &Conversation{
+		log:              log.NewHelper(logger),
+		readStateRepo:    readStateRepo,
+		conversationRepo: conversationRepo,
+		chatRepo:         chatRepo,
+		groupRepo:        groupRepo,
+	}
+}
+
+// ListConversations 分页查询当前登录用户的会话列表
+func (c *Conversation) ListConversations(ctx context.Context, page, pageSize int) ([]*bo.Conversation, int64, error) {
+	userId := auth.GetUserID(ctx)
+	if userId == "" {
+		return nil, 0, v1.ErrorUnauthenticated("user id not found in context")
+	}
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = bo.DefaultConversationPageSize
+	}
+	pageSize = min(pageSize, bo.MaxConversationPageSize)
+	conversations, total, err := c.conversationRepo.ListConversations(ctx, userId, (page-1)*pageSize, pageSize)
+	if err != nil {
+		c.log.WithContext(ctx).Errorf("failed to list conversations. userId=%s, error=%v", userId, err)
+		return nil, 0, v1.ErrorInternalError("failed to list conversations")
+	}
+	return conversations, total, nil
+}
+
+// GetUnreadCount 查询当前登录用户的未读总数和各会话未读数
+func (c *Conversation) GetUnreadCount(ctx context.Context) (int64, []*bo.UnreadCount, error) {
+	userId := auth.GetUserID(ctx)
+	if userId == "" {
+		return 0, nil, v1.ErrorUnauthenticated("user id not found in context")
+	}
+	counts, err := c.readStateRepo.ListUnread(ctx, userId)
+	if err != nil {
+		c.log.WithContext(ctx).Errorf("failed to list unread counts. userId=%s, error=%v", userId, err)
+		return 0, nil, v1.ErrorInternalError("failed to list unread counts")
+	}
+	var total int64
+	for _, count := range counts {
+		total += count.Unread
+	}
+	return total, counts, nil
+}
+
+// GetHistory 查询当前登录用户在会话中的历史消息
+// 多查一条用于判断翻页方向上是否还有更多消息
+func (c *Conversation) GetHistory(ctx context.Context, conversationType im_v1.TargetType, conversationId, beforeMsgId, afterMsgId string, limit int) ([]*bo.ChatMessage, bool, error) {
+	userId := auth.GetUserID(ctx)
+	if userId == "" {
+		return nil, false, v1.ErrorUnauthenticated("user id not found in context")
+	}
+	if beforeMsgId != "" && afterMsgId != "" {
+		return nil, false, v1.ErrorInvalidParameter("before_msg_id and after_msg_id cannot be set at the same time")
+	}
+	if err := c.checkParticipant(ctx, userId, conversationType, conversationId); err != nil {
+		return nil, false, err
+	}
+	if limit <= 0 {
+		limit = bo.DefaultHistoryLimit
+	}
+	limit = min(limit, bo.MaxHistoryLimit)
+	query := &bo.HistoryQuery{
+		UserId:          userId,
+		ConversationKey: im_v1.ConversationKey(conversationType, userId, conversationId),
+		After:           afterMsgId != "",
+		Limit:           limit + 1,
+	}
+	if anchorId := beforeMsgId + afterMsgId; anchorId != "" {
+		anchor, err := c.chatRepo.GetMessage(ctx, anchorId)
+		if err != nil {
+			c.log.WithContext(ctx).Errorf("failed to get history anchor. msgId=%s, error=%v", anchorId, err)
+			return nil, false, v1.ErrorInternalError("failed to get history anchor")
+		}
+		if anchor == nil || anchor.ConversationKey() != query.ConversationKey {
+			return nil, false, v1.ErrorInvalidParameter("anchor message %s not found in conversation", anchorId)
+		}
+		query.Anchor = anchor
+	}
+	messages, err := c.chatRepo.ListHistory(ctx, query)
+	if err != nil {
+		c.log.WithContext(ctx).Errorf("failed to list history. userId=%s, error=%v", userId, err)
+		return nil, false, v1.ErrorInternalError("failed to list history")
+	}
+	hasMore := len(messages) > limit
+	if hasMore {
+		// 去掉远离游标的一条
+		if query.After {
+			messages = messages[:limit]
+		} else {
+			messages = messages[1:]
+		}
+	}
+	return messages, hasMore, nil
+}
+
+// SyncSince 查询当前登录用户在会话中序号大于sinceSeq的消息，用于客户端补齐缺失的消息
+func (c *Conversation) SyncSince(ctx context.Context, conversationType im_v1.TargetType, conversationId string, sinceSeq int64, limit int) ([]*bo.ChatMessage, bool, error) {
+	userId := auth.GetUserID(ctx)
+	if userId == "" {
+		return nil, false, v1.ErrorUnauthenticated("user id not found in context")
+	}
+	if sinceSeq < 0 {
+		return nil, false, v1.ErrorInvalidParameter("since_seq must not be negative")
+	}
+	if err := c.checkParticipant(ctx, userId, conversationType, conversationId); err != nil {
+		return nil, false, err
+	}
+	if limit <= 0 {
+		limit = bo.DefaultHistoryLimit
+	}
+	limit = min(limit, bo.MaxHistoryLimit)
+	conversationKey := im_v1.ConversationKey(conversationType, userId, conversationId)
+	messages, err := c.chatRepo.ListSince(ctx, userId, conversationKey, sinceSeq, limit+1)
+	if err != nil {
+		c.log.WithContext(ctx).Errorf("failed to sync messages. userId=%s, error=%v", userId, err)
+		return nil, false, v1.ErrorInternalError("failed to sync messages")
+	}
+	hasMore := len(messages) > limit
+	if hasMore {
+		messages = messages[:limit]
+	}
+	return messages, hasMore, nil
+}
+
+// DeleteHistoryMessages 删除当前登录用户的历史消息，其他会话成员不受影响
+func (c *Conversation) DeleteHistoryMessages(ctx context.Context, msgIds []string) error {
+	userId := auth.GetUserID(ctx)
+	if userId == "" {
+		return v1.ErrorUnauthenticated("user id not found in context")
+	}
+	if len(msgIds) == 0 || len(msgIds) > bo.MaxDeleteHistoryMsgIds {
+		return v1.ErrorInvalidParameter("msg_ids size must be between 1 and %d", bo.MaxDeleteHistoryMsgIds)
+	}
+	if err := c.chatRepo.DeleteForUser(ctx, userId, msgIds); err != nil {
+		c.log.WithContext(ctx).Errorf("failed to delete history. userId=%s, error=%v", userId, err)
+		return v1.ErrorInternalError("failed to delete history")
+	}
+	return nil
+}
+
+// checkParticipant 校验用户是否为会话成员
+func (c *Conversation) checkParticipant(ctx context.Context, userId string, conversationType im_v1.TargetType, conversationId string) error {
+	if conversationId == "" {
+		return v1.ErrorInvalidParameter("conversation id is empty")
+	}
+	switch conversationType {
+	case im_v1.TargetType_USER:
+		if conversationId == userId {
+			return v1.ErrorInvalidParameter("conversation id is the user itself")
+		}
+		return nil
+	case im_v1.TargetType_GROUP:
+		member, err := c.groupRepo.GetMember(ctx, conversationId, userId)
+		if err != nil {
+			c.log.WithContext(ctx).Errorf("failed to get group member. groupId=%s, error=%v", conversationId, err)
+			return v1.ErrorInternalError("failed to get group member")
+		}
+		if member == nil {
+			return v1.ErrorConversationForbidden("user %s is not a member of group %s", userId, conversationId)
+		}
+		return nil
+	default:
+		return v1.ErrorInvalidParameter("invalid conversation type %s", conversationType)
+	}
+}
@@ -0,0 +1,248 @@
+package biz
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+
+	im_v1 "github.com/xinghe903/chatify/api/im/v1"
+	v1 "github.com/xinghe903/chatify/api/logic/v1"
+
+	"github.com/xinghe903/chatify/pkg/auth"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+var (
+	ErrInvalidDataReport  = errors.New("invalid data report")
+	ErrReportUserMismatch = errors.New("report user id does not match sender")
+)
+
+// DataReportConsumer 数据上报消费者，与用户消息消费者区分不同的topic
+type DataReportConsumer interface {
+	Consumer
+}
+
+// ReportSchemaRepo 数据上报结构仓库接口
+type ReportSchemaRepo interface {
+	// CreateSchema 保存结构的新版本，版本号为同名结构的最大版本加1，写回schema.Version
+	CreateSchema(ctx context.Context, schema *bo.ReportSchema) error
+	// GetSchema 查询结构，version为0时返回最新版本，不存在时返回nil
+	GetSchema(ctx context.Context, name string, version int32) (*bo.ReportSchema, error)
+	// ListSchemas 按名称和版本正序查询结构，name为空时返回全部
+	ListSchemas(ctx context.Context, name string) ([]*bo.ReportSchema, error)
+}
+
+// DataReportRepo 数据上报存储接口，只追加写入
+type DataReportRepo interface {
+	// AppendReports 追加写入上报，同一用户report_id已存在的上报忽略
+	AppendReports(ctx context.Context, userId string, reports []*bo.DataReport) error
+	// ListReports 按上报时间和report_id正序查询
+	ListReports(ctx context.Context, query *bo.DataReportQuery) ([]*bo.StoredDataReport, error)
+}
+
+// Reporter 校验客户端数据上报并写入上报存储
+type Reporter struct {
+	log        *log.Helper
+	consumer   DataReportConsumer
+	mqProducer MqProducer
+	schemaRepo ReportSchemaRepo
+	reportRepo DataReportRepo
+	sonyFlake  *auth.Sonyflake
+	// schemas 已注册的结构版本不可修改，按 name:version 缓存
+	schemas sync.Map
+}
+
+// NewReporter 创建数据上报处理并启动上报存储的消费
+func NewReporter(
+	logger log.Logger,
+	consumer DataReportConsumer,
+	mqProducer MqProducer,
+	schemaRepo ReportSchemaRepo,
+	reportRepo DataReportRepo,
+) (*Reporter, func()) {
+	r := &Reporter{
+		log:        log.NewHelper(logger),
+		consumer:   consumer,
+		mqProducer: mqProducer,
+		schemaRepo: schemaRepo,
+		reportRepo: reportRepo,
+		sonyFlake:  auth.NewSonyflake(),
+	}
+	ctx, cancel := context.WithCancelCause(context.TODO())
+	r.consumer.Start(ctx, nil, r.HandleReports())
+	return r, func() { cancel(errors.New("data report consumer context canceled")) }
+}
+
+// Ingest 校验客户端上报并投递到上报存储，不合法的上报单独丢弃，全部不合法时返回错误
+func (r *Reporter) Ingest(ctx context.Context, baseMsg *im_v1.BaseMessage) error {
+	batch, err := bo.ParseDataReportBatch(baseMsg.Content)
+	if err != nil {
+		return errors.Join(ErrInvalidDataReport, err)
+	}
+	if batch.UserId == "" {
+		batch.UserId = baseMsg.FromUserId
+	}
+	if batch.UserId == "" || batch.UserId != baseMsg.FromUserId {
+		r.log.WithContext(ctx).Warnf("reject data report from %s with user id %s. msgId=%s", baseMsg.FromUserId, batch.UserId, baseMsg.MsgId)
+		return ErrReportUserMismatch
+	}
+	if len(batch.Reports) == 0 || len(batch.Reports) > bo.MaxReportBatchSize {
+		return fmt.Errorf("%w: report count must be between 1 and %d", ErrInvalidDataReport, bo.MaxReportBatchSize)
+	}
+	// 重复消费的上报使用相同的report_id，存储时忽略
+	batch.BatchId = baseMsg.MsgId
+	if batch.BatchId == "" {
+		id, err := r.sonyFlake.GenerateBase62()
+		if err != nil {
+			return errors.Join(err, errors.New("failed to generate report batch id"))
+		}
+		batch.BatchId = "report" + id
+	}
+	now := time.Now()
+	valid := make([]*bo.DataReport, 0, len(batch.Reports))
+	for i, report := range batch.Reports {
+		if report == nil {
+			continue
+		}
+		if err = r.validate(ctx, report, now); err != nil {
+			if !errors.Is(err, ErrInvalidDataReport) {
+				return err
+			}
+			r.log.WithContext(ctx).Warnf("drop invalid data report. msgId=%s, index=%d, schema=%s, error=%v", baseMsg.MsgId, i, report.Schema, err)
+			continue
+		}
+		report.ReportId = batch.BatchId + "-" + strconv.Itoa(i)
+		valid = append(valid, report)
+	}
+	if len(valid) == 0 {
+		return fmt.Errorf("%w: no valid report in message %s", ErrInvalidDataReport, baseMsg.MsgId)
+	}
+	batch.Reports = valid
+	if err = r.mqProducer.SendMessageWithDataReport(ctx, batch); err != nil {
+		r.log.WithContext(ctx).Errorf("send data report message error: %v", err)
+		return err
+	}
+	r.log.WithContext(ctx).Debugf("Receive data report message. msgId=%s, userId=%s, reports=%d", baseMsg.MsgId, batch.UserId, len(valid))
+	return nil
+}
+
+// validate 校验单条上报并固定使用的结构版本，结构查询失败时返回可重试的错误
+func (r *Reporter) validate(ctx context.Context, report *bo.DataReport, now time.Time) error {
+	if err := report.Validate(now); err != nil {
+		return errors.Join(ErrInvalidDataReport, err)
+	}
+	schema, err := r.getSchema(ctx, report.Schema, report.Version)
+	if err != nil {
+		return err
+	}
+	if schema == nil {
+		return fmt.Errorf("%w: schema %s version %d not registered", ErrInvalidDataReport, report.Schema, report.Version)
+	}
+	if err = schema.Check(report.Data); err != nil {
+		return errors.Join(ErrInvalidDataReport, err)
+	}
+	report.Version = schema.Version
+	return nil
+}
+
+// getSchema 查询结构，指定版本的结构会被缓存，最新版本每次查询
+func (r *Reporter) getSchema(ctx context.Context, name string, version int32) (*bo.ReportSchema, error) {
+	key := name + ":" + strconv.Itoa(int(version))
+	if cached, ok := r.schemas.Load(key); ok {
+		return cached.(*bo.ReportSchema), nil
+	}
+	schema, err := r.schemaRepo.GetSchema(ctx, name, version)
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("failed to get report schema. name=%s, version=%d, error=%v", name, version, err)
+		return nil, err
+	}
+	if schema != nil && version > 0 {
+		r.schemas.Store(key, schema)
+	}
+	return schema, nil
+}
+
+// HandleReports 把校验后的上报追加写入上报存储
+func (r *Reporter) HandleReports() MessageHandler {
+	return func(ctx context.Context, key string, value []byte) error {
+		var batch bo.DataReportBatch
+		if err := json.Unmarshal(value, &batch); err != nil {
+			r.log.WithContext(ctx).Errorf("data report json unmarshal error: %v", err)
+			return errors.Join(ErrNonRetryable, err)
+		}
+		if batch.UserId == "" || len(batch.Reports) == 0 {
+			return nil
+		}
+		if err := r.reportRepo.AppendReports(ctx, batch.UserId, batch.Reports); err != nil {
+			r.log.WithContext(ctx).Errorf("failed to append data reports. batchId=%s, error=%v", batch.BatchId, err)
+			return err
+		}
+		return nil
+	}
+}
+
+// RegisterReportSchema 校验并注册上报结构的新版本
+func (r *Reporter) RegisterReportSchema(ctx context.Context, schema *bo.ReportSchema) (*bo.ReportSchema, error) {
+	if err := schema.Validate(); err != nil {
+		return nil, v1.ErrorInvalidParameter("invalid report schema: %v", err)
+	}
+	if err := r.schemaRepo.CreateSchema(ctx, schema); err != nil {
+		r.log.WithContext(ctx).Errorf("failed to create report schema. name=%s, error=%v", schema.Name, err)
+		return nil, v1.ErrorInternalError("failed to create report schema")
+	}
+	r.log.WithContext(ctx).Infof("Registered report schema. name=%s, version=%d, fields=%d", schema.Name, schema.Version, len(schema.Fields))
+	return schema, nil
+}
+
+// ListReportSchemas 查询上报结构的全部版本
+func (r *Reporter) ListReportSchemas(ctx context.Context, name string) ([]*bo.ReportSchema, error) {
+	schemas, err := r.schemaRepo.ListSchemas(ctx, name)
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("failed to list report schemas. name=%s, error=%v", name, err)
+		return nil, v1.ErrorInternalError("failed to list report schemas")
+	}
+	return schemas, nil
+}
+
+// QueryDataReports 按用户和时间范围分页查询上报，返回下一页游标
+func (r *Reporter) QueryDataReports(ctx context.Context, query *bo.DataReportQuery, pageToken string) ([]*bo.StoredDataReport, string, error) {
+	if query.UserId == "" {
+		return nil, "", v1.ErrorInvalidParameter("user id is empty")
+	}
+	if query.EndTime <= 0 {
+		query.EndTime = time.Now().Unix() + 1
+	}
+	if query.StartTime < 0 || query.StartTime >= query.EndTime {
+		return nil, "", v1.ErrorInvalidParameter("start_time must be earlier than end_time")
+	}
+	if time.Duration(query.EndTime-query.StartTime)*time.Second > bo.MaxReportQueryRange {
+		return nil, "", v1.ErrorInvalidParameter("time range must not exceed %s", bo.MaxReportQueryRange)
+	}
+	if pageToken != "" {
+		var err error
+		if query.AfterTimestamp, query.AfterReportId, err = bo.DecodeReportPageToken(pageToken); err != nil {
+			return nil, "", v1.ErrorInvalidParameter("%v", err)
+		}
+	}
+	if query.Limit <= 0 {
+		query.Limit = bo.DefaultReportPageSize
+	}
+	query.Limit = min(query.Limit, bo.MaxReportPageSize)
+	reports, err := r.reportRepo.ListReports(ctx, query)
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("failed to list data reports. userId=%s, error=%v", query.UserId, err)
+		return nil, "", v1.ErrorInternalError("failed to list data reports")
+	}
+	nextPageToken := ""
+	if len(reports) == query.Limit {
+		nextPageToken = bo.EncodeReportPageToken(reports[len(reports)-1])
+	}
+	return reports, nextPageToken, nil
+}
@@ -0,0 +1,188 @@
+package biz
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+
+	v1 "github.com/xinghe903/chatify/api/logic/v1"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// FunnelConsumer 送达漏斗事件消费者，与用户消息消费者区分不同的topic
+type FunnelConsumer interface {
+	Consumer
+}
+
+// FunnelRepo 送达漏斗计数仓库接口
+type FunnelRepo interface {
+	// AddCounts 累加时间段计数
+	AddCounts(ctx context.Context, counts []*bo.FunnelCount) error
+	// ListCounts 查询内容在[start, end)之间的时间段计数
+	ListCounts(ctx context.Context, contentId string, start, end int64) ([]*bo.FunnelCount, error)
+	// MarkReceipts 标记回执已统计，返回与events一一对应的结果，false表示该用户的同类回执已统计过
+	MarkReceipts(ctx context.Context, events []*bo.DeliveryEvent) ([]bool, error)
+	// UnmarkReceipts 清除回执标记，计数写入失败重试时可以再次统计
+	UnmarkReceipts(ctx context.Context, events []*bo.DeliveryEvent) error
+}
+
+// Funnel 按content_id汇总系统推送的送达漏斗
+type Funnel struct {
+	log       *log.Helper
+	consumer  FunnelConsumer
+	repo      FunnelRepo
+	dedupRepo MessageDedupRepo
+}
+
+// NewFunnel 创建送达漏斗并启动事件消费
+func NewFunnel(
+	logger log.Logger,
+	consumer FunnelConsumer,
+	repo FunnelRepo,
+	dedupRepo MessageDedupRepo,
+) (*Funnel, func()) {
+	f := &Funnel{
+		log:       log.NewHelper(logger),
+		consumer:  consumer,
+		repo:      repo,
+		dedupRepo: dedupRepo,
+	}
+	ctx, cancel := context.WithCancelCause(context.TODO())
+	f.consumer.Start(ctx, nil, f.HandleEvents())
+	return f, func() { cancel(errors.New("funnel consumer context canceled")) }
+}
+
+// HandleEvents 处理漏斗事件，回执按用户去重后与push的汇总计数一起写入时间段计数
+func (f *Funnel) HandleEvents() MessageHandler {
+	return func(ctx context.Context, key string, value []byte) error {
+		var batch bo.DeliveryEventBatch
+		if err := json.Unmarshal(value, &batch); err != nil {
+			f.log.WithContext(ctx).Errorf("delivery event json unmarshal error: %v", err)
+			return errors.Join(ErrNonRetryable, err)
+		}
+		if batch.EventId != "" {
+			isNew, err := f.dedupRepo.CheckAndSetDedup(ctx, bo.FunnelEventDedupPrefix+batch.EventId)
+			if err != nil {
+				f.log.WithContext(ctx).Errorf("failed to check delivery event dedup. eventId=%s, error=%v", batch.EventId, err)
+			} else if !isNew {
+				return ErrMessageDuplicate
+			}
+		}
+		var counted, receipts []*bo.DeliveryEvent
+		for _, event := range batch.Events {
+			if event == nil || event.ContentId == "" || event.Count <= 0 || !bo.IsFunnelStage(event.Stage) {
+				continue
+			}
+			if event.UserId != "" {
+				receipts = append(receipts, event)
+				continue
+			}
+			counted = append(counted, event)
+		}
+		var marked []*bo.DeliveryEvent
+		if len(receipts) > 0 {
+			isNew, err := f.repo.MarkReceipts(ctx, receipts)
+			if err != nil {
+				f.release(ctx, batch.EventId, nil)
+				return err
+			}
+			for i, event := range receipts {
+				if isNew[i] {
+					marked = append(marked, event)
+				}
+			}
+			counted = append(counted, marked...)
+		}
+		if len(counted) == 0 {
+			return nil
+		}
+		if err := f.repo.AddCounts(ctx, bo.MergeFunnelCounts(counted)); err != nil {
+			f.log.WithContext(ctx).Errorf("failed to add funnel counts. eventId=%s, error=%v", batch.EventId, err)
+			f.release(ctx, batch.EventId, marked)
+			return err
+		}
+		return nil
+	}
+}
+
+// release 计数失败时清除去重标记，重试时重新统计
+func (f *Funnel) release(ctx context.Context, eventId string, receipts []*bo.DeliveryEvent) {
+	if eventId != "" {
+		if err := f.dedupRepo.ReleaseDedup(ctx, bo.FunnelEventDedupPrefix+eventId); err != nil {
+			f.log.WithContext(ctx).Errorf("failed to release delivery event dedup. eventId=%s, error=%v", eventId, err)
+		}
+	}
+	if len(receipts) > 0 {
+		if err := f.repo.UnmarkReceipts(ctx, receipts); err != nil {
+			f.log.WithContext(ctx).Errorf("failed to unmark receipts. eventId=%s, error=%v", eventId, err)
+		}
+	}
+}
+
+// GetDeliveryFunnel 查询内容在[start, end)之间的漏斗计数，按granularity汇总
+func (f *Funnel) GetDeliveryFunnel(ctx context.Context, contentId string, start, end int64, granularity time.Duration) (*bo.FunnelCounts, []*bo.FunnelBucket, error) {
+	if contentId == "" {
+		return nil, nil, v1.ErrorInvalidParameter("content id is empty")
+	}
+	if end <= 0 {
+		end = time.Now().Unix() + 1
+	}
+	if start < 0 || start >= end {
+		return nil, nil, v1.ErrorInvalidParameter("start_time must be earlier than end_time")
+	}
+	if start > 0 && time.Duration(end-start)*time.Second > bo.MaxFunnelQueryRange {
+		return nil, nil, v1.ErrorInvalidParameter("time range must not exceed %s", bo.MaxFunnelQueryRange)
+	}
+	// 存储时间段按开始时间查询，包含start所在的时间段
+	counts, err := f.repo.ListCounts(ctx, contentId, bo.FunnelBucketStart(start), end)
+	if err != nil {
+		f.log.WithContext(ctx).Errorf("failed to list funnel counts. contentId=%s, error=%v", contentId, err)
+		return nil, nil, v1.ErrorInternalError("failed to list funnel counts")
+	}
+	total, buckets := bo.AggregateFunnel(counts, granularity)
+	return total, buckets, nil
+}
+
+// newReceiptEvents 为系统推送的回执生成漏斗事件，非系统推送的content_id忽略
+func newReceiptEvents(stage bo.FunnelStage, userId string, contentIds []string) []*bo.DeliveryEvent {
+	now := time.Now().Unix()
+	events := make([]*bo.DeliveryEvent, 0, len(contentIds))
+	for _, contentId := range contentIds {
+		if !strings.HasPrefix(contentId, bo.SystemContentIdPrefix) {
+			continue
+		}
+		events = append(events, &bo.DeliveryEvent{
+			ContentId: contentId,
+			Stage:     stage,
+			Count:     1,
+			UserId:    userId,
+			Timestamp: now,
+		})
+	}
+	return events
+}
+
+// newTargetedEvent 提交给push的消息数
+func newTargetedEvent(contentId string, count int) *bo.DeliveryEvent {
+	return &bo.DeliveryEvent{
+		ContentId: contentId,
+		Stage:     bo.FunnelStageTargeted,
+		Count:     int64(count),
+		Timestamp: time.Now().Unix(),
+	}
+}
+
+// sendDeliveryEvents 发送漏斗事件，统计数据丢失不影响消息投递，失败时只记录日志
+func sendDeliveryEvents(ctx context.Context, producer MqProducer, logger *log.Helper, events ...*bo.DeliveryEvent) {
+	if len(events) == 0 {
+		return
+	}
+	if err := producer.SendMessageWithDeliveryEvents(ctx, events); err != nil {
+		logger.WithContext(ctx).Warnf("failed to send delivery events. size=%d, error=%v", len(events), err)
+	}
+}
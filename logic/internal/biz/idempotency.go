@@ -0,0 +1,96 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"unicode/utf8"
+
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+
+	"github.com/xinghe903/chatify/pkg/auth/sign"
+
+	v1 "github.com/xinghe903/chatify/api/logic/v1"
+)
+
+// IdempotencyRepo 幂等请求仓库接口，在窗口内保存首次请求的处理结果
+type IdempotencyRepo interface {
+	// Verify 校验幂等键的签名和时间戳，返回sign包定义的错误
+	Verify(ctx context.Context, key string, timestamp int64, signature string) error
+	// Claim 以处理中状态短时占用幂等键，占用成功返回nil，键已存在时返回已保存的记录
+	Claim(ctx context.Context, requestId string, record *bo.IdempotencyRecord) (*bo.IdempotencyRecord, error)
+	// Complete 保存处理结果，有效期延长到整个幂等窗口
+	Complete(ctx context.Context, requestId string, record *bo.IdempotencyRecord) error
+	// Release 删除幂等键，处理失败且没有发送任何消息时允许客户端重试
+	Release(ctx context.Context, requestId string) error
+}
+
+// sendSystemPushOnce 按幂等键发送系统推送，窗口内的重复请求返回首次请求的结果
+func (l *Logic) sendSystemPushOnce(ctx context.Context, req *v1.SystemPushRequest) (*v1.SystemPushResponse, error) {
+	idem := req.Idempotency
+	if idem.Key == "" || utf8.RuneCountInString(idem.Key) > bo.MaxIdempotencyKeyLength {
+		return nil, v1.ErrorInvalidParameter("idempotency key must be 1-%d characters", bo.MaxIdempotencyKeyLength)
+	}
+	if err := l.idempotencyRepo.Verify(ctx, idem.Key, idem.Timestamp, idem.Signature); err != nil {
+		switch {
+		case errors.Is(err, sign.ErrInvalidSign):
+			return nil, v1.ErrorPermissionDenied("invalid idempotency signature")
+		case errors.Is(err, sign.ErrRequestExpired):
+			return nil, v1.ErrorMessageExpired("idempotency timestamp is out of the allowed window")
+		default:
+			l.log.WithContext(ctx).Errorf("failed to verify idempotency key: %v", err)
+			return nil, v1.ErrorInternalError("failed to verify idempotency key")
+		}
+	}
+	fingerprint, err := bo.SystemPushFingerprint(req)
+	if err != nil {
+		l.log.WithContext(ctx).Errorf("failed to compute request fingerprint: %v", err)
+		return nil, v1.ErrorInternalError("failed to compute request fingerprint")
+	}
+
+	requestId := bo.SystemPushIdempotencyKey(req.FromUserId, idem.Key)
+	existing, err := l.idempotencyRepo.Claim(ctx, requestId, &bo.IdempotencyRecord{Fingerprint: fingerprint})
+	if err != nil {
+		l.log.WithContext(ctx).Errorf("failed to claim idempotency key. key=%s, error=%v", idem.Key, err)
+		return nil, v1.ErrorInternalError("failed to claim idempotency key")
+	}
+	if existing != nil {
+		if existing.Fingerprint != fingerprint {
+			return nil, v1.ErrorInvalidParameter("idempotency key %q was used for a different request", idem.Key)
+		}
+		if !existing.Done {
+			return nil, v1.ErrorMessageRepeat("request with idempotency key %q is in progress", idem.Key)
+		}
+		l.log.WithContext(ctx).Infof("Return stored result for duplicate system push. key=%s, taskID=%s", idem.Key, existing.TaskId)
+		return &v1.SystemPushResponse{
+			ScheduleId:  existing.ScheduleId,
+			TaskId:      existing.TaskId,
+			ContentId:   existing.ContentId,
+			Replayed:    true,
+			SentUsers:   existing.SentUsers,
+			FailedUsers: existing.FailedUsers,
+		}, nil
+	}
+
+	resp, err := l.sendSystemPush(ctx, req)
+	if err != nil {
+		// 分批发送时只要有批次发送成功就返回部分成功的结果，返回错误时没有发送任何消息，可以释放幂等键重试
+		if releaseErr := l.idempotencyRepo.Release(ctx, requestId); releaseErr != nil {
+			l.log.WithContext(ctx).Errorf("failed to release idempotency key. key=%s, error=%v", idem.Key, releaseErr)
+		}
+		return nil, err
+	}
+	err = l.idempotencyRepo.Complete(ctx, requestId, &bo.IdempotencyRecord{
+		Fingerprint: fingerprint,
+		Done:        true,
+		ScheduleId:  resp.ScheduleId,
+		TaskId:      resp.TaskId,
+		ContentId:   resp.ContentId,
+		SentUsers:   resp.SentUsers,
+		FailedUsers: resp.FailedUsers,
+	})
+	if err != nil {
+		// 推送已发送，保存失败只记录日志，窗口内的重试会得到处理中的错误
+		l.log.WithContext(ctx).Errorf("failed to save idempotency result. key=%s, error=%v", idem.Key, err)
+	}
+	return resp, nil
+}
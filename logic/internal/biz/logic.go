@@ -2,6 +2,8 @@ package biz
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/xinghe903/chatify/logic/internal/conf"
 
@@ -18,24 +20,67 @@ type PushRepo interface {
 	SendMessage(ctx context.Context, taskId string, message []*bo.Message) error
 }
 
+// ScheduleRepo 定时推送计划仓库接口
+type ScheduleRepo interface {
+	CreateSchedule(ctx context.Context, schedule *bo.PushSchedule) error
+	// ListSchedules 分页查询计划，status为空时查询全部
+	ListSchedules(ctx context.Context, status bo.PushScheduleStatus, offset, limit int) ([]*bo.PushSchedule, int64, error)
+	// CancelSchedule 取消待执行的计划，返回是否取消成功
+	CancelSchedule(ctx context.Context, scheduleId string) (bool, error)
+	// GetSchedule 查询计划，不存在时返回nil
+	GetSchedule(ctx context.Context, scheduleId string) (*bo.PushSchedule, error)
+	// ListDueSchedules 查询到期的待执行计划
+	ListDueSchedules(ctx context.Context, now int64, limit int) ([]*bo.PushSchedule, error)
+	// ClaimSchedule 仅当计划仍为待执行且下次执行时间等于prevFireAt时，写入新的执行进度
+	// 并在同一事务中保存prevFireAt周期的待发送记录，返回是否抢占成功，用于保证同一周期只发送一次
+	ClaimSchedule(ctx context.Context, schedule *bo.PushSchedule, prevFireAt int64) (bool, error)
+	// ListStaleFires 查询早于staleBefore抢占且仍未记录结果的周期
+	ListStaleFires(ctx context.Context, staleBefore time.Time, limit int) ([]*bo.PushScheduleFire, error)
+	// ClaimStaleFire 仅当周期仍待发送且早于staleBefore抢占时续期，返回是否抢占成功
+	ClaimStaleFire(ctx context.Context, fire *bo.PushScheduleFire, staleBefore time.Time) (bool, error)
+	// RecordFireResult 记录周期的发送结果，同时作为计划最近一次的发送结果
+	RecordFireResult(ctx context.Context, fire *bo.PushScheduleFire) error
+}
+
 type Logic struct {
-	log        *log.Helper
-	pushClient PushRepo
-	config     *conf.Bootstrap
-	sonyFlake  *auth.Sonyflake
+	log             *log.Helper
+	pushClient      PushRepo
+	mqProducer      MqProducer
+	scheduleRepo    ScheduleRepo
+	templateRepo    PushTemplateRepo
+	localeRepo      UserLocaleRepo
+	attributeRepo   UserAttributeRepo
+	preferences     *Preferences
+	idempotencyRepo IdempotencyRepo
+	config          *conf.Bootstrap
+	sonyFlake       *auth.Sonyflake
 }
 
 // NewLogic 构造函数，通过依赖注入获取所有必要的服务
 func NewLogic(
 	logger log.Logger,
 	pushClient PushRepo,
+	mqProducer MqProducer,
+	scheduleRepo ScheduleRepo,
+	templateRepo PushTemplateRepo,
+	localeRepo UserLocaleRepo,
+	attributeRepo UserAttributeRepo,
+	preferences *Preferences,
+	idempotencyRepo IdempotencyRepo,
 	c *conf.Bootstrap,
 ) *Logic {
 	return &Logic{
-		log:        log.NewHelper(logger),
-		pushClient: pushClient,
-		config:     c,
-		sonyFlake:  auth.NewSonyflake(),
+		log:             log.NewHelper(logger),
+		pushClient:      pushClient,
+		mqProducer:      mqProducer,
+		scheduleRepo:    scheduleRepo,
+		templateRepo:    templateRepo,
+		localeRepo:      localeRepo,
+		attributeRepo:   attributeRepo,
+		preferences:     preferences,
+		idempotencyRepo: idempotencyRepo,
+		config:          c,
+		sonyFlake:       auth.NewSonyflake(),
 	}
 }
 
@@ -53,9 +98,23 @@ func NewLogic(
 // @Return error 错误
 func (l *Logic) SendSystemPush(ctx context.Context, req *v1.SystemPushRequest) (*v1.SystemPushResponse, error) {
 	l.log.WithContext(ctx).Infof("Receive system push request from %s, content_id: %s", req.FromUserId, req.ContentId)
+	if req.Idempotency != nil {
+		return l.sendSystemPushOnce(ctx, req)
+	}
+	return l.sendSystemPush(ctx, req)
+}
 
-	// 1. 检查目标用户数量是否超过限制
-	if len(req.ToUserIds) > bo.MaxTargetUsers {
+// sendSystemPush 校验并发送系统推送，定时推送只保存计划
+func (l *Logic) sendSystemPush(ctx context.Context, req *v1.SystemPushRequest) (*v1.SystemPushResponse, error) {
+	// 1. 检查目标用户数量是否超过限制，分群推送在发送时计算接收者
+	if req.TargetType == v1.PushTargetType_SEGMENT {
+		if len(req.ToUserIds) > 0 {
+			return nil, v1.ErrorInvalidParameter("to_user_ids must be empty when target type is segment")
+		}
+		if _, err := bo.ParseSegment(req.Segment); err != nil {
+			return nil, v1.ErrorInvalidParameter("invalid segment: %v", err)
+		}
+	} else if len(req.ToUserIds) > bo.MaxTargetUsers {
 		l.log.WithContext(ctx).Errorf("too many target users limit=%d, input=%d", bo.MaxTargetUsers, len(req.ToUserIds))
 		return nil, v1.ErrorTooManyTargets("too many target users limit=%d, input=%d",
 			bo.MaxTargetUsers, len(req.ToUserIds))
@@ -63,34 +122,194 @@ func (l *Logic) SendSystemPush(ctx context.Context, req *v1.SystemPushRequest) (
 
 	// 2. 进行用户校验和黑白名单过滤
 
-	// 3. 创建消息列表
-	messages := bo.NewMessagesByUserIDs(req)
+	// 3. 定时或重复推送只保存计划，由调度器到期后发送
+	if bo.IsScheduled(req, time.Now().Unix()) {
+		// 绝对过期时间必须晚于首次发送时间，时长在每次发送时重新计算
+		if _, err := bo.ParseExpireTime(req.ExpireTime, time.Unix(max(req.SendAt, time.Now().Unix()), 0)); err != nil {
+			return nil, v1.ErrorInvalidParameter("%v", err)
+		}
+		// 使用模板时提前校验模板和变量，到期后按最新的模板渲染
+		if req.TemplateId != "" {
+			if _, err := l.loadTemplate(ctx, req); err != nil {
+				return nil, err
+			}
+		}
+		scheduleId, err := l.createSchedule(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return &v1.SystemPushResponse{ScheduleId: scheduleId}, nil
+	}
 
-	// 4. 调用push服务发送消息
-	var err error
+	// 4. 立即发送
+	result, err := l.pushNow(ctx, req, "")
+	if err != nil {
+		return nil, err
+	}
+	// 5. 返回成功响应
+	return &v1.SystemPushResponse{
+		TaskId:      result.TaskId,
+		ContentId:   result.ContentId,
+		SentUsers:   result.SentUsers,
+		FailedUsers: result.FailedUsers,
+	}, nil
+}
+
+// pushNow 创建消息并调用push服务发送，没有接收者时任务ID和内容ID都为空
+// 分批发送时某一批失败后继续发送其余批次，只有全部批次都失败时返回错误，避免重试时重复发送已成功的批次
+// baseTaskId非空时各批次的任务ID由它和批次序号派生，同一周期重新发送时任务ID保持不变
+func (l *Logic) pushNow(ctx context.Context, req *v1.SystemPushRequest, baseTaskId string) (*bo.PushResult, error) {
+	expireAt, err := bo.ParseExpireTime(req.ExpireTime, time.Now())
+	if err != nil {
+		return nil, v1.ErrorInvalidParameter("%v", err)
+	}
+	if req.TargetType == v1.PushTargetType_SEGMENT {
+		if req.ToUserIds, err = l.resolveSegment(ctx, req.Segment); err != nil {
+			return nil, err
+		}
+		if len(req.ToUserIds) == 0 {
+			l.log.WithContext(ctx).Infof("Segment matches no users, skip push. Segment: %q", req.Segment)
+			return &bo.PushResult{}, nil
+		}
+	}
+	messages := bo.NewMessagesByUserIDs(req)
+	for _, message := range messages {
+		message.ExpireTime = expireAt
+	}
+	if req.TemplateId != "" {
+		if err := l.renderTemplate(ctx, req, messages); err != nil {
+			return nil, err
+		}
+	}
 	var contentId string
 	if contentId, err = l.sonyFlake.GenerateBase62(); err != nil {
 		l.log.WithContext(ctx).Errorf("failed to generate content id: %v", err)
 		return nil, v1.ErrorInternalError("failed to generate content id: %v", err)
 	}
+	result := &bo.PushResult{ContentId: bo.SystemContentIdPrefix + contentId}
+	// 退订了该类型推送的用户不再发送，处于勿扰时段的用户静默接收
+	messages, suppressions, err := l.preferences.FilterSystemPush(ctx, req.PushType, messages, time.Now())
+	if err != nil {
+		l.log.WithContext(ctx).Errorf("failed to check notification preferences: %v", err)
+		return nil, v1.ErrorInternalError("failed to check notification preferences")
+	}
+	l.preferences.RecordSuppressions(ctx, result.ContentId, suppressions)
+	if len(messages) == 0 {
+		l.log.WithContext(ctx).Infof("All target users opted out, skip push. ContentID: %s", result.ContentId)
+		return result, nil
+	}
 	for _, message := range messages {
-		message.ContentId = "content" + contentId
+		message.ContentId = result.ContentId
 		if message.MsgId, err = l.sonyFlake.GenerateBase62(); err != nil {
 			l.log.WithContext(ctx).Errorf("failed to generate message id: %v", err)
 			return nil, v1.ErrorInternalError("failed to generate message id: %v", err)
 		}
 		message.MsgId = "msg" + message.MsgId
 	}
-	var taskId string
-	if taskId, err = l.sonyFlake.GenerateBase62(); err != nil {
-		l.log.WithContext(ctx).Errorf("failed to generate task id: %v", err)
-		return nil, v1.ErrorInternalError("failed to generate task id: %v", err)
+	// 分群推送的接收者可能超过单次推送上限，按上限分批发送
+	var lastErr error
+	for start := 0; start < len(messages); start += bo.MaxTargetUsers {
+		batch := messages[start:min(start+bo.MaxTargetUsers, len(messages))]
+		var taskId string
+		if baseTaskId != "" {
+			taskId = fmt.Sprintf("%s-%d", baseTaskId, start/bo.MaxTargetUsers)
+		} else if taskId, err = l.sonyFlake.GenerateBase62(); err == nil {
+			taskId = "task" + taskId
+		}
+		if err == nil {
+			err = l.pushClient.SendMessage(ctx, taskId, batch)
+		}
+		if err != nil {
+			l.log.WithContext(ctx).Errorf("failed to send batch to push service. offset=%d, len=%d, error=%v", start, len(batch), err)
+			result.FailedUsers += int64(len(batch))
+			lastErr = err
+			continue
+		}
+		l.log.WithContext(ctx).Infof("Sent message to push service. TaskID: %s, len: %d", taskId, len(batch))
+		result.SentUsers += int64(len(batch))
+		if result.TaskId == "" {
+			result.TaskId = taskId
+		}
 	}
-	if err = l.pushClient.SendMessage(ctx, "task"+taskId, messages); err != nil {
-		l.log.WithContext(ctx).Errorf("failed to send message to push service: %v", err)
-		return nil, v1.ErrorInvokePushFailed("failed to send message to push service: %v", err)
+	if result.SentUsers > 0 {
+		sendDeliveryEvents(ctx, l.mqProducer, l.log, newTargetedEvent(result.ContentId, int(result.SentUsers)))
 	}
-	l.log.WithContext(ctx).Infof("Sent message to push service. TaskID: %s, len: %d", taskId, len(messages))
-	// 5. 返回成功响应
-	return &v1.SystemPushResponse{}, nil
+	if lastErr != nil {
+		if result.SentUsers == 0 {
+			return nil, v1.ErrorInvokePushFailed("failed to send message to push service: %v", lastErr)
+		}
+		result.LastError = lastErr.Error()
+		l.log.WithContext(ctx).Warnf("System push partially sent. ContentID: %s, sent: %d, failed: %d", result.ContentId, result.SentUsers, result.FailedUsers)
+	}
+	return result, nil
+}
+
+// createSchedule 校验重复规则并保存定时推送计划
+func (l *Logic) createSchedule(ctx context.Context, req *v1.SystemPushRequest) (string, error) {
+	if r := req.Recurrence; r != nil {
+		if r.Frequency == v1.RecurrenceFrequency_RECURRENCE_FREQUENCY_UNSPECIFIED {
+			return "", v1.ErrorInvalidParameter("recurrence frequency is required")
+		}
+		if r.Interval < 0 || r.Count < 0 {
+			return "", v1.ErrorInvalidParameter("recurrence interval and count must not be negative")
+		}
+	}
+	schedule := bo.NewPushSchedule(req, time.Now().Unix())
+	if r := req.Recurrence; r != nil && r.Until > 0 && schedule.NextFireAt > r.Until {
+		return "", v1.ErrorInvalidParameter("recurrence until is earlier than send_at")
+	}
+	var err error
+	if schedule.ScheduleId, err = l.sonyFlake.GenerateBase62(); err != nil {
+		l.log.WithContext(ctx).Errorf("failed to generate schedule id: %v", err)
+		return "", v1.ErrorInternalError("failed to generate schedule id: %v", err)
+	}
+	schedule.ScheduleId = "schedule" + schedule.ScheduleId
+	if err = l.scheduleRepo.CreateSchedule(ctx, schedule); err != nil {
+		l.log.WithContext(ctx).Errorf("failed to create push schedule: %v", err)
+		return "", v1.ErrorInternalError("failed to create push schedule")
+	}
+	l.log.WithContext(ctx).Infof("Created push schedule. ScheduleID: %s, NextFireAt: %d", schedule.ScheduleId, schedule.NextFireAt)
+	return schedule.ScheduleId, nil
+}
+
+// ListSystemPushSchedules 分页查询定时推送计划
+func (l *Logic) ListSystemPushSchedules(ctx context.Context, status bo.PushScheduleStatus, page, pageSize int) ([]*bo.PushSchedule, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = bo.DefaultSchedulePageSize
+	}
+	pageSize = min(pageSize, bo.MaxSchedulePageSize)
+	schedules, total, err := l.scheduleRepo.ListSchedules(ctx, status, (page-1)*pageSize, pageSize)
+	if err != nil {
+		l.log.WithContext(ctx).Errorf("failed to list push schedules: %v", err)
+		return nil, 0, v1.ErrorInternalError("failed to list push schedules")
+	}
+	return schedules, total, nil
+}
+
+// CancelSystemPushSchedule 取消待执行的定时推送计划
+func (l *Logic) CancelSystemPushSchedule(ctx context.Context, scheduleId string) error {
+	if scheduleId == "" {
+		return v1.ErrorInvalidParameter("schedule id is empty")
+	}
+	ok, err := l.scheduleRepo.CancelSchedule(ctx, scheduleId)
+	if err != nil {
+		l.log.WithContext(ctx).Errorf("failed to cancel push schedule. ScheduleID: %s, error: %v", scheduleId, err)
+		return v1.ErrorInternalError("failed to cancel push schedule")
+	}
+	if ok {
+		l.log.WithContext(ctx).Infof("Canceled push schedule. ScheduleID: %s", scheduleId)
+		return nil
+	}
+	schedule, err := l.scheduleRepo.GetSchedule(ctx, scheduleId)
+	if err != nil {
+		l.log.WithContext(ctx).Errorf("failed to get push schedule. ScheduleID: %s, error: %v", scheduleId, err)
+		return v1.ErrorInternalError("failed to get push schedule")
+	}
+	if schedule == nil {
+		return v1.ErrorScheduleNotFound("push schedule not found. schedule_id=%s", scheduleId)
+	}
+	return v1.ErrorScheduleStatusConflict("push schedule is %s", schedule.Status)
 }
@@ -4,8 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+	"github.com/xinghe903/chatify/logic/internal/conf"
+
+	"github.com/xinghe903/chatify/pkg/auth"
 
 	im_v1 "github.com/xinghe903/chatify/api/im/v1"
 
@@ -16,12 +20,18 @@ var (
 	ErrInvalidMessageType = errors.New("invalid message type")
 	ErrInvalidTargetType  = errors.New("invalid target type")
 	ErrMessageDuplicate   = errors.New("message already processed")
+	// ErrNonRetryable 消息本身无法处理，消费者不再重试，直接转入死信队列
+	ErrNonRetryable = errors.New("non-retryable message")
 )
 
 type MessageHandler func(ctx context.Context, key string, value []byte) error
 
+// MessageFilter 在批次处理前统一检查消息，返回与values一一对应的结果，false表示跳过该消息
+type MessageFilter func(ctx context.Context, values [][]byte) []bool
+
 type Consumer interface {
-	Start(ctx context.Context, handler MessageHandler)
+	// Start 开始消费，filter为空时不做批量检查
+	Start(ctx context.Context, filter MessageFilter, handler MessageHandler)
 }
 
 // MessageDedupRepo 消息去重仓库接口
@@ -29,17 +39,44 @@ type MessageDedupRepo interface {
 	// CheckAndSetDedup 检查消息是否已消费，如果未消费则标记为已消费
 	// 返回true表示消息未被消费过，false表示消息已被消费过
 	CheckAndSetDedup(ctx context.Context, msgId string) (bool, error)
+	// CheckAndSetDedupBatch 批量检查并标记消息，结果与msgIds一一对应
+	CheckAndSetDedupBatch(ctx context.Context, msgIds []string) ([]bool, error)
+	// ReleaseDedup 清除消费标记，处理失败的消息重试时可以再次消费
+	ReleaseDedup(ctx context.Context, msgId string) error
 }
 
 type MqProducer interface {
-	SendMessageWithDataReport(ctx context.Context, message *bo.DataReport) error
+	// SendMessageWithDataReport 发送校验后的数据上报，同一用户的上报写入同一分区
+	SendMessageWithDataReport(ctx context.Context, batch *bo.DataReportBatch) error
+	SendMessageWithBroadcastChunk(ctx context.Context, task *bo.BroadcastChunkTask) error
+	SendMessageWithUserAbuse(ctx context.Context, event *bo.UserAbuseEvent) error
+	// SendMessageWithDeliveryEvents 发送送达漏斗事件，同一批事件写入一条消息
+	SendMessageWithDeliveryEvents(ctx context.Context, events []*bo.DeliveryEvent) error
+	// SendMessageWithWebhookDelivery 发送webhook投递，同一订阅的投递写入同一分区
+	SendMessageWithWebhookDelivery(ctx context.Context, delivery *bo.WebhookDelivery) error
 }
 
 type UserMessageHandler struct {
-	log        *log.Helper
-	consumer   Consumer
-	dedupRepo  MessageDedupRepo
-	mqProducer MqProducer
+	log              *log.Helper
+	consumer         Consumer
+	dedupRepo        MessageDedupRepo
+	mqProducer       MqProducer
+	pushClient       PushRepo
+	groupRepo        GroupRepo
+	conversationRepo ConversationRepo
+	offlineRepo      OfflineRepo
+	chatRepo         ChatRepo
+	readStateRepo    ReadStateRepo
+	sequenceRepo     SequenceRepo
+	quotaRepo        QuotaRepo
+	reporter         *Reporter
+	webhook          *Webhook
+	attachments      *Attachments
+	preferences      *Preferences
+	quota            *bo.Quota
+	sonyFlake        *auth.Sonyflake
+	recallWindow     time.Duration
+	editWindow       time.Duration
 }
 
 func NewUserMessageHandler(
@@ -47,62 +84,139 @@ func NewUserMessageHandler(
 	consumer Consumer,
 	dedupRepo MessageDedupRepo,
 	mqProducer MqProducer,
+	pushClient PushRepo,
+	groupRepo GroupRepo,
+	conversationRepo ConversationRepo,
+	offlineRepo OfflineRepo,
+	chatRepo ChatRepo,
+	readStateRepo ReadStateRepo,
+	sequenceRepo SequenceRepo,
+	quotaRepo QuotaRepo,
+	reporter *Reporter,
+	webhook *Webhook,
+	attachments *Attachments,
+	preferences *Preferences,
+	c *conf.Bootstrap,
 ) (*UserMessageHandler, func()) {
 	handle := &UserMessageHandler{
-		log:        log.NewHelper(logger),
-		consumer:   consumer,
-		dedupRepo:  dedupRepo,
-		mqProducer: mqProducer,
+		log:              log.NewHelper(logger),
+		consumer:         consumer,
+		dedupRepo:        dedupRepo,
+		mqProducer:       mqProducer,
+		pushClient:       pushClient,
+		groupRepo:        groupRepo,
+		conversationRepo: conversationRepo,
+		offlineRepo:      offlineRepo,
+		chatRepo:         chatRepo,
+		readStateRepo:    readStateRepo,
+		sequenceRepo:     sequenceRepo,
+		quotaRepo:        quotaRepo,
+		reporter:         reporter,
+		webhook:          webhook,
+		attachments:      attachments,
+		preferences:      preferences,
+		quota:            newQuota(c.GetQuota()),
+		sonyFlake:        auth.NewSonyflake(),
+		recallWindow:     c.GetMessage().GetRecallWindow().AsDuration(),
+		editWindow:       c.GetMessage().GetEditWindow().AsDuration(),
+	}
+	if handle.recallWindow <= 0 {
+		handle.recallWindow = bo.DefaultRecallWindow
+	}
+	if handle.editWindow <= 0 {
+		handle.editWindow = bo.DefaultEditWindow
 	}
 	ctx, cancel := context.WithCancelCause(context.TODO())
-	handle.consumer.Start(ctx, handle.Handle())
+	handle.consumer.Start(ctx, handle.Dedup(), handle.Handle())
 	return handle, func() { cancel(errors.New("consumer context canceled")) }
 }
 
+// Dedup 批量检查消息是否已消费（使用 msg_id 作为唯一标识），一个批次只访问一次Redis
+func (h *UserMessageHandler) Dedup() MessageFilter {
+	return func(ctx context.Context, values [][]byte) []bool {
+		msgIds := make([]string, len(values))
+		for i, value := range values {
+			var header struct {
+				MsgId string `json:"msg_id"`
+			}
+			// 解析失败的消息交给Handle处理并转入死信队列
+			if err := json.Unmarshal(value, &header); err == nil {
+				msgIds[i] = header.MsgId
+			}
+		}
+		accepted, err := h.dedupRepo.CheckAndSetDedupBatch(ctx, msgIds)
+		if err != nil {
+			h.log.WithContext(ctx).Errorf("检查消息去重失败: size=%d, error=%v", len(values), err)
+			// Redis错误时继续处理消息，避免因为Redis故障导致消息无法消费
+			accepted = make([]bool, len(values))
+			for i := range accepted {
+				accepted[i] = true
+			}
+			return accepted
+		}
+		for i, ok := range accepted {
+			if !ok {
+				h.log.WithContext(ctx).Debugf("消息已消费，跳过: msgId=%s", msgIds[i])
+			}
+		}
+		return accepted
+	}
+}
+
+// Handle 处理单条消息，去重已经由Dedup在批次处理前完成
 func (h *UserMessageHandler) Handle() MessageHandler {
 	return func(ctx context.Context, key string, value []byte) error {
 		var baseMsg im_v1.BaseMessage
 		if err := json.Unmarshal(value, &baseMsg); err != nil {
 			h.log.WithContext(ctx).Errorf("consumer kafka message json unmarshal error: %v", err)
-			return err
+			return errors.Join(ErrNonRetryable, err)
 		}
-
-		// 检查消息是否已消费（使用 baseMsg.MsgId 作为唯一标识）
-		if baseMsg.MsgId != "" {
-			isNew, err := h.dedupRepo.CheckAndSetDedup(ctx, baseMsg.MsgId)
-			if err != nil {
-				h.log.WithContext(ctx).Errorf("检查消息去重失败: msgId=%s, error=%v", baseMsg.MsgId, err)
-				// Redis错误时继续处理消息，避免因为Redis故障导致消息无法消费
-			} else if !isNew {
-				// 消息已被消费过，跳过
-				h.log.WithContext(ctx).Debugf("消息已消费，跳过: msgId=%s", baseMsg.MsgId)
-				return ErrMessageDuplicate
-			}
-		} else {
+		if baseMsg.MsgId == "" {
 			h.log.WithContext(ctx).Warnf("消息缺少 msgId，跳过去重检查: %v", baseMsg)
 		}
 
 		// 处理消息
+		var err error
 		switch baseMsg.MessageType {
 		case im_v1.MessageType_CHAT:
-			return h.chat(ctx, &baseMsg)
+			err = h.chat(ctx, &baseMsg)
 		case im_v1.MessageType_CONTROL:
-			return h.control(ctx, &baseMsg)
+			err = h.control(ctx, &baseMsg)
 		case im_v1.MessageType_DATAREPORT:
-			return h.dataReport(ctx, &baseMsg)
+			err = h.dataReport(ctx, &baseMsg)
 		default:
-			return ErrInvalidMessageType
+			err = ErrInvalidMessageType
 		}
+		if err == nil {
+			return nil
+		}
+		// 失败的消息会经重试主题或死信重放再次消费，需要清除消费标记
+		if baseMsg.MsgId != "" {
+			if rerr := h.dedupRepo.ReleaseDedup(ctx, baseMsg.MsgId); rerr != nil {
+				h.log.WithContext(ctx).Errorf("failed to release dedup. msgId=%s, error=%v", baseMsg.MsgId, rerr)
+			}
+		}
+		if isNonRetryable(err) {
+			return errors.Join(ErrNonRetryable, err)
+		}
+		return err
 	}
 }
 
-// chat 处理聊天消息
-func (h *UserMessageHandler) chat(ctx context.Context, baseMsg *im_v1.BaseMessage) error {
-	if baseMsg.TargetType != im_v1.TargetType_USER && baseMsg.TargetType != im_v1.TargetType_GROUP {
-		return ErrInvalidTargetType
+// isNonRetryable 消息校验或策略拒绝的错误，重试也不会成功
+func isNonRetryable(err error) bool {
+	for _, target := range []error{
+		ErrInvalidMessageType, ErrInvalidTargetType, ErrInvalidChatMessage, ErrInvalidControlCommand,
+		ErrNotGroupMember, ErrChatMessageNotFound, ErrRevisionNotAllowed, ErrRevisionWindowExpire,
+		ErrQuotaExceeded, ErrSenderMuted, ErrInvalidDataReport, ErrReportUserMismatch,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
 	}
-	h.log.WithContext(ctx).Infof("Receive chat message. baseMsg: %v", baseMsg)
-	return nil
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	return errors.As(err, &syntaxErr) || errors.As(err, &typeErr)
 }
 
 // dataReport 处理数据上报消息
@@ -110,24 +224,5 @@ func (h *UserMessageHandler) dataReport(ctx context.Context, baseMsg *im_v1.Base
 	if baseMsg.TargetType != im_v1.TargetType_SYSTEM {
 		return ErrInvalidTargetType
 	}
-	var dataReport bo.DataReport
-	if err := json.Unmarshal(baseMsg.Content, &dataReport); err != nil {
-		h.log.WithContext(ctx).Errorf("data report json unmarshal error: %v", err)
-		return err
-	}
-	if err := h.mqProducer.SendMessageWithDataReport(ctx, &dataReport); err != nil {
-		h.log.WithContext(ctx).Errorf("send data report message error: %v", err)
-		return err
-	}
-	h.log.WithContext(ctx).Infof("Receive data report message. baseMsg: %v", baseMsg)
-	return nil
-}
-
-// control 处理控制类消息
-func (h *UserMessageHandler) control(ctx context.Context, baseMsg *im_v1.BaseMessage) error {
-	if baseMsg.TargetType != im_v1.TargetType_SYSTEM {
-		return ErrInvalidTargetType
-	}
-	h.log.WithContext(ctx).Infof("Receive control message. baseMsg: %v", baseMsg)
-	return nil
+	return h.reporter.Ingest(ctx, baseMsg)
 }
@@ -0,0 +1,161 @@
+package biz
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+
+	"github.com/xinghe903/chatify/pkg/auth"
+
+	v1 "github.com/xinghe903/chatify/api/logic/v1"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// NotificationPreferenceRepo 通知偏好仓库接口
+type NotificationPreferenceRepo interface {
+	// GetPreferences 查询用户的通知偏好，未设置过时返回nil
+	GetPreferences(ctx context.Context, userId string) (*bo.NotificationPreferences, error)
+	// ListPreferences 批量查询通知偏好，只包含设置过的用户
+	ListPreferences(ctx context.Context, userIds []string) (map[string]*bo.NotificationPreferences, error)
+	// SavePreferences 保存推送类型退订和勿扰时段，为nil的部分保持不变
+	SavePreferences(ctx context.Context, update *bo.NotificationPreferencesUpdate) error
+	// RecordSuppressions 记录被过滤的系统推送，重复记录忽略
+	RecordSuppressions(ctx context.Context, suppressions []*bo.PushSuppression) error
+}
+
+// Preferences 用户通知偏好，决定消息是否静默以及系统推送是否发送
+type Preferences struct {
+	log              *log.Helper
+	repo             NotificationPreferenceRepo
+	conversationRepo ConversationRepo
+}
+
+// NewPreferences 创建通知偏好业务实例
+func NewPreferences(logger log.Logger, repo NotificationPreferenceRepo, conversationRepo ConversationRepo) *Preferences {
+	return &Preferences{
+		log:              log.NewHelper(logger),
+		repo:             repo,
+		conversationRepo: conversationRepo,
+	}
+}
+
+// GetNotificationPreferences 查询当前登录用户的通知偏好和免打扰生效中的会话
+func (p *Preferences) GetNotificationPreferences(ctx context.Context) (*bo.NotificationPreferences, []*bo.ConversationMute, error) {
+	userId := auth.GetUserID(ctx)
+	if userId == "" {
+		return nil, nil, v1.ErrorUnauthenticated("user id not found in context")
+	}
+	preferences, err := p.repo.GetPreferences(ctx, userId)
+	if err != nil {
+		p.log.WithContext(ctx).Errorf("failed to get notification preferences. userId=%s, error=%v", userId, err)
+		return nil, nil, v1.ErrorInternalError("failed to get notification preferences")
+	}
+	if preferences == nil {
+		preferences = &bo.NotificationPreferences{UserId: userId}
+	}
+	mutes, err := p.conversationRepo.ListMuted(ctx, userId, time.Now().Unix())
+	if err != nil {
+		p.log.WithContext(ctx).Errorf("failed to list muted conversations. userId=%s, error=%v", userId, err)
+		return nil, nil, v1.ErrorInternalError("failed to list muted conversations")
+	}
+	return preferences, mutes, nil
+}
+
+// UpdateNotificationPreferences 更新当前登录用户的通知偏好，返回更新后的结果
+func (p *Preferences) UpdateNotificationPreferences(ctx context.Context, update *bo.NotificationPreferencesUpdate) (*bo.NotificationPreferences, []*bo.ConversationMute, error) {
+	update.UserId = auth.GetUserID(ctx)
+	if update.UserId == "" {
+		return nil, nil, v1.ErrorUnauthenticated("user id not found in context")
+	}
+	if err := update.Normalize(); err != nil {
+		return nil, nil, v1.ErrorInvalidParameter("%v", err)
+	}
+	if update.OptOutPushTypes != nil || update.Dnd != nil {
+		if err := p.repo.SavePreferences(ctx, update); err != nil {
+			p.log.WithContext(ctx).Errorf("failed to save notification preferences. userId=%s, error=%v", update.UserId, err)
+			return nil, nil, v1.ErrorInternalError("failed to save notification preferences")
+		}
+	}
+	for _, mute := range update.Mutes {
+		err := p.conversationRepo.SaveMute(ctx, update.UserId, mute.ConversationType, mute.ConversationId, mute.Muted, mute.MuteUntil)
+		if err != nil {
+			p.log.WithContext(ctx).Errorf("failed to save conversation mute. userId=%s, conversationId=%s, error=%v",
+				update.UserId, mute.ConversationId, err)
+			return nil, nil, v1.ErrorInternalError("failed to save conversation mute")
+		}
+	}
+	return p.GetNotificationPreferences(ctx)
+}
+
+// SilentRecipients 返回应静默接收聊天消息的接收者：会话免打扰且未被@，或处于勿扰时段
+func (p *Preferences) SilentRecipients(ctx context.Context, message *bo.ChatMessage, recipients []string, now time.Time) (map[string]bool, error) {
+	silent := make(map[string]bool)
+	if len(recipients) == 0 {
+		return silent, nil
+	}
+	muted, err := p.conversationRepo.ListMutedUsers(ctx, message, recipients, now.Unix())
+	if err != nil {
+		return nil, err
+	}
+	for _, userId := range muted {
+		if !message.IsMentioned(userId) {
+			silent[userId] = true
+		}
+	}
+	preferences, err := p.repo.ListPreferences(ctx, recipients)
+	if err != nil {
+		return nil, err
+	}
+	for userId, preference := range preferences {
+		if preference.InQuietHours(now) {
+			silent[userId] = true
+		}
+	}
+	return silent, nil
+}
+
+// FilterSystemPush 移除退订了该类型推送的接收者，处于勿扰时段的接收者标记为静默
+// 返回剩余的消息和被过滤的记录，记录由调用方在确定内容ID后保存
+func (p *Preferences) FilterSystemPush(ctx context.Context, pushType v1.PushType, messages []*bo.Message, now time.Time) ([]*bo.Message, []*bo.PushSuppression, error) {
+	userIds := make([]string, 0, len(messages))
+	for _, message := range messages {
+		userIds = append(userIds, message.ToUserId)
+	}
+	preferences, err := p.repo.ListPreferences(ctx, userIds)
+	if err != nil {
+		return nil, nil, err
+	}
+	var suppressions []*bo.PushSuppression
+	messages = slices.DeleteFunc(messages, func(message *bo.Message) bool {
+		preference := preferences[message.ToUserId]
+		if preference.OptedOut(pushType) {
+			suppressions = append(suppressions, &bo.PushSuppression{
+				UserId:   message.ToUserId,
+				PushType: pushType,
+				Reason:   bo.SuppressReasonOptOut,
+			})
+			return true
+		}
+		message.Silent = preference.InQuietHours(now)
+		return false
+	})
+	return messages, suppressions, nil
+}
+
+// RecordSuppressions 保存被过滤的系统推送记录，失败只记录日志，不影响发送
+func (p *Preferences) RecordSuppressions(ctx context.Context, contentId string, suppressions []*bo.PushSuppression) {
+	if len(suppressions) == 0 {
+		return
+	}
+	for _, suppression := range suppressions {
+		suppression.ContentId = contentId
+	}
+	if err := p.repo.RecordSuppressions(ctx, suppressions); err != nil {
+		p.log.WithContext(ctx).Warnf("failed to record push suppressions. contentId=%s, len=%d, error=%v", contentId, len(suppressions), err)
+		return
+	}
+	p.log.WithContext(ctx).Infof("Suppressed system push for opted-out users. contentId=%s, len=%d", contentId, len(suppressions))
+}
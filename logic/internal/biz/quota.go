@@ -0,0 +1,156 @@
+package biz
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+	"github.com/xinghe903/chatify/logic/internal/conf"
+
+	im_v1 "github.com/xinghe903/chatify/api/im/v1"
+)
+
+var (
+	ErrQuotaExceeded = errors.New("send quota exceeded")
+	ErrSenderMuted   = errors.New("sender is temporarily muted")
+)
+
+// QuotaRepo 发送配额仓库接口
+type QuotaRepo interface {
+	// MutedUntil 查询用户临时禁言的截止时间，未禁言时返回零值
+	MutedUntil(ctx context.Context, userId string) (time.Time, error)
+	// AcquireRate 在滑动窗口内占用一次该类消息的发送配额，超出limit时返回false
+	AcquireRate(ctx context.Context, userId string, messageType im_v1.MessageType, msgId string, limit int, window time.Duration) (bool, error)
+	// AcquireTarget 记录本次发送的会话，返回窗口内的不同会话数
+	// 新会话会超出limit时不记录并返回-1
+	AcquireTarget(ctx context.Context, userId, target string, limit int, window time.Duration) (int64, bool, error)
+	// CountRepeat 统计窗口内相同内容的发送次数，包含本次
+	CountRepeat(ctx context.Context, userId, digest string, window time.Duration) (int64, error)
+	// AddSpamScore 累加窗口内的垃圾分，返回累计值
+	AddSpamScore(ctx context.Context, userId string, score int64, window time.Duration) (int64, error)
+	// Mute 临时禁言并清空垃圾分，返回统计周期内的禁言次数
+	Mute(ctx context.Context, userId string, until time.Time) (int64, error)
+}
+
+func newQuota(c *conf.Quota) *bo.Quota {
+	quota := &bo.Quota{
+		Window:        c.GetWindow().AsDuration(),
+		ChatLimit:     int(c.GetChatLimit()),
+		ControlLimit:  int(c.GetControlLimit()),
+		TargetLimit:   int(c.GetTargetLimit()),
+		SpamThreshold: int64(c.GetSpamThreshold()),
+		MuteDuration:  c.GetMuteDuration().AsDuration(),
+		LockStrikes:   int64(c.GetLockStrikes()),
+	}
+	if quota.Window <= 0 {
+		quota.Window = bo.DefaultQuotaWindow
+	}
+	if quota.ChatLimit <= 0 {
+		quota.ChatLimit = bo.DefaultChatLimit
+	}
+	if quota.ControlLimit <= 0 {
+		quota.ControlLimit = bo.DefaultControlLimit
+	}
+	if quota.TargetLimit <= 0 {
+		quota.TargetLimit = bo.DefaultTargetLimit
+	}
+	if quota.SpamThreshold <= 0 {
+		quota.SpamThreshold = bo.DefaultSpamThreshold
+	}
+	if quota.MuteDuration <= 0 {
+		quota.MuteDuration = bo.DefaultMuteDuration
+	}
+	if quota.LockStrikes <= 0 {
+		quota.LockStrikes = bo.DefaultLockStrikes
+	}
+	return quota
+}
+
+// checkRate 校验发送者在窗口内未超过该类消息的频率配额
+// Redis故障时放行，避免配额检查影响正常收发
+func (h *UserMessageHandler) checkRate(ctx context.Context, userId, msgId string, messageType im_v1.MessageType, limit int) error {
+	ok, err := h.quotaRepo.AcquireRate(ctx, userId, messageType, msgId, limit, h.quota.Window)
+	if err != nil {
+		h.log.WithContext(ctx).Warnf("failed to acquire rate quota. userId=%s, error=%v", userId, err)
+		return nil
+	}
+	if !ok {
+		h.log.WithContext(ctx).Infof("Rate quota exceeded. userId=%s, type=%s", userId, messageType)
+		return ErrQuotaExceeded
+	}
+	return nil
+}
+
+// admitChat 聊天消息的准入检查
+// 依次校验临时禁言、发送频率和会话数配额，再按重复内容和扇出累计垃圾分，达到阈值时临时禁言
+func (h *UserMessageHandler) admitChat(ctx context.Context, message *bo.ChatMessage) error {
+	userId := message.FromUserId
+	until, err := h.quotaRepo.MutedUntil(ctx, userId)
+	if err != nil {
+		h.log.WithContext(ctx).Warnf("failed to get mute state. userId=%s, error=%v", userId, err)
+	} else if time.Now().Before(until) {
+		return ErrSenderMuted
+	}
+	if err = h.checkRate(ctx, userId, message.MsgId, im_v1.MessageType_CHAT, h.quota.ChatLimit); err != nil {
+		return err
+	}
+
+	var score int64
+	targets, isNew, err := h.quotaRepo.AcquireTarget(ctx, userId, message.ConversationKey(), h.quota.TargetLimit, h.quota.Window)
+	if err != nil {
+		h.log.WithContext(ctx).Warnf("failed to acquire target quota. userId=%s, error=%v", userId, err)
+	} else if targets < 0 {
+		h.log.WithContext(ctx).Infof("Target quota exceeded. userId=%s, conversation=%s", userId, message.ConversationId)
+		return ErrQuotaExceeded
+	} else if isNew && targets > int64(h.quota.TargetLimit/2) {
+		score += bo.FanoutSpamWeight
+	}
+	digest := sha1.Sum(message.Content)
+	repeats, err := h.quotaRepo.CountRepeat(ctx, userId, hex.EncodeToString(digest[:]), h.quota.Window)
+	if err != nil {
+		h.log.WithContext(ctx).Warnf("failed to count repeated content. userId=%s, error=%v", userId, err)
+	} else if repeats > 1 {
+		score += (repeats - 1) * bo.RepeatSpamWeight
+	}
+	if score == 0 {
+		return nil
+	}
+	total, err := h.quotaRepo.AddSpamScore(ctx, userId, score, h.quota.Window)
+	if err != nil {
+		h.log.WithContext(ctx).Warnf("failed to add spam score. userId=%s, error=%v", userId, err)
+		return nil
+	}
+	if total < h.quota.SpamThreshold {
+		return nil
+	}
+	h.mute(ctx, userId, total)
+	return ErrSenderMuted
+}
+
+// mute 临时禁言发送者，统计周期内禁言次数过多时通知auth锁定账号
+func (h *UserMessageHandler) mute(ctx context.Context, userId string, score int64) {
+	until := time.Now().Add(h.quota.MuteDuration)
+	strikes, err := h.quotaRepo.Mute(ctx, userId, until)
+	if err != nil {
+		h.log.WithContext(ctx).Errorf("failed to mute sender. userId=%s, error=%v", userId, err)
+		return
+	}
+	h.log.WithContext(ctx).Warnf("Sender muted for spam. userId=%s, score=%d, strikes=%d, until=%s",
+		userId, score, strikes, until.Format(time.RFC3339))
+	if strikes < h.quota.LockStrikes {
+		return
+	}
+	event := &bo.UserAbuseEvent{
+		UserId:     userId,
+		Reason:     "spam",
+		Strikes:    strikes,
+		MutedUntil: until.Unix(),
+		OccurredAt: time.Now().Unix(),
+	}
+	if err = h.mqProducer.SendMessageWithUserAbuse(ctx, event); err != nil {
+		h.log.WithContext(ctx).Errorf("failed to send user abuse event. userId=%s, error=%v", userId, err)
+	}
+}
@@ -0,0 +1,54 @@
+package biz
+
+import (
+	"context"
+
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+
+	im_v1 "github.com/xinghe903/chatify/api/im/v1"
+)
+
+// markRead 处理已读回执
+// 已读位置只前进不后退，前进后根据消息记录重新统计未读数，多端同时上报时结果一致
+func (h *UserMessageHandler) markRead(ctx context.Context, cmd *im_v1.ControlCommand, msgId string) error {
+	message, err := h.chatRepo.GetMessage(ctx, msgId)
+	if err != nil {
+		return err
+	}
+	if message == nil || !isIncoming(message, cmd) {
+		return ErrChatMessageNotFound
+	}
+	cursor := &bo.ReadCursor{
+		UserId:           cmd.OperatorId,
+		ConversationType: cmd.ConversationType,
+		ConversationId:   cmd.ConversationId,
+		MsgId:            msgId,
+		ReadAt:           message.CreatedAt.UnixMilli(),
+	}
+	advanced, err := h.readStateRepo.AdvanceCursor(ctx, cursor)
+	if err != nil {
+		h.log.WithContext(ctx).Errorf("failed to advance read cursor. operator=%s, error=%v", cmd.OperatorId, err)
+		return err
+	}
+	if !advanced {
+		// 其他设备已经读到更靠后的位置
+		return nil
+	}
+	unread, err := h.chatRepo.CountUnread(ctx, cmd.OperatorId, cmd.ConversationType, cmd.ConversationId, message.CreatedAt)
+	if err != nil {
+		h.log.WithContext(ctx).Errorf("failed to count unread messages. operator=%s, error=%v", cmd.OperatorId, err)
+		return err
+	}
+	return h.readStateRepo.SetUnread(ctx, cmd.OperatorId, cmd.ConversationType, cmd.ConversationId, unread)
+}
+
+// isIncoming 判断消息是否为操作者在该会话中收到的消息
+func isIncoming(message *bo.ChatMessage, cmd *im_v1.ControlCommand) bool {
+	if message.ConversationType != cmd.ConversationType {
+		return false
+	}
+	if cmd.ConversationType == im_v1.TargetType_GROUP {
+		return message.ConversationId == cmd.ConversationId
+	}
+	return message.FromUserId == cmd.ConversationId && message.ConversationId == cmd.OperatorId
+}
@@ -0,0 +1,187 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+
+	v1 "github.com/xinghe903/chatify/api/logic/v1"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/proto"
+)
+
+// LeaderElector 选主接口，保证多实例部署时只有一个调度器在执行
+type LeaderElector interface {
+	// Campaign 阻塞直到成为leader或ctx被取消
+	// 返回的context在失去leader身份时被取消，resign用于主动放弃leader
+	Campaign(ctx context.Context) (leaderCtx context.Context, resign func(), err error)
+}
+
+// PushScheduler 定时推送调度器
+// 只有选主成功的实例会扫描到期计划，每个周期先通过条件更新抢占并保存周期记录再发送，保证同一周期只发送一次
+// 发送中断的周期在租期过后重新发送，使用相同的push任务ID
+type PushScheduler struct {
+	log          *log.Helper
+	logic        *Logic
+	scheduleRepo ScheduleRepo
+	elector      LeaderElector
+}
+
+// NewPushScheduler 创建定时推送调度器，并启动选主协程
+func NewPushScheduler(
+	logger log.Logger,
+	logic *Logic,
+	scheduleRepo ScheduleRepo,
+	elector LeaderElector,
+) (*PushScheduler, func()) {
+	s := &PushScheduler{
+		log:          log.NewHelper(logger),
+		logic:        logic,
+		scheduleRepo: scheduleRepo,
+		elector:      elector,
+	}
+	ctx, cancel := context.WithCancelCause(context.TODO())
+	go s.run(ctx)
+	return s, func() { cancel(errors.New("push scheduler context canceled")) }
+}
+
+// run 循环参与选主，成为leader后执行调度
+func (s *PushScheduler) run(ctx context.Context) {
+	for ctx.Err() == nil {
+		leaderCtx, resign, err := s.elector.Campaign(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				s.log.Errorf("push scheduler campaign error: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+			case <-time.After(bo.ScheduleScanInterval):
+			}
+			continue
+		}
+		s.log.Info("push scheduler became leader")
+		s.loop(leaderCtx)
+		resign()
+		s.log.Info("push scheduler lost leadership")
+	}
+}
+
+// loop 定时扫描到期计划，直到失去leader身份
+// 成为leader时和之后的每个扫描周期都会重新发送前任leader中断的周期
+func (s *PushScheduler) loop(ctx context.Context) {
+	s.redriveStaleFires(ctx)
+	ticker := time.NewTicker(bo.ScheduleScanInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.redriveStaleFires(ctx)
+			s.fireDue(ctx)
+		}
+	}
+}
+
+// fireDue 发送所有到期计划
+func (s *PushScheduler) fireDue(ctx context.Context) {
+	now := time.Now().Unix()
+	schedules, err := s.scheduleRepo.ListDueSchedules(ctx, now, bo.ScheduleScanBatch)
+	if err != nil {
+		s.log.WithContext(ctx).Errorf("failed to list due push schedules: %v", err)
+		return
+	}
+	for _, schedule := range schedules {
+		if ctx.Err() != nil {
+			return
+		}
+		s.fire(ctx, schedule, now)
+	}
+}
+
+// fire 抢占并发送一个计划周期
+// 抢占时已保存待发送的周期记录，发送前崩溃时由redriveStaleFires重新发送
+func (s *PushScheduler) fire(ctx context.Context, schedule *bo.PushSchedule, now int64) {
+	prevFireAt := schedule.NextFireAt
+	schedule.FiredCount++
+	schedule.LastFiredAt = now
+	if !schedule.Advance(now) {
+		schedule.Status = bo.PushScheduleStatusFinished
+	}
+	claimed, err := s.scheduleRepo.ClaimSchedule(ctx, schedule, prevFireAt)
+	if err != nil {
+		s.log.WithContext(ctx).Errorf("failed to claim push schedule. ScheduleID: %s, error: %v", schedule.ScheduleId, err)
+		return
+	}
+	if !claimed {
+		// 计划已被取消或已由其他实例处理
+		s.log.WithContext(ctx).Debugf("push schedule already handled. ScheduleID: %s", schedule.ScheduleId)
+		return
+	}
+	s.send(ctx, schedule, &bo.PushScheduleFire{ScheduleId: schedule.ScheduleId, FireAt: prevFireAt}, now)
+}
+
+// redriveStaleFires 重新发送超过租期仍未记录结果的周期
+// 周期在抢占后、记录结果前中断时（如实例崩溃或失去leader身份），push任务ID不变，可以据此识别重复发送
+func (s *PushScheduler) redriveStaleFires(ctx context.Context) {
+	staleBefore := time.Now().Add(-bo.ScheduleFireLease)
+	fires, err := s.scheduleRepo.ListStaleFires(ctx, staleBefore, bo.ScheduleScanBatch)
+	if err != nil {
+		s.log.WithContext(ctx).Errorf("failed to list stale push schedule fires: %v", err)
+		return
+	}
+	for _, fire := range fires {
+		if ctx.Err() != nil {
+			return
+		}
+		claimed, err := s.scheduleRepo.ClaimStaleFire(ctx, fire, staleBefore)
+		if err != nil {
+			s.log.WithContext(ctx).Errorf("failed to claim stale push schedule fire. ScheduleID: %s, FireAt: %d, error: %v", fire.ScheduleId, fire.FireAt, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		schedule, err := s.scheduleRepo.GetSchedule(ctx, fire.ScheduleId)
+		if err != nil {
+			s.log.WithContext(ctx).Errorf("failed to get push schedule. ScheduleID: %s, error: %v", fire.ScheduleId, err)
+			continue
+		}
+		if schedule == nil {
+			fire.Status = bo.PushScheduleFireStatusFailed
+			fire.LastError = "push schedule not found"
+			if err = s.scheduleRepo.RecordFireResult(ctx, fire); err != nil {
+				s.log.WithContext(ctx).Errorf("failed to record push schedule fire result. ScheduleID: %s, error: %v", fire.ScheduleId, err)
+			}
+			continue
+		}
+		s.log.WithContext(ctx).Warnf("Redrive interrupted push schedule fire. ScheduleID: %s, FireAt: %d", fire.ScheduleId, fire.FireAt)
+		s.send(ctx, schedule, fire, time.Now().Unix())
+	}
+}
+
+// send 发送计划的一个周期并记录结果，push任务ID由计划ID和周期时间派生
+func (s *PushScheduler) send(ctx context.Context, schedule *bo.PushSchedule, fire *bo.PushScheduleFire, now int64) {
+	req := proto.Clone(schedule.Request).(*v1.SystemPushRequest)
+	req.SendAt = 0
+	req.Recurrence = nil
+	req.Timestamp = now
+	result, err := s.logic.pushNow(ctx, req, fire.PushTaskId())
+	if err != nil {
+		fire.Status = bo.PushScheduleFireStatusFailed
+		fire.LastError = err.Error()
+		s.log.WithContext(ctx).Errorf("failed to fire push schedule. ScheduleID: %s, FireAt: %d, error: %v", schedule.ScheduleId, fire.FireAt, err)
+	} else {
+		// 部分批次失败时记录错误，已发送的批次不会重发
+		fire.Status = bo.PushScheduleFireStatusSent
+		fire.TaskId, fire.LastError = result.TaskId, result.LastError
+		s.log.WithContext(ctx).Infof("Fired push schedule. ScheduleID: %s, FireAt: %d, TaskID: %s, sent: %d, failed: %d",
+			schedule.ScheduleId, fire.FireAt, fire.TaskId, result.SentUsers, result.FailedUsers)
+	}
+	if err = s.scheduleRepo.RecordFireResult(ctx, fire); err != nil {
+		s.log.WithContext(ctx).Errorf("failed to record push schedule result. ScheduleID: %s, error: %v", schedule.ScheduleId, err)
+	}
+}
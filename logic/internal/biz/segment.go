@@ -0,0 +1,118 @@
+package biz
+
+import (
+	"context"
+	"slices"
+
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+
+	v1 "github.com/xinghe903/chatify/api/logic/v1"
+)
+
+// UserAttributeRepo 用户属性和标签仓库接口
+type UserAttributeRepo interface {
+	// UpdateAttributes 写入平台和注册时间并增删标签，为空的属性保持不变
+	UpdateAttributes(ctx context.Context, update *bo.UserAttributesUpdate) error
+	// GetAttributes 查询用户的平台、注册时间和标签，不包含语言，不存在时返回nil
+	GetAttributes(ctx context.Context, userId string) (*bo.UserAttributes, error)
+	// CountSegment 统计分群命中的用户数
+	CountSegment(ctx context.Context, segment *bo.Segment) (int64, error)
+	// ListSegmentUsers 按用户ID顺序查询分群命中的用户，最多返回limit个
+	ListSegmentUsers(ctx context.Context, segment *bo.Segment, limit int) ([]string, error)
+}
+
+// UpdateUserAttributes 更新用户属性和标签，语言写入语言偏好
+func (l *Logic) UpdateUserAttributes(ctx context.Context, update *bo.UserAttributesUpdate) (*bo.UserAttributes, error) {
+	if err := update.Normalize(); err != nil {
+		return nil, v1.ErrorInvalidParameter("%v", err)
+	}
+	if len(update.AddTags) > 0 {
+		current, err := l.GetUserAttributes(ctx, update.UserId)
+		if err != nil {
+			return nil, err
+		}
+		tags := slices.DeleteFunc(current.Tags, func(tag string) bool {
+			return slices.Contains(update.RemoveTags, tag)
+		})
+		for _, tag := range update.AddTags {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+		if len(tags) > bo.MaxUserTags {
+			return nil, v1.ErrorInvalidParameter("too many tags limit=%d, input=%d", bo.MaxUserTags, len(tags))
+		}
+	}
+	if err := l.attributeRepo.UpdateAttributes(ctx, update); err != nil {
+		l.log.WithContext(ctx).Errorf("failed to update user attributes. userId=%s, error=%v", update.UserId, err)
+		return nil, v1.ErrorInternalError("failed to update user attributes")
+	}
+	if update.Locale != "" {
+		if err := l.localeRepo.SetLocale(ctx, update.UserId, update.Locale); err != nil {
+			l.log.WithContext(ctx).Errorf("failed to set user locale. userId=%s, error=%v", update.UserId, err)
+			return nil, v1.ErrorInternalError("failed to set user locale")
+		}
+	}
+	return l.GetUserAttributes(ctx, update.UserId)
+}
+
+// GetUserAttributes 查询用户属性和标签，未设置过属性的用户返回空属性
+func (l *Logic) GetUserAttributes(ctx context.Context, userId string) (*bo.UserAttributes, error) {
+	if userId == "" {
+		return nil, v1.ErrorInvalidParameter("user id is empty")
+	}
+	attributes, err := l.attributeRepo.GetAttributes(ctx, userId)
+	if err != nil {
+		l.log.WithContext(ctx).Errorf("failed to get user attributes. userId=%s, error=%v", userId, err)
+		return nil, v1.ErrorInternalError("failed to get user attributes")
+	}
+	if attributes == nil {
+		attributes = &bo.UserAttributes{UserId: userId}
+	}
+	locales, err := l.localeRepo.GetLocales(ctx, []string{userId})
+	if err != nil {
+		l.log.WithContext(ctx).Errorf("failed to get user locale. userId=%s, error=%v", userId, err)
+		return nil, v1.ErrorInternalError("failed to get user locale")
+	}
+	attributes.Locale = locales[userId]
+	return attributes, nil
+}
+
+// PreviewSegment 统计分群命中的用户数并返回部分用户
+func (l *Logic) PreviewSegment(ctx context.Context, expr string) (int64, []string, error) {
+	segment, err := bo.ParseSegment(expr)
+	if err != nil {
+		return 0, nil, v1.ErrorInvalidParameter("invalid segment: %v", err)
+	}
+	count, err := l.attributeRepo.CountSegment(ctx, segment)
+	if err != nil {
+		l.log.WithContext(ctx).Errorf("failed to count segment users. segment=%q, error=%v", expr, err)
+		return 0, nil, v1.ErrorInternalError("failed to count segment users")
+	}
+	if count == 0 {
+		return 0, []string{}, nil
+	}
+	samples, err := l.attributeRepo.ListSegmentUsers(ctx, segment, bo.SegmentPreviewSampleSize)
+	if err != nil {
+		l.log.WithContext(ctx).Errorf("failed to list segment users. segment=%q, error=%v", expr, err)
+		return 0, nil, v1.ErrorInternalError("failed to list segment users")
+	}
+	return count, samples, nil
+}
+
+// resolveSegment 计算分群推送的接收者，超过上限时拒绝发送
+func (l *Logic) resolveSegment(ctx context.Context, expr string) ([]string, error) {
+	segment, err := bo.ParseSegment(expr)
+	if err != nil {
+		return nil, v1.ErrorInvalidParameter("invalid segment: %v", err)
+	}
+	userIds, err := l.attributeRepo.ListSegmentUsers(ctx, segment, bo.MaxSegmentUsers+1)
+	if err != nil {
+		l.log.WithContext(ctx).Errorf("failed to resolve segment. segment=%q, error=%v", expr, err)
+		return nil, v1.ErrorInternalError("failed to resolve segment")
+	}
+	if len(userIds) > bo.MaxSegmentUsers {
+		return nil, v1.ErrorTooManyTargets("segment matches more than %d users, use a broadcast job instead", bo.MaxSegmentUsers)
+	}
+	return userIds, nil
+}
@@ -0,0 +1,169 @@
+package biz
+
+import (
+	"context"
+	"strings"
+
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+
+	v1 "github.com/xinghe903/chatify/api/logic/v1"
+
+	"github.com/xinghe903/chatify/pkg/auth"
+)
+
+// PushTemplateRepo 推送模板仓库接口
+type PushTemplateRepo interface {
+	CreateTemplate(ctx context.Context, template *bo.PushTemplate) error
+	// UpdateTemplate 更新模板，返回模板是否存在
+	UpdateTemplate(ctx context.Context, template *bo.PushTemplate) (bool, error)
+	// GetTemplate 查询模板，不存在时返回nil
+	GetTemplate(ctx context.Context, templateId string) (*bo.PushTemplate, error)
+	// ListTemplates 按创建时间倒序分页查询模板
+	ListTemplates(ctx context.Context, offset, limit int) ([]*bo.PushTemplate, int64, error)
+	// DeleteTemplate 删除模板，返回模板是否存在
+	DeleteTemplate(ctx context.Context, templateId string) (bool, error)
+}
+
+// UserLocaleRepo 用户语言偏好仓库接口
+type UserLocaleRepo interface {
+	// SetLocale 设置用户语言，locale为空时清除
+	SetLocale(ctx context.Context, userId, locale string) error
+	// GetLocales 批量查询用户语言，未设置的用户不在结果中
+	GetLocales(ctx context.Context, userIds []string) (map[string]string, error)
+}
+
+// CreatePushTemplate 校验并创建推送模板
+func (l *Logic) CreatePushTemplate(ctx context.Context, template *bo.PushTemplate) (*bo.PushTemplate, error) {
+	if err := template.Validate(); err != nil {
+		return nil, v1.ErrorInvalidParameter("invalid push template: %v", err)
+	}
+	templateId, err := l.sonyFlake.GenerateBase62()
+	if err != nil {
+		l.log.WithContext(ctx).Errorf("failed to generate template id: %v", err)
+		return nil, v1.ErrorInternalError("failed to generate template id: %v", err)
+	}
+	template.TemplateId = "template" + templateId
+	if err = l.templateRepo.CreateTemplate(ctx, template); err != nil {
+		l.log.WithContext(ctx).Errorf("failed to create push template: %v", err)
+		return nil, v1.ErrorInternalError("failed to create push template")
+	}
+	l.log.WithContext(ctx).Infof("Created push template. TemplateID: %s, Locales: %d", template.TemplateId, len(template.Contents))
+	return l.GetPushTemplate(ctx, template.TemplateId)
+}
+
+// UpdatePushTemplate 校验并整体替换推送模板
+func (l *Logic) UpdatePushTemplate(ctx context.Context, template *bo.PushTemplate) (*bo.PushTemplate, error) {
+	if template.TemplateId == "" {
+		return nil, v1.ErrorInvalidParameter("template id is empty")
+	}
+	if err := template.Validate(); err != nil {
+		return nil, v1.ErrorInvalidParameter("invalid push template: %v", err)
+	}
+	ok, err := l.templateRepo.UpdateTemplate(ctx, template)
+	if err != nil {
+		l.log.WithContext(ctx).Errorf("failed to update push template. TemplateID: %s, error: %v", template.TemplateId, err)
+		return nil, v1.ErrorInternalError("failed to update push template")
+	}
+	if !ok {
+		return nil, v1.ErrorTemplateNotFound("push template not found. template_id=%s", template.TemplateId)
+	}
+	return l.GetPushTemplate(ctx, template.TemplateId)
+}
+
+// GetPushTemplate 查询推送模板
+func (l *Logic) GetPushTemplate(ctx context.Context, templateId string) (*bo.PushTemplate, error) {
+	if templateId == "" {
+		return nil, v1.ErrorInvalidParameter("template id is empty")
+	}
+	template, err := l.templateRepo.GetTemplate(ctx, templateId)
+	if err != nil {
+		l.log.WithContext(ctx).Errorf("failed to get push template. TemplateID: %s, error: %v", templateId, err)
+		return nil, v1.ErrorInternalError("failed to get push template")
+	}
+	if template == nil {
+		return nil, v1.ErrorTemplateNotFound("push template not found. template_id=%s", templateId)
+	}
+	return template, nil
+}
+
+// ListPushTemplates 分页查询推送模板
+func (l *Logic) ListPushTemplates(ctx context.Context, page, pageSize int) ([]*bo.PushTemplate, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = bo.DefaultTemplatePageSize
+	}
+	pageSize = min(pageSize, bo.MaxTemplatePageSize)
+	templates, total, err := l.templateRepo.ListTemplates(ctx, (page-1)*pageSize, pageSize)
+	if err != nil {
+		l.log.WithContext(ctx).Errorf("failed to list push templates: %v", err)
+		return nil, 0, v1.ErrorInternalError("failed to list push templates")
+	}
+	return templates, total, nil
+}
+
+// DeletePushTemplate 删除推送模板
+func (l *Logic) DeletePushTemplate(ctx context.Context, templateId string) error {
+	if templateId == "" {
+		return v1.ErrorInvalidParameter("template id is empty")
+	}
+	ok, err := l.templateRepo.DeleteTemplate(ctx, templateId)
+	if err != nil {
+		l.log.WithContext(ctx).Errorf("failed to delete push template. TemplateID: %s, error: %v", templateId, err)
+		return v1.ErrorInternalError("failed to delete push template")
+	}
+	if !ok {
+		return v1.ErrorTemplateNotFound("push template not found. template_id=%s", templateId)
+	}
+	l.log.WithContext(ctx).Infof("Deleted push template. TemplateID: %s", templateId)
+	return nil
+}
+
+// SetUserLocale 设置当前登录用户的语言偏好
+func (l *Logic) SetUserLocale(ctx context.Context, locale string) error {
+	userId := auth.GetUserID(ctx)
+	if userId == "" {
+		return v1.ErrorUnauthenticated("user id not found in context")
+	}
+	normalized, err := bo.NormalizeLocale(locale)
+	if err != nil {
+		return v1.ErrorInvalidParameter("%v", err)
+	}
+	if err = l.localeRepo.SetLocale(ctx, userId, normalized); err != nil {
+		l.log.WithContext(ctx).Errorf("failed to set user locale. userId=%s, error=%v", userId, err)
+		return v1.ErrorInternalError("failed to set user locale")
+	}
+	return nil
+}
+
+// loadTemplate 查询推送请求引用的模板，并校验每个接收者的变量是否齐全
+func (l *Logic) loadTemplate(ctx context.Context, req *v1.SystemPushRequest) (*bo.PushTemplate, error) {
+	template, err := l.GetPushTemplate(ctx, req.TemplateId)
+	if err != nil {
+		return nil, err
+	}
+	for _, userId := range req.ToUserIds {
+		if missing := template.MissingVariables(req.Variables, req.UserVariables[userId].GetVariables()); len(missing) > 0 {
+			return nil, v1.ErrorInvalidParameter("missing template variables for user %s: %s", userId, strings.Join(missing, ","))
+		}
+	}
+	return template, nil
+}
+
+// renderTemplate 按接收者的语言偏好渲染每条消息的内容
+func (l *Logic) renderTemplate(ctx context.Context, req *v1.SystemPushRequest, messages []*bo.Message) error {
+	template, err := l.loadTemplate(ctx, req)
+	if err != nil {
+		return err
+	}
+	locales, err := l.localeRepo.GetLocales(ctx, req.ToUserIds)
+	if err != nil {
+		// 查询失败时使用模板默认语言，不阻塞推送
+		l.log.WithContext(ctx).Warnf("failed to get user locales, fallback to default locale. TemplateID: %s, error: %v", template.TemplateId, err)
+	}
+	for _, message := range messages {
+		message.Content = template.Render(locales[message.ToUserId], req.Variables, req.UserVariables[message.ToUserId].GetVariables())
+	}
+	return nil
+}
@@ -0,0 +1,329 @@
+package biz
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+	"github.com/xinghe903/chatify/logic/internal/conf"
+
+	im_v1 "github.com/xinghe903/chatify/api/im/v1"
+	v1 "github.com/xinghe903/chatify/api/logic/v1"
+
+	"github.com/xinghe903/chatify/pkg/auth"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// WebhookConsumer webhook投递消费者
+type WebhookConsumer interface {
+	Consumer
+}
+
+// UserStateConsumer 用户上下线事件消费者
+type UserStateConsumer interface {
+	Consumer
+}
+
+// WebhookRepo webhook订阅仓库接口
+type WebhookRepo interface {
+	CreateWebhook(ctx context.Context, subscription *bo.WebhookSubscription) error
+	// ListWebhooks 查询全部订阅，包含签名密钥
+	ListWebhooks(ctx context.Context) ([]*bo.WebhookSubscription, error)
+	// GetWebhook 查询订阅，不存在时返回nil
+	GetWebhook(ctx context.Context, webhookId string) (*bo.WebhookSubscription, error)
+	// DeleteWebhook 删除订阅，返回订阅是否存在
+	DeleteWebhook(ctx context.Context, webhookId string) (bool, error)
+}
+
+// WebhookSender 把事件签名后投递到订阅地址
+// 订阅方返回的错误不需要重试时返回的错误包含 ErrNonRetryable
+type WebhookSender interface {
+	Send(ctx context.Context, subscription *bo.WebhookSubscription, delivery *bo.WebhookDelivery, eventType bo.WebhookEventType) error
+}
+
+// Webhook 把事件扇出为每个订阅一条投递，投递失败时经Kafka重试主题退避重试
+type Webhook struct {
+	log              *log.Helper
+	repo             WebhookRepo
+	sender           WebhookSender
+	mqProducer       MqProducer
+	deliveryConsumer WebhookConsumer
+	stateConsumer    UserStateConsumer
+	dedupRepo        MessageDedupRepo
+	sonyFlake        *auth.Sonyflake
+	// subscriptions 订阅缓存，定时刷新，本实例修改订阅后立即刷新
+	subscriptions atomic.Pointer[[]*bo.WebhookSubscription]
+}
+
+// NewWebhook 创建webhook并启动投递和用户上下线事件的消费
+func NewWebhook(
+	logger log.Logger,
+	c *conf.Bootstrap,
+	repo WebhookRepo,
+	sender WebhookSender,
+	mqProducer MqProducer,
+	deliveryConsumer WebhookConsumer,
+	stateConsumer UserStateConsumer,
+	dedupRepo MessageDedupRepo,
+) (*Webhook, func()) {
+	w := &Webhook{
+		log:              log.NewHelper(logger),
+		repo:             repo,
+		sender:           sender,
+		mqProducer:       mqProducer,
+		deliveryConsumer: deliveryConsumer,
+		stateConsumer:    stateConsumer,
+		dedupRepo:        dedupRepo,
+		sonyFlake:        auth.NewSonyflake(),
+	}
+	w.subscriptions.Store(&[]*bo.WebhookSubscription{})
+	interval := c.GetWebhook().GetRefreshInterval().AsDuration()
+	if interval <= 0 {
+		interval = bo.DefaultWebhookRefreshInterval
+	}
+	ctx, cancel := context.WithCancelCause(context.TODO())
+	w.refresh(ctx)
+	go w.refreshLoop(ctx, interval)
+	w.deliveryConsumer.Start(ctx, nil, w.HandleDelivery())
+	w.stateConsumer.Start(ctx, nil, w.HandleUserState())
+	return w, func() { cancel(errors.New("webhook context canceled")) }
+}
+
+func (w *Webhook) refreshLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.refresh(ctx)
+		}
+	}
+}
+
+// refresh 重新加载订阅缓存，失败时保留旧的缓存
+func (w *Webhook) refresh(ctx context.Context) {
+	subscriptions, err := w.repo.ListWebhooks(ctx)
+	if err != nil {
+		w.log.WithContext(ctx).Errorf("failed to refresh webhook subscriptions: %v", err)
+		return
+	}
+	w.subscriptions.Store(&subscriptions)
+}
+
+// Publish 把事件投递给所有匹配的订阅，事件丢失不影响主流程，失败时只记录日志
+func (w *Webhook) Publish(ctx context.Context, eventType bo.WebhookEventType, botId string, data any) {
+	var matched []*bo.WebhookSubscription
+	event := &bo.WebhookEvent{EventType: eventType, BotId: botId, Data: data}
+	for _, subscription := range *w.subscriptions.Load() {
+		if subscription.Match(event) {
+			matched = append(matched, subscription)
+		}
+	}
+	if len(matched) == 0 {
+		return
+	}
+	eventId, err := w.sonyFlake.GenerateBase62()
+	if err != nil {
+		w.log.WithContext(ctx).Errorf("failed to generate webhook event id: %v", err)
+		return
+	}
+	event.EventId = "event" + eventId
+	event.Timestamp = time.Now().UnixMilli()
+	payload, err := json.Marshal(event)
+	if err != nil {
+		w.log.WithContext(ctx).Errorf("failed to marshal webhook event. eventType=%s, error=%v", eventType, err)
+		return
+	}
+	for _, subscription := range matched {
+		delivery := &bo.WebhookDelivery{
+			DeliveryId: event.EventId + "-" + subscription.WebhookId,
+			WebhookId:  subscription.WebhookId,
+			Event:      payload,
+		}
+		if err = w.mqProducer.SendMessageWithWebhookDelivery(ctx, delivery); err != nil {
+			w.log.WithContext(ctx).Errorf("failed to send webhook delivery. deliveryId=%s, error=%v", delivery.DeliveryId, err)
+		}
+	}
+}
+
+// PublishBotMessage 把发给机器人的聊天消息投递给订阅了该机器人的webhook
+func (w *Webhook) PublishBotMessage(ctx context.Context, message *bo.ChatMessage, botIds []string) {
+	for _, botId := range botIds {
+		conversationId := message.ConversationId
+		if message.ConversationType == im_v1.TargetType_USER {
+			// 机器人视角的单聊会话为发送者
+			conversationId = message.FromUserId
+		}
+		w.Publish(ctx, bo.WebhookEventBotMessage, botId, &bo.WebhookBotMessage{
+			MsgId:          message.MsgId,
+			FromUserId:     message.FromUserId,
+			TargetType:     message.ConversationType.String(),
+			ConversationId: conversationId,
+			Seq:            message.Seq,
+			Content:        string(message.Content),
+			Preview:        bo.MessagePreview(message.Content),
+			Timestamp:      message.Timestamp,
+		})
+	}
+}
+
+// HandleUserState 把access发布的用户上下线事件转为webhook事件，重复消费的事件不再发布
+func (w *Webhook) HandleUserState() MessageHandler {
+	return func(ctx context.Context, key string, value []byte) error {
+		var state bo.UserStateMessage
+		if err := json.Unmarshal(value, &state); err != nil {
+			w.log.WithContext(ctx).Errorf("user state json unmarshal error: %v", err)
+			return errors.Join(ErrNonRetryable, err)
+		}
+		if state.ConnectionId != "" {
+			dedupId := bo.UserStateDedupPrefix + state.ConnectionId + ":" + state.State
+			isNew, err := w.dedupRepo.CheckAndSetDedup(ctx, dedupId)
+			if err != nil {
+				w.log.WithContext(ctx).Errorf("failed to check user state dedup. connectionId=%s, error=%v", state.ConnectionId, err)
+			} else if !isNew {
+				return ErrMessageDuplicate
+			}
+		}
+		eventType := bo.WebhookEventUserOffline
+		if state.State == "online" {
+			eventType = bo.WebhookEventUserOnline
+		}
+		w.Publish(ctx, eventType, "", &bo.WebhookUserState{
+			UserId:         state.UserID,
+			ConnectionId:   state.ConnectionId,
+			ConnectionTime: state.ConnectionTime,
+		})
+		return nil
+	}
+}
+
+// HandleDelivery 投递事件到订阅地址，订阅已删除时丢弃
+// 重复消费的投递不再发送，投递失败时释放去重标识，由重试主题再次投递
+func (w *Webhook) HandleDelivery() MessageHandler {
+	return func(ctx context.Context, key string, value []byte) error {
+		var delivery bo.WebhookDelivery
+		if err := json.Unmarshal(value, &delivery); err != nil {
+			w.log.WithContext(ctx).Errorf("webhook delivery json unmarshal error: %v", err)
+			return errors.Join(ErrNonRetryable, err)
+		}
+		if delivery.DeliveryId != "" {
+			isNew, err := w.dedupRepo.CheckAndSetDedup(ctx, bo.WebhookDeliveryDedupPrefix+delivery.DeliveryId)
+			if err != nil {
+				w.log.WithContext(ctx).Errorf("failed to check webhook delivery dedup. deliveryId=%s, error=%v", delivery.DeliveryId, err)
+			} else if !isNew {
+				return ErrMessageDuplicate
+			}
+		}
+		if err := w.deliver(ctx, &delivery); err != nil {
+			w.release(ctx, delivery.DeliveryId)
+			return err
+		}
+		return nil
+	}
+}
+
+// deliver 查询订阅并发送投递
+func (w *Webhook) deliver(ctx context.Context, delivery *bo.WebhookDelivery) error {
+	var event struct {
+		EventType bo.WebhookEventType `json:"event_type"`
+	}
+	if err := json.Unmarshal(delivery.Event, &event); err != nil {
+		return errors.Join(ErrNonRetryable, err)
+	}
+	subscription, err := w.getSubscription(ctx, delivery.WebhookId)
+	if err != nil {
+		return err
+	}
+	if subscription == nil {
+		w.log.WithContext(ctx).Infof("drop delivery of deleted webhook. deliveryId=%s", delivery.DeliveryId)
+		return nil
+	}
+	if err = w.sender.Send(ctx, subscription, delivery, event.EventType); err != nil {
+		w.log.WithContext(ctx).Warnf("failed to deliver webhook. deliveryId=%s, url=%s, error=%v", delivery.DeliveryId, subscription.URL, err)
+		return err
+	}
+	return nil
+}
+
+// release 删除投递的去重标识，投递失败后由重试主题或重放的消息再次投递
+func (w *Webhook) release(ctx context.Context, deliveryId string) {
+	if deliveryId == "" {
+		return
+	}
+	if err := w.dedupRepo.ReleaseDedup(ctx, bo.WebhookDeliveryDedupPrefix+deliveryId); err != nil {
+		w.log.WithContext(ctx).Errorf("failed to release webhook delivery dedup. deliveryId=%s, error=%v", deliveryId, err)
+	}
+}
+
+// getSubscription 优先从缓存查询订阅，缓存未命中时查询数据库，兼容其他实例刚创建的订阅
+func (w *Webhook) getSubscription(ctx context.Context, webhookId string) (*bo.WebhookSubscription, error) {
+	for _, subscription := range *w.subscriptions.Load() {
+		if subscription.WebhookId == webhookId {
+			return subscription, nil
+		}
+	}
+	subscription, err := w.repo.GetWebhook(ctx, webhookId)
+	if err != nil {
+		w.log.WithContext(ctx).Errorf("failed to get webhook. webhookId=%s, error=%v", webhookId, err)
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// CreateWebhook 校验并创建订阅，返回签名密钥
+func (w *Webhook) CreateWebhook(ctx context.Context, subscription *bo.WebhookSubscription) (*bo.WebhookSubscription, error) {
+	if err := subscription.Validate(); err != nil {
+		return nil, v1.ErrorInvalidParameter("invalid webhook: %v", err)
+	}
+	webhookId, err := w.sonyFlake.GenerateBase62()
+	if err != nil {
+		w.log.WithContext(ctx).Errorf("failed to generate webhook id: %v", err)
+		return nil, v1.ErrorInternalError("failed to generate webhook id: %v", err)
+	}
+	subscription.WebhookId = "webhook" + webhookId
+	if subscription.Secret, err = bo.GenerateSecret(); err != nil {
+		w.log.WithContext(ctx).Errorf("failed to generate webhook secret: %v", err)
+		return nil, v1.ErrorInternalError("failed to generate webhook secret")
+	}
+	if err = w.repo.CreateWebhook(ctx, subscription); err != nil {
+		w.log.WithContext(ctx).Errorf("failed to create webhook: %v", err)
+		return nil, v1.ErrorInternalError("failed to create webhook")
+	}
+	w.refresh(ctx)
+	w.log.WithContext(ctx).Infof("Created webhook. WebhookID: %s, URL: %s, EventTypes: %v", subscription.WebhookId, subscription.URL, subscription.EventTypes)
+	return subscription, nil
+}
+
+// ListWebhooks 查询全部订阅
+func (w *Webhook) ListWebhooks(ctx context.Context) ([]*bo.WebhookSubscription, error) {
+	subscriptions, err := w.repo.ListWebhooks(ctx)
+	if err != nil {
+		w.log.WithContext(ctx).Errorf("failed to list webhooks: %v", err)
+		return nil, v1.ErrorInternalError("failed to list webhooks")
+	}
+	return subscriptions, nil
+}
+
+// DeleteWebhook 删除订阅
+func (w *Webhook) DeleteWebhook(ctx context.Context, webhookId string) error {
+	if webhookId == "" {
+		return v1.ErrorInvalidParameter("webhook id is empty")
+	}
+	ok, err := w.repo.DeleteWebhook(ctx, webhookId)
+	if err != nil {
+		w.log.WithContext(ctx).Errorf("failed to delete webhook. WebhookID: %s, error: %v", webhookId, err)
+		return v1.ErrorInternalError("failed to delete webhook")
+	}
+	if !ok {
+		return v1.ErrorWebhookNotFound("webhook not found. webhook_id=%s", webhookId)
+	}
+	w.refresh(ctx)
+	w.log.WithContext(ctx).Infof("Deleted webhook. WebhookID: %s", webhookId)
+	return nil
+}
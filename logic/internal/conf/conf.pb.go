@@ -29,6 +29,11 @@ type Bootstrap struct {
 	Client        *Client                `protobuf:"bytes,3,opt,name=client,proto3" json:"client,omitempty"`
 	Monitoring    *Monitoring            `protobuf:"bytes,4,opt,name=monitoring,proto3" json:"monitoring,omitempty"`
 	Security      *Security              `protobuf:"bytes,5,opt,name=security,proto3" json:"security,omitempty"`
+	Message       *Message               `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	Quota         *Quota                 `protobuf:"bytes,7,opt,name=quota,proto3" json:"quota,omitempty"`
+	Webhook       *Webhook               `protobuf:"bytes,8,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	Attachment    *Attachment            `protobuf:"bytes,9,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	Idempotency   *Idempotency           `protobuf:"bytes,10,opt,name=idempotency,proto3" json:"idempotency,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -98,6 +103,418 @@ func (x *Bootstrap) GetSecurity() *Security {
 	return nil
 }
 
+func (x *Bootstrap) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetQuota() *Quota {
+	if x != nil {
+		return x.Quota
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetWebhook() *Webhook {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetAttachment() *Attachment {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetIdempotency() *Idempotency {
+	if x != nil {
+		return x.Idempotency
+	}
+	return nil
+}
+
+// 系统推送幂等配置
+type Idempotency struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secret        string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`                                    // 幂等键签名密钥，为空时使用 server.secret
+	Window        *durationpb.Duration   `protobuf:"bytes,2,opt,name=window,proto3" json:"window,omitempty"`                                    // 保存首次请求结果的时长，窗口内的重复请求直接返回该结果，默认24h
+	TimeWindow    *durationpb.Duration   `protobuf:"bytes,3,opt,name=time_window,json=timeWindow,proto3" json:"time_window,omitempty"`          // 签名时间戳允许的偏差，默认5m
+	ProcessingTtl *durationpb.Duration   `protobuf:"bytes,4,opt,name=processing_ttl,json=processingTtl,proto3" json:"processing_ttl,omitempty"` // 处理中状态的占用时长，处理完成后延长到window，进程崩溃后超时释放，默认5m
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Idempotency) Reset() {
+	*x = Idempotency{}
+	mi := &file_conf_conf_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Idempotency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Idempotency) ProtoMessage() {}
+
+func (x *Idempotency) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Idempotency.ProtoReflect.Descriptor instead.
+func (*Idempotency) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Idempotency) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *Idempotency) GetWindow() *durationpb.Duration {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (x *Idempotency) GetTimeWindow() *durationpb.Duration {
+	if x != nil {
+		return x.TimeWindow
+	}
+	return nil
+}
+
+func (x *Idempotency) GetProcessingTtl() *durationpb.Duration {
+	if x != nil {
+		return x.ProcessingTtl
+	}
+	return nil
+}
+
+// 附件上传配置
+type Attachment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Storage       string                 `protobuf:"bytes,1,opt,name=storage,proto3" json:"storage,omitempty"`                               // 存储后端，目前支持local，默认local
+	LocalDir      string                 `protobuf:"bytes,2,opt,name=local_dir,json=localDir,proto3" json:"local_dir,omitempty"`             // local存储的根目录，多实例部署时需要挂载共享目录
+	ChunkSize     int64                  `protobuf:"varint,3,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`         // 分片大小（单位: 字节），默认1MB
+	MaxFileSize   int64                  `protobuf:"varint,4,opt,name=max_file_size,json=maxFileSize,proto3" json:"max_file_size,omitempty"` // 单个附件大小上限（单位: 字节），默认100MB
+	UserQuota     int64                  `protobuf:"varint,5,opt,name=user_quota,json=userQuota,proto3" json:"user_quota,omitempty"`         // 每个用户的存储配额（单位: 字节），包含未完成的上传，默认1GB
+	UploadExpire  *durationpb.Duration   `protobuf:"bytes,6,opt,name=upload_expire,json=uploadExpire,proto3" json:"upload_expire,omitempty"` // 未完成的上传保留时长，默认24h
+	UrlExpire     *durationpb.Duration   `protobuf:"bytes,7,opt,name=url_expire,json=urlExpire,proto3" json:"url_expire,omitempty"`          // 下载地址有效期，默认1h
+	PublicUrl     string                 `protobuf:"bytes,8,opt,name=public_url,json=publicUrl,proto3" json:"public_url,omitempty"`          // 下载地址前缀，例如 https://chatify.example.com，为空时返回相对路径
+	Secret        string                 `protobuf:"bytes,9,opt,name=secret,proto3" json:"secret,omitempty"`                                 // 下载地址签名密钥，为空时使用 server.secret
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_conf_conf_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Attachment) GetStorage() string {
+	if x != nil {
+		return x.Storage
+	}
+	return ""
+}
+
+func (x *Attachment) GetLocalDir() string {
+	if x != nil {
+		return x.LocalDir
+	}
+	return ""
+}
+
+func (x *Attachment) GetChunkSize() int64 {
+	if x != nil {
+		return x.ChunkSize
+	}
+	return 0
+}
+
+func (x *Attachment) GetMaxFileSize() int64 {
+	if x != nil {
+		return x.MaxFileSize
+	}
+	return 0
+}
+
+func (x *Attachment) GetUserQuota() int64 {
+	if x != nil {
+		return x.UserQuota
+	}
+	return 0
+}
+
+func (x *Attachment) GetUploadExpire() *durationpb.Duration {
+	if x != nil {
+		return x.UploadExpire
+	}
+	return nil
+}
+
+func (x *Attachment) GetUrlExpire() *durationpb.Duration {
+	if x != nil {
+		return x.UrlExpire
+	}
+	return nil
+}
+
+func (x *Attachment) GetPublicUrl() string {
+	if x != nil {
+		return x.PublicUrl
+	}
+	return ""
+}
+
+func (x *Attachment) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+// webhook投递配置
+type Webhook struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Timeout         *durationpb.Duration   `protobuf:"bytes,1,opt,name=timeout,proto3" json:"timeout,omitempty"`                                        // 单次投递的HTTP超时
+	RefreshInterval *durationpb.Duration   `protobuf:"bytes,2,opt,name=refresh_interval,json=refreshInterval,proto3" json:"refresh_interval,omitempty"` // 订阅缓存的刷新间隔
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Webhook) Reset() {
+	*x = Webhook{}
+	mi := &file_conf_conf_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Webhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Webhook) ProtoMessage() {}
+
+func (x *Webhook) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Webhook.ProtoReflect.Descriptor instead.
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Webhook) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *Webhook) GetRefreshInterval() *durationpb.Duration {
+	if x != nil {
+		return x.RefreshInterval
+	}
+	return nil
+}
+
+// 聊天消息配置
+type Message struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecallWindow  *durationpb.Duration   `protobuf:"bytes,1,opt,name=recall_window,json=recallWindow,proto3" json:"recall_window,omitempty"` // 消息发出后允许撤回的时长
+	EditWindow    *durationpb.Duration   `protobuf:"bytes,2,opt,name=edit_window,json=editWindow,proto3" json:"edit_window,omitempty"`       // 消息发出后允许编辑的时长
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Message) Reset() {
+	*x = Message{}
+	mi := &file_conf_conf_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Message) ProtoMessage() {}
+
+func (x *Message) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Message.ProtoReflect.Descriptor instead.
+func (*Message) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Message) GetRecallWindow() *durationpb.Duration {
+	if x != nil {
+		return x.RecallWindow
+	}
+	return nil
+}
+
+func (x *Message) GetEditWindow() *durationpb.Duration {
+	if x != nil {
+		return x.EditWindow
+	}
+	return nil
+}
+
+// 发送配额与反垃圾配置
+type Quota struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Window        *durationpb.Duration   `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`                                     // 滑动窗口时长
+	ChatLimit     int32                  `protobuf:"varint,2,opt,name=chat_limit,json=chatLimit,proto3" json:"chat_limit,omitempty"`             // 窗口内最多发送的聊天消息数
+	ControlLimit  int32                  `protobuf:"varint,3,opt,name=control_limit,json=controlLimit,proto3" json:"control_limit,omitempty"`    // 窗口内最多发送的控制指令数
+	TargetLimit   int32                  `protobuf:"varint,4,opt,name=target_limit,json=targetLimit,proto3" json:"target_limit,omitempty"`       // 窗口内最多向多少个不同会话发送消息
+	SpamThreshold int32                  `protobuf:"varint,5,opt,name=spam_threshold,json=spamThreshold,proto3" json:"spam_threshold,omitempty"` // 窗口内垃圾分达到该值时临时禁言
+	MuteDuration  *durationpb.Duration   `protobuf:"bytes,6,opt,name=mute_duration,json=muteDuration,proto3" json:"mute_duration,omitempty"`     // 临时禁言时长
+	LockStrikes   int32                  `protobuf:"varint,7,opt,name=lock_strikes,json=lockStrikes,proto3" json:"lock_strikes,omitempty"`       // 一天内被禁言达到该次数时通知auth锁定账号
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Quota) Reset() {
+	*x = Quota{}
+	mi := &file_conf_conf_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Quota) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quota) ProtoMessage() {}
+
+func (x *Quota) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quota.ProtoReflect.Descriptor instead.
+func (*Quota) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Quota) GetWindow() *durationpb.Duration {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (x *Quota) GetChatLimit() int32 {
+	if x != nil {
+		return x.ChatLimit
+	}
+	return 0
+}
+
+func (x *Quota) GetControlLimit() int32 {
+	if x != nil {
+		return x.ControlLimit
+	}
+	return 0
+}
+
+func (x *Quota) GetTargetLimit() int32 {
+	if x != nil {
+		return x.TargetLimit
+	}
+	return 0
+}
+
+func (x *Quota) GetSpamThreshold() int32 {
+	if x != nil {
+		return x.SpamThreshold
+	}
+	return 0
+}
+
+func (x *Quota) GetMuteDuration() *durationpb.Duration {
+	if x != nil {
+		return x.MuteDuration
+	}
+	return nil
+}
+
+func (x *Quota) GetLockStrikes() int32 {
+	if x != nil {
+		return x.LockStrikes
+	}
+	return 0
+}
+
 type Security struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	WhiteList     []string               `protobuf:"bytes,1,rep,name=white_list,json=whiteList,proto3" json:"white_list,omitempty"`
@@ -108,7 +525,7 @@ type Security struct {
 
 func (x *Security) Reset() {
 	*x = Security{}
-	mi := &file_conf_conf_proto_msgTypes[1]
+	mi := &file_conf_conf_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -120,7 +537,7 @@ func (x *Security) String() string {
 func (*Security) ProtoMessage() {}
 
 func (x *Security) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[1]
+	mi := &file_conf_conf_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -133,7 +550,7 @@ func (x *Security) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Security.ProtoReflect.Descriptor instead.
 func (*Security) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{1}
+	return file_conf_conf_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Security) GetWhiteList() []string {
@@ -153,13 +570,14 @@ func (x *Security) GetBlackList() []string {
 type Client struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PushClient    *PushClient            `protobuf:"bytes,1,opt,name=push_client,json=pushClient,proto3" json:"push_client,omitempty"`
+	OfflineClient *OfflineClient         `protobuf:"bytes,2,opt,name=offline_client,json=offlineClient,proto3" json:"offline_client,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Client) Reset() {
 	*x = Client{}
-	mi := &file_conf_conf_proto_msgTypes[2]
+	mi := &file_conf_conf_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -171,7 +589,7 @@ func (x *Client) String() string {
 func (*Client) ProtoMessage() {}
 
 func (x *Client) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[2]
+	mi := &file_conf_conf_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -184,7 +602,7 @@ func (x *Client) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Client.ProtoReflect.Descriptor instead.
 func (*Client) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{2}
+	return file_conf_conf_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *Client) GetPushClient() *PushClient {
@@ -194,6 +612,13 @@ func (x *Client) GetPushClient() *PushClient {
 	return nil
 }
 
+func (x *Client) GetOfflineClient() *OfflineClient {
+	if x != nil {
+		return x.OfflineClient
+	}
+	return nil
+}
+
 type PushClient struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Addr          string                 `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
@@ -204,7 +629,7 @@ type PushClient struct {
 
 func (x *PushClient) Reset() {
 	*x = PushClient{}
-	mi := &file_conf_conf_proto_msgTypes[3]
+	mi := &file_conf_conf_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -216,7 +641,7 @@ func (x *PushClient) String() string {
 func (*PushClient) ProtoMessage() {}
 
 func (x *PushClient) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[3]
+	mi := &file_conf_conf_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -229,7 +654,7 @@ func (x *PushClient) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PushClient.ProtoReflect.Descriptor instead.
 func (*PushClient) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{3}
+	return file_conf_conf_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *PushClient) GetAddr() string {
@@ -246,6 +671,58 @@ func (x *PushClient) GetTimeout() *durationpb.Duration {
 	return nil
 }
 
+type OfflineClient struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Addr          string                 `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
+	Timeout       *durationpb.Duration   `protobuf:"bytes,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OfflineClient) Reset() {
+	*x = OfflineClient{}
+	mi := &file_conf_conf_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OfflineClient) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfflineClient) ProtoMessage() {}
+
+func (x *OfflineClient) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfflineClient.ProtoReflect.Descriptor instead.
+func (*OfflineClient) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *OfflineClient) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *OfflineClient) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
 type Server struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Http          *Server_HTTP           `protobuf:"bytes,1,opt,name=http,proto3" json:"http,omitempty"`
@@ -257,7 +734,7 @@ type Server struct {
 
 func (x *Server) Reset() {
 	*x = Server{}
-	mi := &file_conf_conf_proto_msgTypes[4]
+	mi := &file_conf_conf_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -269,7 +746,7 @@ func (x *Server) String() string {
 func (*Server) ProtoMessage() {}
 
 func (x *Server) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[4]
+	mi := &file_conf_conf_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -282,7 +759,7 @@ func (x *Server) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Server.ProtoReflect.Descriptor instead.
 func (*Server) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{4}
+	return file_conf_conf_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Server) GetHttp() *Server_HTTP {
@@ -318,7 +795,7 @@ type Data struct {
 
 func (x *Data) Reset() {
 	*x = Data{}
-	mi := &file_conf_conf_proto_msgTypes[5]
+	mi := &file_conf_conf_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -330,7 +807,7 @@ func (x *Data) String() string {
 func (*Data) ProtoMessage() {}
 
 func (x *Data) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[5]
+	mi := &file_conf_conf_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -343,7 +820,7 @@ func (x *Data) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Data.ProtoReflect.Descriptor instead.
 func (*Data) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{5}
+	return file_conf_conf_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *Data) GetDatabase() *Data_Database {
@@ -386,7 +863,7 @@ type Monitoring struct {
 
 func (x *Monitoring) Reset() {
 	*x = Monitoring{}
-	mi := &file_conf_conf_proto_msgTypes[6]
+	mi := &file_conf_conf_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -398,7 +875,7 @@ func (x *Monitoring) String() string {
 func (*Monitoring) ProtoMessage() {}
 
 func (x *Monitoring) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[6]
+	mi := &file_conf_conf_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -411,7 +888,7 @@ func (x *Monitoring) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Monitoring.ProtoReflect.Descriptor instead.
 func (*Monitoring) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{6}
+	return file_conf_conf_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *Monitoring) GetServiceName() string {
@@ -454,7 +931,7 @@ type Tracing struct {
 
 func (x *Tracing) Reset() {
 	*x = Tracing{}
-	mi := &file_conf_conf_proto_msgTypes[7]
+	mi := &file_conf_conf_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -466,7 +943,7 @@ func (x *Tracing) String() string {
 func (*Tracing) ProtoMessage() {}
 
 func (x *Tracing) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[7]
+	mi := &file_conf_conf_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -479,7 +956,7 @@ func (x *Tracing) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Tracing.ProtoReflect.Descriptor instead.
 func (*Tracing) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{7}
+	return file_conf_conf_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *Tracing) GetExporter() string {
@@ -515,7 +992,7 @@ type Logging struct {
 
 func (x *Logging) Reset() {
 	*x = Logging{}
-	mi := &file_conf_conf_proto_msgTypes[8]
+	mi := &file_conf_conf_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -527,7 +1004,7 @@ func (x *Logging) String() string {
 func (*Logging) ProtoMessage() {}
 
 func (x *Logging) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[8]
+	mi := &file_conf_conf_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -540,7 +1017,7 @@ func (x *Logging) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Logging.ProtoReflect.Descriptor instead.
 func (*Logging) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{8}
+	return file_conf_conf_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *Logging) GetLevel() string {
@@ -574,7 +1051,7 @@ type Metrics struct {
 
 func (x *Metrics) Reset() {
 	*x = Metrics{}
-	mi := &file_conf_conf_proto_msgTypes[9]
+	mi := &file_conf_conf_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -586,7 +1063,7 @@ func (x *Metrics) String() string {
 func (*Metrics) ProtoMessage() {}
 
 func (x *Metrics) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[9]
+	mi := &file_conf_conf_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -599,7 +1076,7 @@ func (x *Metrics) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Metrics.ProtoReflect.Descriptor instead.
 func (*Metrics) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{9}
+	return file_conf_conf_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *Metrics) GetPrometheus() *Metrics_Prometheus {
@@ -620,7 +1097,7 @@ type Server_HTTP struct {
 
 func (x *Server_HTTP) Reset() {
 	*x = Server_HTTP{}
-	mi := &file_conf_conf_proto_msgTypes[10]
+	mi := &file_conf_conf_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -632,7 +1109,7 @@ func (x *Server_HTTP) String() string {
 func (*Server_HTTP) ProtoMessage() {}
 
 func (x *Server_HTTP) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[10]
+	mi := &file_conf_conf_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -645,7 +1122,7 @@ func (x *Server_HTTP) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Server_HTTP.ProtoReflect.Descriptor instead.
 func (*Server_HTTP) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{4, 0}
+	return file_conf_conf_proto_rawDescGZIP(), []int{10, 0}
 }
 
 func (x *Server_HTTP) GetNetwork() string {
@@ -680,7 +1157,7 @@ type Server_GRPC struct {
 
 func (x *Server_GRPC) Reset() {
 	*x = Server_GRPC{}
-	mi := &file_conf_conf_proto_msgTypes[11]
+	mi := &file_conf_conf_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -692,7 +1169,7 @@ func (x *Server_GRPC) String() string {
 func (*Server_GRPC) ProtoMessage() {}
 
 func (x *Server_GRPC) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[11]
+	mi := &file_conf_conf_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -705,7 +1182,7 @@ func (x *Server_GRPC) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Server_GRPC.ProtoReflect.Descriptor instead.
 func (*Server_GRPC) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{4, 1}
+	return file_conf_conf_proto_rawDescGZIP(), []int{10, 1}
 }
 
 func (x *Server_GRPC) GetNetwork() string {
@@ -739,7 +1216,7 @@ type Data_Database struct {
 
 func (x *Data_Database) Reset() {
 	*x = Data_Database{}
-	mi := &file_conf_conf_proto_msgTypes[12]
+	mi := &file_conf_conf_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -751,7 +1228,7 @@ func (x *Data_Database) String() string {
 func (*Data_Database) ProtoMessage() {}
 
 func (x *Data_Database) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[12]
+	mi := &file_conf_conf_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -764,7 +1241,7 @@ func (x *Data_Database) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Data_Database.ProtoReflect.Descriptor instead.
 func (*Data_Database) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{5, 0}
+	return file_conf_conf_proto_rawDescGZIP(), []int{11, 0}
 }
 
 func (x *Data_Database) GetDriver() string {
@@ -793,7 +1270,7 @@ type Data_Redis struct {
 
 func (x *Data_Redis) Reset() {
 	*x = Data_Redis{}
-	mi := &file_conf_conf_proto_msgTypes[13]
+	mi := &file_conf_conf_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -805,7 +1282,7 @@ func (x *Data_Redis) String() string {
 func (*Data_Redis) ProtoMessage() {}
 
 func (x *Data_Redis) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[13]
+	mi := &file_conf_conf_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -818,7 +1295,7 @@ func (x *Data_Redis) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Data_Redis.ProtoReflect.Descriptor instead.
 func (*Data_Redis) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{5, 1}
+	return file_conf_conf_proto_rawDescGZIP(), []int{11, 1}
 }
 
 func (x *Data_Redis) GetNetwork() string {
@@ -861,7 +1338,7 @@ type Data_Etcd struct {
 
 func (x *Data_Etcd) Reset() {
 	*x = Data_Etcd{}
-	mi := &file_conf_conf_proto_msgTypes[14]
+	mi := &file_conf_conf_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -873,7 +1350,7 @@ func (x *Data_Etcd) String() string {
 func (*Data_Etcd) ProtoMessage() {}
 
 func (x *Data_Etcd) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[14]
+	mi := &file_conf_conf_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -886,7 +1363,7 @@ func (x *Data_Etcd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Data_Etcd.ProtoReflect.Descriptor instead.
 func (*Data_Etcd) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{5, 2}
+	return file_conf_conf_proto_rawDescGZIP(), []int{11, 2}
 }
 
 func (x *Data_Etcd) GetEndpoints() []string {
@@ -918,18 +1395,20 @@ func (x *Data_Etcd) GetPassword() string {
 }
 
 type Data_Kafka struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Brokers       []string               `protobuf:"bytes,1,rep,name=brokers,proto3" json:"brokers,omitempty"`
-	GroupId       string                 `protobuf:"bytes,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
-	RetryCount    int32                  `protobuf:"varint,3,opt,name=retry_count,json=retryCount,proto3" json:"retry_count,omitempty"`
-	Timeout       *durationpb.Duration   `protobuf:"bytes,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Brokers         []string               `protobuf:"bytes,1,rep,name=brokers,proto3" json:"brokers,omitempty"`
+	GroupId         string                 `protobuf:"bytes,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	RetryCount      int32                  `protobuf:"varint,3,opt,name=retry_count,json=retryCount,proto3" json:"retry_count,omitempty"`
+	Timeout         *durationpb.Duration   `protobuf:"bytes,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	ConsumerRetries []*Data_ConsumerRetry  `protobuf:"bytes,5,rep,name=consumer_retries,json=consumerRetries,proto3" json:"consumer_retries,omitempty"` // 按主题配置消费失败的重试策略
+	ConsumerBatch   *Data_ConsumerBatch    `protobuf:"bytes,6,opt,name=consumer_batch,json=consumerBatch,proto3" json:"consumer_batch,omitempty"`       // 批量消费配置
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *Data_Kafka) Reset() {
 	*x = Data_Kafka{}
-	mi := &file_conf_conf_proto_msgTypes[15]
+	mi := &file_conf_conf_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -941,7 +1420,7 @@ func (x *Data_Kafka) String() string {
 func (*Data_Kafka) ProtoMessage() {}
 
 func (x *Data_Kafka) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[15]
+	mi := &file_conf_conf_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -954,7 +1433,7 @@ func (x *Data_Kafka) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Data_Kafka.ProtoReflect.Descriptor instead.
 func (*Data_Kafka) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{5, 3}
+	return file_conf_conf_proto_rawDescGZIP(), []int{11, 3}
 }
 
 func (x *Data_Kafka) GetBrokers() []string {
@@ -985,6 +1464,158 @@ func (x *Data_Kafka) GetTimeout() *durationpb.Duration {
 	return nil
 }
 
+func (x *Data_Kafka) GetConsumerRetries() []*Data_ConsumerRetry {
+	if x != nil {
+		return x.ConsumerRetries
+	}
+	return nil
+}
+
+func (x *Data_Kafka) GetConsumerBatch() *Data_ConsumerBatch {
+	if x != nil {
+		return x.ConsumerBatch
+	}
+	return nil
+}
+
+// 每个分区按批次拉取消息，批内按key分配到固定的worker并行处理，整批处理完后再提交位移
+type Data_ConsumerBatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MaxSize       int32                  `protobuf:"varint,1,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"` // 每批最多消息数，默认100
+	MaxWait       *durationpb.Duration   `protobuf:"bytes,2,opt,name=max_wait,json=maxWait,proto3" json:"max_wait,omitempty"`  // 凑批的最长等待时间，默认100ms
+	Workers       int32                  `protobuf:"varint,3,opt,name=workers,proto3" json:"workers,omitempty"`                // 每个分区的并发处理数，默认8，为1时退化为逐条顺序处理
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_ConsumerBatch) Reset() {
+	*x = Data_ConsumerBatch{}
+	mi := &file_conf_conf_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_ConsumerBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_ConsumerBatch) ProtoMessage() {}
+
+func (x *Data_ConsumerBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_ConsumerBatch.ProtoReflect.Descriptor instead.
+func (*Data_ConsumerBatch) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{11, 4}
+}
+
+func (x *Data_ConsumerBatch) GetMaxSize() int32 {
+	if x != nil {
+		return x.MaxSize
+	}
+	return 0
+}
+
+func (x *Data_ConsumerBatch) GetMaxWait() *durationpb.Duration {
+	if x != nil {
+		return x.MaxWait
+	}
+	return nil
+}
+
+func (x *Data_ConsumerBatch) GetWorkers() int32 {
+	if x != nil {
+		return x.Workers
+	}
+	return 0
+}
+
+// 消费失败后依次投递到 {topic}.retry.{n}，延迟到期后重新消费，重试耗尽后投递到 {topic}.dlq
+type Data_ConsumerRetry struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Topic             string                 `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	MaxRetries        int32                  `protobuf:"varint,2,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`                        // 最大重试次数，小于0表示不重试
+	InitialBackoff    *durationpb.Duration   `protobuf:"bytes,3,opt,name=initial_backoff,json=initialBackoff,proto3" json:"initial_backoff,omitempty"`             // 第一次重试的延迟，之后每次翻倍
+	MaxBackoff        *durationpb.Duration   `protobuf:"bytes,4,opt,name=max_backoff,json=maxBackoff,proto3" json:"max_backoff,omitempty"`                         // 重试延迟上限
+	DisableDeadLetter bool                   `protobuf:"varint,5,opt,name=disable_dead_letter,json=disableDeadLetter,proto3" json:"disable_dead_letter,omitempty"` // 重试耗尽后直接丢弃，不投递到死信队列
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Data_ConsumerRetry) Reset() {
+	*x = Data_ConsumerRetry{}
+	mi := &file_conf_conf_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_ConsumerRetry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_ConsumerRetry) ProtoMessage() {}
+
+func (x *Data_ConsumerRetry) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_ConsumerRetry.ProtoReflect.Descriptor instead.
+func (*Data_ConsumerRetry) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{11, 5}
+}
+
+func (x *Data_ConsumerRetry) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *Data_ConsumerRetry) GetMaxRetries() int32 {
+	if x != nil {
+		return x.MaxRetries
+	}
+	return 0
+}
+
+func (x *Data_ConsumerRetry) GetInitialBackoff() *durationpb.Duration {
+	if x != nil {
+		return x.InitialBackoff
+	}
+	return nil
+}
+
+func (x *Data_ConsumerRetry) GetMaxBackoff() *durationpb.Duration {
+	if x != nil {
+		return x.MaxBackoff
+	}
+	return nil
+}
+
+func (x *Data_ConsumerRetry) GetDisableDeadLetter() bool {
+	if x != nil {
+		return x.DisableDeadLetter
+	}
+	return false
+}
+
 type Tracing_Jaeger struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Endpoint      string                 `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
@@ -995,7 +1626,7 @@ type Tracing_Jaeger struct {
 
 func (x *Tracing_Jaeger) Reset() {
 	*x = Tracing_Jaeger{}
-	mi := &file_conf_conf_proto_msgTypes[16]
+	mi := &file_conf_conf_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1007,7 +1638,7 @@ func (x *Tracing_Jaeger) String() string {
 func (*Tracing_Jaeger) ProtoMessage() {}
 
 func (x *Tracing_Jaeger) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[16]
+	mi := &file_conf_conf_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1020,7 +1651,7 @@ func (x *Tracing_Jaeger) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Tracing_Jaeger.ProtoReflect.Descriptor instead.
 func (*Tracing_Jaeger) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{7, 0}
+	return file_conf_conf_proto_rawDescGZIP(), []int{13, 0}
 }
 
 func (x *Tracing_Jaeger) GetEndpoint() string {
@@ -1047,7 +1678,7 @@ type Metrics_Prometheus struct {
 
 func (x *Metrics_Prometheus) Reset() {
 	*x = Metrics_Prometheus{}
-	mi := &file_conf_conf_proto_msgTypes[17]
+	mi := &file_conf_conf_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1059,7 +1690,7 @@ func (x *Metrics_Prometheus) String() string {
 func (*Metrics_Prometheus) ProtoMessage() {}
 
 func (x *Metrics_Prometheus) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[17]
+	mi := &file_conf_conf_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1072,7 +1703,7 @@ func (x *Metrics_Prometheus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Metrics_Prometheus.ProtoReflect.Descriptor instead.
 func (*Metrics_Prometheus) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{9, 0}
+	return file_conf_conf_proto_rawDescGZIP(), []int{15, 0}
 }
 
 func (x *Metrics_Prometheus) GetEndpoint() string {
@@ -1094,7 +1725,7 @@ var File_conf_conf_proto protoreflect.FileDescriptor
 const file_conf_conf_proto_rawDesc = "" +
 	"\n" +
 	"\x0fconf/conf.proto\x12\n" +
-	"kratos.api\x1a\x1egoogle/protobuf/duration.proto\"\xf3\x01\n" +
+	"kratos.api\x1a\x1egoogle/protobuf/duration.proto\"\xed\x03\n" +
 	"\tBootstrap\x12*\n" +
 	"\x06server\x18\x01 \x01(\v2\x12.kratos.api.ServerR\x06server\x12$\n" +
 	"\x04data\x18\x02 \x01(\v2\x10.kratos.api.DataR\x04data\x12*\n" +
@@ -1102,18 +1733,67 @@ const file_conf_conf_proto_rawDesc = "" +
 	"\n" +
 	"monitoring\x18\x04 \x01(\v2\x16.kratos.api.MonitoringR\n" +
 	"monitoring\x120\n" +
-	"\bsecurity\x18\x05 \x01(\v2\x14.kratos.api.SecurityR\bsecurity\"H\n" +
+	"\bsecurity\x18\x05 \x01(\v2\x14.kratos.api.SecurityR\bsecurity\x12-\n" +
+	"\amessage\x18\x06 \x01(\v2\x13.kratos.api.MessageR\amessage\x12'\n" +
+	"\x05quota\x18\a \x01(\v2\x11.kratos.api.QuotaR\x05quota\x12-\n" +
+	"\awebhook\x18\b \x01(\v2\x13.kratos.api.WebhookR\awebhook\x126\n" +
+	"\n" +
+	"attachment\x18\t \x01(\v2\x16.kratos.api.AttachmentR\n" +
+	"attachment\x129\n" +
+	"\vidempotency\x18\n" +
+	" \x01(\v2\x17.kratos.api.IdempotencyR\vidempotency\"\xd6\x01\n" +
+	"\vIdempotency\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x121\n" +
+	"\x06window\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x06window\x12:\n" +
+	"\vtime_window\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\n" +
+	"timeWindow\x12@\n" +
+	"\x0eprocessing_ttl\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\rprocessingTtl\"\xd6\x02\n" +
+	"\n" +
+	"Attachment\x12\x18\n" +
+	"\astorage\x18\x01 \x01(\tR\astorage\x12\x1b\n" +
+	"\tlocal_dir\x18\x02 \x01(\tR\blocalDir\x12\x1d\n" +
+	"\n" +
+	"chunk_size\x18\x03 \x01(\x03R\tchunkSize\x12\"\n" +
+	"\rmax_file_size\x18\x04 \x01(\x03R\vmaxFileSize\x12\x1d\n" +
+	"\n" +
+	"user_quota\x18\x05 \x01(\x03R\tuserQuota\x12>\n" +
+	"\rupload_expire\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\fuploadExpire\x128\n" +
+	"\n" +
+	"url_expire\x18\a \x01(\v2\x19.google.protobuf.DurationR\turlExpire\x12\x1d\n" +
+	"\n" +
+	"public_url\x18\b \x01(\tR\tpublicUrl\x12\x16\n" +
+	"\x06secret\x18\t \x01(\tR\x06secret\"\x84\x01\n" +
+	"\aWebhook\x123\n" +
+	"\atimeout\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x12D\n" +
+	"\x10refresh_interval\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x0frefreshInterval\"\x85\x01\n" +
+	"\aMessage\x12>\n" +
+	"\rrecall_window\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\frecallWindow\x12:\n" +
+	"\vedit_window\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\n" +
+	"editWindow\"\xab\x02\n" +
+	"\x05Quota\x121\n" +
+	"\x06window\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x06window\x12\x1d\n" +
+	"\n" +
+	"chat_limit\x18\x02 \x01(\x05R\tchatLimit\x12#\n" +
+	"\rcontrol_limit\x18\x03 \x01(\x05R\fcontrolLimit\x12!\n" +
+	"\ftarget_limit\x18\x04 \x01(\x05R\vtargetLimit\x12%\n" +
+	"\x0espam_threshold\x18\x05 \x01(\x05R\rspamThreshold\x12>\n" +
+	"\rmute_duration\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\fmuteDuration\x12!\n" +
+	"\flock_strikes\x18\a \x01(\x05R\vlockStrikes\"H\n" +
 	"\bSecurity\x12\x1d\n" +
 	"\n" +
 	"white_list\x18\x01 \x03(\tR\twhiteList\x12\x1d\n" +
 	"\n" +
-	"black_list\x18\x02 \x03(\tR\tblackList\"A\n" +
+	"black_list\x18\x02 \x03(\tR\tblackList\"\x83\x01\n" +
 	"\x06Client\x127\n" +
 	"\vpush_client\x18\x01 \x01(\v2\x16.kratos.api.PushClientR\n" +
-	"pushClient\"U\n" +
+	"pushClient\x12@\n" +
+	"\x0eoffline_client\x18\x02 \x01(\v2\x19.kratos.api.OfflineClientR\rofflineClient\"U\n" +
 	"\n" +
 	"PushClient\x12\x12\n" +
 	"\x04addr\x18\x01 \x01(\tR\x04addr\x123\n" +
+	"\atimeout\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"X\n" +
+	"\rOfflineClient\x12\x12\n" +
+	"\x04addr\x18\x01 \x01(\tR\x04addr\x123\n" +
 	"\atimeout\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"\xd0\x02\n" +
 	"\x06Server\x12+\n" +
 	"\x04http\x18\x01 \x01(\v2\x17.kratos.api.Server.HTTPR\x04http\x12+\n" +
@@ -1126,7 +1806,7 @@ const file_conf_conf_proto_rawDesc = "" +
 	"\x04GRPC\x12\x18\n" +
 	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
 	"\x04addr\x18\x02 \x01(\tR\x04addr\x123\n" +
-	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"\xe8\x05\n" +
+	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"\xef\t\n" +
 	"\x04Data\x125\n" +
 	"\bdatabase\x18\x01 \x01(\v2\x19.kratos.api.Data.DatabaseR\bdatabase\x12,\n" +
 	"\x05redis\x18\x02 \x01(\v2\x16.kratos.api.Data.RedisR\x05redis\x12)\n" +
@@ -1144,13 +1824,27 @@ const file_conf_conf_proto_rawDesc = "" +
 	"\tendpoints\x18\x01 \x03(\tR\tendpoints\x12<\n" +
 	"\fdial_timeout\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\vdialTimeout\x12\x1a\n" +
 	"\busername\x18\x03 \x01(\tR\busername\x12\x1a\n" +
-	"\bpassword\x18\x04 \x01(\tR\bpassword\x1a\x92\x01\n" +
+	"\bpassword\x18\x04 \x01(\tR\bpassword\x1a\xa4\x02\n" +
 	"\x05Kafka\x12\x18\n" +
 	"\abrokers\x18\x01 \x03(\tR\abrokers\x12\x19\n" +
 	"\bgroup_id\x18\x02 \x01(\tR\agroupId\x12\x1f\n" +
 	"\vretry_count\x18\x03 \x01(\x05R\n" +
 	"retryCount\x123\n" +
-	"\atimeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"\xbc\x01\n" +
+	"\atimeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x12I\n" +
+	"\x10consumer_retries\x18\x05 \x03(\v2\x1e.kratos.api.Data.ConsumerRetryR\x0fconsumerRetries\x12E\n" +
+	"\x0econsumer_batch\x18\x06 \x01(\v2\x1e.kratos.api.Data.ConsumerBatchR\rconsumerBatch\x1az\n" +
+	"\rConsumerBatch\x12\x19\n" +
+	"\bmax_size\x18\x01 \x01(\x05R\amaxSize\x124\n" +
+	"\bmax_wait\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\amaxWait\x12\x18\n" +
+	"\aworkers\x18\x03 \x01(\x05R\aworkers\x1a\xf6\x01\n" +
+	"\rConsumerRetry\x12\x14\n" +
+	"\x05topic\x18\x01 \x01(\tR\x05topic\x12\x1f\n" +
+	"\vmax_retries\x18\x02 \x01(\x05R\n" +
+	"maxRetries\x12B\n" +
+	"\x0finitial_backoff\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x0einitialBackoff\x12:\n" +
+	"\vmax_backoff\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\n" +
+	"maxBackoff\x12.\n" +
+	"\x13disable_dead_letter\x18\x05 \x01(\bR\x11disableDeadLetter\"\xbc\x01\n" +
 	"\n" +
 	"Monitoring\x12!\n" +
 	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12-\n" +
@@ -1189,60 +1883,91 @@ func file_conf_conf_proto_rawDescGZIP() []byte {
 	return file_conf_conf_proto_rawDescData
 }
 
-var file_conf_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_conf_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_conf_conf_proto_goTypes = []any{
 	(*Bootstrap)(nil),           // 0: kratos.api.Bootstrap
-	(*Security)(nil),            // 1: kratos.api.Security
-	(*Client)(nil),              // 2: kratos.api.Client
-	(*PushClient)(nil),          // 3: kratos.api.PushClient
-	(*Server)(nil),              // 4: kratos.api.Server
-	(*Data)(nil),                // 5: kratos.api.Data
-	(*Monitoring)(nil),          // 6: kratos.api.Monitoring
-	(*Tracing)(nil),             // 7: kratos.api.Tracing
-	(*Logging)(nil),             // 8: kratos.api.Logging
-	(*Metrics)(nil),             // 9: kratos.api.Metrics
-	(*Server_HTTP)(nil),         // 10: kratos.api.Server.HTTP
-	(*Server_GRPC)(nil),         // 11: kratos.api.Server.GRPC
-	(*Data_Database)(nil),       // 12: kratos.api.Data.Database
-	(*Data_Redis)(nil),          // 13: kratos.api.Data.Redis
-	(*Data_Etcd)(nil),           // 14: kratos.api.Data.Etcd
-	(*Data_Kafka)(nil),          // 15: kratos.api.Data.Kafka
-	(*Tracing_Jaeger)(nil),      // 16: kratos.api.Tracing.Jaeger
-	(*Metrics_Prometheus)(nil),  // 17: kratos.api.Metrics.Prometheus
-	(*durationpb.Duration)(nil), // 18: google.protobuf.Duration
+	(*Idempotency)(nil),         // 1: kratos.api.Idempotency
+	(*Attachment)(nil),          // 2: kratos.api.Attachment
+	(*Webhook)(nil),             // 3: kratos.api.Webhook
+	(*Message)(nil),             // 4: kratos.api.Message
+	(*Quota)(nil),               // 5: kratos.api.Quota
+	(*Security)(nil),            // 6: kratos.api.Security
+	(*Client)(nil),              // 7: kratos.api.Client
+	(*PushClient)(nil),          // 8: kratos.api.PushClient
+	(*OfflineClient)(nil),       // 9: kratos.api.OfflineClient
+	(*Server)(nil),              // 10: kratos.api.Server
+	(*Data)(nil),                // 11: kratos.api.Data
+	(*Monitoring)(nil),          // 12: kratos.api.Monitoring
+	(*Tracing)(nil),             // 13: kratos.api.Tracing
+	(*Logging)(nil),             // 14: kratos.api.Logging
+	(*Metrics)(nil),             // 15: kratos.api.Metrics
+	(*Server_HTTP)(nil),         // 16: kratos.api.Server.HTTP
+	(*Server_GRPC)(nil),         // 17: kratos.api.Server.GRPC
+	(*Data_Database)(nil),       // 18: kratos.api.Data.Database
+	(*Data_Redis)(nil),          // 19: kratos.api.Data.Redis
+	(*Data_Etcd)(nil),           // 20: kratos.api.Data.Etcd
+	(*Data_Kafka)(nil),          // 21: kratos.api.Data.Kafka
+	(*Data_ConsumerBatch)(nil),  // 22: kratos.api.Data.ConsumerBatch
+	(*Data_ConsumerRetry)(nil),  // 23: kratos.api.Data.ConsumerRetry
+	(*Tracing_Jaeger)(nil),      // 24: kratos.api.Tracing.Jaeger
+	(*Metrics_Prometheus)(nil),  // 25: kratos.api.Metrics.Prometheus
+	(*durationpb.Duration)(nil), // 26: google.protobuf.Duration
 }
 var file_conf_conf_proto_depIdxs = []int32{
-	4,  // 0: kratos.api.Bootstrap.server:type_name -> kratos.api.Server
-	5,  // 1: kratos.api.Bootstrap.data:type_name -> kratos.api.Data
-	2,  // 2: kratos.api.Bootstrap.client:type_name -> kratos.api.Client
-	6,  // 3: kratos.api.Bootstrap.monitoring:type_name -> kratos.api.Monitoring
-	1,  // 4: kratos.api.Bootstrap.security:type_name -> kratos.api.Security
-	3,  // 5: kratos.api.Client.push_client:type_name -> kratos.api.PushClient
-	18, // 6: kratos.api.PushClient.timeout:type_name -> google.protobuf.Duration
-	10, // 7: kratos.api.Server.http:type_name -> kratos.api.Server.HTTP
-	11, // 8: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
-	12, // 9: kratos.api.Data.database:type_name -> kratos.api.Data.Database
-	13, // 10: kratos.api.Data.redis:type_name -> kratos.api.Data.Redis
-	14, // 11: kratos.api.Data.etcd:type_name -> kratos.api.Data.Etcd
-	15, // 12: kratos.api.Data.kafka:type_name -> kratos.api.Data.Kafka
-	7,  // 13: kratos.api.Monitoring.tracing:type_name -> kratos.api.Tracing
-	8,  // 14: kratos.api.Monitoring.logging:type_name -> kratos.api.Logging
-	9,  // 15: kratos.api.Monitoring.metrics:type_name -> kratos.api.Metrics
-	16, // 16: kratos.api.Tracing.jaeger:type_name -> kratos.api.Tracing.Jaeger
-	17, // 17: kratos.api.Metrics.prometheus:type_name -> kratos.api.Metrics.Prometheus
-	18, // 18: kratos.api.Server.HTTP.timeout:type_name -> google.protobuf.Duration
-	18, // 19: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
-	18, // 20: kratos.api.Data.Redis.read_timeout:type_name -> google.protobuf.Duration
-	18, // 21: kratos.api.Data.Redis.write_timeout:type_name -> google.protobuf.Duration
-	18, // 22: kratos.api.Data.Etcd.dial_timeout:type_name -> google.protobuf.Duration
-	18, // 23: kratos.api.Data.Kafka.timeout:type_name -> google.protobuf.Duration
-	18, // 24: kratos.api.Tracing.Jaeger.timeout:type_name -> google.protobuf.Duration
-	18, // 25: kratos.api.Metrics.Prometheus.timeout:type_name -> google.protobuf.Duration
-	26, // [26:26] is the sub-list for method output_type
-	26, // [26:26] is the sub-list for method input_type
-	26, // [26:26] is the sub-list for extension type_name
-	26, // [26:26] is the sub-list for extension extendee
-	0,  // [0:26] is the sub-list for field type_name
+	10, // 0: kratos.api.Bootstrap.server:type_name -> kratos.api.Server
+	11, // 1: kratos.api.Bootstrap.data:type_name -> kratos.api.Data
+	7,  // 2: kratos.api.Bootstrap.client:type_name -> kratos.api.Client
+	12, // 3: kratos.api.Bootstrap.monitoring:type_name -> kratos.api.Monitoring
+	6,  // 4: kratos.api.Bootstrap.security:type_name -> kratos.api.Security
+	4,  // 5: kratos.api.Bootstrap.message:type_name -> kratos.api.Message
+	5,  // 6: kratos.api.Bootstrap.quota:type_name -> kratos.api.Quota
+	3,  // 7: kratos.api.Bootstrap.webhook:type_name -> kratos.api.Webhook
+	2,  // 8: kratos.api.Bootstrap.attachment:type_name -> kratos.api.Attachment
+	1,  // 9: kratos.api.Bootstrap.idempotency:type_name -> kratos.api.Idempotency
+	26, // 10: kratos.api.Idempotency.window:type_name -> google.protobuf.Duration
+	26, // 11: kratos.api.Idempotency.time_window:type_name -> google.protobuf.Duration
+	26, // 12: kratos.api.Idempotency.processing_ttl:type_name -> google.protobuf.Duration
+	26, // 13: kratos.api.Attachment.upload_expire:type_name -> google.protobuf.Duration
+	26, // 14: kratos.api.Attachment.url_expire:type_name -> google.protobuf.Duration
+	26, // 15: kratos.api.Webhook.timeout:type_name -> google.protobuf.Duration
+	26, // 16: kratos.api.Webhook.refresh_interval:type_name -> google.protobuf.Duration
+	26, // 17: kratos.api.Message.recall_window:type_name -> google.protobuf.Duration
+	26, // 18: kratos.api.Message.edit_window:type_name -> google.protobuf.Duration
+	26, // 19: kratos.api.Quota.window:type_name -> google.protobuf.Duration
+	26, // 20: kratos.api.Quota.mute_duration:type_name -> google.protobuf.Duration
+	8,  // 21: kratos.api.Client.push_client:type_name -> kratos.api.PushClient
+	9,  // 22: kratos.api.Client.offline_client:type_name -> kratos.api.OfflineClient
+	26, // 23: kratos.api.PushClient.timeout:type_name -> google.protobuf.Duration
+	26, // 24: kratos.api.OfflineClient.timeout:type_name -> google.protobuf.Duration
+	16, // 25: kratos.api.Server.http:type_name -> kratos.api.Server.HTTP
+	17, // 26: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
+	18, // 27: kratos.api.Data.database:type_name -> kratos.api.Data.Database
+	19, // 28: kratos.api.Data.redis:type_name -> kratos.api.Data.Redis
+	20, // 29: kratos.api.Data.etcd:type_name -> kratos.api.Data.Etcd
+	21, // 30: kratos.api.Data.kafka:type_name -> kratos.api.Data.Kafka
+	13, // 31: kratos.api.Monitoring.tracing:type_name -> kratos.api.Tracing
+	14, // 32: kratos.api.Monitoring.logging:type_name -> kratos.api.Logging
+	15, // 33: kratos.api.Monitoring.metrics:type_name -> kratos.api.Metrics
+	24, // 34: kratos.api.Tracing.jaeger:type_name -> kratos.api.Tracing.Jaeger
+	25, // 35: kratos.api.Metrics.prometheus:type_name -> kratos.api.Metrics.Prometheus
+	26, // 36: kratos.api.Server.HTTP.timeout:type_name -> google.protobuf.Duration
+	26, // 37: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
+	26, // 38: kratos.api.Data.Redis.read_timeout:type_name -> google.protobuf.Duration
+	26, // 39: kratos.api.Data.Redis.write_timeout:type_name -> google.protobuf.Duration
+	26, // 40: kratos.api.Data.Etcd.dial_timeout:type_name -> google.protobuf.Duration
+	26, // 41: kratos.api.Data.Kafka.timeout:type_name -> google.protobuf.Duration
+	23, // 42: kratos.api.Data.Kafka.consumer_retries:type_name -> kratos.api.Data.ConsumerRetry
+	22, // 43: kratos.api.Data.Kafka.consumer_batch:type_name -> kratos.api.Data.ConsumerBatch
+	26, // 44: kratos.api.Data.ConsumerBatch.max_wait:type_name -> google.protobuf.Duration
+	26, // 45: kratos.api.Data.ConsumerRetry.initial_backoff:type_name -> google.protobuf.Duration
+	26, // 46: kratos.api.Data.ConsumerRetry.max_backoff:type_name -> google.protobuf.Duration
+	26, // 47: kratos.api.Tracing.Jaeger.timeout:type_name -> google.protobuf.Duration
+	26, // 48: kratos.api.Metrics.Prometheus.timeout:type_name -> google.protobuf.Duration
+	49, // [49:49] is the sub-list for method output_type
+	49, // [49:49] is the sub-list for method input_type
+	49, // [49:49] is the sub-list for extension type_name
+	49, // [49:49] is the sub-list for extension extendee
+	0,  // [0:49] is the sub-list for field type_name
 }
 
 func init() { file_conf_conf_proto_init() }
@@ -1256,7 +1981,7 @@ func file_conf_conf_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_conf_conf_proto_rawDesc), len(file_conf_conf_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   18,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,208 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/xinghe903/chatify/logic/internal/biz"
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+	"github.com/xinghe903/chatify/logic/internal/data/po"
+
+	"github.com/xinghe903/chatify/pkg/auth"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ biz.AttachmentRepo = (*attachmentRepo)(nil)
+
+// attachmentRepo 附件和分片上传仓库实现
+type attachmentRepo struct {
+	data      *Data
+	log       *log.Helper
+	sonyFlake *auth.Sonyflake
+}
+
+// NewAttachmentRepo 创建附件仓库实例
+func NewAttachmentRepo(data *Data, logger log.Logger) biz.AttachmentRepo {
+	return &attachmentRepo{
+		data:      data,
+		log:       log.NewHelper(logger),
+		sonyFlake: auth.NewSonyflake(),
+	}
+}
+
+// CreateUpload 保存上传会话
+func (r *attachmentRepo) CreateUpload(ctx context.Context, upload *bo.AttachmentUpload) error {
+	m := po.NewAttachmentUploadFromBo(upload)
+	var err error
+	if m.ID, err = r.sonyFlake.GenerateBase62(); err != nil {
+		return errors.Join(err, errors.New("failed to generate attachment upload ID"))
+	}
+	if err = r.data.db.WithContext(ctx).Create(m).Error; err != nil {
+		return errors.Join(err, errors.New("failed to create attachment upload"))
+	}
+	return nil
+}
+
+// GetUpload 查询上传会话及已上传的分片序号
+func (r *attachmentRepo) GetUpload(ctx context.Context, uploadId string) (*bo.AttachmentUpload, error) {
+	var m po.AttachmentUpload
+	err := r.data.db.WithContext(ctx).Where("upload_id = ?", uploadId).First(&m).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Join(err, errors.New("failed to get attachment upload"))
+	}
+	upload := m.ToBo()
+	upload.UploadedChunks = []int{}
+	err = r.data.db.WithContext(ctx).Model(&po.AttachmentChunk{}).
+		Where("upload_id = ?", uploadId).Order("chunk_index ASC").Pluck("chunk_index", &upload.UploadedChunks).Error
+	if err != nil {
+		return nil, errors.Join(err, errors.New("failed to list uploaded chunks"))
+	}
+	return upload, nil
+}
+
+// MarkChunkUploaded 记录分片已上传，重复上传的分片不重复记录
+func (r *attachmentRepo) MarkChunkUploaded(ctx context.Context, uploadId string, index int) (int, error) {
+	m := &po.AttachmentChunk{UploadID: uploadId, ChunkIndex: index}
+	var err error
+	if m.ID, err = r.sonyFlake.GenerateBase62(); err != nil {
+		return 0, errors.Join(err, errors.New("failed to generate attachment chunk ID"))
+	}
+	if err = r.data.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(m).Error; err != nil {
+		return 0, errors.Join(err, errors.New("failed to mark chunk uploaded"))
+	}
+	var count int64
+	err = r.data.db.WithContext(ctx).Model(&po.AttachmentChunk{}).Where("upload_id = ?", uploadId).Count(&count).Error
+	if err != nil {
+		return 0, errors.Join(err, errors.New("failed to count uploaded chunks"))
+	}
+	return int(count), nil
+}
+
+// CompleteUpload 在一个事务中标记上传完成、登记内容并保存附件
+func (r *attachmentRepo) CompleteUpload(ctx context.Context, uploadId string, attachment *bo.Attachment) (bool, error) {
+	object := &po.AttachmentObject{Sha256: attachment.Sha256, Size: attachment.Size, StorageKey: attachment.StorageKey}
+	m := po.NewAttachmentFromBo(attachment)
+	var err error
+	if object.ID, err = r.sonyFlake.GenerateBase62(); err != nil {
+		return false, errors.Join(err, errors.New("failed to generate attachment object ID"))
+	}
+	if m.ID, err = r.sonyFlake.GenerateBase62(); err != nil {
+		return false, errors.Join(err, errors.New("failed to generate attachment ID"))
+	}
+	completed := false
+	err = r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&po.AttachmentUpload{}).
+			Where("upload_id = ? AND status = ?", uploadId, bo.AttachmentUploadPending).
+			Updates(map[string]interface{}{
+				"status":        bo.AttachmentUploadCompleted,
+				"attachment_id": attachment.AttachmentId,
+				"sha256":        attachment.Sha256,
+			})
+		if result.Error != nil {
+			return errors.Join(result.Error, errors.New("failed to complete attachment upload"))
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(object).Error; err != nil {
+			return errors.Join(err, errors.New("failed to create attachment object"))
+		}
+		if err := tx.Create(m).Error; err != nil {
+			return errors.Join(err, errors.New("failed to create attachment"))
+		}
+		if err := tx.Where("upload_id = ?", uploadId).Delete(&po.AttachmentChunk{}).Error; err != nil {
+			return errors.Join(err, errors.New("failed to delete uploaded chunks"))
+		}
+		completed = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	attachment.CreatedAt = m.CreatedAt
+	return completed, nil
+}
+
+// DeleteUpload 删除上传会话及分片记录
+func (r *attachmentRepo) DeleteUpload(ctx context.Context, uploadId string) error {
+	return r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("upload_id = ?", uploadId).Delete(&po.AttachmentChunk{}).Error; err != nil {
+			return errors.Join(err, errors.New("failed to delete uploaded chunks"))
+		}
+		if err := tx.Where("upload_id = ?", uploadId).Delete(&po.AttachmentUpload{}).Error; err != nil {
+			return errors.Join(err, errors.New("failed to delete attachment upload"))
+		}
+		return nil
+	})
+}
+
+// ListExpiredUploads 查询过期仍未完成的上传
+func (r *attachmentRepo) ListExpiredUploads(ctx context.Context, before time.Time, limit int) ([]*bo.AttachmentUpload, error) {
+	var models []*po.AttachmentUpload
+	err := r.data.db.WithContext(ctx).
+		Where("status = ? AND expires_at < ?", bo.AttachmentUploadPending, before).
+		Order("expires_at ASC").Limit(limit).Find(&models).Error
+	if err != nil {
+		return nil, errors.Join(err, errors.New("failed to list expired attachment uploads"))
+	}
+	uploads := make([]*bo.AttachmentUpload, 0, len(models))
+	for _, m := range models {
+		uploads = append(uploads, m.ToBo())
+	}
+	return uploads, nil
+}
+
+// GetAttachment 查询附件及其内容的存储位置
+func (r *attachmentRepo) GetAttachment(ctx context.Context, attachmentId string) (*bo.Attachment, error) {
+	var m po.Attachment
+	err := r.data.db.WithContext(ctx).Where("attachment_id = ?", attachmentId).First(&m).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Join(err, errors.New("failed to get attachment"))
+	}
+	key, err := r.GetStorageKey(ctx, m.Sha256)
+	if err != nil {
+		return nil, err
+	}
+	return m.ToBo(key), nil
+}
+
+// GetStorageKey 按sha256查询已保存内容的存储位置
+func (r *attachmentRepo) GetStorageKey(ctx context.Context, sha256 string) (string, error) {
+	var keys []string
+	err := r.data.db.WithContext(ctx).Model(&po.AttachmentObject{}).
+		Where("sha256 = ?", sha256).Limit(1).Pluck("storage_key", &keys).Error
+	if err != nil {
+		return "", errors.Join(err, errors.New("failed to get attachment object"))
+	}
+	if len(keys) == 0 {
+		return "", nil
+	}
+	return keys[0], nil
+}
+
+// UsedBytes 统计用户附件和未过期上传占用的存储
+func (r *attachmentRepo) UsedBytes(ctx context.Context, userId string, now time.Time) (int64, error) {
+	var attachments, uploads int64
+	err := r.data.db.WithContext(ctx).Model(&po.Attachment{}).
+		Where("user_id = ?", userId).Select("COALESCE(SUM(size), 0)").Scan(&attachments).Error
+	if err != nil {
+		return 0, errors.Join(err, errors.New("failed to sum attachment size"))
+	}
+	err = r.data.db.WithContext(ctx).Model(&po.AttachmentUpload{}).
+		Where("user_id = ? AND status = ? AND expires_at >= ?", userId, bo.AttachmentUploadPending, now).
+		Select("COALESCE(SUM(size), 0)").Scan(&uploads).Error
+	if err != nil {
+		return 0, errors.Join(err, errors.New("failed to sum pending upload size"))
+	}
+	return attachments + uploads, nil
+}
@@ -0,0 +1,150 @@
+package data
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/xinghe903/chatify/logic/internal/biz"
+	"github.com/xinghe903/chatify/logic/internal/conf"
+)
+
+// 附件存储后端类型
+const (
+	AttachmentStorageLocal = "local"
+)
+
+const (
+	defaultAttachmentLocalDir = "./data/attachments"
+	// composedFileName 上传目录中合并后内容的文件名
+	composedFileName = "composed"
+)
+
+// NewAttachmentStorage 按配置创建附件存储后端
+func NewAttachmentStorage(c *conf.Bootstrap) (biz.AttachmentStorage, error) {
+	ac := c.GetAttachment()
+	switch ac.GetStorage() {
+	case "", AttachmentStorageLocal:
+		dir := ac.GetLocalDir()
+		if dir == "" {
+			dir = defaultAttachmentLocalDir
+		}
+		return newLocalAttachmentStorage(dir)
+	default:
+		return nil, fmt.Errorf("unsupported attachment storage %q", ac.GetStorage())
+	}
+}
+
+var _ biz.AttachmentStorage = (*localAttachmentStorage)(nil)
+
+// localAttachmentStorage 本地文件系统存储
+// 分片保存在 {root}/uploads/{uploadId}/{index}，对象保存在 {root}/{key}
+type localAttachmentStorage struct {
+	root string
+}
+
+func newLocalAttachmentStorage(root string) (*localAttachmentStorage, error) {
+	if err := os.MkdirAll(filepath.Join(root, "uploads"), 0o755); err != nil {
+		return nil, errors.Join(err, errors.New("failed to create attachment storage directory"))
+	}
+	return &localAttachmentStorage{root: root}, nil
+}
+
+func (s *localAttachmentStorage) uploadDir(uploadId string) string {
+	return filepath.Join(s.root, "uploads", filepath.Base(uploadId))
+}
+
+// WriteChunk 先写入临时文件再重命名，避免读到写了一半的分片
+func (s *localAttachmentStorage) WriteChunk(ctx context.Context, uploadId string, index int, data []byte) error {
+	dir := s.uploadDir(uploadId)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return errors.Join(err, errors.New("failed to create upload directory"))
+	}
+	tmp, err := os.CreateTemp(dir, "chunk-*.tmp")
+	if err != nil {
+		return errors.Join(err, errors.New("failed to create chunk file"))
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return errors.Join(err, errors.New("failed to write chunk file"))
+	}
+	if err = tmp.Close(); err != nil {
+		return errors.Join(err, errors.New("failed to close chunk file"))
+	}
+	if err = os.Rename(tmp.Name(), filepath.Join(dir, strconv.Itoa(index))); err != nil {
+		return errors.Join(err, errors.New("failed to save chunk file"))
+	}
+	return nil
+}
+
+// Compose 按序号把分片追加到合并文件，同时计算sha256
+func (s *localAttachmentStorage) Compose(ctx context.Context, uploadId string, chunkCount int) (string, int64, error) {
+	dir := s.uploadDir(uploadId)
+	out, err := os.Create(filepath.Join(dir, composedFileName))
+	if err != nil {
+		return "", 0, errors.Join(err, errors.New("failed to create composed file"))
+	}
+	defer out.Close()
+	h := sha256.New()
+	w := io.MultiWriter(out, h)
+	var size int64
+	for i := range chunkCount {
+		if err = ctx.Err(); err != nil {
+			return "", 0, err
+		}
+		n, err := appendChunk(w, filepath.Join(dir, strconv.Itoa(i)))
+		if err != nil {
+			return "", 0, errors.Join(err, fmt.Errorf("failed to compose chunk %d", i))
+		}
+		size += n
+	}
+	if err = out.Sync(); err != nil {
+		return "", 0, errors.Join(err, errors.New("failed to sync composed file"))
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
+func appendChunk(w io.Writer, name string) (int64, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	return io.Copy(w, f)
+}
+
+// Commit 把合并文件移动到对象位置，同一文件系统内的重命名是原子的
+func (s *localAttachmentStorage) Commit(ctx context.Context, uploadId string, key string) error {
+	target := filepath.Join(s.root, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return errors.Join(err, errors.New("failed to create object directory"))
+	}
+	if err := os.Rename(filepath.Join(s.uploadDir(uploadId), composedFileName), target); err != nil {
+		return errors.Join(err, errors.New("failed to commit object"))
+	}
+	return nil
+}
+
+// DeleteUpload 删除上传目录
+func (s *localAttachmentStorage) DeleteUpload(ctx context.Context, uploadId string) error {
+	if err := os.RemoveAll(s.uploadDir(uploadId)); err != nil {
+		return errors.Join(err, errors.New("failed to delete upload directory"))
+	}
+	return nil
+}
+
+// Open 打开对象文件
+func (s *localAttachmentStorage) Open(ctx context.Context, key string) (io.ReadSeekCloser, error) {
+	f, err := os.Open(filepath.Join(s.root, filepath.FromSlash(key)))
+	if err != nil {
+		return nil, errors.Join(err, errors.New("failed to open object"))
+	}
+	return f, nil
+}
@@ -0,0 +1,69 @@
+package data
+
+import (
+	"context"
+	"errors"
+
+	"github.com/xinghe903/chatify/logic/internal/biz"
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+	"github.com/xinghe903/chatify/logic/internal/data/po"
+
+	"github.com/xinghe903/chatify/pkg/auth"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+)
+
+var _ biz.BotRepo = (*botRepo)(nil)
+
+// botRepo 机器人账号仓库实现
+type botRepo struct {
+	data      *Data
+	log       *log.Helper
+	sonyFlake *auth.Sonyflake
+}
+
+// NewBotRepo 创建机器人账号仓库实例
+func NewBotRepo(data *Data, logger log.Logger) biz.BotRepo {
+	return &botRepo{
+		data:      data,
+		log:       log.NewHelper(logger),
+		sonyFlake: auth.NewSonyflake(),
+	}
+}
+
+// CreateBot 保存机器人账号
+func (r *botRepo) CreateBot(ctx context.Context, bot *bo.Bot) error {
+	m := po.NewBotFromBo(bot)
+	var err error
+	if m.ID, err = r.sonyFlake.GenerateBase62(); err != nil {
+		return errors.Join(err, errors.New("failed to generate bot ID"))
+	}
+	if err = r.data.db.WithContext(ctx).Create(m).Error; err != nil {
+		return errors.Join(err, errors.New("failed to create bot"))
+	}
+	bot.CreatedAt = m.CreatedAt
+	return nil
+}
+
+// GetBotByToken 按token摘要查询机器人
+func (r *botRepo) GetBotByToken(ctx context.Context, tokenHash string) (*bo.Bot, error) {
+	var m po.Bot
+	err := r.data.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&m).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Join(err, errors.New("failed to get bot"))
+	}
+	return m.ToBo(), nil
+}
+
+// DeleteBot 删除机器人账号
+func (r *botRepo) DeleteBot(ctx context.Context, botId string) (bool, error) {
+	result := r.data.db.WithContext(ctx).Where("bot_id = ?", botId).Delete(&po.Bot{})
+	if result.Error != nil {
+		return false, errors.Join(result.Error, errors.New("failed to delete bot"))
+	}
+	return result.RowsAffected > 0, nil
+}
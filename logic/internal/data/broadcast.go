@@ -0,0 +1,263 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/xinghe903/chatify/logic/internal/biz"
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+	"github.com/xinghe903/chatify/logic/internal/data/po"
+
+	"github.com/xinghe903/chatify/pkg/auth"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+)
+
+const broadcastChunkBatchSize = 100 // 每次插入分片数量
+
+var _ biz.BroadcastRepo = (*broadcastRepo)(nil)
+
+// broadcastRepo 广播任务仓库实现
+type broadcastRepo struct {
+	data      *Data
+	log       *log.Helper
+	sonyFlake *auth.Sonyflake
+}
+
+// NewBroadcastRepo 创建广播任务仓库实例
+func NewBroadcastRepo(data *Data, logger log.Logger) biz.BroadcastRepo {
+	return &broadcastRepo{
+		data:      data,
+		log:       log.NewHelper(logger),
+		sonyFlake: auth.NewSonyflake(),
+	}
+}
+
+// CreateJob 在同一事务中创建任务及分片
+func (r *broadcastRepo) CreateJob(ctx context.Context, job *bo.BroadcastJob, chunks []*bo.BroadcastChunk) error {
+	poJob := po.NewBroadcastJobFromBo(job)
+	var err error
+	if poJob.ID, err = r.sonyFlake.GenerateBase62(); err != nil {
+		return errors.Join(err, errors.New("failed to generate broadcast job ID"))
+	}
+	poChunks := make([]*po.BroadcastChunk, 0, len(chunks))
+	for _, chunk := range chunks {
+		poChunk, err := po.NewBroadcastChunkFromBo(chunk)
+		if err != nil {
+			return errors.Join(err, errors.New("failed to encode broadcast chunk"))
+		}
+		if poChunk.ID, err = r.sonyFlake.GenerateBase62(); err != nil {
+			return errors.Join(err, errors.New("failed to generate broadcast chunk ID"))
+		}
+		poChunks = append(poChunks, poChunk)
+	}
+	err = r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(poJob).Error; err != nil {
+			return errors.Join(err, errors.New("failed to create broadcast job"))
+		}
+		if err := tx.CreateInBatches(poChunks, broadcastChunkBatchSize).Error; err != nil {
+			return errors.Join(err, errors.New("failed to create broadcast chunks"))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	job.CreatedAt = poJob.CreatedAt
+	return nil
+}
+
+// GetJob 查询广播任务
+func (r *broadcastRepo) GetJob(ctx context.Context, jobId string) (*bo.BroadcastJob, error) {
+	var job po.BroadcastJob
+	err := r.data.db.WithContext(ctx).Where("job_id = ?", jobId).First(&job).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Join(err, errors.New("failed to get broadcast job"))
+	}
+	return job.ToBo(), nil
+}
+
+// UpdateJobStatus 条件更新任务状态
+func (r *broadcastRepo) UpdateJobStatus(ctx context.Context, jobId string, from []bo.BroadcastJobStatus, to bo.BroadcastJobStatus) (bool, error) {
+	statuses := make([]string, 0, len(from))
+	for _, status := range from {
+		statuses = append(statuses, string(status))
+	}
+	result := r.data.db.WithContext(ctx).
+		Model(&po.BroadcastJob{}).
+		Where("job_id = ?", jobId).
+		Where("status IN ?", statuses).
+		Updates(map[string]interface{}{
+			"status":     string(to),
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, errors.Join(result.Error, errors.New("failed to update broadcast job status"))
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetChunk 查询分片
+func (r *broadcastRepo) GetChunk(ctx context.Context, jobId string, chunkIndex int32) (*bo.BroadcastChunk, error) {
+	var chunk po.BroadcastChunk
+	err := r.data.db.WithContext(ctx).
+		Where("job_id = ? AND chunk_index = ?", jobId, chunkIndex).
+		First(&chunk).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Join(err, errors.New("failed to get broadcast chunk"))
+	}
+	return chunk.ToBo()
+}
+
+// ListChunkIndexes 查询指定状态的分片序号
+func (r *broadcastRepo) ListChunkIndexes(ctx context.Context, jobId string, status bo.BroadcastChunkStatus) ([]int32, error) {
+	var indexes []int32
+	err := r.data.db.WithContext(ctx).
+		Model(&po.BroadcastChunk{}).
+		Where("job_id = ? AND status = ?", jobId, string(status)).
+		Order("chunk_index ASC").
+		Pluck("chunk_index", &indexes).Error
+	if err != nil {
+		return nil, errors.Join(err, errors.New("failed to list broadcast chunks"))
+	}
+	return indexes, nil
+}
+
+// ClaimChunk 通过条件更新抢占分片，保证同一分片只会被投递一次
+func (r *broadcastRepo) ClaimChunk(ctx context.Context, jobId string, chunkIndex int32) (string, error) {
+	token, err := r.sonyFlake.GenerateBase62()
+	if err != nil {
+		return "", errors.Join(err, errors.New("failed to generate broadcast chunk claim token"))
+	}
+	result := r.data.db.WithContext(ctx).
+		Model(&po.BroadcastChunk{}).
+		Where("job_id = ? AND chunk_index = ?", jobId, chunkIndex).
+		Where("status = ?", string(bo.BroadcastChunkStatusPending)).
+		Updates(map[string]interface{}{
+			"status":      string(bo.BroadcastChunkStatusProcessing),
+			"claim_token": token,
+			"updated_at":  time.Now(),
+		})
+	if result.Error != nil {
+		return "", errors.Join(result.Error, errors.New("failed to claim broadcast chunk"))
+	}
+	if result.RowsAffected == 0 {
+		return "", nil
+	}
+	return token, nil
+}
+
+// ResetStaleChunks 逐个通过条件更新重置超时分片，多个实例同时重置时同一分片只会被一个实例重置
+func (r *broadcastRepo) ResetStaleChunks(ctx context.Context, staleBefore time.Time, limit int) ([]*bo.BroadcastChunkTask, error) {
+	var rows []struct {
+		JobID      string
+		ChunkIndex int32
+		JobStatus  string
+	}
+	err := r.data.db.WithContext(ctx).
+		Model(&po.BroadcastChunk{}).
+		Select("chatify_broadcast_chunk.job_id, chatify_broadcast_chunk.chunk_index, chatify_broadcast_job.status AS job_status").
+		Joins("JOIN chatify_broadcast_job ON chatify_broadcast_job.job_id = chatify_broadcast_chunk.job_id").
+		Where("chatify_broadcast_chunk.status = ? AND chatify_broadcast_chunk.updated_at < ?", string(bo.BroadcastChunkStatusProcessing), staleBefore).
+		Where("chatify_broadcast_job.status IN ?", []string{string(bo.BroadcastJobStatusRunning), string(bo.BroadcastJobStatusPaused)}).
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, errors.Join(err, errors.New("failed to list stale broadcast chunks"))
+	}
+	var tasks []*bo.BroadcastChunkTask
+	for _, row := range rows {
+		result := r.data.db.WithContext(ctx).
+			Model(&po.BroadcastChunk{}).
+			Where("job_id = ? AND chunk_index = ?", row.JobID, row.ChunkIndex).
+			Where("status = ? AND updated_at < ?", string(bo.BroadcastChunkStatusProcessing), staleBefore).
+			Updates(map[string]interface{}{
+				"status":      string(bo.BroadcastChunkStatusPending),
+				"claim_token": "",
+				"updated_at":  time.Now(),
+			})
+		if result.Error != nil {
+			return tasks, errors.Join(result.Error, errors.New("failed to reset stale broadcast chunk"))
+		}
+		if result.RowsAffected > 0 && row.JobStatus == string(bo.BroadcastJobStatusRunning) {
+			tasks = append(tasks, &bo.BroadcastChunkTask{JobId: row.JobID, ChunkIndex: row.ChunkIndex})
+		}
+	}
+	return tasks, nil
+}
+
+// FinishChunk 记录分片结果并累加任务进度
+// 只有仍持有抢占令牌的投递中分片会被更新，分片超时被重置或重新抢占后旧的结果不再计入任务进度
+func (r *broadcastRepo) FinishChunk(ctx context.Context, chunk *bo.BroadcastChunk, sentUsers, failedUsers int64) (bool, error) {
+	now := time.Now()
+	finished := false
+	err := r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&po.BroadcastChunk{}).
+			Where("job_id = ? AND chunk_index = ?", chunk.JobId, chunk.ChunkIndex).
+			Where("status = ? AND claim_token = ?", string(bo.BroadcastChunkStatusProcessing), chunk.ClaimToken).
+			Updates(map[string]interface{}{
+				"status":      string(chunk.Status),
+				"task_id":     chunk.TaskId,
+				"description": chunk.Description,
+				"claim_token": "",
+				"updated_at":  now,
+			})
+		if result.Error != nil {
+			return errors.Join(result.Error, errors.New("failed to update broadcast chunk"))
+		}
+		if result.RowsAffected != 1 {
+			return nil
+		}
+		if err := tx.Model(&po.BroadcastJob{}).
+			Where("job_id = ?", chunk.JobId).
+			Updates(map[string]interface{}{
+				"finished_chunks": gorm.Expr("finished_chunks + 1"),
+				"sent_users":      gorm.Expr("sent_users + ?", sentUsers),
+				"failed_users":    gorm.Expr("failed_users + ?", failedUsers),
+				"updated_at":      now,
+			}).Error; err != nil {
+			return errors.Join(err, errors.New("failed to update broadcast job progress"))
+		}
+		// 全部分片处理完成后任务置为完成，已取消的任务保持取消状态
+		if err := tx.Model(&po.BroadcastJob{}).
+			Where("job_id = ?", chunk.JobId).
+			Where("finished_chunks >= total_chunks").
+			Where("status IN ?", []string{string(bo.BroadcastJobStatusRunning), string(bo.BroadcastJobStatusPaused)}).
+			Updates(map[string]interface{}{
+				"status":     string(bo.BroadcastJobStatusCompleted),
+				"updated_at": now,
+			}).Error; err != nil {
+			return errors.Join(err, errors.New("failed to complete broadcast job"))
+		}
+		finished = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return finished, nil
+}
+
+// CancelPendingChunks 取消所有待投递分片
+func (r *broadcastRepo) CancelPendingChunks(ctx context.Context, jobId string) error {
+	result := r.data.db.WithContext(ctx).
+		Model(&po.BroadcastChunk{}).
+		Where("job_id = ? AND status = ?", jobId, string(bo.BroadcastChunkStatusPending)).
+		Updates(map[string]interface{}{
+			"status":     string(bo.BroadcastChunkStatusCanceled),
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return errors.Join(result.Error, errors.New("failed to cancel broadcast chunks"))
+	}
+	r.log.WithContext(ctx).Infof("Canceled %d pending broadcast chunks. jobID=%s", result.RowsAffected, jobId)
+	return nil
+}
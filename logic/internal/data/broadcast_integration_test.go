@@ -0,0 +1,80 @@
+//go:build integration
+
+package data
+
+// 需要可用的MySQL，通过 MYSQL_DSN 指定，例如:
+//   MYSQL_DSN="root:password@tcp(127.0.0.1:3306)/chatify?charset=utf8mb4&parseTime=True&loc=Local" go test -tags integration -run Integration ./internal/data/
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+	"github.com/xinghe903/chatify/logic/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// TestIntegrationFinishReclaimedChunk 分片超时被重新抢占后，旧持有者的结果不计入任务进度
+func TestIntegrationFinishReclaimedChunk(t *testing.T) {
+	dsn := os.Getenv("MYSQL_DSN")
+	if dsn == "" {
+		t.Skip("MYSQL_DSN is not set")
+	}
+	logger := log.NewStdLogger(io.Discard)
+	db, err := initMySQLClient(&conf.Data{Database: &conf.Data_Database{Source: dsn}}, logger)
+	if err != nil {
+		t.Fatalf("connect mysql: %v", err)
+	}
+	repo := NewBroadcastRepo(&Data{db: db}, logger)
+	ctx := context.Background()
+
+	jobId := fmt.Sprintf("bjit%d", time.Now().UnixNano())
+	job := &bo.BroadcastJob{JobId: jobId, Status: bo.BroadcastJobStatusRunning, TotalUsers: 2, TotalChunks: 1}
+	if err = repo.CreateJob(ctx, job, bo.SplitBroadcastChunks(jobId, []string{"u1", "u2"})); err != nil {
+		t.Fatalf("CreateJob() error = %v", err)
+	}
+	staleToken, err := repo.ClaimChunk(ctx, jobId, 0)
+	if err != nil || staleToken == "" {
+		t.Fatalf("ClaimChunk() = %q, %v, want a token", staleToken, err)
+	}
+	// 租期到期，分片被重置并由另一个实例重新抢占
+	if _, err = repo.ResetStaleChunks(ctx, time.Now().Add(time.Second), 100); err != nil {
+		t.Fatalf("ResetStaleChunks() error = %v", err)
+	}
+	token, err := repo.ClaimChunk(ctx, jobId, 0)
+	if err != nil || token == "" || token == staleToken {
+		t.Fatalf("ClaimChunk() after reset = %q, %v, want a new token", token, err)
+	}
+
+	chunk := &bo.BroadcastChunk{JobId: jobId, ChunkIndex: 0, Status: bo.BroadcastChunkStatusSent, ClaimToken: staleToken}
+	finished, err := repo.FinishChunk(ctx, chunk, 2, 0)
+	if err != nil || finished {
+		t.Fatalf("FinishChunk() with stale token = %v, %v, want false", finished, err)
+	}
+	if job, err = repo.GetJob(ctx, jobId); err != nil {
+		t.Fatalf("GetJob() error = %v", err)
+	}
+	if job.FinishedChunks != 0 || job.SentUsers != 0 || job.Status != bo.BroadcastJobStatusRunning {
+		t.Fatalf("job after stale finish = %+v, want no progress", job)
+	}
+
+	chunk.ClaimToken = token
+	if finished, err = repo.FinishChunk(ctx, chunk, 2, 0); err != nil || !finished {
+		t.Fatalf("FinishChunk() with current token = %v, %v, want true", finished, err)
+	}
+	// 同一结果重复提交不会再次累加
+	if finished, err = repo.FinishChunk(ctx, chunk, 2, 0); err != nil || finished {
+		t.Fatalf("FinishChunk() twice = %v, %v, want false", finished, err)
+	}
+	if job, err = repo.GetJob(ctx, jobId); err != nil {
+		t.Fatalf("GetJob() error = %v", err)
+	}
+	if job.FinishedChunks != 1 || job.SentUsers != 2 || job.Status != bo.BroadcastJobStatusCompleted {
+		t.Fatalf("job after finish = %+v, want one finished chunk and completed", job)
+	}
+}
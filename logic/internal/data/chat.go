@@ -0,0 +1,204 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/xinghe903/chatify/logic/internal/biz"
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+	"github.com/xinghe903/chatify/logic/internal/data/po"
+
+	"github.com/xinghe903/chatify/pkg/auth"
+
+	im_v1 "github.com/xinghe903/chatify/api/im/v1"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ biz.ChatRepo = (*chatRepo)(nil)
+
+// chatRepo 聊天消息仓库实现
+type chatRepo struct {
+	data      *Data
+	log       *log.Helper
+	sonyFlake *auth.Sonyflake
+}
+
+// NewChatRepo 创建聊天消息仓库实例
+func NewChatRepo(data *Data, logger log.Logger) biz.ChatRepo {
+	return &chatRepo{
+		data:      data,
+		log:       log.NewHelper(logger),
+		sonyFlake: auth.NewSonyflake(),
+	}
+}
+
+// SaveMessage 保存聊天消息，msg_id已存在时不覆盖，用已保存的记录填充message
+// 重试的消息在保存后失败过，再次保存时继续后续的投递
+func (r *chatRepo) SaveMessage(ctx context.Context, message *bo.ChatMessage) (bool, error) {
+	now := time.Now()
+	m := po.NewChatMessageFromBo(message)
+	m.SentAt = now.UnixMilli()
+	m.CreatedAt = now
+	m.UpdatedAt = now
+	var err error
+	if m.ID, err = r.sonyFlake.GenerateBase62(); err != nil {
+		return false, errors.Join(err, errors.New("failed to generate chat message ID"))
+	}
+	result := r.data.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(m)
+	if result.Error != nil {
+		return false, errors.Join(result.Error, errors.New("failed to save chat message"))
+	}
+	if result.RowsAffected > 0 {
+		message.SentAt = m.SentAt
+		message.CreatedAt = m.CreatedAt
+		return true, nil
+	}
+	var saved po.ChatMessage
+	if err = r.data.db.WithContext(ctx).Where("msg_id = ?", message.MsgId).First(&saved).Error; err != nil {
+		return false, errors.Join(err, errors.New("failed to reload chat message"))
+	}
+	mentionUserIds, mentionAll := message.MentionUserIds, message.MentionAll
+	*message = *saved.ToBo()
+	message.MentionUserIds, message.MentionAll = mentionUserIds, mentionAll
+	return false, nil
+}
+
+// GetMessage 查询聊天消息
+func (r *chatRepo) GetMessage(ctx context.Context, msgId string) (*bo.ChatMessage, error) {
+	var m po.ChatMessage
+	err := r.data.db.WithContext(ctx).Where("msg_id = ?", msgId).First(&m).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Join(err, errors.New("failed to get chat message"))
+	}
+	return m.ToBo(), nil
+}
+
+// ReviseMessage 撤回或编辑消息，撤回时清空消息内容
+func (r *chatRepo) ReviseMessage(ctx context.Context, msgId string, status bo.ChatMessageStatus, content []byte, operatorId string) (bool, error) {
+	now := time.Now()
+	result := r.data.db.WithContext(ctx).
+		Model(&po.ChatMessage{}).
+		Where("msg_id = ? AND status <> ?", msgId, string(bo.ChatMessageStatusRecalled)).
+		Updates(map[string]interface{}{
+			"status":     string(status),
+			"content":    content,
+			"revised_by": operatorId,
+			"revised_at": now.Unix(),
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return false, errors.Join(result.Error, errors.New("failed to revise chat message"))
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// MarkDelivered 记录消息完成投递的时间，只记录第一次
+func (r *chatRepo) MarkDelivered(ctx context.Context, msgId string, deliveredAt int64) error {
+	err := r.data.db.WithContext(ctx).
+		Model(&po.ChatMessage{}).
+		Where("msg_id = ? AND delivered_at = 0", msgId).
+		Updates(map[string]interface{}{
+			"delivered_at": deliveredAt,
+			"updated_at":   time.Now(),
+		}).Error
+	if err != nil {
+		return errors.Join(err, errors.New("failed to mark chat message delivered"))
+	}
+	return nil
+}
+
+// CountUnread 统计用户在会话中晚于since收到的未撤回消息数
+func (r *chatRepo) CountUnread(ctx context.Context, userId string, conversationType im_v1.TargetType, conversationId string, since time.Time) (int64, error) {
+	query := r.data.db.WithContext(ctx).
+		Model(&po.ChatMessage{}).
+		Where("conversation_type = ? AND created_at > ? AND status <> ?",
+			int32(conversationType), since, string(bo.ChatMessageStatusRecalled))
+	if conversationType == im_v1.TargetType_GROUP {
+		query = query.Where("conversation_id = ? AND from_user_id <> ?", conversationId, userId)
+	} else {
+		// 单聊消息以接收者作为会话ID保存
+		query = query.Where("conversation_id = ? AND from_user_id = ?", userId, conversationId)
+	}
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, errors.Join(err, errors.New("failed to count unread messages"))
+	}
+	return count, nil
+}
+
+// ListHistory 按(sent_at, msg_id)游标扫描会话时间线，排除用户已删除的消息，结果按时间正序返回
+func (r *chatRepo) ListHistory(ctx context.Context, query *bo.HistoryQuery) ([]*bo.ChatMessage, error) {
+	db := r.data.db.WithContext(ctx).
+		Model(&po.ChatMessage{}).
+		Where("conversation_key = ?", query.ConversationKey).
+		Where("NOT EXISTS (SELECT 1 FROM chatify_chat_message_deletion d WHERE d.user_id = ? AND d.msg_id = chatify_chat_message.msg_id AND d.deleted_at IS NULL)", query.UserId)
+	anchor := query.Anchor
+	switch {
+	case anchor == nil:
+		db = db.Order("sent_at DESC, msg_id DESC")
+	case query.After:
+		db = db.Where("sent_at > ? OR (sent_at = ? AND msg_id > ?)", anchor.SentAt, anchor.SentAt, anchor.MsgId).
+			Order("sent_at ASC, msg_id ASC")
+	default:
+		db = db.Where("sent_at < ? OR (sent_at = ? AND msg_id < ?)", anchor.SentAt, anchor.SentAt, anchor.MsgId).
+			Order("sent_at DESC, msg_id DESC")
+	}
+	var rows []*po.ChatMessage
+	if err := db.Limit(query.Limit).Find(&rows).Error; err != nil {
+		return nil, errors.Join(err, errors.New("failed to list chat history"))
+	}
+	messages := make([]*bo.ChatMessage, 0, len(rows))
+	for _, row := range rows {
+		messages = append(messages, row.ToBo())
+	}
+	if !query.After {
+		slices.Reverse(messages)
+	}
+	return messages, nil
+}
+
+// ListSince 按序号增量扫描会话消息，排除用户已删除的消息
+func (r *chatRepo) ListSince(ctx context.Context, userId, conversationKey string, sinceSeq int64, limit int) ([]*bo.ChatMessage, error) {
+	var rows []*po.ChatMessage
+	err := r.data.db.WithContext(ctx).
+		Where("conversation_key = ? AND seq > ?", conversationKey, sinceSeq).
+		Where("NOT EXISTS (SELECT 1 FROM chatify_chat_message_deletion d WHERE d.user_id = ? AND d.msg_id = chatify_chat_message.msg_id AND d.deleted_at IS NULL)", userId).
+		Order("seq ASC").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, errors.Join(err, errors.New("failed to list chat messages since seq"))
+	}
+	messages := make([]*bo.ChatMessage, 0, len(rows))
+	for _, row := range rows {
+		messages = append(messages, row.ToBo())
+	}
+	return messages, nil
+}
+
+// DeleteForUser 为用户隐藏消息，重复删除时忽略
+func (r *chatRepo) DeleteForUser(ctx context.Context, userId string, msgIds []string) error {
+	rows := make([]*po.ChatMessageDeletion, 0, len(msgIds))
+	for _, msgId := range msgIds {
+		id, err := r.sonyFlake.GenerateBase62()
+		if err != nil {
+			return errors.Join(err, errors.New("failed to generate chat message deletion ID"))
+		}
+		row := &po.ChatMessageDeletion{UserID: userId, MsgID: msgId}
+		row.ID = id
+		rows = append(rows, row)
+	}
+	err := r.data.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+	if err != nil {
+		return errors.Join(err, errors.New("failed to delete chat history"))
+	}
+	return nil
+}
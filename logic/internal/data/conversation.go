@@ -0,0 +1,244 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/xinghe903/chatify/logic/internal/biz"
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+	"github.com/xinghe903/chatify/logic/internal/data/po"
+
+	"github.com/xinghe903/chatify/pkg/auth"
+
+	im_v1 "github.com/xinghe903/chatify/api/im/v1"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ biz.ConversationRepo = (*conversationRepo)(nil)
+
+// conversationRepo 会话仓库实现
+type conversationRepo struct {
+	data      *Data
+	log       *log.Helper
+	sonyFlake *auth.Sonyflake
+}
+
+// NewConversationRepo 创建会话仓库实例
+func NewConversationRepo(data *Data, logger log.Logger) biz.ConversationRepo {
+	return &conversationRepo{
+		data:      data,
+		log:       log.NewHelper(logger),
+		sonyFlake: auth.NewSonyflake(),
+	}
+}
+
+// SaveMute 保存免打扰设置
+func (r *conversationRepo) SaveMute(ctx context.Context, userId string, conversationType im_v1.TargetType, conversationId string, muted bool, muteUntil int64) error {
+	m := &po.Conversation{
+		UserID:           userId,
+		ConversationType: int32(conversationType),
+		ConversationID:   conversationId,
+		Muted:            muted,
+		MuteUntil:        muteUntil,
+	}
+	err := r.upsert(ctx, m, map[string]interface{}{
+		"muted":      muted,
+		"mute_until": muteUntil,
+		"updated_at": time.Now(),
+	})
+	if err != nil {
+		return errors.Join(err, errors.New("failed to save conversation mute"))
+	}
+	return nil
+}
+
+// SavePinned 保存置顶设置
+func (r *conversationRepo) SavePinned(ctx context.Context, userId string, conversationType im_v1.TargetType, conversationId string, pinned bool) error {
+	m := &po.Conversation{
+		UserID:           userId,
+		ConversationType: int32(conversationType),
+		ConversationID:   conversationId,
+		Pinned:           pinned,
+	}
+	err := r.upsert(ctx, m, map[string]interface{}{
+		"pinned":     pinned,
+		"updated_at": time.Now(),
+	})
+	if err != nil {
+		return errors.Join(err, errors.New("failed to save conversation pinned"))
+	}
+	return nil
+}
+
+// AppendMessage 更新会话最后一条消息，为接收者增加未读数，被@的接收者记录@时间
+// 消费乱序时只保留时间最新的消息作为摘要，last_message_at必须最后更新
+func (r *conversationRepo) AppendMessage(ctx context.Context, message *bo.ChatMessage, recipients []string) error {
+	messageAt := message.CreatedAt.UnixMilli()
+	preview := bo.MessagePreview(message.Content)
+	newRow := func(userId string) (*po.Conversation, error) {
+		id, err := r.sonyFlake.GenerateBase62()
+		if err != nil {
+			return nil, errors.Join(err, errors.New("failed to generate conversation ID"))
+		}
+		m := &po.Conversation{
+			UserID:           userId,
+			ConversationType: int32(message.ConversationType),
+			ConversationID:   message.PeerConversationId(userId),
+			LastMsgID:        message.MsgId,
+			LastSenderID:     message.FromUserId,
+			LastPreview:      preview,
+			LastMessageAt:    messageAt,
+		}
+		m.ID = id
+		return m, nil
+	}
+	lastMessage := clause.Set{
+		{Column: clause.Column{Name: "last_msg_id"}, Value: gorm.Expr("IF(VALUES(last_message_at) >= last_message_at, VALUES(last_msg_id), last_msg_id)")},
+		{Column: clause.Column{Name: "last_sender_id"}, Value: gorm.Expr("IF(VALUES(last_message_at) >= last_message_at, VALUES(last_sender_id), last_sender_id)")},
+		{Column: clause.Column{Name: "last_preview"}, Value: gorm.Expr("IF(VALUES(last_message_at) >= last_message_at, VALUES(last_preview), last_preview)")},
+		{Column: clause.Column{Name: "updated_at"}, Value: gorm.Expr("VALUES(updated_at)")},
+		{Column: clause.Column{Name: "last_message_at"}, Value: gorm.Expr("GREATEST(last_message_at, VALUES(last_message_at))")},
+	}
+
+	sender, err := newRow(message.FromUserId)
+	if err != nil {
+		return err
+	}
+	if err = r.data.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   conversationColumns,
+		DoUpdates: lastMessage,
+	}).Create(sender).Error; err != nil {
+		return errors.Join(err, errors.New("failed to update sender conversation"))
+	}
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	rows := make([]*po.Conversation, 0, len(recipients))
+	for _, userId := range recipients {
+		row, err := newRow(userId)
+		if err != nil {
+			return err
+		}
+		row.UnreadCount = 1
+		if message.IsMentioned(userId) {
+			row.MentionedAt = messageAt
+		}
+		rows = append(rows, row)
+	}
+	incrUnread := append(clause.Set{
+		{Column: clause.Column{Name: "unread_count"}, Value: gorm.Expr("unread_count + 1")},
+		{Column: clause.Column{Name: "mentioned_at"}, Value: gorm.Expr("GREATEST(mentioned_at, VALUES(mentioned_at))")},
+	}, lastMessage...)
+	if err = r.data.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   conversationColumns,
+		DoUpdates: incrUnread,
+	}).CreateInBatches(rows, 500).Error; err != nil {
+		return errors.Join(err, errors.New("failed to update recipient conversations"))
+	}
+
+	pipe := r.data.redisClient.Pipeline()
+	for _, row := range rows {
+		field := unreadField(message.ConversationType, row.ConversationID)
+		incrUnreadScript.Eval(ctx, pipe, []string{redisUnreadKeyPrefix + row.UserID}, field)
+	}
+	if _, err = pipe.Exec(ctx); err != nil {
+		return errors.Join(err, errors.New("failed to increase cached unread count"))
+	}
+	return nil
+}
+
+// ReviseLastMessage 更新以该消息为最后一条消息的会话摘要
+func (r *conversationRepo) ReviseLastMessage(ctx context.Context, msgId, preview string) error {
+	err := r.data.db.WithContext(ctx).
+		Model(&po.Conversation{}).
+		Where("last_msg_id = ?", msgId).
+		Updates(map[string]interface{}{
+			"last_preview": preview,
+			"updated_at":   time.Now(),
+		}).Error
+	if err != nil {
+		return errors.Join(err, errors.New("failed to revise conversation preview"))
+	}
+	return nil
+}
+
+// ListConversations 分页查询会话，只返回有过消息的会话
+func (r *conversationRepo) ListConversations(ctx context.Context, userId string, offset, limit int) ([]*bo.Conversation, int64, error) {
+	query := r.data.db.WithContext(ctx).
+		Model(&po.Conversation{}).
+		Where("user_id = ? AND last_message_at > 0", userId)
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Join(err, errors.New("failed to count conversations"))
+	}
+	var rows []*po.Conversation
+	if err := query.Order("pinned DESC, last_message_at DESC").Offset(offset).Limit(limit).Find(&rows).Error; err != nil {
+		return nil, 0, errors.Join(err, errors.New("failed to list conversations"))
+	}
+	conversations := make([]*bo.Conversation, 0, len(rows))
+	for _, row := range rows {
+		conversations = append(conversations, row.ToBo())
+	}
+	return conversations, total, nil
+}
+
+// ListMuted 查询免打扰生效中的会话
+func (r *conversationRepo) ListMuted(ctx context.Context, userId string, now int64) ([]*bo.ConversationMute, error) {
+	var rows []*po.Conversation
+	err := r.data.db.WithContext(ctx).
+		Where("user_id = ? AND muted = ? AND (mute_until = 0 OR mute_until > ?)", userId, true, now).
+		Order("updated_at DESC").Find(&rows).Error
+	if err != nil {
+		return nil, errors.Join(err, errors.New("failed to list muted conversations"))
+	}
+	mutes := make([]*bo.ConversationMute, 0, len(rows))
+	for _, row := range rows {
+		mutes = append(mutes, &bo.ConversationMute{
+			ConversationType: im_v1.TargetType(row.ConversationType),
+			ConversationId:   row.ConversationID,
+			Muted:            row.Muted,
+			MuteUntil:        row.MuteUntil,
+		})
+	}
+	return mutes, nil
+}
+
+// ListMutedUsers 按批查询开启了免打扰的接收者，按用户查询可以使用唯一索引
+func (r *conversationRepo) ListMutedUsers(ctx context.Context, message *bo.ChatMessage, recipients []string, now int64) ([]string, error) {
+	if len(recipients) == 0 {
+		return nil, nil
+	}
+	// 接收者视角的会话ID对所有接收者相同：单聊为发送者，群聊为群ID
+	conversationId := message.PeerConversationId(recipients[0])
+	var muted []string
+	for start := 0; start < len(recipients); start += bo.MaxTargetUsers {
+		var userIds []string
+		err := r.data.db.WithContext(ctx).Model(&po.Conversation{}).
+			Where("user_id IN ? AND conversation_type = ? AND conversation_id = ?",
+				recipients[start:min(start+bo.MaxTargetUsers, len(recipients))], int32(message.ConversationType), conversationId).
+			Where("muted = ? AND (mute_until = 0 OR mute_until > ?)", true, now).
+			Pluck("user_id", &userIds).Error
+		if err != nil {
+			return nil, errors.Join(err, errors.New("failed to list muted users"))
+		}
+		muted = append(muted, userIds...)
+	}
+	return muted, nil
+}
+
+// upsert 插入会话，已存在时只更新给定字段
+func (r *conversationRepo) upsert(ctx context.Context, m *po.Conversation, updates map[string]interface{}) error {
+	var err error
+	if m.ID, err = r.sonyFlake.GenerateBase62(); err != nil {
+		return errors.Join(err, errors.New("failed to generate conversation ID"))
+	}
+	return r.data.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   conversationColumns,
+		DoUpdates: clause.Assignments(updates),
+	}).Create(m).Error
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/xinghe903/chatify/logic/internal/conf"
+	"github.com/xinghe903/chatify/logic/internal/data/po"
 
 	"github.com/xinghe903/chatify/pkg/model"
 
@@ -26,6 +27,34 @@ var ProviderSet = wire.NewSet(NewData,
 	NewKafkaConsumer,
 	NewMessageDedupRepo,
 	NewKafkaProducer,
+	NewBroadcastConsumer,
+	NewBroadcastRepo,
+	NewScheduleRepo,
+	NewLeaderElector,
+	NewOfflineClient,
+	NewGroupRepo,
+	NewConversationRepo,
+	NewChatRepo,
+	NewReadStateRepo,
+	NewSequenceRepo,
+	NewQuotaRepo,
+	NewPushTemplateRepo,
+	NewUserLocaleRepo,
+	NewFunnelConsumer,
+	NewFunnelRepo,
+	NewDataReportConsumer,
+	NewReportSchemaRepo,
+	NewDataReportRepo,
+	NewUserAttributeRepo,
+	NewBotRepo,
+	NewWebhookRepo,
+	NewWebhookSender,
+	NewWebhookConsumer,
+	NewUserStateConsumer,
+	NewAttachmentRepo,
+	NewAttachmentStorage,
+	NewNotificationPreferenceRepo,
+	NewIdempotencyRepo,
 )
 
 // Data 数据层主结构
@@ -99,7 +128,19 @@ func initMySQLClient(c *conf.Data, logg log.Logger) (*gorm.DB, error) {
 	if err := sqlDB.Ping(); err != nil {
 		return nil, fmt.Errorf("failed to ping mysql: %w", err)
 	}
-	db.AutoMigrate()
+	db.AutoMigrate(po.BroadcastJob{}, po.BroadcastChunk{}, po.PushSchedule{}, po.PushScheduleFire{},
+		po.GroupMember{}, po.ChatMessage{}, po.Conversation{},
+		po.ChatMessageDeletion{}, po.PushTemplate{}, po.UserLocale{},
+		po.DeliveryFunnel{}, po.ReportSchema{}, po.DataReport{},
+		po.Bot{}, po.WebhookSubscription{}, po.UserProfile{}, po.UserTag{},
+		po.Attachment{}, po.AttachmentObject{}, po.AttachmentUpload{}, po.AttachmentChunk{},
+		po.NotificationPreference{}, po.PushSuppression{})
+	// 上报的唯一索引改为按用户划分，删除旧的全局唯一索引
+	if db.Migrator().HasIndex(&po.DataReport{}, "idx_report_id") {
+		if err := db.Migrator().DropIndex(&po.DataReport{}, "idx_report_id"); err != nil {
+			log.NewHelper(logg).Warnf("failed to drop legacy data report index: %v", err)
+		}
+	}
 
 	log.NewHelper(logg).Info("MySQL client initialized successfully")
 
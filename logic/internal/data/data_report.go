@@ -0,0 +1,189 @@
+package data
+
+import (
+	"context"
+	"errors"
+
+	"github.com/xinghe903/chatify/logic/internal/biz"
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+	"github.com/xinghe903/chatify/logic/internal/data/po"
+
+	"github.com/xinghe903/chatify/pkg/auth"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// reportSchemaMaxRetries 并发注册同名结构时版本号冲突的重试次数
+	reportSchemaMaxRetries = 3
+	// mysqlErrDuplicateEntry 唯一索引冲突的错误码
+	mysqlErrDuplicateEntry = 1062
+)
+
+var (
+	_ biz.ReportSchemaRepo = (*reportSchemaRepo)(nil)
+	_ biz.DataReportRepo   = (*dataReportRepo)(nil)
+)
+
+// reportSchemaRepo 数据上报结构仓库实现
+type reportSchemaRepo struct {
+	data      *Data
+	log       *log.Helper
+	sonyFlake *auth.Sonyflake
+}
+
+// NewReportSchemaRepo 创建数据上报结构仓库实例
+func NewReportSchemaRepo(data *Data, logger log.Logger) biz.ReportSchemaRepo {
+	return &reportSchemaRepo{
+		data:      data,
+		log:       log.NewHelper(logger),
+		sonyFlake: auth.NewSonyflake(),
+	}
+}
+
+// CreateSchema 以同名结构的最大版本加1保存新版本，唯一索引冲突时重新计算版本
+func (r *reportSchemaRepo) CreateSchema(ctx context.Context, schema *bo.ReportSchema) error {
+	var err error
+	for i := 0; i < reportSchemaMaxRetries; i++ {
+		if err = r.createSchema(ctx, schema); !isDuplicateKey(err) {
+			return err
+		}
+	}
+	return err
+}
+
+func (r *reportSchemaRepo) createSchema(ctx context.Context, schema *bo.ReportSchema) error {
+	var latest int32
+	err := r.data.db.WithContext(ctx).Model(&po.ReportSchema{}).
+		Where("name = ?", schema.Name).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&latest).Error
+	if err != nil {
+		return errors.Join(err, errors.New("failed to query latest report schema version"))
+	}
+	schema.Version = latest + 1
+	m, err := po.NewReportSchemaFromBo(schema)
+	if err != nil {
+		return errors.Join(err, errors.New("failed to encode report schema"))
+	}
+	if m.ID, err = r.sonyFlake.GenerateBase62(); err != nil {
+		return errors.Join(err, errors.New("failed to generate report schema ID"))
+	}
+	if err = r.data.db.WithContext(ctx).Create(m).Error; err != nil {
+		return errors.Join(err, errors.New("failed to create report schema"))
+	}
+	schema.CreatedAt = m.CreatedAt
+	return nil
+}
+
+// isDuplicateKey 判断是否为唯一索引冲突
+func isDuplicateKey(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry
+}
+
+// GetSchema 查询结构，version为0时返回最新版本
+func (r *reportSchemaRepo) GetSchema(ctx context.Context, name string, version int32) (*bo.ReportSchema, error) {
+	var m po.ReportSchema
+	db := r.data.db.WithContext(ctx).Where("name = ?", name)
+	if version > 0 {
+		db = db.Where("version = ?", version)
+	} else {
+		db = db.Order("version DESC")
+	}
+	if err := db.First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Join(err, errors.New("failed to get report schema"))
+	}
+	schema, err := m.ToBo()
+	if err != nil {
+		return nil, errors.Join(err, errors.New("failed to decode report schema"))
+	}
+	return schema, nil
+}
+
+// ListSchemas 按名称和版本正序查询结构
+func (r *reportSchemaRepo) ListSchemas(ctx context.Context, name string) ([]*bo.ReportSchema, error) {
+	var rows []*po.ReportSchema
+	db := r.data.db.WithContext(ctx)
+	if name != "" {
+		db = db.Where("name = ?", name)
+	}
+	if err := db.Order("name ASC, version ASC").Find(&rows).Error; err != nil {
+		return nil, errors.Join(err, errors.New("failed to list report schemas"))
+	}
+	schemas := make([]*bo.ReportSchema, 0, len(rows))
+	for _, row := range rows {
+		schema, err := row.ToBo()
+		if err != nil {
+			return nil, errors.Join(err, errors.New("failed to decode report schema"))
+		}
+		schemas = append(schemas, schema)
+	}
+	return schemas, nil
+}
+
+// dataReportRepo 数据上报存储实现，只提供追加写入和查询
+type dataReportRepo struct {
+	data      *Data
+	log       *log.Helper
+	sonyFlake *auth.Sonyflake
+}
+
+// NewDataReportRepo 创建数据上报存储实例
+func NewDataReportRepo(data *Data, logger log.Logger) biz.DataReportRepo {
+	return &dataReportRepo{
+		data:      data,
+		log:       log.NewHelper(logger),
+		sonyFlake: auth.NewSonyflake(),
+	}
+}
+
+// AppendReports 追加写入上报，重复消费时同一用户report_id冲突的行忽略
+// report_id来自客户端的msg_id，唯一索引按用户划分，不同用户使用相同的msg_id不会互相覆盖
+func (r *dataReportRepo) AppendReports(ctx context.Context, userId string, reports []*bo.DataReport) error {
+	rows := make([]*po.DataReport, 0, len(reports))
+	for _, report := range reports {
+		id, err := r.sonyFlake.GenerateBase62()
+		if err != nil {
+			return errors.Join(err, errors.New("failed to generate data report ID"))
+		}
+		row := po.NewDataReportFromBo(userId, report)
+		row.ID = id
+		rows = append(rows, row)
+	}
+	err := r.data.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "user_id"}, {Name: "report_id"}}, DoNothing: true}).
+		Create(&rows).Error
+	if err != nil {
+		return errors.Join(err, errors.New("failed to append data reports"))
+	}
+	return nil
+}
+
+// ListReports 按上报时间和report_id正序查询用户的上报
+func (r *dataReportRepo) ListReports(ctx context.Context, query *bo.DataReportQuery) ([]*bo.StoredDataReport, error) {
+	db := r.data.db.WithContext(ctx).
+		Where("user_id = ? AND timestamp >= ? AND timestamp < ?", query.UserId, query.StartTime, query.EndTime)
+	if query.Schema != "" {
+		db = db.Where("`schema` = ?", query.Schema)
+	}
+	if query.AfterReportId != "" {
+		db = db.Where("(timestamp > ? OR (timestamp = ? AND report_id > ?))",
+			query.AfterTimestamp, query.AfterTimestamp, query.AfterReportId)
+	}
+	var rows []*po.DataReport
+	if err := db.Order("timestamp ASC, report_id ASC").Limit(query.Limit).Find(&rows).Error; err != nil {
+		return nil, errors.Join(err, errors.New("failed to list data reports"))
+	}
+	reports := make([]*bo.StoredDataReport, 0, len(rows))
+	for _, row := range rows {
+		reports = append(reports, row.ToBo())
+	}
+	return reports, nil
+}
@@ -0,0 +1,117 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/xinghe903/chatify/logic/internal/biz"
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+	"github.com/xinghe903/chatify/logic/internal/data/po"
+
+	"github.com/xinghe903/chatify/pkg/auth"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// redisFunnelReceiptKeyPrefix 回执统计标记，key格式为：chatify:logic:funnel:receipt:{stage}:{contentId}:{userId}
+	redisFunnelReceiptKeyPrefix  = "chatify:logic:funnel:receipt:"
+	redisFunnelReceiptExpiration = 30 * 24 * time.Hour
+)
+
+var _ biz.FunnelRepo = (*funnelRepo)(nil)
+
+// funnelRepo 送达漏斗计数仓库实现
+type funnelRepo struct {
+	data      *Data
+	log       *log.Helper
+	sonyFlake *auth.Sonyflake
+}
+
+// NewFunnelRepo 创建送达漏斗计数仓库实例
+func NewFunnelRepo(data *Data, logger log.Logger) biz.FunnelRepo {
+	return &funnelRepo{
+		data:      data,
+		log:       log.NewHelper(logger),
+		sonyFlake: auth.NewSonyflake(),
+	}
+}
+
+// AddCounts 按(content_id, bucket_start, stage)累加计数，不存在时插入
+func (r *funnelRepo) AddCounts(ctx context.Context, counts []*bo.FunnelCount) error {
+	rows := make([]*po.DeliveryFunnel, 0, len(counts))
+	for _, count := range counts {
+		id, err := r.sonyFlake.GenerateBase62()
+		if err != nil {
+			return errors.Join(err, errors.New("failed to generate delivery funnel ID"))
+		}
+		row := po.NewDeliveryFunnelFromBo(count)
+		row.ID = id
+		rows = append(rows, row)
+	}
+	err := r.data.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "content_id"}, {Name: "bucket_start"}, {Name: "stage"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"total":      gorm.Expr("total + VALUES(total)"),
+			"updated_at": time.Now(),
+		}),
+	}).Create(&rows).Error
+	if err != nil {
+		return errors.Join(err, errors.New("failed to add delivery funnel counts"))
+	}
+	return nil
+}
+
+// ListCounts 查询内容在[start, end)之间的时间段计数
+func (r *funnelRepo) ListCounts(ctx context.Context, contentId string, start, end int64) ([]*bo.FunnelCount, error) {
+	var rows []*po.DeliveryFunnel
+	err := r.data.db.WithContext(ctx).
+		Where("content_id = ? AND bucket_start >= ? AND bucket_start < ?", contentId, start, end).
+		Order("bucket_start ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, errors.Join(err, errors.New("failed to list delivery funnel counts"))
+	}
+	counts := make([]*bo.FunnelCount, 0, len(rows))
+	for _, row := range rows {
+		counts = append(counts, row.ToBo())
+	}
+	return counts, nil
+}
+
+// MarkReceipts 通过一次pipeline为每条回执执行 SET NX EX
+func (r *funnelRepo) MarkReceipts(ctx context.Context, events []*bo.DeliveryEvent) ([]bool, error) {
+	pipe := r.data.redisClient.Pipeline()
+	cmds := make([]*redis.BoolCmd, 0, len(events))
+	for _, event := range events {
+		cmds = append(cmds, pipe.SetNX(ctx, receiptKey(event), 1, redisFunnelReceiptExpiration))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, errors.Join(err, errors.New("failed to mark receipts"))
+	}
+	result := make([]bool, 0, len(cmds))
+	for _, cmd := range cmds {
+		result = append(result, cmd.Val())
+	}
+	return result, nil
+}
+
+// UnmarkReceipts 删除回执统计标记
+func (r *funnelRepo) UnmarkReceipts(ctx context.Context, events []*bo.DeliveryEvent) error {
+	keys := make([]string, 0, len(events))
+	for _, event := range events {
+		keys = append(keys, receiptKey(event))
+	}
+	if err := r.data.redisClient.Del(ctx, keys...).Err(); err != nil {
+		return errors.Join(err, errors.New("failed to unmark receipts"))
+	}
+	return nil
+}
+
+func receiptKey(event *bo.DeliveryEvent) string {
+	return redisFunnelReceiptKeyPrefix + string(event.Stage) + ":" + event.ContentId + ":" + event.UserId
+}
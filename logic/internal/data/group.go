@@ -0,0 +1,55 @@
+package data
+
+import (
+	"context"
+	"errors"
+
+	"github.com/xinghe903/chatify/logic/internal/biz"
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+	"github.com/xinghe903/chatify/logic/internal/data/po"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+)
+
+var _ biz.GroupRepo = (*groupRepo)(nil)
+
+// groupRepo 群成员仓库实现
+type groupRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+// NewGroupRepo 创建群成员仓库实例
+func NewGroupRepo(data *Data, logger log.Logger) biz.GroupRepo {
+	return &groupRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+// GetMember 查询群成员
+func (r *groupRepo) GetMember(ctx context.Context, groupId, userId string) (*bo.GroupMember, error) {
+	var m po.GroupMember
+	err := r.data.db.WithContext(ctx).Where("group_id = ? AND user_id = ?", groupId, userId).First(&m).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Join(err, errors.New("failed to get group member"))
+	}
+	return m.ToBo(), nil
+}
+
+// ListMembers 查询群内全部成员
+func (r *groupRepo) ListMembers(ctx context.Context, groupId string) ([]*bo.GroupMember, error) {
+	var rows []*po.GroupMember
+	if err := r.data.db.WithContext(ctx).Where("group_id = ?", groupId).Find(&rows).Error; err != nil {
+		return nil, errors.Join(err, errors.New("failed to list group members"))
+	}
+	members := make([]*bo.GroupMember, 0, len(rows))
+	for _, row := range rows {
+		members = append(members, row.ToBo())
+	}
+	return members, nil
+}
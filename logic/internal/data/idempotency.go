@@ -0,0 +1,117 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/xinghe903/chatify/logic/internal/biz"
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+	"github.com/xinghe903/chatify/logic/internal/conf"
+
+	"github.com/xinghe903/chatify/pkg/auth/sign"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+var _ biz.IdempotencyRepo = (*idempotencyRepo)(nil)
+
+// idempotencyRepo 幂等请求仓库实现，使用防重放校验器在Redis中保存处理记录
+type idempotencyRepo struct {
+	checker       *sign.ReplayChecker
+	secret        string
+	processingTTL time.Duration
+	log           *log.Helper
+}
+
+// NewIdempotencyRepo 创建幂等请求仓库实例
+func NewIdempotencyRepo(data *Data, c *conf.Bootstrap, logger log.Logger) biz.IdempotencyRepo {
+	ic := c.GetIdempotency()
+	window := ic.GetWindow().AsDuration()
+	if window <= 0 {
+		window = bo.DefaultIdempotencyWindow
+	}
+	timeWindow := ic.GetTimeWindow().AsDuration()
+	if timeWindow <= 0 {
+		timeWindow = bo.DefaultIdempotencyTimeWindow
+	}
+	processingTTL := ic.GetProcessingTtl().AsDuration()
+	if processingTTL <= 0 {
+		processingTTL = bo.DefaultIdempotencyProcessingTTL
+	}
+	secret := ic.GetSecret()
+	if secret == "" {
+		secret = c.GetServer().GetSecret()
+	}
+	return &idempotencyRepo{
+		checker:       sign.NewReplayChecker(data.redisClient, int(window.Seconds()), int(timeWindow.Milliseconds())),
+		secret:        secret,
+		processingTTL: min(processingTTL, window),
+		log:           log.NewHelper(logger),
+	}
+}
+
+// Verify 校验幂等键的签名和时间戳
+func (r *idempotencyRepo) Verify(ctx context.Context, key string, timestamp int64, signature string) error {
+	if r.secret == "" {
+		return errors.New("idempotency secret is not configured")
+	}
+	return r.checker.VerifyRequest(&sign.SignParam{
+		RequestID: key,
+		Timestamp: timestamp,
+		Signature: signature,
+	}, r.secret)
+}
+
+// Claim 原子地占用幂等键，键已存在时返回已保存的记录
+// 处理中的记录只占用processingTTL，进程在处理中崩溃时键会较快释放，完成后由Complete延长到整个窗口
+func (r *idempotencyRepo) Claim(ctx context.Context, requestId string, record *bo.IdempotencyRecord) (*bo.IdempotencyRecord, error) {
+	value, err := json.Marshal(record)
+	if err != nil {
+		return nil, errors.Join(err, errors.New("failed to marshal idempotency record"))
+	}
+	// 占用失败后记录可能恰好过期，重试一次
+	for range 2 {
+		claimed, err := r.checker.Claim(ctx, requestId, string(value), r.processingTTL)
+		if err != nil {
+			return nil, errors.Join(err, errors.New("failed to claim idempotency key"))
+		}
+		if claimed {
+			return nil, nil
+		}
+		stored, ok, err := r.checker.Load(ctx, requestId)
+		if err != nil {
+			return nil, errors.Join(err, errors.New("failed to load idempotency record"))
+		}
+		if !ok {
+			continue
+		}
+		var existing bo.IdempotencyRecord
+		if err = json.Unmarshal([]byte(stored), &existing); err != nil {
+			return nil, errors.Join(err, errors.New("failed to unmarshal idempotency record"))
+		}
+		return &existing, nil
+	}
+	return nil, errors.New("idempotency key changed concurrently")
+}
+
+// Complete 保存处理结果，有效期从保存时重新计算
+func (r *idempotencyRepo) Complete(ctx context.Context, requestId string, record *bo.IdempotencyRecord) error {
+	value, err := json.Marshal(record)
+	if err != nil {
+		return errors.Join(err, errors.New("failed to marshal idempotency record"))
+	}
+	if err = r.checker.Store(ctx, requestId, string(value)); err != nil {
+		return errors.Join(err, errors.New("failed to save idempotency record"))
+	}
+	return nil
+}
+
+// Release 删除幂等键
+func (r *idempotencyRepo) Release(ctx context.Context, requestId string) error {
+	if err := r.checker.Forget(ctx, requestId); err != nil {
+		return errors.Join(err, errors.New("failed to release idempotency key"))
+	}
+	return nil
+}
@@ -2,11 +2,15 @@ package data
 
 import (
 	"context"
+	"hash/fnv"
+	"sync"
 	"time"
 
 	"github.com/xinghe903/chatify/logic/internal/biz"
 	"github.com/xinghe903/chatify/logic/internal/conf"
 
+	"github.com/xinghe903/chatify/pkg/kafka"
+
 	"github.com/go-kratos/kratos/v2/log"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
@@ -16,17 +20,85 @@ import (
 
 const (
 	KafkaTopicUserMessage = "user_message"
+	KafkaTopicUserState   = "user_state"
 )
 
 var _ biz.Consumer = (*kafkaConsumer)(nil)
 
 type kafkaConsumer struct {
 	consumerGroup sarama.ConsumerGroup
+	retrier       *kafka.Retrier
+	batch         *consumerBatch
 	log           *log.Helper
 	topics        []string
 }
 
 func NewKafkaConsumer(c *conf.Bootstrap, logger log.Logger) (biz.Consumer, func()) {
+	return newKafkaConsumer(c.Data.Kafka.GroupId, c, logger, KafkaTopicUserMessage)
+}
+
+// NewBroadcastConsumer 创建广播分片消费者，使用独立的消费者组，避免与用户消息消费互相触发重平衡
+func NewBroadcastConsumer(c *conf.Bootstrap, logger log.Logger) (biz.BroadcastConsumer, func()) {
+	return newKafkaConsumer(c.Data.Kafka.GroupId+"-broadcast", c, logger, KafkaTopicBroadcastChunk)
+}
+
+// NewFunnelConsumer 创建送达漏斗事件消费者，使用独立的消费者组
+func NewFunnelConsumer(c *conf.Bootstrap, logger log.Logger) (biz.FunnelConsumer, func()) {
+	return newKafkaConsumer(c.Data.Kafka.GroupId+"-funnel", c, logger, KafkaTopicDeliveryEvent)
+}
+
+// NewDataReportConsumer 创建数据上报消费者，使用独立的消费者组
+func NewDataReportConsumer(c *conf.Bootstrap, logger log.Logger) (biz.DataReportConsumer, func()) {
+	return newKafkaConsumer(c.Data.Kafka.GroupId+"-report", c, logger, KafkaTopicDataReport)
+}
+
+// NewWebhookConsumer 创建webhook投递消费者，使用独立的消费者组
+func NewWebhookConsumer(c *conf.Bootstrap, logger log.Logger) (biz.WebhookConsumer, func()) {
+	return newKafkaConsumer(c.Data.Kafka.GroupId+"-webhook", c, logger, KafkaTopicWebhook)
+}
+
+// NewUserStateConsumer 订阅access发布的用户上下线事件，用于触发webhook
+// user_state的重试主题由push的消费者组使用，这里不重试也不订阅重试主题，避免重复处理push的重试消息
+func NewUserStateConsumer(c *conf.Bootstrap, logger log.Logger) (biz.UserStateConsumer, func()) {
+	consumer, cleanup := newKafkaConsumer(c.Data.Kafka.GroupId+"-user-state", c, logger, KafkaTopicUserState)
+	consumer.retrier.Disable(KafkaTopicUserState)
+	consumer.topics = consumer.retrier.Topics()
+	return consumer, cleanup
+}
+
+// 批量消费的默认配置
+const (
+	defaultBatchMaxSize = 100
+	defaultBatchMaxWait = 100 * time.Millisecond
+	defaultBatchWorkers = 8
+)
+
+// consumerBatch 批量消费配置
+type consumerBatch struct {
+	maxSize int
+	maxWait time.Duration
+	workers int
+}
+
+func newConsumerBatch(bc *conf.Data_ConsumerBatch) *consumerBatch {
+	batch := &consumerBatch{
+		maxSize: defaultBatchMaxSize,
+		maxWait: defaultBatchMaxWait,
+		workers: defaultBatchWorkers,
+	}
+	if bc.GetMaxSize() > 0 {
+		batch.maxSize = int(bc.GetMaxSize())
+	}
+	if bc.GetMaxWait() != nil {
+		batch.maxWait = bc.GetMaxWait().AsDuration()
+	}
+	if bc.GetWorkers() > 0 {
+		batch.workers = int(bc.GetWorkers())
+	}
+	return batch
+}
+
+func newKafkaConsumer(groupId string, c *conf.Bootstrap, logger log.Logger, topics ...string) (*kafkaConsumer, func()) {
 	kconf := c.Data.Kafka
 	logg := log.NewHelper(logger)
 	// 创建 Sarama 配置
@@ -35,33 +107,44 @@ func NewKafkaConsumer(c *conf.Bootstrap, logger log.Logger) (biz.Consumer, func(
 	config.Consumer.Offsets.Initial = sarama.OffsetOldest                  // 从最旧消息开始消费
 	config.Consumer.Return.Errors = true                                   // 启用错误通道
 
-	// 可选：启用自动提交 offset
-	config.Consumer.Offsets.AutoCommit.Enable = true
-	config.Consumer.Offsets.AutoCommit.Interval = 1 * time.Second
+	// 每批处理完后手动提交 offset
+	config.Consumer.Offsets.AutoCommit.Enable = false
 
 	// 创建消费者组
-	consumerGroup, err := sarama.NewConsumerGroup(kconf.Brokers, kconf.GroupId, config)
+	consumerGroup, err := sarama.NewConsumerGroup(kconf.Brokers, groupId, config)
 	if err != nil {
 		panic("创建消费者组失败" + err.Error())
 	}
+	retrier, err := newRetrier(kconf, groupId, logger, topics...)
+	if err != nil {
+		panic("创建重试生产者失败" + err.Error())
+	}
 	cleanup := func() {
 		if err := consumerGroup.Close(); err != nil {
 			logg.Errorf("关闭消费者组失败: %v", err)
 		}
+		if err := retrier.Close(); err != nil {
+			logg.Errorf("关闭重试生产者失败: %v", err)
+		}
 	}
 	return &kafkaConsumer{
 		consumerGroup: consumerGroup,
+		retrier:       retrier,
+		batch:         newConsumerBatch(kconf.GetConsumerBatch()),
 		log:           logg,
-		topics:        []string{KafkaTopicUserMessage},
+		topics:        retrier.Topics(),
 	}, cleanup
 }
 
-func (k *kafkaConsumer) Start(ctx context.Context, handler biz.MessageHandler) {
+func (k *kafkaConsumer) Start(ctx context.Context, filter biz.MessageFilter, handler biz.MessageHandler) {
 	k.log.Info("消费者组启动，等待消息...")
 	// 启动消费者
 	go func() {
 		handler := consumerGroupHandler{
+			filter:  filter,
 			handler: handler,
+			retrier: k.retrier,
+			batch:   k.batch,
 			log:     k.log,
 		}
 		for {
@@ -84,7 +167,10 @@ func (k *kafkaConsumer) Start(ctx context.Context, handler biz.MessageHandler) {
 // 消费者组处理器
 type consumerGroupHandler struct {
 	log     *log.Helper
+	filter  biz.MessageFilter
 	handler biz.MessageHandler
+	retrier *kafka.Retrier
+	batch   *consumerBatch
 }
 
 // Setup 在消费者加入组后、开始消费前调用
@@ -98,34 +184,167 @@ func (h consumerGroupHandler) Cleanup(_ sarama.ConsumerGroupSession) error {
 }
 
 // ConsumeClaim 处理每个分区的消息
+// 按批次拉取消息，批内相同key的消息分配到同一个worker按顺序处理，生产者按会话分区和设置key，因此同一会话的消息有序
+// 整批处理完后按分区顺序提交位移，处理失败的消息转入重试主题或死信队列，转发失败时只提交之前的位移并结束本次会话，
+// 重新平衡后从转发失败的消息开始再次消费，之后已处理的消息由各消费者跳过：聊天消息、漏斗事件、webhook投递和用户上下线事件
+// 按消息标识去重，广播分片通过抢占、数据上报按report_id忽略重复写入
 func (h consumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for {
+		batch, ok := h.collect(session.Context(), claim)
+		if !ok {
+			// 会话结束时丢弃未处理的批次，重新平衡后再次消费
+			return nil
+		}
+		if err := h.process(session, batch); err != nil {
+			return err
+		}
+	}
+}
+
+// collect 拉取一批消息，达到批次上限或自第一条消息起超过等待时间后返回，会话结束时返回false
+func (h consumerGroupHandler) collect(ctx context.Context, claim sarama.ConsumerGroupClaim) ([]*sarama.ConsumerMessage, bool) {
+	batch := make([]*sarama.ConsumerMessage, 0, h.batch.maxSize)
+	var timer *time.Timer
+	var timeout <-chan time.Time
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+	for len(batch) < h.batch.maxSize {
+		select {
+		case message, ok := <-claim.Messages():
+			if !ok {
+				return nil, false
+			}
+			// 其他消费者组的重试或重放消息不等待，在process中跳过
+			if h.retrier.Owns(message) && !h.retrier.Wait(ctx, message) {
+				return nil, false
+			}
+			batch = append(batch, message)
+			if timer == nil {
+				timer = time.NewTimer(h.batch.maxWait)
+				timeout = timer.C
+			}
+		case <-timeout:
+			return batch, true
+		case <-ctx.Done():
+			return nil, false
+		}
+	}
+	return batch, true
+}
+
+// process 处理一批消息并提交位移
+func (h consumerGroupHandler) process(session sarama.ConsumerGroupSession, batch []*sarama.ConsumerMessage) error {
 	ctx := context.Background()
-	for message := range claim.Messages() {
-		// 创建 trace 上下文
-		carrier := make(propagation.HeaderCarrier)
-		for _, h := range message.Headers {
-			key := string(h.Key)
-			value := string(h.Value)
-			carrier.Set(key, value)
+	accepted := make([]bool, len(batch))
+	if h.filter != nil {
+		values := make([][]byte, len(batch))
+		for i, message := range batch {
+			values[i] = message.Value
+		}
+		accepted = h.filter(ctx, values)
+	} else {
+		for i := range accepted {
+			accepted[i] = true
+		}
+	}
+	for i, message := range batch {
+		accepted[i] = accepted[i] && h.retrier.Owns(message)
+	}
+
+	// 按key分配worker，保证同一key的消息按分区顺序处理
+	workers := min(h.batch.workers, len(batch))
+	queues := make([][]int, workers)
+	for i, message := range batch {
+		if !accepted[i] {
+			continue
 		}
-		prop := otel.GetTextMapPropagator()
-		ctxWithTrace := prop.Extract(ctx, carrier)
-		tracer := otel.Tracer("sarama-consumer")
-		_, span := tracer.Start(ctxWithTrace, "process-kafka-message")
-		h.log.WithContext(ctxWithTrace).Debugf("收到消息: Topic=%s, Partition=%d, Offset=%d, Key=%s, Value=%s, Timestamp=%v",
-			message.Topic,
-			message.Partition,
-			message.Offset,
-			string(message.Key),
-			string(message.Value),
-			message.Timestamp,
-		)
-		if err := h.handler(ctxWithTrace, string(message.Key), message.Value); err != nil {
-			h.log.WithContext(ctxWithTrace).Errorf("处理消息失败: %v", err)
+		worker := int(message.Offset % int64(workers))
+		if len(message.Key) > 0 {
+			hasher := fnv.New32a()
+			_, _ = hasher.Write(message.Key)
+			worker = int(hasher.Sum32() % uint32(workers))
+		}
+		queues[worker] = append(queues[worker], i)
+	}
+	forwardErrs := make([]error, len(batch))
+	var wg sync.WaitGroup
+	for _, queue := range queues {
+		if len(queue) == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, i := range queue {
+				forwardErrs[i] = h.handle(ctx, batch[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, message := range batch {
+		if forwardErrs[i] != nil {
+			session.Commit()
+			return forwardErrs[i]
 		}
-		// 手动提交位移（可选，也可以设置自动提交）
 		session.MarkMessage(message, "")
-		span.End()
 	}
+	session.Commit()
 	return nil
 }
+
+// handle 处理单条消息，处理失败时转发到重试主题或死信队列，只返回转发失败的错误
+func (h consumerGroupHandler) handle(ctx context.Context, message *sarama.ConsumerMessage) error {
+	// 创建 trace 上下文
+	carrier := make(propagation.HeaderCarrier)
+	for _, h := range message.Headers {
+		key := string(h.Key)
+		value := string(h.Value)
+		carrier.Set(key, value)
+	}
+	prop := otel.GetTextMapPropagator()
+	ctxWithTrace := prop.Extract(ctx, carrier)
+	tracer := otel.Tracer("sarama-consumer")
+	_, span := tracer.Start(ctxWithTrace, "process-kafka-message")
+	defer span.End()
+	h.log.WithContext(ctxWithTrace).Debugf("收到消息: Topic=%s, Partition=%d, Offset=%d, Key=%s, Value=%s, Timestamp=%v",
+		message.Topic,
+		message.Partition,
+		message.Offset,
+		string(message.Key),
+		string(message.Value),
+		message.Timestamp,
+	)
+	if err := h.handler(ctxWithTrace, string(message.Key), message.Value); err != nil {
+		h.log.WithContext(ctxWithTrace).Errorf("处理消息失败: %v", err)
+		if ferr := h.retrier.Fail(ctxWithTrace, message, err); ferr != nil {
+			h.log.WithContext(ctxWithTrace).Errorf("转发失败消息失败: %v", ferr)
+			return ferr
+		}
+	}
+	return nil
+}
+
+// newRetrier 按配置创建失败消息重试器，重复消息视为已处理，不可重试的错误直接进入死信队列
+func newRetrier(kconf *conf.Data_Kafka, groupId string, logger log.Logger, topics ...string) (*kafka.Retrier, error) {
+	policies := make([]*kafka.RetryPolicy, 0, len(kconf.GetConsumerRetries()))
+	for _, rc := range kconf.GetConsumerRetries() {
+		policies = append(policies, &kafka.RetryPolicy{
+			Topic:             rc.Topic,
+			MaxRetries:        int(rc.MaxRetries),
+			InitialBackoff:    rc.GetInitialBackoff().AsDuration(),
+			MaxBackoff:        rc.GetMaxBackoff().AsDuration(),
+			DisableDeadLetter: rc.DisableDeadLetter,
+		})
+	}
+	return kafka.NewRetrier(&kafka.RetrierConfig{
+		Brokers:      kconf.Brokers,
+		GroupId:      groupId,
+		Policies:     policies,
+		Ignored:      biz.ErrMessageDuplicate,
+		NonRetryable: biz.ErrNonRetryable,
+	}, logger, topics...)
+}
@@ -0,0 +1,213 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/xinghe903/chatify/pkg/kafka"
+
+	"github.com/IBM/sarama"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// testSession 记录提交位移的消费者组会话
+type testSession struct {
+	ctx    context.Context
+	mu     sync.Mutex
+	marked []int64
+}
+
+func (s *testSession) Claims() map[string][]int32               { return nil }
+func (s *testSession) MemberID() string                         { return "test" }
+func (s *testSession) GenerationID() int32                      { return 1 }
+func (s *testSession) MarkOffset(string, int32, int64, string)  {}
+func (s *testSession) Commit()                                  {}
+func (s *testSession) ResetOffset(string, int32, int64, string) {}
+func (s *testSession) Context() context.Context                 { return s.ctx }
+func (s *testSession) MarkMessage(msg *sarama.ConsumerMessage, _ string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.marked = append(s.marked, msg.Offset)
+}
+
+// testClaim 从channel读取消息的分区
+type testClaim struct {
+	messages chan *sarama.ConsumerMessage
+}
+
+func (c *testClaim) Topic() string                            { return KafkaTopicUserMessage }
+func (c *testClaim) Partition() int32                         { return 0 }
+func (c *testClaim) InitialOffset() int64                     { return 0 }
+func (c *testClaim) HighWaterMarkOffset() int64               { return 0 }
+func (c *testClaim) Messages() <-chan *sarama.ConsumerMessage { return c.messages }
+
+func newTestHandler(handler func(ctx context.Context, key string, value []byte) error, workers int) consumerGroupHandler {
+	logger := log.NewStdLogger(io.Discard)
+	return consumerGroupHandler{
+		log:     log.NewHelper(logger),
+		handler: handler,
+		retrier: kafka.NewRetrierWithProducer(nil, &kafka.RetrierConfig{}, logger, KafkaTopicUserMessage),
+		batch:   &consumerBatch{maxSize: 50, maxWait: 5 * time.Millisecond, workers: workers},
+	}
+}
+
+// interleavedMessages 生成keys个会话交替写入同一分区的消息，value为会话内的序号
+func interleavedMessages(keys, perKey int) []*sarama.ConsumerMessage {
+	messages := make([]*sarama.ConsumerMessage, 0, keys*perKey)
+	for seq := 0; seq < perKey; seq++ {
+		for k := 0; k < keys; k++ {
+			messages = append(messages, &sarama.ConsumerMessage{
+				Topic:  KafkaTopicUserMessage,
+				Key:    []byte(fmt.Sprintf("u:user%d:user%d", k, k+1)),
+				Value:  []byte(fmt.Sprint(seq)),
+				Offset: int64(len(messages)),
+			})
+		}
+	}
+	return messages
+}
+
+// TestConsumeClaimKeepsOrderPerKey 同一会话的消息按分区顺序处理，不同会话并行处理，全部处理完后才提交位移
+func TestConsumeClaimKeepsOrderPerKey(t *testing.T) {
+	const keys, perKey = 13, 40
+	var (
+		mu       sync.Mutex
+		received = make(map[string][]string)
+		running  atomic.Int32
+		peak     atomic.Int32
+		handled  atomic.Int32
+	)
+	h := newTestHandler(func(ctx context.Context, key string, value []byte) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		// 随机耗时，打乱不同worker的完成顺序
+		time.Sleep(time.Duration(rand.IntN(300)) * time.Microsecond)
+		mu.Lock()
+		received[key] = append(received[key], string(value))
+		mu.Unlock()
+		handled.Add(1)
+		return nil
+	}, 8)
+
+	messages := interleavedMessages(keys, perKey)
+	ctx, cancel := context.WithCancel(context.Background())
+	session := &testSession{ctx: ctx}
+	claim := &testClaim{messages: make(chan *sarama.ConsumerMessage, len(messages))}
+	for _, message := range messages {
+		claim.messages <- message
+	}
+	done := make(chan error, 1)
+	go func() { done <- h.ConsumeClaim(session, claim) }()
+
+	deadline := time.After(10 * time.Second)
+	for handled.Load() < int32(len(messages)) {
+		select {
+		case <-deadline:
+			t.Fatalf("handled %d of %d messages before timeout", handled.Load(), len(messages))
+		case <-time.After(time.Millisecond):
+		}
+	}
+	// 最后一批处理完后位移才会提交
+	for {
+		session.mu.Lock()
+		n := len(session.marked)
+		session.mu.Unlock()
+		if n == len(messages) {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("marked %d of %d messages before timeout", n, len(messages))
+		case <-time.After(time.Millisecond):
+		}
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("ConsumeClaim returned error: %v", err)
+	}
+
+	for key, values := range received {
+		if len(values) != perKey {
+			t.Fatalf("key %s received %d messages, want %d", key, len(values), perKey)
+		}
+		for seq, value := range values {
+			if value != fmt.Sprint(seq) {
+				t.Fatalf("key %s out of order: got %v", key, values)
+			}
+		}
+	}
+	for i, offset := range session.marked {
+		if offset != int64(i) {
+			t.Fatalf("offsets marked out of order: %v", session.marked)
+		}
+	}
+	if peak.Load() < 2 {
+		t.Fatalf("messages of different keys were not handled in parallel, peak=%d", peak.Load())
+	}
+}
+
+// TestProcessSkipsOtherGroupMessages 其他消费者组的重试或重放消息不处理，但按顺序提交位移
+func TestProcessSkipsOtherGroupMessages(t *testing.T) {
+	var handled []int64
+	h := newTestHandler(func(ctx context.Context, key string, value []byte) error {
+		handled = append(handled, int64(value[0]))
+		return nil
+	}, 1)
+	h.retrier = kafka.NewRetrierWithProducer(nil, &kafka.RetrierConfig{GroupId: "logic"}, log.NewStdLogger(io.Discard), KafkaTopicUserMessage)
+	groupHeader := func(group string) []*sarama.RecordHeader {
+		return []*sarama.RecordHeader{{Key: []byte(kafka.HeaderConsumerGroup), Value: []byte(group)}}
+	}
+	batch := []*sarama.ConsumerMessage{
+		{Topic: KafkaTopicUserMessage, Offset: 0, Value: []byte{0}},
+		{Topic: KafkaTopicUserMessage, Offset: 1, Value: []byte{1}, Headers: groupHeader("other")},
+		{Topic: KafkaTopicUserMessage, Offset: 2, Value: []byte{2}, Headers: groupHeader("logic")},
+	}
+	session := &testSession{ctx: context.Background()}
+	if err := h.process(session, batch); err != nil {
+		t.Fatalf("process() error = %v", err)
+	}
+	if fmt.Sprint(handled) != "[0 2]" {
+		t.Fatalf("handled %v, want [0 2]", handled)
+	}
+	if fmt.Sprint(session.marked) != "[0 1 2]" {
+		t.Fatalf("marked %v, want [0 1 2]", session.marked)
+	}
+}
+
+// BenchmarkConsumeClaim 对比逐条顺序处理和按key并行处理的吞吐，handler用一次短暂休眠模拟下游调用
+func BenchmarkConsumeClaim(b *testing.B) {
+	for _, workers := range []int{1, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			var handled atomic.Int64
+			h := newTestHandler(func(ctx context.Context, key string, value []byte) error {
+				time.Sleep(50 * time.Microsecond)
+				handled.Add(1)
+				return nil
+			}, workers)
+			messages := interleavedMessages(64, b.N/64+1)[:b.N]
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			claim := &testClaim{messages: make(chan *sarama.ConsumerMessage, len(messages))}
+			b.ResetTimer()
+			go func() { _ = h.ConsumeClaim(&testSession{ctx: ctx}, claim) }()
+			for _, message := range messages {
+				claim.messages <- message
+			}
+			for handled.Load() < int64(b.N) {
+				time.Sleep(100 * time.Microsecond)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+//go:build integration
+
+package data
+
+// 需要可用的Kafka，通过 KAFKA_BROKERS 指定（逗号分隔），例如:
+//   KAFKA_BROKERS=127.0.0.1:9092 go test -tags integration -run Integration ./internal/data/
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/xinghe903/chatify/logic/internal/conf"
+
+	"github.com/IBM/sarama"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// TestIntegrationOrderingPerConversation 经真实Kafka的多分区主题，同一会话的消息按发送顺序被消费
+func TestIntegrationOrderingPerConversation(t *testing.T) {
+	brokers := os.Getenv("KAFKA_BROKERS")
+	if brokers == "" {
+		t.Skip("KAFKA_BROKERS is not set")
+	}
+	const partitions, keys, perKey = 4, 20, 50
+	topic := fmt.Sprintf("chatify_ordering_it_%d", time.Now().UnixNano())
+	admin, err := sarama.NewClusterAdmin(strings.Split(brokers, ","), sarama.NewConfig())
+	if err != nil {
+		t.Fatalf("create cluster admin: %v", err)
+	}
+	defer admin.Close()
+	if err = admin.CreateTopic(topic, &sarama.TopicDetail{NumPartitions: partitions, ReplicationFactor: 1}, false); err != nil {
+		t.Fatalf("create topic: %v", err)
+	}
+	defer func() { _ = admin.DeleteTopic(topic) }()
+
+	c := &conf.Bootstrap{Data: &conf.Data{Kafka: &conf.Data_Kafka{
+		Brokers:    strings.Split(brokers, ","),
+		GroupId:    topic,
+		RetryCount: 3,
+		Timeout:    durationpb.New(5 * time.Second),
+		// 测试主题不订阅重试主题
+		ConsumerRetries: []*conf.Data_ConsumerRetry{{Topic: topic, MaxRetries: -1, DisableDeadLetter: true}},
+		ConsumerBatch:   &conf.Data_ConsumerBatch{MaxSize: 32, Workers: 4},
+	}}}
+	logger := log.NewStdLogger(io.Discard)
+
+	var (
+		mu       sync.Mutex
+		received = make(map[string][]string)
+		total    int
+		all      = make(chan struct{})
+	)
+	consumer, cleanupConsumer := newKafkaConsumer(topic, c, logger, topic)
+	defer cleanupConsumer()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	consumer.Start(ctx, nil, func(ctx context.Context, key string, value []byte) error {
+		mu.Lock()
+		defer mu.Unlock()
+		received[key] = append(received[key], string(value))
+		if total++; total == keys*perKey {
+			close(all)
+		}
+		return nil
+	})
+
+	mq, cleanupProducer, err := NewKafkaProducer(c, logger)
+	if err != nil {
+		t.Fatalf("create producer: %v", err)
+	}
+	defer cleanupProducer()
+	producer := mq.(*KafkaProducer)
+	for seq := 0; seq < perKey; seq++ {
+		for k := 0; k < keys; k++ {
+			key := fmt.Sprintf("u:user%d:user%d", k, k+1)
+			if err = producer.SendMessage(ctx, topic, key, []byte(fmt.Sprint(seq))); err != nil {
+				t.Fatalf("send message: %v", err)
+			}
+		}
+	}
+
+	select {
+	case <-all:
+	case <-time.After(60 * time.Second):
+		mu.Lock()
+		defer mu.Unlock()
+		t.Fatalf("received %d of %d messages before timeout", total, keys*perKey)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for key, values := range received {
+		if len(values) != perKey {
+			t.Fatalf("key %s received %d messages, want %d", key, len(values), perKey)
+		}
+		for seq, value := range values {
+			if value != fmt.Sprint(seq) {
+				t.Fatalf("key %s out of order: got %v", key, values)
+			}
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/xinghe903/chatify/logic/internal/conf"
 
 	"github.com/xinghe903/chatify/pkg/auth"
+	"github.com/xinghe903/chatify/pkg/event"
 
 	"github.com/IBM/sarama"
 	"github.com/go-kratos/kratos/v2/log"
@@ -19,7 +20,11 @@ import (
 )
 
 const (
-	KafkaTopicDataReport = "data_report"
+	KafkaTopicDataReport     = "data_report"
+	KafkaTopicBroadcastChunk = "broadcast_chunk"
+	KafkaTopicUserAbuse      = event.TopicUserAbuse
+	KafkaTopicDeliveryEvent  = event.TopicDelivery
+	KafkaTopicWebhook        = "webhook_delivery"
 )
 
 var _ biz.MqProducer = (*KafkaProducer)(nil)
@@ -42,7 +47,9 @@ func NewKafkaProducer(cb *conf.Bootstrap, logger log.Logger) (biz.MqProducer, fu
 	config.Producer.Return.Errors = true               // 返回错误消息
 	config.Producer.Timeout = c.Timeout.AsDuration()   // 超时时间
 	config.Producer.Compression = sarama.CompressionSnappy
-	config.Producer.Partitioner = sarama.NewRandomPartitioner
+	// 相同key的消息写入同一分区，保证分区内有序
+	config.Producer.Partitioner = sarama.NewHashPartitioner
+	config.Net.MaxOpenRequests = 1 // 重试时不会打乱同一分区内的发送顺序
 	fmt.Printf("Kafka brokers: %v\n", c.Brokers)
 	// 创建异步生产者
 	producer, err := sarama.NewAsyncProducer(c.Brokers, config)
@@ -89,32 +96,90 @@ func NewKafkaProducer(cb *conf.Bootstrap, logger log.Logger) (biz.MqProducer, fu
 
 // SendMessageWithDataReport 把数据上报消息发送到Kafka
 // @param ctx context.Context 上下文
-// @param message *bo.DataReport 数据上报消息
+// @param batch *bo.DataReportBatch 校验后的数据上报
 // @return error 错误信息
-func (p *KafkaProducer) SendMessageWithDataReport(ctx context.Context, message *bo.DataReport) error {
+func (p *KafkaProducer) SendMessageWithDataReport(ctx context.Context, batch *bo.DataReportBatch) error {
 	// 将消息序列化为JSON
-	data, err := json.Marshal(message)
+	data, err := json.Marshal(batch)
 	if err != nil {
 		return fmt.Errorf("marshal message error: %w", err)
 	}
-	return p.SendMessage(ctx, KafkaTopicDataReport, data)
+	return p.SendMessage(ctx, KafkaTopicDataReport, batch.UserId, data)
+}
+
+// SendMessageWithBroadcastChunk 把广播分片任务发送到Kafka
+// @param ctx context.Context 上下文
+// @param task *bo.BroadcastChunkTask 广播分片任务
+// @return error 错误信息
+func (p *KafkaProducer) SendMessageWithBroadcastChunk(ctx context.Context, task *bo.BroadcastChunkTask) error {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("marshal broadcast chunk error: %w", err)
+	}
+	// 分片之间没有顺序要求，随机分区以便并行处理
+	return p.SendMessage(ctx, KafkaTopicBroadcastChunk, "", data)
+}
+
+// SendMessageWithUserAbuse 把用户滥用事件发送到Kafka，由auth服务锁定账号
+// @param ctx context.Context 上下文
+// @param event *bo.UserAbuseEvent 用户滥用事件
+// @return error 错误信息
+func (p *KafkaProducer) SendMessageWithUserAbuse(ctx context.Context, event *bo.UserAbuseEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal user abuse event error: %w", err)
+	}
+	return p.SendMessage(ctx, KafkaTopicUserAbuse, event.UserId, data)
+}
+
+// SendMessageWithDeliveryEvents 把送达漏斗事件发送到Kafka
+// @param ctx context.Context 上下文
+// @param events []*bo.DeliveryEvent 漏斗事件
+// @return error 错误信息
+func (p *KafkaProducer) SendMessageWithDeliveryEvents(ctx context.Context, events []*bo.DeliveryEvent) error {
+	eventId, err := p.snowflake.GenerateBase62()
+	if err != nil {
+		return fmt.Errorf("generate event ID error: %w", err)
+	}
+	data, err := json.Marshal(&bo.DeliveryEventBatch{EventId: eventId, Events: events})
+	if err != nil {
+		return fmt.Errorf("marshal delivery events error: %w", err)
+	}
+	// 同一内容的事件写入同一分区
+	return p.SendMessage(ctx, KafkaTopicDeliveryEvent, events[0].ContentId, data)
+}
+
+// SendMessageWithWebhookDelivery 把webhook投递发送到Kafka
+// @param ctx context.Context 上下文
+// @param delivery *bo.WebhookDelivery 一个订阅的一次投递
+// @return error 错误信息
+func (p *KafkaProducer) SendMessageWithWebhookDelivery(ctx context.Context, delivery *bo.WebhookDelivery) error {
+	data, err := json.Marshal(delivery)
+	if err != nil {
+		return fmt.Errorf("marshal webhook delivery error: %w", err)
+	}
+	// 同一订阅的投递写入同一分区，按事件顺序投递
+	return p.SendMessage(ctx, KafkaTopicWebhook, delivery.WebhookId, data)
 }
 
 // SendMessage 发送消息到Kafka
 // @param ctx context.Context 上下文
 // @param topic string Kafka主题
+// @param key string 分区键，为空时生成随机key，消息均匀分布到各分区
 // @param data []byte 消息数据
 // @return error 错误信息
-func (p *KafkaProducer) SendMessage(ctx context.Context, topic string, data []byte) error {
+func (p *KafkaProducer) SendMessage(ctx context.Context, topic, key string, data []byte) error {
 	if p.producer == nil {
 		return fmt.Errorf("kafka producer not initialized")
 	}
-	// 生成消息ID作为Key，用于防止重复消费
-	// 使用Sonyflake分布式ID生成器，确保全局唯一
-	msgID, err := p.snowflake.GenerateBase62()
-	if err != nil {
-		p.log.WithContext(ctx).Errorf("Generate message ID error: %v", err)
-		return fmt.Errorf("generate message ID error: %w", err)
+	if key == "" {
+		// 使用Sonyflake分布式ID生成器，确保全局唯一
+		msgID, err := p.snowflake.GenerateBase62()
+		if err != nil {
+			p.log.WithContext(ctx).Errorf("Generate message ID error: %v", err)
+			return fmt.Errorf("generate message ID error: %w", err)
+		}
+		key = msgID
 	}
 	// 导入OpenTelemetry的propagation
 	headers := make([]sarama.RecordHeader, 0)
@@ -129,10 +194,10 @@ func (p *KafkaProducer) SendMessage(ctx context.Context, topic string, data []by
 			})
 		}
 	}
-	// 创建Sarama消息，设置Key为消息ID
+	// 创建Sarama消息，按Key哈希选择分区
 	saramaMsg := &sarama.ProducerMessage{
 		Topic:   topic,
-		Key:     sarama.StringEncoder(msgID),
+		Key:     sarama.StringEncoder(key),
 		Value:   sarama.ByteEncoder(data),
 		Headers: headers,
 	}
@@ -0,0 +1,74 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/xinghe903/chatify/logic/internal/biz"
+
+	"github.com/go-kratos/kratos/v2/log"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
+)
+
+const (
+	schedulerElectionKey = "/chatify/logic/scheduler/leader"
+	schedulerSessionTTL  = 15 // etcd租约时间（秒），leader宕机后其他实例最迟在该时间后接管
+)
+
+var _ biz.LeaderElector = (*etcdLeaderElector)(nil)
+
+// etcdLeaderElector 基于etcd election实现的选主
+type etcdLeaderElector struct {
+	client *clientv3.Client
+	log    *log.Helper
+	value  string
+}
+
+// NewLeaderElector 创建基于etcd的选主实例
+func NewLeaderElector(client *clientv3.Client, logger log.Logger) biz.LeaderElector {
+	value, err := os.Hostname()
+	if err != nil || value == "" {
+		value = "logic"
+	}
+	return &etcdLeaderElector{
+		client: client,
+		log:    log.NewHelper(logger),
+		value:  value,
+	}
+}
+
+// Campaign 创建租约会话并参与选举，阻塞直到成为leader
+func (e *etcdLeaderElector) Campaign(ctx context.Context) (context.Context, func(), error) {
+	session, err := concurrency.NewSession(e.client,
+		concurrency.WithTTL(schedulerSessionTTL),
+		concurrency.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, nil, errors.Join(err, errors.New("failed to create etcd session"))
+	}
+	election := concurrency.NewElection(session, schedulerElectionKey)
+	if err = election.Campaign(ctx, e.value); err != nil {
+		session.Close()
+		return nil, nil, errors.Join(err, errors.New("failed to campaign scheduler leader"))
+	}
+	leaderCtx, cancel := context.WithCancel(ctx)
+	// 租约失效（网络分区或etcd不可用）时立即放弃leader身份
+	go func() {
+		select {
+		case <-session.Done():
+			e.log.Warn("scheduler leader session expired")
+		case <-leaderCtx.Done():
+		}
+		cancel()
+	}()
+	resign := func() {
+		cancel()
+		if err := election.Resign(context.Background()); err != nil {
+			e.log.Warnf("failed to resign scheduler leader: %v", err)
+		}
+		session.Close()
+	}
+	return leaderCtx, resign, nil
+}
@@ -33,33 +33,50 @@ func NewMessageDedupRepo(data *Data, logger log.Logger) biz.MessageDedupRepo {
 }
 
 // CheckAndSetDedup 检查消息是否已消费，如果未消费则标记为已消费
-// 使用 Redis Set 集合存储消息ID，key格式为：chatify:logic:kafka:dedup:msg:{msgId}
+// 使用 SET NX EX 一次完成检查和标记，key格式为：chatify:logic:kafka:dedup:msg:{msgId}
 // 返回true表示消息未被消费过，false表示消息已被消费过
 func (r *messageDedupRepo) CheckAndSetDedup(ctx context.Context, msgId string) (bool, error) {
 	if msgId == "" {
 		return false, fmt.Errorf("msgId cannot be empty")
 	}
-
-	// 格式化Redis key: chatify:logic:kafka:dedup:msg:{msgId}
-	key := redisDedupKeyPrefix + msgId
-
-	// 使用 Redis Set 的 SADD 命令添加消息ID到集合中
-	// SADD 返回添加的元素个数：
-	// - 返回 1：表示该元素是新的，成功添加到集合
-	// - 返回 0：表示该元素已存在于集合中，已消费过
-	result, err := r.redisClient.SAdd(ctx, key, msgId).Result()
+	isNew, err := r.redisClient.SetNX(ctx, redisDedupKeyPrefix+msgId, 1, redisDedupExpiration).Result()
 	if err != nil {
-		return false, fmt.Errorf("redis sadd failed: %w", err)
+		return false, fmt.Errorf("redis setnx failed: %w", err)
 	}
+	return isNew, nil
+}
 
-	// 如果消息ID是新添加的（result > 0），设置过期时间为1天
-	if result > 0 {
-		if err := r.redisClient.Expire(ctx, key, redisDedupExpiration).Err(); err != nil {
-			r.log.Warnf("failed to set expire for key %s: %v", key, err)
-			// 不返回错误，因为主要功能（去重）已经完成
+// CheckAndSetDedupBatch 批量检查并标记消息，所有 SET NX EX 命令通过一次pipeline发送
+// 空msgId不做去重，结果为true
+func (r *messageDedupRepo) CheckAndSetDedupBatch(ctx context.Context, msgIds []string) ([]bool, error) {
+	result := make([]bool, len(msgIds))
+	cmds := make([]*redis.BoolCmd, len(msgIds))
+	pipe := r.redisClient.Pipeline()
+	for i, msgId := range msgIds {
+		if msgId == "" {
+			result[i] = true
+			continue
 		}
-		return true, nil // 消息未被消费过
+		cmds[i] = pipe.SetNX(ctx, redisDedupKeyPrefix+msgId, 1, redisDedupExpiration)
+	}
+	if pipe.Len() == 0 {
+		return result, nil
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("redis pipeline setnx failed: %w", err)
 	}
+	for i, cmd := range cmds {
+		if cmd != nil {
+			result[i] = cmd.Val()
+		}
+	}
+	return result, nil
+}
 
-	return false, nil // 消息已被消费过（Set 中已存在）
+// ReleaseDedup 删除消息的消费标记
+func (r *messageDedupRepo) ReleaseDedup(ctx context.Context, msgId string) error {
+	if err := r.redisClient.Del(ctx, redisDedupKeyPrefix+msgId).Err(); err != nil {
+		return fmt.Errorf("redis del failed: %w", err)
+	}
+	return nil
 }
@@ -0,0 +1,55 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+)
+
+// newBenchDedupRepo 连接 REDIS_ADDR 指定的Redis，未设置时跳过
+func newBenchDedupRepo(b *testing.B) *messageDedupRepo {
+	addr := os.Getenv("REDIS_ADDR")
+	if addr == "" {
+		b.Skip("REDIS_ADDR is not set")
+	}
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	b.Cleanup(func() { _ = client.Close() })
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		b.Fatalf("ping redis: %v", err)
+	}
+	return &messageDedupRepo{redisClient: client, log: log.NewHelper(log.NewStdLogger(io.Discard))}
+}
+
+// BenchmarkDedup 对比逐条 SET NX EX 和一批消息一次pipeline的去重开销，每批100条
+func BenchmarkDedup(b *testing.B) {
+	const batchSize = 100
+	ctx := context.Background()
+	b.Run("single", func(b *testing.B) {
+		repo := newBenchDedupRepo(b)
+		prefix := fmt.Sprintf("bench-single-%d-", b.N)
+		for i := 0; i < b.N; i++ {
+			if _, err := repo.CheckAndSetDedup(ctx, fmt.Sprint(prefix, i)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("pipeline", func(b *testing.B) {
+		repo := newBenchDedupRepo(b)
+		prefix := fmt.Sprintf("bench-pipeline-%d-", b.N)
+		msgIds := make([]string, 0, batchSize)
+		for i := 0; i < b.N; i++ {
+			msgIds = append(msgIds, fmt.Sprint(prefix, i))
+			if len(msgIds) == batchSize || i == b.N-1 {
+				if _, err := repo.CheckAndSetDedupBatch(ctx, msgIds); err != nil {
+					b.Fatal(err)
+				}
+				msgIds = msgIds[:0]
+			}
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package data
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/xinghe903/chatify/logic/internal/biz"
+	"github.com/xinghe903/chatify/logic/internal/conf"
+
+	pb "github.com/xinghe903/chatify/api/offline/v1"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware/circuitbreaker"
+	"github.com/go-kratos/kratos/v2/middleware/tracing"
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/go-kratos/kratos/v2/transport/grpc"
+)
+
+var _ biz.OfflineRepo = (*OfflineClient)(nil)
+
+// OfflineClient 封装offline服务的客户端操作
+type OfflineClient struct {
+	client pb.OfflineServiceClient
+	log    *log.Helper
+}
+
+// NewOfflineClient 创建offline服务gRPC客户端
+func NewOfflineClient(c *conf.Bootstrap, logger log.Logger, r registry.Discovery) (biz.OfflineRepo, func()) {
+	cfg := c.Client.OfflineClient
+	conn, err := grpc.DialInsecure(
+		context.Background(),
+		grpc.WithEndpoint(fmt.Sprintf("discovery:///%s", cfg.Addr)),
+		grpc.WithDiscovery(r),
+		grpc.WithMiddleware(
+			tracing.Client(),
+			circuitbreaker.Client(),
+		),
+	)
+	if err != nil {
+		panic("Failed to create offline service gRPC connection. " + err.Error())
+	}
+	log.NewHelper(logger).Info("Offline service gRPC client initialized successfully")
+	cleanup := func() {
+		if err := conn.Close(); err != nil {
+			log.NewHelper(logger).Errorf("Failed to close offline service connection: %v", err)
+		} else {
+			log.NewHelper(logger).Info("Offline service connection closed successfully")
+		}
+	}
+	return &OfflineClient{
+		client: pb.NewOfflineServiceClient(conn),
+		log:    log.NewHelper(logger),
+	}, cleanup
+}
+
+// AcknowledgeMessages 确认离线消息已送达
+func (p *OfflineClient) AcknowledgeMessages(ctx context.Context, userId string, msgIds []string) error {
+	if len(msgIds) == 0 {
+		return nil
+	}
+	_, err := p.client.AcknowledgeMessages(ctx, &pb.AckRequest{
+		UserId:     userId,
+		MessageIds: msgIds,
+	})
+	return err
+}
+
+// ReviseMessages 撤回或编辑尚未投递的离线消息
+func (p *OfflineClient) ReviseMessages(ctx context.Context, contentId string, recalled bool, content []byte) error {
+	resp, err := p.client.ReviseMessages(ctx, &pb.ReviseRequest{
+		ContentId: contentId,
+		Recalled:  recalled,
+		Content:   content,
+	})
+	if err != nil {
+		return err
+	}
+	p.log.WithContext(ctx).Debugf("Revised offline messages. contentId=%s, affected=%d", contentId, resp.Affected)
+	return nil
+}
@@ -0,0 +1,170 @@
+package po
+
+import (
+	"strings"
+	"time"
+
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+
+	"github.com/xinghe903/chatify/pkg/model"
+
+	"gorm.io/gorm"
+)
+
+// Attachment 附件实体类
+// 数据库表名: chatify_attachment
+type Attachment struct {
+	model.BaseModel
+	AttachmentID string `json:"attachment_id" gorm:"type:varchar(64);uniqueIndex:idx_attachment_id"`
+	UserID       string `json:"user_id" gorm:"type:varchar(64);index:idx_user_id"`
+	FileName     string `json:"file_name" gorm:"type:varchar(1024)"`
+	MimeType     string `json:"mime_type" gorm:"type:varchar(128)"`
+	Size         int64  `json:"size"`
+	Sha256       string `json:"sha256" gorm:"type:char(64);index:idx_sha256"`
+}
+
+// TableName 设置表名
+func (Attachment) TableName() string {
+	return "chatify_attachment"
+}
+
+// BeforeCreate GORM钩子，创建前的处理
+func (a *Attachment) BeforeCreate(tx *gorm.DB) error {
+	if !strings.HasPrefix(a.ID, "atid") {
+		// attachment id prefix
+		a.ID = "atid" + a.ID
+	}
+	return nil
+}
+
+func NewAttachmentFromBo(attachment *bo.Attachment) *Attachment {
+	return &Attachment{
+		AttachmentID: attachment.AttachmentId,
+		UserID:       attachment.UserId,
+		FileName:     attachment.FileName,
+		MimeType:     attachment.MimeType,
+		Size:         attachment.Size,
+		Sha256:       attachment.Sha256,
+	}
+}
+
+func (a *Attachment) ToBo(storageKey string) *bo.Attachment {
+	return &bo.Attachment{
+		AttachmentId: a.AttachmentID,
+		UserId:       a.UserID,
+		FileName:     a.FileName,
+		MimeType:     a.MimeType,
+		Size:         a.Size,
+		Sha256:       a.Sha256,
+		StorageKey:   storageKey,
+		CreatedAt:    a.CreatedAt,
+	}
+}
+
+// AttachmentObject 已保存的附件内容，相同sha256的内容只保存一份
+// 数据库表名: chatify_attachment_object
+type AttachmentObject struct {
+	model.BaseModel
+	Sha256     string `json:"sha256" gorm:"type:char(64);uniqueIndex:idx_sha256"`
+	Size       int64  `json:"size"`
+	StorageKey string `json:"storage_key" gorm:"type:varchar(255)"`
+}
+
+// TableName 设置表名
+func (AttachmentObject) TableName() string {
+	return "chatify_attachment_object"
+}
+
+// BeforeCreate GORM钩子，创建前的处理
+func (o *AttachmentObject) BeforeCreate(tx *gorm.DB) error {
+	if !strings.HasPrefix(o.ID, "aoid") {
+		// attachment object id prefix
+		o.ID = "aoid" + o.ID
+	}
+	return nil
+}
+
+// AttachmentUpload 附件分片上传实体类
+// 数据库表名: chatify_attachment_upload
+type AttachmentUpload struct {
+	model.BaseModel
+	UploadID     string    `json:"upload_id" gorm:"type:varchar(64);uniqueIndex:idx_upload_id"`
+	UserID       string    `json:"user_id" gorm:"type:varchar(64);index:idx_user_status,priority:1"`
+	FileName     string    `json:"file_name" gorm:"type:varchar(1024)"`
+	MimeType     string    `json:"mime_type" gorm:"type:varchar(128)"`
+	Size         int64     `json:"size"`
+	Sha256       string    `json:"sha256" gorm:"type:varchar(64)"`
+	ChunkSize    int64     `json:"chunk_size"`
+	ChunkCount   int       `json:"chunk_count"`
+	Status       string    `json:"status" gorm:"type:varchar(16);index:idx_user_status,priority:2;index:idx_status_expires,priority:1"`
+	AttachmentID string    `json:"attachment_id" gorm:"type:varchar(64)"`
+	ExpiresAt    time.Time `json:"expires_at" gorm:"index:idx_status_expires,priority:2"`
+}
+
+// TableName 设置表名
+func (AttachmentUpload) TableName() string {
+	return "chatify_attachment_upload"
+}
+
+// BeforeCreate GORM钩子，创建前的处理
+func (u *AttachmentUpload) BeforeCreate(tx *gorm.DB) error {
+	if !strings.HasPrefix(u.ID, "auid") {
+		// attachment upload id prefix
+		u.ID = "auid" + u.ID
+	}
+	return nil
+}
+
+func NewAttachmentUploadFromBo(upload *bo.AttachmentUpload) *AttachmentUpload {
+	return &AttachmentUpload{
+		UploadID:     upload.UploadId,
+		UserID:       upload.UserId,
+		FileName:     upload.FileName,
+		MimeType:     upload.MimeType,
+		Size:         upload.Size,
+		Sha256:       upload.Sha256,
+		ChunkSize:    upload.ChunkSize,
+		ChunkCount:   upload.ChunkCount,
+		Status:       string(upload.Status),
+		AttachmentID: upload.AttachmentId,
+		ExpiresAt:    upload.ExpiresAt,
+	}
+}
+
+func (u *AttachmentUpload) ToBo() *bo.AttachmentUpload {
+	return &bo.AttachmentUpload{
+		UploadId:     u.UploadID,
+		UserId:       u.UserID,
+		FileName:     u.FileName,
+		MimeType:     u.MimeType,
+		Size:         u.Size,
+		Sha256:       u.Sha256,
+		ChunkSize:    u.ChunkSize,
+		ChunkCount:   u.ChunkCount,
+		Status:       bo.AttachmentUploadStatus(u.Status),
+		AttachmentId: u.AttachmentID,
+		ExpiresAt:    u.ExpiresAt,
+	}
+}
+
+// AttachmentChunk 已上传的附件分片
+// 数据库表名: chatify_attachment_chunk
+type AttachmentChunk struct {
+	model.BaseModel
+	UploadID   string `json:"upload_id" gorm:"type:varchar(64);uniqueIndex:idx_upload_chunk,priority:1"`
+	ChunkIndex int    `json:"chunk_index" gorm:"uniqueIndex:idx_upload_chunk,priority:2"`
+}
+
+// TableName 设置表名
+func (AttachmentChunk) TableName() string {
+	return "chatify_attachment_chunk"
+}
+
+// BeforeCreate GORM钩子，创建前的处理
+func (c *AttachmentChunk) BeforeCreate(tx *gorm.DB) error {
+	if !strings.HasPrefix(c.ID, "acid") {
+		// attachment chunk id prefix
+		c.ID = "acid" + c.ID
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package po
+
+import (
+	"strings"
+
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+
+	"github.com/xinghe903/chatify/pkg/model"
+
+	"gorm.io/gorm"
+)
+
+// Bot 机器人账号实体类
+// 数据库表名: chatify_bot
+type Bot struct {
+	model.BaseModel
+	BotID     string `json:"bot_id" gorm:"type:varchar(64);uniqueIndex:idx_bot_id"`
+	Name      string `json:"name" gorm:"type:varchar(64)"`
+	OwnerID   string `json:"owner_id" gorm:"type:varchar(64);index:idx_owner_id"`
+	TokenHash string `json:"-" gorm:"type:char(64);uniqueIndex:idx_token_hash"`
+}
+
+// TableName 设置表名
+func (Bot) TableName() string {
+	return "chatify_bot"
+}
+
+// BeforeCreate GORM钩子，创建前的处理
+func (b *Bot) BeforeCreate(tx *gorm.DB) error {
+	if !strings.HasPrefix(b.ID, "bid") {
+		// bot id prefix
+		b.ID = "bid" + b.ID
+	}
+	return nil
+}
+
+func NewBotFromBo(bot *bo.Bot) *Bot {
+	return &Bot{
+		BotID:     bot.BotId,
+		Name:      bot.Name,
+		OwnerID:   bot.OwnerId,
+		TokenHash: bot.TokenHash,
+	}
+}
+
+func (b *Bot) ToBo() *bo.Bot {
+	return &bo.Bot{
+		BotId:     b.BotID,
+		Name:      b.Name,
+		OwnerId:   b.OwnerID,
+		TokenHash: b.TokenHash,
+		CreatedAt: b.CreatedAt,
+	}
+}
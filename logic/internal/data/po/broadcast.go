@@ -0,0 +1,146 @@
+package po
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+
+	"github.com/xinghe903/chatify/pkg/model"
+
+	v1 "github.com/xinghe903/chatify/api/logic/v1"
+
+	"gorm.io/gorm"
+)
+
+// BroadcastJob 广播任务实体类
+// 数据库表名: chatify_broadcast_job
+type BroadcastJob struct {
+	model.BaseModel
+	JobID          string `json:"job_id" gorm:"type:varchar(64);uniqueIndex:idx_job_id"`
+	Status         string `json:"status" gorm:"type:varchar(20);index:idx_status"`
+	ContentID      string `json:"content_id" gorm:"type:varchar(64);index:idx_content_id"`
+	Content        []byte `json:"content" gorm:"type:blob"`
+	PushType       int32  `json:"push_type"`
+	FromUserID     string `json:"from_user_id" gorm:"type:varchar(64)"`
+	Timestamp      int64  `json:"timestamp"`
+	ExpireTime     string `json:"expire_time" gorm:"type:varchar(32)"`
+	TotalUsers     int64  `json:"total_users"`
+	TotalChunks    int32  `json:"total_chunks"`
+	FinishedChunks int32  `json:"finished_chunks"`
+	SentUsers      int64  `json:"sent_users"`
+	FailedUsers    int64  `json:"failed_users"`
+}
+
+// TableName 设置表名
+func (BroadcastJob) TableName() string {
+	return "chatify_broadcast_job"
+}
+
+// BeforeCreate GORM钩子，创建前的处理
+func (j *BroadcastJob) BeforeCreate(tx *gorm.DB) error {
+	if !strings.HasPrefix(j.ID, "bjid") {
+		// broadcast job id prefix
+		j.ID = "bjid" + j.ID
+	}
+	return nil
+}
+
+func NewBroadcastJobFromBo(job *bo.BroadcastJob) *BroadcastJob {
+	return &BroadcastJob{
+		JobID:          job.JobId,
+		Status:         string(job.Status),
+		ContentID:      job.ContentId,
+		Content:        job.Content,
+		PushType:       int32(job.PushType),
+		FromUserID:     job.FromUserId,
+		Timestamp:      job.Timestamp,
+		ExpireTime:     job.ExpireTime,
+		TotalUsers:     job.TotalUsers,
+		TotalChunks:    job.TotalChunks,
+		FinishedChunks: job.FinishedChunks,
+		SentUsers:      job.SentUsers,
+		FailedUsers:    job.FailedUsers,
+	}
+}
+
+func (j *BroadcastJob) ToBo() *bo.BroadcastJob {
+	return &bo.BroadcastJob{
+		JobId:          j.JobID,
+		Status:         bo.BroadcastJobStatus(j.Status),
+		ContentId:      j.ContentID,
+		Content:        j.Content,
+		PushType:       v1.PushType(j.PushType),
+		FromUserId:     j.FromUserID,
+		Timestamp:      j.Timestamp,
+		ExpireTime:     j.ExpireTime,
+		TotalUsers:     j.TotalUsers,
+		TotalChunks:    j.TotalChunks,
+		FinishedChunks: j.FinishedChunks,
+		SentUsers:      j.SentUsers,
+		FailedUsers:    j.FailedUsers,
+		CreatedAt:      j.CreatedAt,
+		UpdatedAt:      j.UpdatedAt,
+	}
+}
+
+// BroadcastChunk 广播任务分片实体类
+// 数据库表名: chatify_broadcast_chunk
+type BroadcastChunk struct {
+	model.BaseModel
+	JobID       string `json:"job_id" gorm:"type:varchar(64);uniqueIndex:idx_job_chunk,priority:1;index:idx_job_status,priority:1"`
+	ChunkIndex  int32  `json:"chunk_index" gorm:"uniqueIndex:idx_job_chunk,priority:2"`
+	UserIDs     string `json:"user_ids" gorm:"type:mediumtext"` // JSON数组
+	UserCount   int32  `json:"user_count"`
+	Status      string `json:"status" gorm:"type:varchar(20);index:idx_job_status,priority:2"`
+	TaskID      string `json:"task_id" gorm:"type:varchar(64);index:idx_task_id"`
+	Description string `json:"description" gorm:"type:varchar(255)"`
+	ClaimToken  string `json:"claim_token" gorm:"type:varchar(64)"` // 投递中分片的抢占令牌，重置后失效
+}
+
+// TableName 设置表名
+func (BroadcastChunk) TableName() string {
+	return "chatify_broadcast_chunk"
+}
+
+// BeforeCreate GORM钩子，创建前的处理
+func (c *BroadcastChunk) BeforeCreate(tx *gorm.DB) error {
+	if !strings.HasPrefix(c.ID, "bcid") {
+		// broadcast chunk id prefix
+		c.ID = "bcid" + c.ID
+	}
+	return nil
+}
+
+func NewBroadcastChunkFromBo(chunk *bo.BroadcastChunk) (*BroadcastChunk, error) {
+	userIds, err := json.Marshal(chunk.UserIds)
+	if err != nil {
+		return nil, err
+	}
+	return &BroadcastChunk{
+		JobID:       chunk.JobId,
+		ChunkIndex:  chunk.ChunkIndex,
+		UserIDs:     string(userIds),
+		UserCount:   int32(len(chunk.UserIds)),
+		Status:      string(chunk.Status),
+		TaskID:      chunk.TaskId,
+		Description: chunk.Description,
+		ClaimToken:  chunk.ClaimToken,
+	}, nil
+}
+
+func (c *BroadcastChunk) ToBo() (*bo.BroadcastChunk, error) {
+	var userIds []string
+	if err := json.Unmarshal([]byte(c.UserIDs), &userIds); err != nil {
+		return nil, err
+	}
+	return &bo.BroadcastChunk{
+		JobId:       c.JobID,
+		ChunkIndex:  c.ChunkIndex,
+		UserIds:     userIds,
+		Status:      bo.BroadcastChunkStatus(c.Status),
+		TaskId:      c.TaskID,
+		Description: c.Description,
+		ClaimToken:  c.ClaimToken,
+	}, nil
+}
@@ -0,0 +1,107 @@
+package po
+
+import (
+	"strings"
+
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+
+	"github.com/xinghe903/chatify/pkg/model"
+
+	im_v1 "github.com/xinghe903/chatify/api/im/v1"
+
+	"gorm.io/gorm"
+)
+
+// ChatMessage 聊天消息实体类
+// 数据库表名: chatify_chat_message
+type ChatMessage struct {
+	model.BaseModel
+	MsgID            string `json:"msg_id" gorm:"type:varchar(64);uniqueIndex:idx_msg_id;index:idx_timeline,priority:3"`
+	FromUserID       string `json:"from_user_id" gorm:"type:varchar(64);index:idx_from_user"`
+	ConversationType int32  `json:"conversation_type"`
+	ConversationID   string `json:"conversation_id" gorm:"type:varchar(64);index:idx_conversation"`
+	ConversationKey  string `json:"conversation_key" gorm:"type:varchar(140);index:idx_timeline,priority:1;index:idx_conversation_seq,priority:1"`
+	SentAt           int64  `json:"sent_at" gorm:"index:idx_timeline,priority:2"` // 单位: 毫秒
+	Seq              int64  `json:"seq" gorm:"index:idx_conversation_seq,priority:2"`
+	Content          []byte `json:"content" gorm:"type:blob"`
+	ContentType      int32  `json:"content_type"`
+	Timestamp        int64  `json:"timestamp"`
+	Status           string `json:"status" gorm:"type:varchar(20)"`
+	RevisedBy        string `json:"revised_by" gorm:"type:varchar(64)"`
+	RevisedAt        int64  `json:"revised_at"`
+	DeliveredAt      int64  `json:"delivered_at"` // 单位: 毫秒，为0表示尚未完成投递
+}
+
+// TableName 设置表名
+func (ChatMessage) TableName() string {
+	return "chatify_chat_message"
+}
+
+// BeforeCreate GORM钩子，创建前的处理
+func (m *ChatMessage) BeforeCreate(tx *gorm.DB) error {
+	if !strings.HasPrefix(m.ID, "cmsg") {
+		// chat message id prefix
+		m.ID = "cmsg" + m.ID
+	}
+	return nil
+}
+
+func NewChatMessageFromBo(message *bo.ChatMessage) *ChatMessage {
+	return &ChatMessage{
+		MsgID:            message.MsgId,
+		FromUserID:       message.FromUserId,
+		ConversationType: int32(message.ConversationType),
+		ConversationID:   message.ConversationId,
+		ConversationKey:  message.ConversationKey(),
+		SentAt:           message.SentAt,
+		Seq:              message.Seq,
+		Content:          message.Content,
+		ContentType:      int32(message.ContentType),
+		Timestamp:        message.Timestamp,
+		Status:           string(message.Status),
+		RevisedBy:        message.RevisedBy,
+		RevisedAt:        message.RevisedAt,
+		DeliveredAt:      message.DeliveredAt,
+	}
+}
+
+func (m *ChatMessage) ToBo() *bo.ChatMessage {
+	return &bo.ChatMessage{
+		MsgId:            m.MsgID,
+		FromUserId:       m.FromUserID,
+		ConversationType: im_v1.TargetType(m.ConversationType),
+		ConversationId:   m.ConversationID,
+		Content:          m.Content,
+		ContentType:      bo.MessageType(m.ContentType),
+		Timestamp:        m.Timestamp,
+		Status:           bo.ChatMessageStatus(m.Status),
+		RevisedBy:        m.RevisedBy,
+		RevisedAt:        m.RevisedAt,
+		SentAt:           m.SentAt,
+		Seq:              m.Seq,
+		DeliveredAt:      m.DeliveredAt,
+		CreatedAt:        m.CreatedAt,
+	}
+}
+
+// ChatMessageDeletion 用户删除的历史消息，只对该用户隐藏
+// 数据库表名: chatify_chat_message_deletion
+type ChatMessageDeletion struct {
+	model.BaseModel
+	UserID string `json:"user_id" gorm:"type:varchar(64);uniqueIndex:idx_user_msg,priority:1"`
+	MsgID  string `json:"msg_id" gorm:"type:varchar(64);uniqueIndex:idx_user_msg,priority:2"`
+}
+
+// TableName 设置表名
+func (ChatMessageDeletion) TableName() string {
+	return "chatify_chat_message_deletion"
+}
+
+// BeforeCreate GORM钩子，创建前的处理
+func (d *ChatMessageDeletion) BeforeCreate(tx *gorm.DB) error {
+	if !strings.HasPrefix(d.ID, "cmdl") {
+		// chat message deletion id prefix
+		d.ID = "cmdl" + d.ID
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package po
+
+import (
+	"strings"
+
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+
+	"github.com/xinghe903/chatify/pkg/model"
+
+	im_v1 "github.com/xinghe903/chatify/api/im/v1"
+
+	"gorm.io/gorm"
+)
+
+// Conversation 用户会话实体类，每个用户每个单聊对象或群一行
+// 保存最后一条消息摘要、已读位置、未读数以及置顶和免打扰设置，Redis中未读数丢失时以此为准重建
+// 数据库表名: chatify_conversation
+type Conversation struct {
+	model.BaseModel
+	UserID           string `json:"user_id" gorm:"type:varchar(64);uniqueIndex:idx_user_conversation,priority:1;index:idx_user_list,priority:1"`
+	ConversationType int32  `json:"conversation_type" gorm:"uniqueIndex:idx_user_conversation,priority:2"`
+	ConversationID   string `json:"conversation_id" gorm:"type:varchar(64);uniqueIndex:idx_user_conversation,priority:3"`
+	LastMsgID        string `json:"last_msg_id" gorm:"type:varchar(64);index:idx_last_msg_id"`
+	LastSenderID     string `json:"last_sender_id" gorm:"type:varchar(64)"`
+	LastPreview      string `json:"last_preview" gorm:"type:varchar(255)"`
+	LastMessageAt    int64  `json:"last_message_at" gorm:"index:idx_user_list,priority:3"` // 单位: 毫秒
+	ReadMsgID        string `json:"read_msg_id" gorm:"type:varchar(64)"`
+	ReadAt           int64  `json:"read_at"` // 单位: 毫秒
+	UnreadCount      int64  `json:"unread_count"`
+	Pinned           bool   `json:"pinned" gorm:"index:idx_user_list,priority:2"`
+	Muted            bool   `json:"muted"`
+	MuteUntil        int64  `json:"mute_until"`
+	MentionedAt      int64  `json:"mentioned_at"` // 最近一条@该用户的消息时间（单位: 毫秒），晚于read_at时会话显示被@
+}
+
+// TableName 设置表名
+func (Conversation) TableName() string {
+	return "chatify_conversation"
+}
+
+// BeforeCreate GORM钩子，创建前的处理
+func (c *Conversation) BeforeCreate(tx *gorm.DB) error {
+	if !strings.HasPrefix(c.ID, "cvid") {
+		// conversation id prefix
+		c.ID = "cvid" + c.ID
+	}
+	return nil
+}
+
+func (c *Conversation) ToBo() *bo.Conversation {
+	return &bo.Conversation{
+		UserId:           c.UserID,
+		ConversationType: im_v1.TargetType(c.ConversationType),
+		ConversationId:   c.ConversationID,
+		LastMsgId:        c.LastMsgID,
+		LastSenderId:     c.LastSenderID,
+		LastPreview:      c.LastPreview,
+		LastMessageAt:    c.LastMessageAt,
+		ReadMsgId:        c.ReadMsgID,
+		ReadAt:           c.ReadAt,
+		UnreadCount:      c.UnreadCount,
+		Pinned:           c.Pinned,
+		Muted:            c.Muted,
+		MuteUntil:        c.MuteUntil,
+		MentionedAt:      c.MentionedAt,
+	}
+}
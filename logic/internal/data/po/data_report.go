@@ -0,0 +1,111 @@
+package po
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+
+	"github.com/xinghe903/chatify/pkg/model"
+
+	"gorm.io/gorm"
+)
+
+// ReportSchema 数据上报结构实体类，每个版本一行，注册后不再修改
+// 数据库表名: chatify_report_schema
+type ReportSchema struct {
+	model.BaseModel
+	Name                  string `json:"name" gorm:"type:varchar(64);uniqueIndex:idx_schema_version,priority:1"`
+	Version               int32  `json:"version" gorm:"uniqueIndex:idx_schema_version,priority:2"`
+	Fields                []byte `json:"fields" gorm:"type:blob"` // 字段定义JSON数组
+	AllowAdditionalFields bool   `json:"allow_additional_fields"`
+}
+
+// TableName 设置表名
+func (ReportSchema) TableName() string {
+	return "chatify_report_schema"
+}
+
+// BeforeCreate GORM钩子，创建前的处理
+func (s *ReportSchema) BeforeCreate(tx *gorm.DB) error {
+	if !strings.HasPrefix(s.ID, "rsid") {
+		// report schema id prefix
+		s.ID = "rsid" + s.ID
+	}
+	return nil
+}
+
+func NewReportSchemaFromBo(schema *bo.ReportSchema) (*ReportSchema, error) {
+	fields, err := json.Marshal(schema.Fields)
+	if err != nil {
+		return nil, err
+	}
+	return &ReportSchema{
+		Name:                  schema.Name,
+		Version:               schema.Version,
+		Fields:                fields,
+		AllowAdditionalFields: schema.AllowAdditionalFields,
+	}, nil
+}
+
+func (s *ReportSchema) ToBo() (*bo.ReportSchema, error) {
+	schema := &bo.ReportSchema{
+		Name:                  s.Name,
+		Version:               s.Version,
+		AllowAdditionalFields: s.AllowAdditionalFields,
+		CreatedAt:             s.CreatedAt,
+	}
+	if err := json.Unmarshal(s.Fields, &schema.Fields); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// DataReport 数据上报实体类，只追加写入
+// 数据库表名: chatify_data_report
+type DataReport struct {
+	model.BaseModel
+	ReportID  string `json:"report_id" gorm:"type:varchar(96);uniqueIndex:idx_user_report,priority:2"` // 由上报消息的msg_id生成，只在用户内唯一
+	UserID    string `json:"user_id" gorm:"type:varchar(64);index:idx_user_time,priority:1;uniqueIndex:idx_user_report,priority:1"`
+	Timestamp int64  `json:"timestamp" gorm:"index:idx_user_time,priority:2"` // 客户端上报时间，单位: 秒
+	Schema    string `json:"schema" gorm:"type:varchar(64)"`
+	Version   int32  `json:"version"`
+	Data      []byte `json:"data" gorm:"type:blob"`
+}
+
+// TableName 设置表名
+func (DataReport) TableName() string {
+	return "chatify_data_report"
+}
+
+// BeforeCreate GORM钩子，创建前的处理
+func (r *DataReport) BeforeCreate(tx *gorm.DB) error {
+	if !strings.HasPrefix(r.ID, "drid") {
+		// data report id prefix
+		r.ID = "drid" + r.ID
+	}
+	return nil
+}
+
+func NewDataReportFromBo(userId string, report *bo.DataReport) *DataReport {
+	return &DataReport{
+		ReportID:  report.ReportId,
+		UserID:    userId,
+		Timestamp: report.Timestamp,
+		Schema:    report.Schema,
+		Version:   report.Version,
+		Data:      report.Data,
+	}
+}
+
+func (r *DataReport) ToBo() *bo.StoredDataReport {
+	return &bo.StoredDataReport{
+		ReportId:   r.ReportID,
+		UserId:     r.UserID,
+		Schema:     r.Schema,
+		Version:    r.Version,
+		Timestamp:  r.Timestamp,
+		Data:       string(r.Data),
+		ReceivedAt: r.CreatedAt,
+	}
+}
@@ -0,0 +1,53 @@
+package po
+
+import (
+	"strings"
+
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+
+	"github.com/xinghe903/chatify/pkg/model"
+
+	"gorm.io/gorm"
+)
+
+// DeliveryFunnel 送达漏斗时间段计数实体类
+// 数据库表名: chatify_delivery_funnel
+type DeliveryFunnel struct {
+	model.BaseModel
+	ContentID   string `json:"content_id" gorm:"type:varchar(64);uniqueIndex:idx_funnel,priority:1"`
+	BucketStart int64  `json:"bucket_start" gorm:"uniqueIndex:idx_funnel,priority:2"` // 单位: 秒
+	Stage       string `json:"stage" gorm:"type:varchar(20);uniqueIndex:idx_funnel,priority:3"`
+	Total       int64  `json:"total"`
+}
+
+// TableName 设置表名
+func (DeliveryFunnel) TableName() string {
+	return "chatify_delivery_funnel"
+}
+
+// BeforeCreate GORM钩子，创建前的处理
+func (f *DeliveryFunnel) BeforeCreate(tx *gorm.DB) error {
+	if !strings.HasPrefix(f.ID, "dfid") {
+		// delivery funnel id prefix
+		f.ID = "dfid" + f.ID
+	}
+	return nil
+}
+
+func NewDeliveryFunnelFromBo(count *bo.FunnelCount) *DeliveryFunnel {
+	return &DeliveryFunnel{
+		ContentID:   count.ContentId,
+		BucketStart: count.BucketStart,
+		Stage:       string(count.Stage),
+		Total:       count.Count,
+	}
+}
+
+func (f *DeliveryFunnel) ToBo() *bo.FunnelCount {
+	return &bo.FunnelCount{
+		ContentId:   f.ContentID,
+		BucketStart: f.BucketStart,
+		Stage:       bo.FunnelStage(f.Stage),
+		Count:       f.Total,
+	}
+}
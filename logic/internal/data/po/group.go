@@ -0,0 +1,42 @@
+package po
+
+import (
+	"strings"
+
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+
+	"github.com/xinghe903/chatify/pkg/model"
+
+	"gorm.io/gorm"
+)
+
+// GroupMember 群成员实体类，由群组服务写入，logic只读
+// 数据库表名: chatify_group_member
+type GroupMember struct {
+	model.BaseModel
+	GroupID string `json:"group_id" gorm:"type:varchar(64);uniqueIndex:idx_group_user,priority:1"`
+	UserID  string `json:"user_id" gorm:"type:varchar(64);uniqueIndex:idx_group_user,priority:2;index:idx_user_id"`
+	Role    string `json:"role" gorm:"type:varchar(20)"`
+}
+
+// TableName 设置表名
+func (GroupMember) TableName() string {
+	return "chatify_group_member"
+}
+
+// BeforeCreate GORM钩子，创建前的处理
+func (m *GroupMember) BeforeCreate(tx *gorm.DB) error {
+	if !strings.HasPrefix(m.ID, "gmid") {
+		// group member id prefix
+		m.ID = "gmid" + m.ID
+	}
+	return nil
+}
+
+func (m *GroupMember) ToBo() *bo.GroupMember {
+	return &bo.GroupMember{
+		GroupId: m.GroupID,
+		UserId:  m.UserID,
+		Role:    bo.GroupMemberRole(m.Role),
+	}
+}
@@ -0,0 +1,114 @@
+package po
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+
+	"github.com/xinghe903/chatify/pkg/model"
+
+	v1 "github.com/xinghe903/chatify/api/logic/v1"
+
+	"gorm.io/gorm"
+)
+
+// NotificationPreference 用户通知偏好实体类，每个用户一行
+// 数据库表名: chatify_notification_preference
+type NotificationPreference struct {
+	model.BaseModel
+	UserID          string `json:"user_id" gorm:"type:varchar(64);uniqueIndex:idx_user_id"`
+	OptOutPushTypes string `json:"opt_out_push_types" gorm:"type:varchar(64)"` // 退订的推送类型，逗号分隔
+	DndEnabled      bool   `json:"dnd_enabled"`
+	DndStart        string `json:"dnd_start" gorm:"type:varchar(5)"` // 格式 HH:MM
+	DndEnd          string `json:"dnd_end" gorm:"type:varchar(5)"`   // 格式 HH:MM
+	DndTimezone     string `json:"dnd_timezone" gorm:"type:varchar(64)"`
+}
+
+// TableName 设置表名
+func (NotificationPreference) TableName() string {
+	return "chatify_notification_preference"
+}
+
+// BeforeCreate GORM钩子，创建前的处理
+func (p *NotificationPreference) BeforeCreate(tx *gorm.DB) error {
+	if !strings.HasPrefix(p.ID, "npid") {
+		// notification preference id prefix
+		p.ID = "npid" + p.ID
+	}
+	return nil
+}
+
+func NewNotificationPreferenceFromBo(update *bo.NotificationPreferencesUpdate) *NotificationPreference {
+	m := &NotificationPreference{
+		UserID:          update.UserId,
+		OptOutPushTypes: JoinPushTypes(update.OptOutPushTypes),
+	}
+	if update.Dnd != nil {
+		m.DndEnabled = update.Dnd.Enabled
+		m.DndStart = update.Dnd.Start
+		m.DndEnd = update.Dnd.End
+		m.DndTimezone = update.Dnd.Timezone
+	}
+	return m
+}
+
+func (p *NotificationPreference) ToBo() *bo.NotificationPreferences {
+	preferences := &bo.NotificationPreferences{
+		UserId: p.UserID,
+		Dnd: bo.DndWindow{
+			Enabled:  p.DndEnabled,
+			Start:    p.DndStart,
+			End:      p.DndEnd,
+			Timezone: p.DndTimezone,
+		},
+	}
+	for _, s := range strings.Split(p.OptOutPushTypes, ",") {
+		if n, err := strconv.Atoi(s); err == nil {
+			preferences.OptOutPushTypes = append(preferences.OptOutPushTypes, v1.PushType(n))
+		}
+	}
+	return preferences
+}
+
+// JoinPushTypes 把推送类型编码为逗号分隔的字符串
+func JoinPushTypes(pushTypes []v1.PushType) string {
+	values := make([]string, 0, len(pushTypes))
+	for _, pushType := range pushTypes {
+		values = append(values, strconv.Itoa(int(pushType)))
+	}
+	return strings.Join(values, ",")
+}
+
+// PushSuppression 因用户偏好未发送的系统推送记录
+// 数据库表名: chatify_push_suppression
+type PushSuppression struct {
+	model.BaseModel
+	ContentID string `json:"content_id" gorm:"type:varchar(64);uniqueIndex:idx_content_user,priority:1"`
+	UserID    string `json:"user_id" gorm:"type:varchar(64);uniqueIndex:idx_content_user,priority:2;index:idx_user_id"`
+	PushType  int32  `json:"push_type"`
+	Reason    string `json:"reason" gorm:"type:varchar(32)"`
+}
+
+// TableName 设置表名
+func (PushSuppression) TableName() string {
+	return "chatify_push_suppression"
+}
+
+// BeforeCreate GORM钩子，创建前的处理
+func (s *PushSuppression) BeforeCreate(tx *gorm.DB) error {
+	if !strings.HasPrefix(s.ID, "psid") {
+		// push suppression id prefix
+		s.ID = "psid" + s.ID
+	}
+	return nil
+}
+
+func NewPushSuppressionFromBo(suppression *bo.PushSuppression) *PushSuppression {
+	return &PushSuppression{
+		ContentID: suppression.ContentId,
+		UserID:    suppression.UserId,
+		PushType:  int32(suppression.PushType),
+		Reason:    string(suppression.Reason),
+	}
+}
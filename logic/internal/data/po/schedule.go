@@ -0,0 +1,122 @@
+package po
+
+import (
+	"strings"
+
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+
+	"github.com/xinghe903/chatify/pkg/model"
+
+	v1 "github.com/xinghe903/chatify/api/logic/v1"
+
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+)
+
+// PushSchedule 定时推送计划实体类
+// 数据库表名: chatify_push_schedule
+type PushSchedule struct {
+	model.BaseModel
+	ScheduleID  string `json:"schedule_id" gorm:"type:varchar(64);uniqueIndex:idx_schedule_id"`
+	Status      string `json:"status" gorm:"type:varchar(20);index:idx_status_fire,priority:1"`
+	NextFireAt  int64  `json:"next_fire_at" gorm:"index:idx_status_fire,priority:2"`
+	Request     []byte `json:"request" gorm:"type:mediumblob"` // SystemPushRequest protobuf编码
+	FiredCount  int32  `json:"fired_count"`
+	LastFiredAt int64  `json:"last_fired_at"`
+	LastTaskID  string `json:"last_task_id" gorm:"type:varchar(64)"`
+	LastError   string `json:"last_error" gorm:"type:varchar(255)"`
+}
+
+// TableName 设置表名
+func (PushSchedule) TableName() string {
+	return "chatify_push_schedule"
+}
+
+// BeforeCreate GORM钩子，创建前的处理
+func (p *PushSchedule) BeforeCreate(tx *gorm.DB) error {
+	if !strings.HasPrefix(p.ID, "psid") {
+		// push schedule id prefix
+		p.ID = "psid" + p.ID
+	}
+	return nil
+}
+
+func NewPushScheduleFromBo(schedule *bo.PushSchedule) (*PushSchedule, error) {
+	request, err := proto.Marshal(schedule.Request)
+	if err != nil {
+		return nil, err
+	}
+	return &PushSchedule{
+		ScheduleID:  schedule.ScheduleId,
+		Status:      string(schedule.Status),
+		NextFireAt:  schedule.NextFireAt,
+		Request:     request,
+		FiredCount:  schedule.FiredCount,
+		LastFiredAt: schedule.LastFiredAt,
+		LastTaskID:  schedule.LastTaskId,
+		LastError:   schedule.LastError,
+	}, nil
+}
+
+func (p *PushSchedule) ToBo() (*bo.PushSchedule, error) {
+	var request v1.SystemPushRequest
+	if err := proto.Unmarshal(p.Request, &request); err != nil {
+		return nil, err
+	}
+	return &bo.PushSchedule{
+		ScheduleId:  p.ScheduleID,
+		Status:      bo.PushScheduleStatus(p.Status),
+		Request:     &request,
+		NextFireAt:  p.NextFireAt,
+		FiredCount:  p.FiredCount,
+		LastFiredAt: p.LastFiredAt,
+		LastTaskId:  p.LastTaskID,
+		LastError:   p.LastError,
+		CreatedAt:   p.CreatedAt,
+	}, nil
+}
+
+// PushScheduleFire 定时推送计划的发送周期实体类
+// 数据库表名: chatify_push_schedule_fire
+type PushScheduleFire struct {
+	model.BaseModel
+	ScheduleID string `json:"schedule_id" gorm:"type:varchar(64);uniqueIndex:idx_schedule_fire,priority:1"`
+	FireAt     int64  `json:"fire_at" gorm:"uniqueIndex:idx_schedule_fire,priority:2"`
+	Status     string `json:"status" gorm:"type:varchar(20);index:idx_status"`
+	TaskID     string `json:"task_id" gorm:"type:varchar(64)"`
+	LastError  string `json:"last_error" gorm:"type:varchar(255)"`
+}
+
+// TableName 设置表名
+func (PushScheduleFire) TableName() string {
+	return "chatify_push_schedule_fire"
+}
+
+// BeforeCreate GORM钩子，创建前的处理
+func (f *PushScheduleFire) BeforeCreate(tx *gorm.DB) error {
+	if !strings.HasPrefix(f.ID, "sfid") {
+		// schedule fire id prefix
+		f.ID = "sfid" + f.ID
+	}
+	return nil
+}
+
+func NewPushScheduleFireFromBo(fire *bo.PushScheduleFire) *PushScheduleFire {
+	return &PushScheduleFire{
+		ScheduleID: fire.ScheduleId,
+		FireAt:     fire.FireAt,
+		Status:     string(fire.Status),
+		TaskID:     fire.TaskId,
+		LastError:  fire.LastError,
+	}
+}
+
+func (f *PushScheduleFire) ToBo() *bo.PushScheduleFire {
+	return &bo.PushScheduleFire{
+		ScheduleId: f.ScheduleID,
+		FireAt:     f.FireAt,
+		Status:     bo.PushScheduleFireStatus(f.Status),
+		TaskId:     f.TaskID,
+		LastError:  f.LastError,
+	}
+}
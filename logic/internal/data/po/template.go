@@ -0,0 +1,94 @@
+package po
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+
+	"github.com/xinghe903/chatify/pkg/model"
+
+	"gorm.io/gorm"
+)
+
+// PushTemplate 推送模板实体类
+// 数据库表名: chatify_push_template
+type PushTemplate struct {
+	model.BaseModel
+	TemplateID    string `json:"template_id" gorm:"type:varchar(64);uniqueIndex:idx_template_id"`
+	Name          string `json:"name" gorm:"type:varchar(128)"`
+	Variables     []byte `json:"variables" gorm:"type:blob"` // 变量名JSON数组
+	DefaultLocale string `json:"default_locale" gorm:"type:varchar(35)"`
+	Contents      []byte `json:"contents" gorm:"type:mediumblob"` // 语言到内容的JSON对象
+}
+
+// TableName 设置表名
+func (PushTemplate) TableName() string {
+	return "chatify_push_template"
+}
+
+// BeforeCreate GORM钩子，创建前的处理
+func (p *PushTemplate) BeforeCreate(tx *gorm.DB) error {
+	if !strings.HasPrefix(p.ID, "ptid") {
+		// push template id prefix
+		p.ID = "ptid" + p.ID
+	}
+	return nil
+}
+
+func NewPushTemplateFromBo(template *bo.PushTemplate) (*PushTemplate, error) {
+	variables, err := json.Marshal(template.Variables)
+	if err != nil {
+		return nil, err
+	}
+	contents, err := json.Marshal(template.Contents)
+	if err != nil {
+		return nil, err
+	}
+	return &PushTemplate{
+		TemplateID:    template.TemplateId,
+		Name:          template.Name,
+		Variables:     variables,
+		DefaultLocale: template.DefaultLocale,
+		Contents:      contents,
+	}, nil
+}
+
+func (p *PushTemplate) ToBo() (*bo.PushTemplate, error) {
+	template := &bo.PushTemplate{
+		TemplateId:    p.TemplateID,
+		Name:          p.Name,
+		DefaultLocale: p.DefaultLocale,
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.UpdatedAt,
+	}
+	if err := json.Unmarshal(p.Variables, &template.Variables); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(p.Contents, &template.Contents); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// UserLocale 用户语言偏好实体类
+// 数据库表名: chatify_user_locale
+type UserLocale struct {
+	model.BaseModel
+	UserID string `json:"user_id" gorm:"type:varchar(64);uniqueIndex:idx_user_id"`
+	Locale string `json:"locale" gorm:"type:varchar(35)"`
+}
+
+// TableName 设置表名
+func (UserLocale) TableName() string {
+	return "chatify_user_locale"
+}
+
+// BeforeCreate GORM钩子，创建前的处理
+func (u *UserLocale) BeforeCreate(tx *gorm.DB) error {
+	if !strings.HasPrefix(u.ID, "ulid") {
+		// user locale id prefix
+		u.ID = "ulid" + u.ID
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package po
+
+import (
+	"strings"
+
+	"github.com/xinghe903/chatify/pkg/model"
+
+	"gorm.io/gorm"
+)
+
+// UserProfile 用户属性实体类，语言保存在 chatify_user_locale
+// 数据库表名: chatify_user_profile
+type UserProfile struct {
+	model.BaseModel
+	UserID       string `json:"user_id" gorm:"type:varchar(64);uniqueIndex:idx_user_id"`
+	Platform     string `json:"platform" gorm:"type:varchar(32);index:idx_platform"`
+	RegisteredAt int64  `json:"registered_at" gorm:"index:idx_registered_at"` // 单位: 秒
+}
+
+// TableName 设置表名
+func (UserProfile) TableName() string {
+	return "chatify_user_profile"
+}
+
+// BeforeCreate GORM钩子，创建前的处理
+func (u *UserProfile) BeforeCreate(tx *gorm.DB) error {
+	if !strings.HasPrefix(u.ID, "upid") {
+		// user profile id prefix
+		u.ID = "upid" + u.ID
+	}
+	return nil
+}
+
+// UserTag 用户标签实体类
+// 数据库表名: chatify_user_tag
+type UserTag struct {
+	model.BaseModel
+	UserID string `json:"user_id" gorm:"type:varchar(64);uniqueIndex:idx_user_tag,priority:1"`
+	Tag    string `json:"tag" gorm:"type:varchar(64);uniqueIndex:idx_user_tag,priority:2;index:idx_tag"`
+}
+
+// TableName 设置表名
+func (UserTag) TableName() string {
+	return "chatify_user_tag"
+}
+
+// BeforeCreate GORM钩子，创建前的处理
+func (u *UserTag) BeforeCreate(tx *gorm.DB) error {
+	if !strings.HasPrefix(u.ID, "utid") {
+		// user tag id prefix
+		u.ID = "utid" + u.ID
+	}
+	return nil
+}
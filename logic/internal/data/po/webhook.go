@@ -0,0 +1,65 @@
+package po
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+
+	"github.com/xinghe903/chatify/pkg/model"
+
+	"gorm.io/gorm"
+)
+
+// WebhookSubscription webhook订阅实体类
+// 数据库表名: chatify_webhook
+type WebhookSubscription struct {
+	model.BaseModel
+	WebhookID  string `json:"webhook_id" gorm:"type:varchar(64);uniqueIndex:idx_webhook_id"`
+	URL        string `json:"url" gorm:"type:varchar(512)"`
+	EventTypes []byte `json:"event_types" gorm:"type:blob"` // 事件类型JSON数组
+	BotID      string `json:"bot_id" gorm:"type:varchar(64)"`
+	Secret     string `json:"-" gorm:"type:varchar(128)"`
+}
+
+// TableName 设置表名
+func (WebhookSubscription) TableName() string {
+	return "chatify_webhook"
+}
+
+// BeforeCreate GORM钩子，创建前的处理
+func (w *WebhookSubscription) BeforeCreate(tx *gorm.DB) error {
+	if !strings.HasPrefix(w.ID, "whid") {
+		// webhook id prefix
+		w.ID = "whid" + w.ID
+	}
+	return nil
+}
+
+func NewWebhookSubscriptionFromBo(subscription *bo.WebhookSubscription) (*WebhookSubscription, error) {
+	eventTypes, err := json.Marshal(subscription.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+	return &WebhookSubscription{
+		WebhookID:  subscription.WebhookId,
+		URL:        subscription.URL,
+		EventTypes: eventTypes,
+		BotID:      subscription.BotId,
+		Secret:     subscription.Secret,
+	}, nil
+}
+
+func (w *WebhookSubscription) ToBo() (*bo.WebhookSubscription, error) {
+	subscription := &bo.WebhookSubscription{
+		WebhookId: w.WebhookID,
+		URL:       w.URL,
+		BotId:     w.BotID,
+		Secret:    w.Secret,
+		CreatedAt: w.CreatedAt,
+	}
+	if err := json.Unmarshal(w.EventTypes, &subscription.EventTypes); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
@@ -0,0 +1,111 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/xinghe903/chatify/logic/internal/biz"
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+	"github.com/xinghe903/chatify/logic/internal/data/po"
+
+	"github.com/xinghe903/chatify/pkg/auth"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ biz.NotificationPreferenceRepo = (*notificationPreferenceRepo)(nil)
+
+// notificationPreferenceRepo 通知偏好仓库实现
+type notificationPreferenceRepo struct {
+	data      *Data
+	log       *log.Helper
+	sonyFlake *auth.Sonyflake
+}
+
+// NewNotificationPreferenceRepo 创建通知偏好仓库实例
+func NewNotificationPreferenceRepo(data *Data, logger log.Logger) biz.NotificationPreferenceRepo {
+	return &notificationPreferenceRepo{
+		data:      data,
+		log:       log.NewHelper(logger),
+		sonyFlake: auth.NewSonyflake(),
+	}
+}
+
+// GetPreferences 查询用户的通知偏好
+func (r *notificationPreferenceRepo) GetPreferences(ctx context.Context, userId string) (*bo.NotificationPreferences, error) {
+	var m po.NotificationPreference
+	err := r.data.db.WithContext(ctx).Where("user_id = ?", userId).First(&m).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Join(err, errors.New("failed to get notification preferences"))
+	}
+	return m.ToBo(), nil
+}
+
+// ListPreferences 按批查询通知偏好，大群的接收者较多时避免IN列表过长
+func (r *notificationPreferenceRepo) ListPreferences(ctx context.Context, userIds []string) (map[string]*bo.NotificationPreferences, error) {
+	preferences := make(map[string]*bo.NotificationPreferences)
+	for start := 0; start < len(userIds); start += bo.MaxTargetUsers {
+		var models []*po.NotificationPreference
+		err := r.data.db.WithContext(ctx).
+			Where("user_id IN ?", userIds[start:min(start+bo.MaxTargetUsers, len(userIds))]).
+			Find(&models).Error
+		if err != nil {
+			return nil, errors.Join(err, errors.New("failed to list notification preferences"))
+		}
+		for _, m := range models {
+			preferences[m.UserID] = m.ToBo()
+		}
+	}
+	return preferences, nil
+}
+
+// SavePreferences 插入或更新通知偏好，只更新给定的部分
+func (r *notificationPreferenceRepo) SavePreferences(ctx context.Context, update *bo.NotificationPreferencesUpdate) error {
+	m := po.NewNotificationPreferenceFromBo(update)
+	var err error
+	if m.ID, err = r.sonyFlake.GenerateBase62(); err != nil {
+		return errors.Join(err, errors.New("failed to generate notification preference ID"))
+	}
+	assignments := map[string]interface{}{"updated_at": time.Now()}
+	if update.OptOutPushTypes != nil {
+		assignments["opt_out_push_types"] = m.OptOutPushTypes
+	}
+	if update.Dnd != nil {
+		assignments["dnd_enabled"] = m.DndEnabled
+		assignments["dnd_start"] = m.DndStart
+		assignments["dnd_end"] = m.DndEnd
+		assignments["dnd_timezone"] = m.DndTimezone
+	}
+	err = r.data.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.Assignments(assignments),
+	}).Create(m).Error
+	if err != nil {
+		return errors.Join(err, errors.New("failed to save notification preferences"))
+	}
+	return nil
+}
+
+// RecordSuppressions 保存被过滤的系统推送，同一内容同一用户只记录一次
+func (r *notificationPreferenceRepo) RecordSuppressions(ctx context.Context, suppressions []*bo.PushSuppression) error {
+	models := make([]*po.PushSuppression, 0, len(suppressions))
+	for _, suppression := range suppressions {
+		m := po.NewPushSuppressionFromBo(suppression)
+		var err error
+		if m.ID, err = r.sonyFlake.GenerateBase62(); err != nil {
+			return errors.Join(err, errors.New("failed to generate push suppression ID"))
+		}
+		models = append(models, m)
+	}
+	err := r.data.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(models, 500).Error
+	if err != nil {
+		return errors.Join(err, errors.New("failed to record push suppressions"))
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xinghe903/chatify/logic/internal/biz"
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+
+	im_v1 "github.com/xinghe903/chatify/api/im/v1"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// redisQuotaRateKeyPrefix 发送频率滑动窗口，zset结构：{userId}:{messageType}，member为消息ID，score为发送时间（毫秒）
+	redisQuotaRateKeyPrefix = "chatify:logic:quota:rate:"
+	// redisQuotaTargetKeyPrefix 窗口内发送过的会话，zset结构：member为会话标识，score为最后发送时间（毫秒）
+	redisQuotaTargetKeyPrefix = "chatify:logic:quota:target:"
+	// redisQuotaRepeatKeyPrefix 窗口内相同内容的发送次数：{userId}:{digest}
+	redisQuotaRepeatKeyPrefix = "chatify:logic:quota:repeat:"
+	redisQuotaSpamKeyPrefix   = "chatify:logic:quota:spam:"
+	// redisQuotaMuteKeyPrefix 临时禁言截止时间（单位: 秒）
+	redisQuotaMuteKeyPrefix   = "chatify:logic:quota:mute:"
+	redisQuotaStrikeKeyPrefix = "chatify:logic:quota:strike:"
+)
+
+var (
+	// 移除窗口外的记录后检查数量，未超出时记录本次发送
+	acquireRateScript = redis.NewScript(`
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', ARGV[1] - ARGV[2])
+if redis.call('ZCARD', KEYS[1]) >= tonumber(ARGV[3]) then
+	return 0
+end
+redis.call('ZADD', KEYS[1], ARGV[1], ARGV[4])
+redis.call('PEXPIRE', KEYS[1], ARGV[2])
+return 1`)
+	// 已记录的会话只刷新时间，新会话超出数量时返回-1，否则返回{会话数, 是否新会话}
+	acquireTargetScript = redis.NewScript(`
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', ARGV[1] - ARGV[2])
+local isNew = 0
+if not redis.call('ZSCORE', KEYS[1], ARGV[4]) then
+	if redis.call('ZCARD', KEYS[1]) >= tonumber(ARGV[3]) then
+		return {-1, 1}
+	end
+	isNew = 1
+end
+redis.call('ZADD', KEYS[1], ARGV[1], ARGV[4])
+redis.call('PEXPIRE', KEYS[1], ARGV[2])
+return {redis.call('ZCARD', KEYS[1]), isNew}`)
+	// 计数并在首次写入时设置过期时间
+	incrWindowScript = redis.NewScript(`
+local count = redis.call('INCRBY', KEYS[1], ARGV[1])
+if count == tonumber(ARGV[1]) then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return count`)
+)
+
+var _ biz.QuotaRepo = (*quotaRepo)(nil)
+
+// quotaRepo 发送配额仓库实现
+type quotaRepo struct {
+	redisClient *redis.Client
+	log         *log.Helper
+}
+
+// NewQuotaRepo 创建发送配额仓库
+func NewQuotaRepo(data *Data, logger log.Logger) biz.QuotaRepo {
+	return &quotaRepo{
+		redisClient: data.redisClient,
+		log:         log.NewHelper(logger),
+	}
+}
+
+func (r *quotaRepo) MutedUntil(ctx context.Context, userId string) (time.Time, error) {
+	value, err := r.redisClient.Get(ctx, redisQuotaMuteKeyPrefix+userId).Int64()
+	if errors.Is(err, redis.Nil) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, errors.Join(err, errors.New("failed to get mute state"))
+	}
+	return time.Unix(value, 0), nil
+}
+
+func (r *quotaRepo) AcquireRate(ctx context.Context, userId string, messageType im_v1.MessageType, msgId string, limit int, window time.Duration) (bool, error) {
+	key := redisQuotaRateKeyPrefix + userId + ":" + strings.ToLower(messageType.String())
+	if msgId == "" {
+		// 缺少消息ID时用纳秒时间戳区分同一毫秒内的多次发送
+		msgId = strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+	ok, err := acquireRateScript.Run(ctx, r.redisClient, []string{key},
+		time.Now().UnixMilli(), window.Milliseconds(), limit, msgId).Int()
+	if err != nil {
+		return false, errors.Join(err, errors.New("failed to acquire rate quota"))
+	}
+	return ok == 1, nil
+}
+
+func (r *quotaRepo) AcquireTarget(ctx context.Context, userId, target string, limit int, window time.Duration) (int64, bool, error) {
+	result, err := acquireTargetScript.Run(ctx, r.redisClient, []string{redisQuotaTargetKeyPrefix + userId},
+		time.Now().UnixMilli(), window.Milliseconds(), limit, target).Int64Slice()
+	if err != nil {
+		return 0, false, errors.Join(err, errors.New("failed to acquire target quota"))
+	}
+	if len(result) != 2 {
+		return 0, false, errors.New("unexpected target quota result")
+	}
+	return result[0], result[1] == 1, nil
+}
+
+func (r *quotaRepo) CountRepeat(ctx context.Context, userId, digest string, window time.Duration) (int64, error) {
+	count, err := incrWindowScript.Run(ctx, r.redisClient, []string{redisQuotaRepeatKeyPrefix + userId + ":" + digest},
+		1, window.Milliseconds()).Int64()
+	if err != nil {
+		return 0, errors.Join(err, errors.New("failed to count repeated content"))
+	}
+	return count, nil
+}
+
+func (r *quotaRepo) AddSpamScore(ctx context.Context, userId string, score int64, window time.Duration) (int64, error) {
+	total, err := incrWindowScript.Run(ctx, r.redisClient, []string{redisQuotaSpamKeyPrefix + userId},
+		score, window.Milliseconds()).Int64()
+	if err != nil {
+		return 0, errors.Join(err, errors.New("failed to add spam score"))
+	}
+	return total, nil
+}
+
+func (r *quotaRepo) Mute(ctx context.Context, userId string, until time.Time) (int64, error) {
+	var strikes *redis.Cmd
+	_, err := r.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, redisQuotaMuteKeyPrefix+userId, until.Unix(), time.Until(until))
+		pipe.Del(ctx, redisQuotaSpamKeyPrefix+userId)
+		strikes = incrWindowScript.Eval(ctx, pipe, []string{redisQuotaStrikeKeyPrefix + userId},
+			1, bo.StrikeWindow.Milliseconds())
+		return nil
+	})
+	if err != nil {
+		return 0, errors.Join(err, errors.New("failed to mute sender"))
+	}
+	return strikes.Int64()
+}
@@ -0,0 +1,184 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xinghe903/chatify/logic/internal/biz"
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+	"github.com/xinghe903/chatify/logic/internal/data/po"
+
+	"github.com/xinghe903/chatify/pkg/auth"
+
+	im_v1 "github.com/xinghe903/chatify/api/im/v1"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// redisUnreadKeyPrefix 用户未读数，hash结构：field为{conversationType}:{conversationId}，value为未读数
+	redisUnreadKeyPrefix = "chatify:logic:unread:"
+	// redisUnreadLoadedField 标记hash已从MySQL完整加载
+	redisUnreadLoadedField = "_loaded"
+	redisUnreadExpiration  = 7 * 24 * time.Hour
+)
+
+// 只在缓存已完整加载时修改，避免产生只包含部分会话的hash
+var (
+	incrUnreadScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	return redis.call('HINCRBY', KEYS[1], ARGV[1], 1)
+end
+return 0`)
+	setUnreadScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	return redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+end
+return 0`)
+)
+
+var _ biz.ReadStateRepo = (*readStateRepo)(nil)
+
+// readStateRepo 已读位置和未读数仓库实现，与会话共用chatify_conversation表
+// MySQL保存已读位置和未读数，Redis缓存未读数用于查询
+type readStateRepo struct {
+	data      *Data
+	log       *log.Helper
+	sonyFlake *auth.Sonyflake
+}
+
+// NewReadStateRepo 创建已读状态仓库实例
+func NewReadStateRepo(data *Data, logger log.Logger) biz.ReadStateRepo {
+	return &readStateRepo{
+		data:      data,
+		log:       log.NewHelper(logger),
+		sonyFlake: auth.NewSonyflake(),
+	}
+}
+
+// AdvanceCursor 写入已读位置，仅当新位置更靠后时覆盖
+// MySQL在ON DUPLICATE KEY UPDATE未改变任何值时影响行数为0，以此判断是否前进
+func (r *readStateRepo) AdvanceCursor(ctx context.Context, cursor *bo.ReadCursor) (bool, error) {
+	m := &po.Conversation{
+		UserID:           cursor.UserId,
+		ConversationType: int32(cursor.ConversationType),
+		ConversationID:   cursor.ConversationId,
+		ReadMsgID:        cursor.MsgId,
+		ReadAt:           cursor.ReadAt,
+	}
+	var err error
+	if m.ID, err = r.sonyFlake.GenerateBase62(); err != nil {
+		return false, errors.Join(err, errors.New("failed to generate conversation ID"))
+	}
+	// 赋值按顺序执行，read_at必须最后更新
+	result := r.data.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: conversationColumns,
+		DoUpdates: clause.Set{
+			{Column: clause.Column{Name: "read_msg_id"}, Value: gorm.Expr("IF(VALUES(read_at) > read_at, VALUES(read_msg_id), read_msg_id)")},
+			{Column: clause.Column{Name: "updated_at"}, Value: gorm.Expr("IF(VALUES(read_at) > read_at, VALUES(updated_at), updated_at)")},
+			{Column: clause.Column{Name: "read_at"}, Value: gorm.Expr("GREATEST(read_at, VALUES(read_at))")},
+		},
+	}).Create(m)
+	if result.Error != nil {
+		return false, errors.Join(result.Error, errors.New("failed to advance read cursor"))
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// SetUnread 覆盖未读数
+func (r *readStateRepo) SetUnread(ctx context.Context, userId string, conversationType im_v1.TargetType, conversationId string, unread int64) error {
+	err := r.data.db.WithContext(ctx).
+		Model(&po.Conversation{}).
+		Where("user_id = ? AND conversation_type = ? AND conversation_id = ?", userId, int32(conversationType), conversationId).
+		Updates(map[string]interface{}{
+			"unread_count": unread,
+			"updated_at":   time.Now(),
+		}).Error
+	if err != nil {
+		return errors.Join(err, errors.New("failed to set unread count"))
+	}
+	key := redisUnreadKeyPrefix + userId
+	field := unreadField(conversationType, conversationId)
+	if err = setUnreadScript.Run(ctx, r.data.redisClient, []string{key}, field, unread).Err(); err != nil && !errors.Is(err, redis.Nil) {
+		return errors.Join(err, errors.New("failed to set cached unread count"))
+	}
+	return nil
+}
+
+// ListUnread 优先读取Redis，缓存不存在时从MySQL加载
+func (r *readStateRepo) ListUnread(ctx context.Context, userId string) ([]*bo.UnreadCount, error) {
+	key := redisUnreadKeyPrefix + userId
+	values, err := r.data.redisClient.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, errors.Join(err, errors.New("failed to get cached unread counts"))
+	}
+	if len(values) == 0 {
+		if values, err = r.loadUnread(ctx, userId); err != nil {
+			return nil, err
+		}
+	}
+	counts := make([]*bo.UnreadCount, 0, len(values))
+	for field, value := range values {
+		unread, _ := strconv.ParseInt(value, 10, 64)
+		if field == redisUnreadLoadedField || unread <= 0 {
+			continue
+		}
+		conversationType, conversationId, ok := parseUnreadField(field)
+		if !ok {
+			continue
+		}
+		counts = append(counts, &bo.UnreadCount{
+			ConversationType: conversationType,
+			ConversationId:   conversationId,
+			Unread:           unread,
+		})
+	}
+	return counts, nil
+}
+
+// loadUnread 从MySQL加载用户全部会话的未读数并写入缓存
+func (r *readStateRepo) loadUnread(ctx context.Context, userId string) (map[string]string, error) {
+	var rows []*po.Conversation
+	if err := r.data.db.WithContext(ctx).Where("user_id = ?", userId).Find(&rows).Error; err != nil {
+		return nil, errors.Join(err, errors.New("failed to load unread counts"))
+	}
+	values := make(map[string]string, len(rows)+1)
+	values[redisUnreadLoadedField] = "1"
+	for _, row := range rows {
+		values[unreadField(im_v1.TargetType(row.ConversationType), row.ConversationID)] = strconv.FormatInt(row.UnreadCount, 10)
+	}
+	key := redisUnreadKeyPrefix + userId
+	pipe := r.data.redisClient.TxPipeline()
+	pipe.HSet(ctx, key, values)
+	pipe.Expire(ctx, key, redisUnreadExpiration)
+	if _, err := pipe.Exec(ctx); err != nil {
+		// 缓存写入失败不影响本次查询结果
+		r.log.WithContext(ctx).Warnf("failed to cache unread counts. userId=%s, error=%v", userId, err)
+	}
+	return values, nil
+}
+
+var conversationColumns = []clause.Column{{Name: "user_id"}, {Name: "conversation_type"}, {Name: "conversation_id"}}
+
+func unreadField(conversationType im_v1.TargetType, conversationId string) string {
+	return fmt.Sprintf("%d:%s", conversationType, conversationId)
+}
+
+func parseUnreadField(field string) (im_v1.TargetType, string, bool) {
+	typ, id, ok := strings.Cut(field, ":")
+	if !ok {
+		return 0, "", false
+	}
+	n, err := strconv.Atoi(typ)
+	if err != nil {
+		return 0, "", false
+	}
+	return im_v1.TargetType(n), id, true
+}
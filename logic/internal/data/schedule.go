@@ -0,0 +1,230 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/xinghe903/chatify/logic/internal/biz"
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+	"github.com/xinghe903/chatify/logic/internal/data/po"
+
+	"github.com/xinghe903/chatify/pkg/auth"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+)
+
+var _ biz.ScheduleRepo = (*scheduleRepo)(nil)
+
+// scheduleRepo 定时推送计划仓库实现
+type scheduleRepo struct {
+	data      *Data
+	log       *log.Helper
+	sonyFlake *auth.Sonyflake
+}
+
+// NewScheduleRepo 创建定时推送计划仓库实例
+func NewScheduleRepo(data *Data, logger log.Logger) biz.ScheduleRepo {
+	return &scheduleRepo{
+		data:      data,
+		log:       log.NewHelper(logger),
+		sonyFlake: auth.NewSonyflake(),
+	}
+}
+
+// CreateSchedule 保存定时推送计划
+func (r *scheduleRepo) CreateSchedule(ctx context.Context, schedule *bo.PushSchedule) error {
+	m, err := po.NewPushScheduleFromBo(schedule)
+	if err != nil {
+		return errors.Join(err, errors.New("failed to encode push schedule"))
+	}
+	if m.ID, err = r.sonyFlake.GenerateBase62(); err != nil {
+		return errors.Join(err, errors.New("failed to generate push schedule ID"))
+	}
+	if err = r.data.db.WithContext(ctx).Create(m).Error; err != nil {
+		return errors.Join(err, errors.New("failed to create push schedule"))
+	}
+	return nil
+}
+
+// ListSchedules 按创建时间倒序分页查询计划
+func (r *scheduleRepo) ListSchedules(ctx context.Context, status bo.PushScheduleStatus, offset, limit int) ([]*bo.PushSchedule, int64, error) {
+	query := r.data.db.WithContext(ctx).Model(&po.PushSchedule{})
+	if status != "" {
+		query = query.Where("status = ?", string(status))
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Join(err, errors.New("failed to count push schedules"))
+	}
+	var rows []*po.PushSchedule
+	if err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&rows).Error; err != nil {
+		return nil, 0, errors.Join(err, errors.New("failed to list push schedules"))
+	}
+	schedules, err := toScheduleBos(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return schedules, total, nil
+}
+
+// CancelSchedule 取消待执行的计划
+func (r *scheduleRepo) CancelSchedule(ctx context.Context, scheduleId string) (bool, error) {
+	result := r.data.db.WithContext(ctx).
+		Model(&po.PushSchedule{}).
+		Where("schedule_id = ? AND status = ?", scheduleId, string(bo.PushScheduleStatusPending)).
+		Updates(map[string]interface{}{
+			"status":     string(bo.PushScheduleStatusCanceled),
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, errors.Join(result.Error, errors.New("failed to cancel push schedule"))
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetSchedule 查询计划
+func (r *scheduleRepo) GetSchedule(ctx context.Context, scheduleId string) (*bo.PushSchedule, error) {
+	var m po.PushSchedule
+	err := r.data.db.WithContext(ctx).Where("schedule_id = ?", scheduleId).First(&m).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Join(err, errors.New("failed to get push schedule"))
+	}
+	return m.ToBo()
+}
+
+// ListDueSchedules 查询到期的待执行计划
+func (r *scheduleRepo) ListDueSchedules(ctx context.Context, now int64, limit int) ([]*bo.PushSchedule, error) {
+	var rows []*po.PushSchedule
+	err := r.data.db.WithContext(ctx).
+		Where("status = ? AND next_fire_at <= ?", string(bo.PushScheduleStatusPending), now).
+		Order("next_fire_at ASC").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, errors.Join(err, errors.New("failed to list due push schedules"))
+	}
+	return toScheduleBos(rows)
+}
+
+// ClaimSchedule 以next_fire_at作为版本号进行条件更新，只有一个调用方能抢占成功
+// 抢占成功时在同一事务中写入待发送的周期记录，发送前崩溃时由leader根据该记录重新发送
+func (r *scheduleRepo) ClaimSchedule(ctx context.Context, schedule *bo.PushSchedule, prevFireAt int64) (bool, error) {
+	fire := po.NewPushScheduleFireFromBo(&bo.PushScheduleFire{
+		ScheduleId: schedule.ScheduleId,
+		FireAt:     prevFireAt,
+		Status:     bo.PushScheduleFireStatusPending,
+	})
+	var err error
+	if fire.ID, err = r.sonyFlake.GenerateBase62(); err != nil {
+		return false, errors.Join(err, errors.New("failed to generate push schedule fire ID"))
+	}
+	claimed := false
+	err = r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&po.PushSchedule{}).
+			Where("schedule_id = ? AND status = ? AND next_fire_at = ?",
+				schedule.ScheduleId, string(bo.PushScheduleStatusPending), prevFireAt).
+			Updates(map[string]interface{}{
+				"status":        string(schedule.Status),
+				"next_fire_at":  schedule.NextFireAt,
+				"fired_count":   schedule.FiredCount,
+				"last_fired_at": schedule.LastFiredAt,
+				"updated_at":    time.Now(),
+			})
+		if result.Error != nil {
+			return errors.Join(result.Error, errors.New("failed to claim push schedule"))
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		if err := tx.Create(fire).Error; err != nil {
+			return errors.Join(err, errors.New("failed to create push schedule fire"))
+		}
+		claimed = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return claimed, nil
+}
+
+// ListStaleFires 查询超过租期仍待发送的周期
+func (r *scheduleRepo) ListStaleFires(ctx context.Context, staleBefore time.Time, limit int) ([]*bo.PushScheduleFire, error) {
+	var rows []*po.PushScheduleFire
+	err := r.data.db.WithContext(ctx).
+		Where("status = ? AND updated_at < ?", string(bo.PushScheduleFireStatusPending), staleBefore).
+		Order("fire_at ASC").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, errors.Join(err, errors.New("failed to list stale push schedule fires"))
+	}
+	fires := make([]*bo.PushScheduleFire, 0, len(rows))
+	for _, row := range rows {
+		fires = append(fires, row.ToBo())
+	}
+	return fires, nil
+}
+
+// ClaimStaleFire 通过条件更新续期超时周期，多个实例同时重新发送时同一周期只会被一个实例抢占
+func (r *scheduleRepo) ClaimStaleFire(ctx context.Context, fire *bo.PushScheduleFire, staleBefore time.Time) (bool, error) {
+	result := r.data.db.WithContext(ctx).
+		Model(&po.PushScheduleFire{}).
+		Where("schedule_id = ? AND fire_at = ?", fire.ScheduleId, fire.FireAt).
+		Where("status = ? AND updated_at < ?", string(bo.PushScheduleFireStatusPending), staleBefore).
+		Update("updated_at", time.Now())
+	if result.Error != nil {
+		return false, errors.Join(result.Error, errors.New("failed to claim stale push schedule fire"))
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// RecordFireResult 在同一事务中记录周期结果和计划最近一次的发送结果
+func (r *scheduleRepo) RecordFireResult(ctx context.Context, fire *bo.PushScheduleFire) error {
+	lastError := fire.LastError
+	if len(lastError) > 255 {
+		lastError = lastError[:255]
+	}
+	now := time.Now()
+	return r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&po.PushScheduleFire{}).
+			Where("schedule_id = ? AND fire_at = ?", fire.ScheduleId, fire.FireAt).
+			Updates(map[string]interface{}{
+				"status":     string(fire.Status),
+				"task_id":    fire.TaskId,
+				"last_error": lastError,
+				"updated_at": now,
+			}).Error
+		if err != nil {
+			return errors.Join(err, errors.New("failed to record push schedule fire result"))
+		}
+		err = tx.Model(&po.PushSchedule{}).
+			Where("schedule_id = ?", fire.ScheduleId).
+			Updates(map[string]interface{}{
+				"last_task_id": fire.TaskId,
+				"last_error":   lastError,
+				"updated_at":   now,
+			}).Error
+		if err != nil {
+			return errors.Join(err, errors.New("failed to record push schedule result"))
+		}
+		return nil
+	})
+}
+
+func toScheduleBos(rows []*po.PushSchedule) ([]*bo.PushSchedule, error) {
+	schedules := make([]*bo.PushSchedule, 0, len(rows))
+	for _, row := range rows {
+		schedule, err := row.ToBo()
+		if err != nil {
+			return nil, errors.Join(err, errors.New("failed to decode push schedule"))
+		}
+		schedules = append(schedules, schedule)
+	}
+	return schedules, nil
+}
@@ -0,0 +1,81 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/xinghe903/chatify/logic/internal/biz"
+	"github.com/xinghe903/chatify/logic/internal/data/po"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	redisSeqKeyPrefix = "chatify:logic:seq:"
+)
+
+// incrIfExistsScript 只在计数器存在时递增，不存在时返回-1，避免Redis丢数据后序号从1重新开始
+var incrIfExistsScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return -1
+end
+return redis.call('INCR', KEYS[1])`)
+
+var _ biz.SequenceRepo = (*sequenceRepo)(nil)
+
+// sequenceRepo 会话序号仓库实现
+type sequenceRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+// NewSequenceRepo 创建会话序号仓库
+func NewSequenceRepo(data *Data, logger log.Logger) biz.SequenceRepo {
+	return &sequenceRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+// NextSeq 使用INCR为会话分配序号，key格式为：chatify:logic:seq:{conversationKey}
+// 计数器不存在时从MySQL中已保存的最大序号恢复
+func (r *sequenceRepo) NextSeq(ctx context.Context, conversationKey string) (int64, error) {
+	key := redisSeqKeyPrefix + conversationKey
+	seq, err := incrIfExistsScript.Run(ctx, r.data.redisClient, []string{key}).Int64()
+	if err != nil {
+		return 0, errors.Join(err, errors.New("failed to incr conversation seq"))
+	}
+	if seq > 0 {
+		return seq, nil
+	}
+	highWater, err := r.maxSavedSeq(ctx, conversationKey)
+	if err != nil {
+		return 0, err
+	}
+	// 并发恢复时只有第一个写入生效，其余直接递增
+	if err = r.data.redisClient.SetNX(ctx, key, highWater, 0).Err(); err != nil {
+		return 0, errors.Join(err, errors.New("failed to restore conversation seq"))
+	}
+	r.log.WithContext(ctx).Infof("restore conversation seq. conversationKey=%s, seq=%d", conversationKey, highWater)
+	seq, err = r.data.redisClient.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, errors.Join(err, errors.New("failed to incr conversation seq"))
+	}
+	return seq, nil
+}
+
+// maxSavedSeq 查询会话已保存的最大序号
+func (r *sequenceRepo) maxSavedSeq(ctx context.Context, conversationKey string) (int64, error) {
+	var highWater sql.NullInt64
+	err := r.data.db.WithContext(ctx).
+		Model(&po.ChatMessage{}).
+		Where("conversation_key = ?", conversationKey).
+		Select("MAX(seq)").
+		Scan(&highWater).Error
+	if err != nil {
+		return 0, errors.Join(err, errors.New("failed to query conversation max seq"))
+	}
+	return highWater.Int64, nil
+}
@@ -0,0 +1,179 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/xinghe903/chatify/logic/internal/biz"
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+	"github.com/xinghe903/chatify/logic/internal/data/po"
+
+	"github.com/xinghe903/chatify/pkg/auth"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	_ biz.PushTemplateRepo = (*templateRepo)(nil)
+	_ biz.UserLocaleRepo   = (*userLocaleRepo)(nil)
+)
+
+// templateRepo 推送模板仓库实现
+type templateRepo struct {
+	data      *Data
+	log       *log.Helper
+	sonyFlake *auth.Sonyflake
+}
+
+// NewPushTemplateRepo 创建推送模板仓库实例
+func NewPushTemplateRepo(data *Data, logger log.Logger) biz.PushTemplateRepo {
+	return &templateRepo{
+		data:      data,
+		log:       log.NewHelper(logger),
+		sonyFlake: auth.NewSonyflake(),
+	}
+}
+
+// CreateTemplate 保存推送模板
+func (r *templateRepo) CreateTemplate(ctx context.Context, template *bo.PushTemplate) error {
+	m, err := po.NewPushTemplateFromBo(template)
+	if err != nil {
+		return errors.Join(err, errors.New("failed to encode push template"))
+	}
+	if m.ID, err = r.sonyFlake.GenerateBase62(); err != nil {
+		return errors.Join(err, errors.New("failed to generate push template ID"))
+	}
+	if err = r.data.db.WithContext(ctx).Create(m).Error; err != nil {
+		return errors.Join(err, errors.New("failed to create push template"))
+	}
+	return nil
+}
+
+// UpdateTemplate 整体替换模板的名称、变量和多语言内容
+func (r *templateRepo) UpdateTemplate(ctx context.Context, template *bo.PushTemplate) (bool, error) {
+	m, err := po.NewPushTemplateFromBo(template)
+	if err != nil {
+		return false, errors.Join(err, errors.New("failed to encode push template"))
+	}
+	result := r.data.db.WithContext(ctx).
+		Model(&po.PushTemplate{}).
+		Where("template_id = ?", template.TemplateId).
+		Updates(map[string]interface{}{
+			"name":           m.Name,
+			"variables":      m.Variables,
+			"default_locale": m.DefaultLocale,
+			"contents":       m.Contents,
+			"updated_at":     time.Now(),
+		})
+	if result.Error != nil {
+		return false, errors.Join(result.Error, errors.New("failed to update push template"))
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetTemplate 查询推送模板
+func (r *templateRepo) GetTemplate(ctx context.Context, templateId string) (*bo.PushTemplate, error) {
+	var m po.PushTemplate
+	err := r.data.db.WithContext(ctx).Where("template_id = ?", templateId).First(&m).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Join(err, errors.New("failed to get push template"))
+	}
+	template, err := m.ToBo()
+	if err != nil {
+		return nil, errors.Join(err, errors.New("failed to decode push template"))
+	}
+	return template, nil
+}
+
+// ListTemplates 按创建时间倒序分页查询模板
+func (r *templateRepo) ListTemplates(ctx context.Context, offset, limit int) ([]*bo.PushTemplate, int64, error) {
+	query := r.data.db.WithContext(ctx).Model(&po.PushTemplate{})
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Join(err, errors.New("failed to count push templates"))
+	}
+	var rows []*po.PushTemplate
+	if err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&rows).Error; err != nil {
+		return nil, 0, errors.Join(err, errors.New("failed to list push templates"))
+	}
+	templates := make([]*bo.PushTemplate, 0, len(rows))
+	for _, row := range rows {
+		template, err := row.ToBo()
+		if err != nil {
+			return nil, 0, errors.Join(err, errors.New("failed to decode push template"))
+		}
+		templates = append(templates, template)
+	}
+	return templates, total, nil
+}
+
+// DeleteTemplate 删除推送模板
+func (r *templateRepo) DeleteTemplate(ctx context.Context, templateId string) (bool, error) {
+	result := r.data.db.WithContext(ctx).Where("template_id = ?", templateId).Delete(&po.PushTemplate{})
+	if result.Error != nil {
+		return false, errors.Join(result.Error, errors.New("failed to delete push template"))
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// userLocaleRepo 用户语言偏好仓库实现
+type userLocaleRepo struct {
+	data      *Data
+	log       *log.Helper
+	sonyFlake *auth.Sonyflake
+}
+
+// NewUserLocaleRepo 创建用户语言偏好仓库实例
+func NewUserLocaleRepo(data *Data, logger log.Logger) biz.UserLocaleRepo {
+	return &userLocaleRepo{
+		data:      data,
+		log:       log.NewHelper(logger),
+		sonyFlake: auth.NewSonyflake(),
+	}
+}
+
+// SetLocale 按用户ID写入语言偏好，已存在时覆盖
+func (r *userLocaleRepo) SetLocale(ctx context.Context, userId, locale string) error {
+	if locale == "" {
+		if err := r.data.db.WithContext(ctx).Where("user_id = ?", userId).Delete(&po.UserLocale{}).Error; err != nil {
+			return errors.Join(err, errors.New("failed to clear user locale"))
+		}
+		return nil
+	}
+	id, err := r.sonyFlake.GenerateBase62()
+	if err != nil {
+		return errors.Join(err, errors.New("failed to generate user locale ID"))
+	}
+	row := &po.UserLocale{UserID: userId, Locale: locale}
+	row.ID = id
+	err = r.data.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"locale": locale, "updated_at": time.Now()}),
+	}).Create(row).Error
+	if err != nil {
+		return errors.Join(err, errors.New("failed to set user locale"))
+	}
+	return nil
+}
+
+// GetLocales 批量查询用户语言偏好
+func (r *userLocaleRepo) GetLocales(ctx context.Context, userIds []string) (map[string]string, error) {
+	locales := make(map[string]string, len(userIds))
+	if len(userIds) == 0 {
+		return locales, nil
+	}
+	var rows []*po.UserLocale
+	if err := r.data.db.WithContext(ctx).Where("user_id IN ?", userIds).Find(&rows).Error; err != nil {
+		return nil, errors.Join(err, errors.New("failed to get user locales"))
+	}
+	for _, row := range rows {
+		locales[row.UserID] = row.Locale
+	}
+	return locales, nil
+}
@@ -0,0 +1,180 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/xinghe903/chatify/logic/internal/biz"
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+	"github.com/xinghe903/chatify/logic/internal/data/po"
+
+	"github.com/xinghe903/chatify/pkg/auth"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ biz.UserAttributeRepo = (*userAttributeRepo)(nil)
+
+// userAttributeRepo 用户属性和标签仓库实现
+type userAttributeRepo struct {
+	data      *Data
+	log       *log.Helper
+	sonyFlake *auth.Sonyflake
+}
+
+// NewUserAttributeRepo 创建用户属性仓库实例
+func NewUserAttributeRepo(data *Data, logger log.Logger) biz.UserAttributeRepo {
+	return &userAttributeRepo{
+		data:      data,
+		log:       log.NewHelper(logger),
+		sonyFlake: auth.NewSonyflake(),
+	}
+}
+
+// UpdateAttributes 在一个事务中写入用户属性并增删标签
+// 属性行总是存在，只设置了语言或标签的用户也会进入分群
+func (r *userAttributeRepo) UpdateAttributes(ctx context.Context, update *bo.UserAttributesUpdate) error {
+	id, err := r.sonyFlake.GenerateBase62()
+	if err != nil {
+		return errors.Join(err, errors.New("failed to generate user profile ID"))
+	}
+	profile := &po.UserProfile{UserID: update.UserId, Platform: update.Platform, RegisteredAt: update.RegisteredAt}
+	profile.ID = id
+	assignments := map[string]interface{}{"updated_at": time.Now()}
+	if update.Platform != "" {
+		assignments["platform"] = update.Platform
+	}
+	if update.RegisteredAt > 0 {
+		assignments["registered_at"] = update.RegisteredAt
+	}
+	tags := make([]*po.UserTag, 0, len(update.AddTags))
+	for _, tag := range update.AddTags {
+		row := &po.UserTag{UserID: update.UserId, Tag: tag}
+		if row.ID, err = r.sonyFlake.GenerateBase62(); err != nil {
+			return errors.Join(err, errors.New("failed to generate user tag ID"))
+		}
+		tags = append(tags, row)
+	}
+	return r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.Assignments(assignments),
+		}).Create(profile).Error
+		if err != nil {
+			return errors.Join(err, errors.New("failed to upsert user profile"))
+		}
+		if len(update.RemoveTags) > 0 {
+			err = tx.Where("user_id = ? AND tag IN ?", update.UserId, update.RemoveTags).Delete(&po.UserTag{}).Error
+			if err != nil {
+				return errors.Join(err, errors.New("failed to remove user tags"))
+			}
+		}
+		if len(tags) > 0 {
+			if err = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tags).Error; err != nil {
+				return errors.Join(err, errors.New("failed to add user tags"))
+			}
+		}
+		return nil
+	})
+}
+
+// GetAttributes 查询用户属性和标签
+func (r *userAttributeRepo) GetAttributes(ctx context.Context, userId string) (*bo.UserAttributes, error) {
+	var profile po.UserProfile
+	err := r.data.db.WithContext(ctx).Where("user_id = ?", userId).First(&profile).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Join(err, errors.New("failed to get user profile"))
+	}
+	attributes := &bo.UserAttributes{
+		UserId:       userId,
+		Platform:     profile.Platform,
+		RegisteredAt: profile.RegisteredAt,
+		Tags:         []string{},
+	}
+	err = r.data.db.WithContext(ctx).Model(&po.UserTag{}).
+		Where("user_id = ?", userId).Order("tag ASC").Pluck("tag", &attributes.Tags).Error
+	if err != nil {
+		return nil, errors.Join(err, errors.New("failed to get user tags"))
+	}
+	return attributes, nil
+}
+
+// CountSegment 统计分群命中的用户数
+func (r *userAttributeRepo) CountSegment(ctx context.Context, segment *bo.Segment) (int64, error) {
+	var count int64
+	if err := r.segmentQuery(ctx, segment).Count(&count).Error; err != nil {
+		return 0, errors.Join(err, errors.New("failed to count segment users"))
+	}
+	return count, nil
+}
+
+// ListSegmentUsers 按用户ID顺序查询分群命中的用户
+func (r *userAttributeRepo) ListSegmentUsers(ctx context.Context, segment *bo.Segment, limit int) ([]string, error) {
+	var userIds []string
+	err := r.segmentQuery(ctx, segment).Order("p.user_id ASC").Limit(limit).Pluck("p.user_id", &userIds).Error
+	if err != nil {
+		return nil, errors.Join(err, errors.New("failed to list segment users"))
+	}
+	return userIds, nil
+}
+
+// segmentQuery 以用户属性表为全集，关联语言偏好，按分群表达式过滤
+func (r *userAttributeRepo) segmentQuery(ctx context.Context, segment *bo.Segment) *gorm.DB {
+	condition, args := segmentCondition(segment)
+	return r.data.db.WithContext(ctx).
+		Table(po.UserProfile{}.TableName()+" AS p").
+		Joins("LEFT JOIN "+po.UserLocale{}.TableName()+" AS l ON l.user_id = p.user_id").
+		Where("("+condition+")", args...)
+}
+
+// segmentCondition 把分群表达式转换为SQL条件，取值全部使用占位符
+func segmentCondition(segment *bo.Segment) (string, []any) {
+	if p := segment.Predicate; p != nil {
+		return predicateCondition(p)
+	}
+	parts := make([]string, 0, len(segment.Children))
+	var args []any
+	for _, child := range segment.Children {
+		part, childArgs := segmentCondition(child)
+		parts = append(parts, "("+part+")")
+		args = append(args, childArgs...)
+	}
+	return strings.Join(parts, " "+strings.ToUpper(string(segment.Logic))+" "), args
+}
+
+func predicateCondition(p *bo.SegmentPredicate) (string, []any) {
+	tagExists := "EXISTS (SELECT 1 FROM " + po.UserTag{}.TableName() + " AS t WHERE t.user_id = p.user_id AND t.tag = ?)"
+	switch p.Field {
+	case bo.SegmentFieldPlatform:
+		if p.Op == bo.SegmentOpNe {
+			return "p.platform <> ?", []any{p.Value}
+		}
+		return "p.platform = ?", []any{p.Value}
+	case bo.SegmentFieldLocale:
+		if p.Op == bo.SegmentOpNe {
+			return "l.locale IS NULL OR l.locale <> ?", []any{p.Value}
+		}
+		return "l.locale = ?", []any{p.Value}
+	case bo.SegmentFieldTag:
+		if p.Op == bo.SegmentOpNe {
+			return "NOT " + tagExists, []any{p.Value}
+		}
+		return tagExists, []any{p.Value}
+	case bo.SegmentFieldRegisteredAt:
+		op := string(p.Op)
+		if p.Op == bo.SegmentOpNe {
+			op = "<>"
+		}
+		// 未设置注册时间的用户不参与时间比较
+		return fmt.Sprintf("p.registered_at > 0 AND p.registered_at %s ?", op), []any{p.Time}
+	}
+	return "1 = 0", nil
+}
@@ -0,0 +1,148 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/xinghe903/chatify/logic/internal/biz"
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
+	"github.com/xinghe903/chatify/logic/internal/conf"
+	"github.com/xinghe903/chatify/logic/internal/data/po"
+
+	"github.com/xinghe903/chatify/pkg/auth"
+	"github.com/xinghe903/chatify/pkg/auth/sign"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+)
+
+var (
+	_ biz.WebhookRepo   = (*webhookRepo)(nil)
+	_ biz.WebhookSender = (*webhookSender)(nil)
+)
+
+// webhookRepo webhook订阅仓库实现
+type webhookRepo struct {
+	data      *Data
+	log       *log.Helper
+	sonyFlake *auth.Sonyflake
+}
+
+// NewWebhookRepo 创建webhook订阅仓库实例
+func NewWebhookRepo(data *Data, logger log.Logger) biz.WebhookRepo {
+	return &webhookRepo{
+		data:      data,
+		log:       log.NewHelper(logger),
+		sonyFlake: auth.NewSonyflake(),
+	}
+}
+
+// CreateWebhook 保存订阅
+func (r *webhookRepo) CreateWebhook(ctx context.Context, subscription *bo.WebhookSubscription) error {
+	m, err := po.NewWebhookSubscriptionFromBo(subscription)
+	if err != nil {
+		return errors.Join(err, errors.New("failed to encode webhook"))
+	}
+	if m.ID, err = r.sonyFlake.GenerateBase62(); err != nil {
+		return errors.Join(err, errors.New("failed to generate webhook ID"))
+	}
+	if err = r.data.db.WithContext(ctx).Create(m).Error; err != nil {
+		return errors.Join(err, errors.New("failed to create webhook"))
+	}
+	subscription.CreatedAt = m.CreatedAt
+	return nil
+}
+
+// ListWebhooks 按创建时间查询全部订阅
+func (r *webhookRepo) ListWebhooks(ctx context.Context) ([]*bo.WebhookSubscription, error) {
+	var rows []*po.WebhookSubscription
+	if err := r.data.db.WithContext(ctx).Order("created_at ASC").Find(&rows).Error; err != nil {
+		return nil, errors.Join(err, errors.New("failed to list webhooks"))
+	}
+	subscriptions := make([]*bo.WebhookSubscription, 0, len(rows))
+	for _, row := range rows {
+		subscription, err := row.ToBo()
+		if err != nil {
+			return nil, errors.Join(err, errors.New("failed to decode webhook"))
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, nil
+}
+
+// GetWebhook 查询订阅
+func (r *webhookRepo) GetWebhook(ctx context.Context, webhookId string) (*bo.WebhookSubscription, error) {
+	var m po.WebhookSubscription
+	err := r.data.db.WithContext(ctx).Where("webhook_id = ?", webhookId).First(&m).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Join(err, errors.New("failed to get webhook"))
+	}
+	subscription, err := m.ToBo()
+	if err != nil {
+		return nil, errors.Join(err, errors.New("failed to decode webhook"))
+	}
+	return subscription, nil
+}
+
+// DeleteWebhook 删除订阅
+func (r *webhookRepo) DeleteWebhook(ctx context.Context, webhookId string) (bool, error) {
+	result := r.data.db.WithContext(ctx).Where("webhook_id = ?", webhookId).Delete(&po.WebhookSubscription{})
+	if result.Error != nil {
+		return false, errors.Join(result.Error, errors.New("failed to delete webhook"))
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// webhookSender 通过HTTP POST投递webhook事件
+type webhookSender struct {
+	client *http.Client
+}
+
+// NewWebhookSender 创建webhook投递客户端
+func NewWebhookSender(c *conf.Bootstrap) biz.WebhookSender {
+	timeout := c.GetWebhook().GetTimeout().AsDuration()
+	if timeout <= 0 {
+		timeout = bo.DefaultWebhookTimeout
+	}
+	return &webhookSender{client: &http.Client{Timeout: timeout}}
+}
+
+// Send 签名并投递事件，2xx视为成功
+// 除408和429外的4xx说明订阅方拒绝该请求，重试也不会成功
+func (s *webhookSender) Send(ctx context.Context, subscription *bo.WebhookSubscription, delivery *bo.WebhookDelivery, eventType bo.WebhookEventType) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Event))
+	if err != nil {
+		return errors.Join(biz.ErrNonRetryable, err)
+	}
+	timestamp := time.Now().UnixMilli()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(bo.WebhookHeaderEvent, string(eventType))
+	req.Header.Set(bo.WebhookHeaderDelivery, delivery.DeliveryId)
+	req.Header.Set(bo.WebhookHeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(bo.WebhookHeaderSignature, sign.SignPayload(subscription.Secret, timestamp, delivery.Event))
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Join(err, errors.New("failed to post webhook"))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, bo.MaxWebhookErrorBodyLength))
+	err = fmt.Errorf("webhook responded with status %d: %s", resp.StatusCode, body)
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		return errors.Join(biz.ErrNonRetryable, err)
+	}
+	return err
+}
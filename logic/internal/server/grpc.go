@@ -28,6 +28,7 @@ func NewGRPCServer(cb *conf.Bootstrap, svc *service.LogicService, logger log.Log
 				metrics.WithRequests(monitoring.MetricRequests),
 			),
 			ratelimit.Server(),
+			userContext(),
 			// middleware.ErrorEncoder(),
 		),
 	}
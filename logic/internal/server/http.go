@@ -1,6 +1,7 @@
 package server
 
 import (
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
 	"github.com/xinghe903/chatify/logic/internal/conf"
 	"github.com/xinghe903/chatify/logic/internal/service"
 
@@ -29,6 +30,7 @@ func NewHTTPServer(cb *conf.Bootstrap, svc *service.LogicService, logger log.Log
 				metrics.WithRequests(monitoring.MetricRequests),
 			),
 			ratelimit.Server(),
+			userContext(),
 		),
 	}
 	if c.Http.Network != "" {
@@ -42,6 +44,7 @@ func NewHTTPServer(cb *conf.Bootstrap, svc *service.LogicService, logger log.Log
 	}
 	srv := http.NewServer(opts...)
 	srv.Handle("/metrics", promhttp.Handler())
+	srv.HandleFunc(bo.AttachmentDownloadPath, svc.DownloadAttachment)
 	v1.RegisterLogicServiceHTTPServer(srv, svc)
 	return srv
 }
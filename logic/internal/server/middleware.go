@@ -0,0 +1,23 @@
+package server
+
+import (
+	"context"
+
+	"github.com/xinghe903/chatify/pkg/auth"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+)
+
+// userContext 从网关鉴权后透传的请求头中读取登录用户，写入context
+func userContext() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if tr, ok := transport.FromServerContext(ctx); ok {
+				header := tr.RequestHeader()
+				ctx = auth.NewContext(ctx, header.Get(string(auth.USER_ID)), header.Get(string(auth.USER_NAME)))
+			}
+			return handler(ctx, req)
+		}
+	}
+}
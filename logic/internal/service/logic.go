@@ -2,30 +2,82 @@ package service
 
 import (
 	"context"
+	"errors"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
 
 	"github.com/xinghe903/chatify/logic/internal/biz"
+	"github.com/xinghe903/chatify/logic/internal/biz/bo"
 
+	im_v1 "github.com/xinghe903/chatify/api/im/v1"
 	v1 "github.com/xinghe903/chatify/api/logic/v1"
 )
 
 // LogicService is a greeter service.
 type LogicService struct {
 	v1.UnimplementedLogicServiceServer
-	log      *log.Helper
-	uc       *biz.Logic
-	consumer *biz.UserMessageHandler
+	log          *log.Helper
+	uc           *biz.Logic
+	consumer     *biz.UserMessageHandler
+	broadcast    *biz.Broadcast
+	scheduler    *biz.PushScheduler
+	conversation *biz.Conversation
+	funnel       *biz.Funnel
+	reporter     *biz.Reporter
+	webhook      *biz.Webhook
+	bots         *biz.Bots
+	attachments  *biz.Attachments
+	preferences  *biz.Preferences
 }
 
 // NewLogicService new a greeter service.
-func NewLogicService(uc *biz.Logic, logger log.Logger, consumer *biz.UserMessageHandler) *LogicService {
+func NewLogicService(uc *biz.Logic,
+	logger log.Logger,
+	consumer *biz.UserMessageHandler,
+	broadcast *biz.Broadcast,
+	scheduler *biz.PushScheduler,
+	conversation *biz.Conversation,
+	funnel *biz.Funnel,
+	reporter *biz.Reporter,
+	webhook *biz.Webhook,
+	bots *biz.Bots,
+	attachments *biz.Attachments,
+	preferences *biz.Preferences,
+) *LogicService {
 	return &LogicService{uc: uc,
-		log:      log.NewHelper(logger),
-		consumer: consumer, // 仅用作进入handler并初始化数据消费协程
+		log:          log.NewHelper(logger),
+		consumer:     consumer, // 初始化数据消费协程，同时提供同步发送入口
+		broadcast:    broadcast,
+		scheduler:    scheduler, // 仅用作初始化定时推送调度协程
+		conversation: conversation,
+		funnel:       funnel, // 初始化漏斗事件消费协程，同时提供查询入口
+		reporter:     reporter,
+		webhook:      webhook, // 初始化webhook投递协程，同时提供订阅管理入口
+		bots:         bots,
+		attachments:  attachments, // 初始化过期上传清理协程，同时提供上传下载入口
+		preferences:  preferences,
 	}
 }
 
+// ValidateAndProcessMessage 同步发送聊天消息
+func (s *LogicService) ValidateAndProcessMessage(ctx context.Context, in *v1.ChatInputRequest) (*v1.ChatInputResponse, error) {
+	message, err := s.consumer.SendChatMessage(ctx, in.Message)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.ChatInputResponse{
+		MsgId:  message.MsgId,
+		Seq:    message.Seq,
+		SentAt: message.SentAt,
+	}, nil
+}
+
 // SendSystemPush 实现系统推送接口
 func (s *LogicService) SendSystemPush(ctx context.Context, in *v1.SystemPushRequest) (*v1.SystemPushResponse, error) {
 	s.log.WithContext(ctx).Infof("Receive system push request. ContentID: %s, UserCount: %d", in.ContentId, len(in.ToUserIds))
@@ -38,3 +90,788 @@ func (s *LogicService) SendSystemPush(ctx context.Context, in *v1.SystemPushRequ
 
 	return resp, nil
 }
+
+// ListSystemPushSchedules 查询定时推送计划
+func (s *LogicService) ListSystemPushSchedules(ctx context.Context, in *v1.ListSystemPushSchedulesRequest) (*v1.ListSystemPushSchedulesResponse, error) {
+	var status bo.PushScheduleStatus
+	switch in.Status {
+	case v1.PushScheduleStatus_SCHEDULE_PENDING:
+		status = bo.PushScheduleStatusPending
+	case v1.PushScheduleStatus_SCHEDULE_FINISHED:
+		status = bo.PushScheduleStatusFinished
+	case v1.PushScheduleStatus_SCHEDULE_CANCELED:
+		status = bo.PushScheduleStatusCanceled
+	}
+	schedules, total, err := s.uc.ListSystemPushSchedules(ctx, status, int(in.Page), int(in.PageSize))
+	if err != nil {
+		return nil, err
+	}
+	resp := &v1.ListSystemPushSchedulesResponse{
+		Schedules: make([]*v1.PushSchedule, 0, len(schedules)),
+		Total:     total,
+	}
+	for _, schedule := range schedules {
+		resp.Schedules = append(resp.Schedules, toPushSchedule(schedule))
+	}
+	return resp, nil
+}
+
+// CancelSystemPushSchedule 取消定时推送计划
+func (s *LogicService) CancelSystemPushSchedule(ctx context.Context, in *v1.CancelSystemPushScheduleRequest) (*v1.CancelSystemPushScheduleResponse, error) {
+	if err := s.uc.CancelSystemPushSchedule(ctx, in.ScheduleId); err != nil {
+		return nil, err
+	}
+	return &v1.CancelSystemPushScheduleResponse{}, nil
+}
+
+// CreatePushTemplate 创建推送模板
+func (s *LogicService) CreatePushTemplate(ctx context.Context, in *v1.CreatePushTemplateRequest) (*v1.PushTemplate, error) {
+	template, err := s.uc.CreatePushTemplate(ctx, &bo.PushTemplate{
+		Name:          in.Name,
+		Variables:     in.Variables,
+		DefaultLocale: in.DefaultLocale,
+		Contents:      toTemplateContents(in.Contents),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toPushTemplate(template), nil
+}
+
+// UpdatePushTemplate 更新推送模板
+func (s *LogicService) UpdatePushTemplate(ctx context.Context, in *v1.UpdatePushTemplateRequest) (*v1.PushTemplate, error) {
+	template, err := s.uc.UpdatePushTemplate(ctx, &bo.PushTemplate{
+		TemplateId:    in.TemplateId,
+		Name:          in.Name,
+		Variables:     in.Variables,
+		DefaultLocale: in.DefaultLocale,
+		Contents:      toTemplateContents(in.Contents),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toPushTemplate(template), nil
+}
+
+// GetPushTemplate 查询推送模板
+func (s *LogicService) GetPushTemplate(ctx context.Context, in *v1.GetPushTemplateRequest) (*v1.PushTemplate, error) {
+	template, err := s.uc.GetPushTemplate(ctx, in.TemplateId)
+	if err != nil {
+		return nil, err
+	}
+	return toPushTemplate(template), nil
+}
+
+// ListPushTemplates 分页查询推送模板
+func (s *LogicService) ListPushTemplates(ctx context.Context, in *v1.ListPushTemplatesRequest) (*v1.ListPushTemplatesResponse, error) {
+	templates, total, err := s.uc.ListPushTemplates(ctx, int(in.Page), int(in.PageSize))
+	if err != nil {
+		return nil, err
+	}
+	resp := &v1.ListPushTemplatesResponse{
+		Templates: make([]*v1.PushTemplate, 0, len(templates)),
+		Total:     total,
+	}
+	for _, template := range templates {
+		resp.Templates = append(resp.Templates, toPushTemplate(template))
+	}
+	return resp, nil
+}
+
+// DeletePushTemplate 删除推送模板
+func (s *LogicService) DeletePushTemplate(ctx context.Context, in *v1.DeletePushTemplateRequest) (*v1.DeletePushTemplateResponse, error) {
+	if err := s.uc.DeletePushTemplate(ctx, in.TemplateId); err != nil {
+		return nil, err
+	}
+	return &v1.DeletePushTemplateResponse{}, nil
+}
+
+// SetUserLocale 设置当前用户的语言偏好
+func (s *LogicService) SetUserLocale(ctx context.Context, in *v1.SetUserLocaleRequest) (*v1.SetUserLocaleResponse, error) {
+	if err := s.uc.SetUserLocale(ctx, in.Locale); err != nil {
+		return nil, err
+	}
+	return &v1.SetUserLocaleResponse{}, nil
+}
+
+// UpdateUserAttributes 更新用户属性和标签
+func (s *LogicService) UpdateUserAttributes(ctx context.Context, in *v1.UpdateUserAttributesRequest) (*v1.UserAttributes, error) {
+	attributes, err := s.uc.UpdateUserAttributes(ctx, &bo.UserAttributesUpdate{
+		UserId:       in.UserId,
+		Platform:     in.Platform,
+		Locale:       in.Locale,
+		RegisteredAt: in.RegisteredAt,
+		AddTags:      in.AddTags,
+		RemoveTags:   in.RemoveTags,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toUserAttributes(attributes), nil
+}
+
+// GetUserAttributes 查询用户属性和标签
+func (s *LogicService) GetUserAttributes(ctx context.Context, in *v1.GetUserAttributesRequest) (*v1.UserAttributes, error) {
+	attributes, err := s.uc.GetUserAttributes(ctx, in.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return toUserAttributes(attributes), nil
+}
+
+// PreviewSegment 预览分群命中的用户数
+func (s *LogicService) PreviewSegment(ctx context.Context, in *v1.PreviewSegmentRequest) (*v1.PreviewSegmentResponse, error) {
+	count, samples, err := s.uc.PreviewSegment(ctx, in.Segment)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.PreviewSegmentResponse{Count: count, SampleUserIds: samples}, nil
+}
+
+// GetDeliveryFunnel 查询推送内容的送达漏斗
+func (s *LogicService) GetDeliveryFunnel(ctx context.Context, in *v1.GetDeliveryFunnelRequest) (*v1.GetDeliveryFunnelResponse, error) {
+	granularity := time.Hour
+	if in.Granularity == v1.FunnelGranularity_FUNNEL_DAY {
+		granularity = 24 * time.Hour
+	}
+	total, buckets, err := s.funnel.GetDeliveryFunnel(ctx, in.ContentId, in.StartTime, in.EndTime, granularity)
+	if err != nil {
+		return nil, err
+	}
+	resp := &v1.GetDeliveryFunnelResponse{
+		ContentId: in.ContentId,
+		Total:     toDeliveryFunnelCounts(total),
+		Buckets:   make([]*v1.DeliveryFunnelBucket, 0, len(buckets)),
+	}
+	for _, bucket := range buckets {
+		resp.Buckets = append(resp.Buckets, &v1.DeliveryFunnelBucket{
+			BucketStart: bucket.BucketStart,
+			Counts:      toDeliveryFunnelCounts(bucket.Counts),
+		})
+	}
+	return resp, nil
+}
+
+// RegisterReportSchema 注册数据上报结构的新版本
+func (s *LogicService) RegisterReportSchema(ctx context.Context, in *v1.RegisterReportSchemaRequest) (*v1.ReportSchema, error) {
+	schema := &bo.ReportSchema{
+		Name:                  in.Name,
+		Fields:                make([]*bo.ReportField, 0, len(in.Fields)),
+		AllowAdditionalFields: in.AllowAdditionalFields,
+	}
+	for _, field := range in.Fields {
+		schema.Fields = append(schema.Fields, &bo.ReportField{
+			Name:     field.Name,
+			Type:     bo.ReportFieldType(field.Type),
+			Required: field.Required,
+		})
+	}
+	schema, err := s.reporter.RegisterReportSchema(ctx, schema)
+	if err != nil {
+		return nil, err
+	}
+	return toReportSchema(schema), nil
+}
+
+// ListReportSchemas 查询数据上报结构
+func (s *LogicService) ListReportSchemas(ctx context.Context, in *v1.ListReportSchemasRequest) (*v1.ListReportSchemasResponse, error) {
+	schemas, err := s.reporter.ListReportSchemas(ctx, in.Name)
+	if err != nil {
+		return nil, err
+	}
+	resp := &v1.ListReportSchemasResponse{Schemas: make([]*v1.ReportSchema, 0, len(schemas))}
+	for _, schema := range schemas {
+		resp.Schemas = append(resp.Schemas, toReportSchema(schema))
+	}
+	return resp, nil
+}
+
+// QueryDataReports 按用户和时间范围查询数据上报
+func (s *LogicService) QueryDataReports(ctx context.Context, in *v1.QueryDataReportsRequest) (*v1.QueryDataReportsResponse, error) {
+	reports, nextPageToken, err := s.reporter.QueryDataReports(ctx, &bo.DataReportQuery{
+		UserId:    in.UserId,
+		Schema:    in.Schema,
+		StartTime: in.StartTime,
+		EndTime:   in.EndTime,
+		Limit:     int(in.PageSize),
+	}, in.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	resp := &v1.QueryDataReportsResponse{
+		Reports:       make([]*v1.DataReportRecord, 0, len(reports)),
+		NextPageToken: nextPageToken,
+	}
+	for _, report := range reports {
+		resp.Reports = append(resp.Reports, &v1.DataReportRecord{
+			ReportId:   report.ReportId,
+			UserId:     report.UserId,
+			Schema:     report.Schema,
+			Version:    report.Version,
+			Timestamp:  report.Timestamp,
+			Data:       report.Data,
+			ReceivedAt: report.ReceivedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// CreateBot 创建机器人账号
+func (s *LogicService) CreateBot(ctx context.Context, in *v1.CreateBotRequest) (*v1.CreateBotResponse, error) {
+	bot, token, err := s.bots.CreateBot(ctx, in.Name, in.OwnerId)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.CreateBotResponse{
+		Bot: &v1.Bot{
+			BotId:     bot.BotId,
+			Name:      bot.Name,
+			OwnerId:   bot.OwnerId,
+			CreatedAt: bot.CreatedAt.Unix(),
+		},
+		Token: token,
+	}, nil
+}
+
+// DeleteBot 删除机器人账号
+func (s *LogicService) DeleteBot(ctx context.Context, in *v1.DeleteBotRequest) (*v1.DeleteBotResponse, error) {
+	if err := s.bots.DeleteBot(ctx, in.BotId); err != nil {
+		return nil, err
+	}
+	return &v1.DeleteBotResponse{}, nil
+}
+
+// SendBotMessage 机器人发送聊天消息，使用 Authorization: Bot {token} 认证
+func (s *LogicService) SendBotMessage(ctx context.Context, in *v1.SendBotMessageRequest) (*v1.SendBotMessageResponse, error) {
+	var authorization string
+	if tr, ok := transport.FromServerContext(ctx); ok {
+		authorization = tr.RequestHeader().Get("Authorization")
+	}
+	message, err := s.bots.SendMessage(ctx, authorization, &im_v1.BaseMessage{
+		MsgId:       in.MsgId,
+		MessageType: im_v1.MessageType_CHAT,
+		TargetType:  in.TargetType,
+		ToUserId:    in.ToId,
+		Content:     in.Content,
+		Timestamp:   time.Now().Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &v1.SendBotMessageResponse{
+		MsgId:     message.MsgId,
+		Seq:       message.Seq,
+		Timestamp: message.Timestamp,
+	}, nil
+}
+
+// CreateWebhook 创建webhook订阅
+func (s *LogicService) CreateWebhook(ctx context.Context, in *v1.CreateWebhookRequest) (*v1.CreateWebhookResponse, error) {
+	subscription := &bo.WebhookSubscription{
+		URL:        in.Url,
+		EventTypes: make([]bo.WebhookEventType, 0, len(in.EventTypes)),
+		BotId:      in.BotId,
+	}
+	for _, eventType := range in.EventTypes {
+		subscription.EventTypes = append(subscription.EventTypes, bo.WebhookEventType(eventType))
+	}
+	subscription, err := s.webhook.CreateWebhook(ctx, subscription)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.CreateWebhookResponse{
+		Webhook: toWebhook(subscription),
+		Secret:  subscription.Secret,
+	}, nil
+}
+
+// ListWebhooks 查询webhook订阅
+func (s *LogicService) ListWebhooks(ctx context.Context, in *v1.ListWebhooksRequest) (*v1.ListWebhooksResponse, error) {
+	subscriptions, err := s.webhook.ListWebhooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &v1.ListWebhooksResponse{Webhooks: make([]*v1.Webhook, 0, len(subscriptions))}
+	for _, subscription := range subscriptions {
+		resp.Webhooks = append(resp.Webhooks, toWebhook(subscription))
+	}
+	return resp, nil
+}
+
+// DeleteWebhook 删除webhook订阅
+func (s *LogicService) DeleteWebhook(ctx context.Context, in *v1.DeleteWebhookRequest) (*v1.DeleteWebhookResponse, error) {
+	if err := s.webhook.DeleteWebhook(ctx, in.WebhookId); err != nil {
+		return nil, err
+	}
+	return &v1.DeleteWebhookResponse{}, nil
+}
+
+// CreateAttachmentUpload 创建附件上传
+func (s *LogicService) CreateAttachmentUpload(ctx context.Context, in *v1.CreateAttachmentUploadRequest) (*v1.AttachmentUpload, error) {
+	upload, err := s.attachments.CreateUpload(ctx, &bo.AttachmentUpload{
+		FileName: in.FileName,
+		MimeType: in.MimeType,
+		Size:     in.Size,
+		Sha256:   in.Sha256,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.toAttachmentUpload(upload), nil
+}
+
+// UploadAttachmentChunk 上传附件分片
+func (s *LogicService) UploadAttachmentChunk(ctx context.Context, in *v1.UploadAttachmentChunkRequest) (*v1.UploadAttachmentChunkResponse, error) {
+	count, err := s.attachments.UploadChunk(ctx, in.UploadId, int(in.Index), in.Data)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.UploadAttachmentChunkResponse{UploadedCount: int32(count)}, nil
+}
+
+// GetAttachmentUpload 查询附件上传进度
+func (s *LogicService) GetAttachmentUpload(ctx context.Context, in *v1.GetAttachmentUploadRequest) (*v1.AttachmentUpload, error) {
+	upload, err := s.attachments.GetUpload(ctx, in.UploadId)
+	if err != nil {
+		return nil, err
+	}
+	return s.toAttachmentUpload(upload), nil
+}
+
+// CompleteAttachmentUpload 完成附件上传
+func (s *LogicService) CompleteAttachmentUpload(ctx context.Context, in *v1.CompleteAttachmentUploadRequest) (*v1.AttachmentUpload, error) {
+	upload, err := s.attachments.CompleteUpload(ctx, in.UploadId)
+	if err != nil {
+		return nil, err
+	}
+	return s.toAttachmentUpload(upload), nil
+}
+
+// GetAttachment 查询附件和下载地址
+func (s *LogicService) GetAttachment(ctx context.Context, in *v1.GetAttachmentRequest) (*v1.Attachment, error) {
+	attachment, downloadURL, expires, err := s.attachments.GetAttachment(ctx, in.AttachmentId)
+	if err != nil {
+		return nil, err
+	}
+	return toAttachment(attachment, downloadURL, expires), nil
+}
+
+// DownloadAttachment 按签名的下载地址返回附件内容，支持Range请求
+// 签名已经限定了附件和有效期，不需要登录用户
+func (s *LogicService) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	expires, _ := strconv.ParseInt(query.Get("expires"), 10, 64)
+	attachment, reader, err := s.attachments.OpenDownload(r.Context(), query.Get("attachment_id"), expires, query.Get("signature"))
+	switch {
+	case err == nil:
+	case errors.Is(err, biz.ErrAttachmentURLInvalid):
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	case v1.IsAttachmentNotFound(err):
+		http.Error(w, "attachment not found", http.StatusNotFound)
+		return
+	default:
+		http.Error(w, "failed to open attachment", http.StatusInternalServerError)
+		return
+	}
+	defer reader.Close()
+	header := w.Header()
+	if attachment.MimeType != "" {
+		header.Set("Content-Type", attachment.MimeType)
+	}
+	header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	header.Set("ETag", `"`+attachment.Sha256+`"`)
+	// 禁止浏览器按内容猜测类型，避免上传的html等内容在下载域名下被执行
+	header.Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, attachment.FileName, attachment.CreatedAt, reader)
+}
+
+// CreateBroadcastJob 创建广播任务
+func (s *LogicService) CreateBroadcastJob(ctx context.Context, in *v1.CreateBroadcastJobRequest) (*v1.BroadcastJobResponse, error) {
+	s.log.WithContext(ctx).Infof("Receive broadcast job request. UserCount: %d, FileSize: %d", len(in.ToUserIds), len(in.RecipientFile))
+	job, err := s.broadcast.CreateBroadcastJob(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return toBroadcastJobResponse(job), nil
+}
+
+// GetBroadcastJob 查询广播任务进度
+func (s *LogicService) GetBroadcastJob(ctx context.Context, in *v1.BroadcastJobRequest) (*v1.BroadcastJobResponse, error) {
+	job, err := s.broadcast.GetBroadcastJob(ctx, in.JobId)
+	if err != nil {
+		return nil, err
+	}
+	return toBroadcastJobResponse(job), nil
+}
+
+// PauseBroadcastJob 暂停广播任务
+func (s *LogicService) PauseBroadcastJob(ctx context.Context, in *v1.BroadcastJobRequest) (*v1.BroadcastJobResponse, error) {
+	job, err := s.broadcast.PauseBroadcastJob(ctx, in.JobId)
+	if err != nil {
+		return nil, err
+	}
+	return toBroadcastJobResponse(job), nil
+}
+
+// ResumeBroadcastJob 恢复广播任务
+func (s *LogicService) ResumeBroadcastJob(ctx context.Context, in *v1.BroadcastJobRequest) (*v1.BroadcastJobResponse, error) {
+	job, err := s.broadcast.ResumeBroadcastJob(ctx, in.JobId)
+	if err != nil {
+		return nil, err
+	}
+	return toBroadcastJobResponse(job), nil
+}
+
+// CancelBroadcastJob 取消广播任务
+func (s *LogicService) CancelBroadcastJob(ctx context.Context, in *v1.BroadcastJobRequest) (*v1.BroadcastJobResponse, error) {
+	job, err := s.broadcast.CancelBroadcastJob(ctx, in.JobId)
+	if err != nil {
+		return nil, err
+	}
+	return toBroadcastJobResponse(job), nil
+}
+
+// ListConversations 分页查询当前用户的会话列表
+func (s *LogicService) ListConversations(ctx context.Context, in *v1.ListConversationsRequest) (*v1.ListConversationsResponse, error) {
+	conversations, total, err := s.conversation.ListConversations(ctx, int(in.Page), int(in.PageSize))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().Unix()
+	items := make([]*v1.ConversationInfo, 0, len(conversations))
+	for _, conversation := range conversations {
+		items = append(items, &v1.ConversationInfo{
+			ConversationType: conversation.ConversationType,
+			ConversationId:   conversation.ConversationId,
+			LastMsgId:        conversation.LastMsgId,
+			LastSenderId:     conversation.LastSenderId,
+			LastPreview:      conversation.LastPreview,
+			LastMessageAt:    conversation.LastMessageAt,
+			Unread:           conversation.UnreadCount,
+			Pinned:           conversation.Pinned,
+			Muted:            conversation.IsMuted(now),
+			MuteUntil:        conversation.MuteUntil,
+			Mentioned:        conversation.IsMentioned(),
+		})
+	}
+	return &v1.ListConversationsResponse{
+		Conversations: items,
+		Total:         total,
+	}, nil
+}
+
+// GetHistory 分页查询会话历史消息
+func (s *LogicService) GetHistory(ctx context.Context, in *v1.GetHistoryRequest) (*v1.GetHistoryResponse, error) {
+	messages, hasMore, err := s.conversation.GetHistory(ctx, in.ConversationType, in.ConversationId,
+		in.BeforeMsgId, in.AfterMsgId, int(in.Limit))
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*v1.HistoryMessage, 0, len(messages))
+	for _, message := range messages {
+		items = append(items, toHistoryMessage(message))
+	}
+	return &v1.GetHistoryResponse{
+		Messages: items,
+		HasMore:  hasMore,
+	}, nil
+}
+
+// SyncSince 按会话序号增量同步消息
+func (s *LogicService) SyncSince(ctx context.Context, in *v1.SyncSinceRequest) (*v1.SyncSinceResponse, error) {
+	messages, hasMore, err := s.conversation.SyncSince(ctx, in.ConversationType, in.ConversationId, in.SinceSeq, int(in.Limit))
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*v1.HistoryMessage, 0, len(messages))
+	for _, message := range messages {
+		items = append(items, toHistoryMessage(message))
+	}
+	return &v1.SyncSinceResponse{
+		Messages: items,
+		HasMore:  hasMore,
+	}, nil
+}
+
+// DeleteHistoryMessages 删除当前用户的历史消息
+func (s *LogicService) DeleteHistoryMessages(ctx context.Context, in *v1.DeleteHistoryMessagesRequest) (*v1.DeleteHistoryMessagesResponse, error) {
+	if err := s.conversation.DeleteHistoryMessages(ctx, in.MsgIds); err != nil {
+		return nil, err
+	}
+	return &v1.DeleteHistoryMessagesResponse{}, nil
+}
+
+// GetUnreadCount 查询当前用户的未读数
+func (s *LogicService) GetUnreadCount(ctx context.Context, in *v1.GetUnreadCountRequest) (*v1.GetUnreadCountResponse, error) {
+	total, counts, err := s.conversation.GetUnreadCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conversations := make([]*v1.ConversationUnread, 0, len(counts))
+	for _, count := range counts {
+		conversations = append(conversations, &v1.ConversationUnread{
+			ConversationType: count.ConversationType,
+			ConversationId:   count.ConversationId,
+			Unread:           count.Unread,
+		})
+	}
+	return &v1.GetUnreadCountResponse{
+		Total:         total,
+		Conversations: conversations,
+	}, nil
+}
+
+// GetNotificationPreferences 查询当前用户的通知偏好
+func (s *LogicService) GetNotificationPreferences(ctx context.Context, in *v1.GetNotificationPreferencesRequest) (*v1.NotificationPreferences, error) {
+	preferences, mutes, err := s.preferences.GetNotificationPreferences(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toNotificationPreferences(preferences, mutes), nil
+}
+
+// UpdateNotificationPreferences 更新当前用户的通知偏好
+func (s *LogicService) UpdateNotificationPreferences(ctx context.Context, in *v1.UpdateNotificationPreferencesRequest) (*v1.NotificationPreferences, error) {
+	update := &bo.NotificationPreferencesUpdate{}
+	if in.UpdateOptOutPushTypes || len(in.OptOutPushTypes) > 0 {
+		update.OptOutPushTypes = append([]v1.PushType{}, in.OptOutPushTypes...)
+	}
+	if in.Dnd != nil {
+		update.Dnd = &bo.DndWindow{
+			Enabled:  in.Dnd.Enabled,
+			Start:    in.Dnd.Start,
+			End:      in.Dnd.End,
+			Timezone: in.Dnd.Timezone,
+		}
+	}
+	for _, mute := range in.ConversationMutes {
+		update.Mutes = append(update.Mutes, &bo.ConversationMute{
+			ConversationType: mute.ConversationType,
+			ConversationId:   mute.ConversationId,
+			Muted:            mute.Muted,
+			MuteUntil:        mute.MuteUntil,
+		})
+	}
+	preferences, mutes, err := s.preferences.UpdateNotificationPreferences(ctx, update)
+	if err != nil {
+		return nil, err
+	}
+	return toNotificationPreferences(preferences, mutes), nil
+}
+
+func toNotificationPreferences(preferences *bo.NotificationPreferences, mutes []*bo.ConversationMute) *v1.NotificationPreferences {
+	out := &v1.NotificationPreferences{
+		OptOutPushTypes: preferences.OptOutPushTypes,
+		Dnd: &v1.DndWindow{
+			Enabled:  preferences.Dnd.Enabled,
+			Start:    preferences.Dnd.Start,
+			End:      preferences.Dnd.End,
+			Timezone: preferences.Dnd.Timezone,
+		},
+		MutedConversations: make([]*v1.ConversationMute, 0, len(mutes)),
+	}
+	for _, mute := range mutes {
+		out.MutedConversations = append(out.MutedConversations, &v1.ConversationMute{
+			ConversationType: mute.ConversationType,
+			ConversationId:   mute.ConversationId,
+			Muted:            mute.Muted,
+			MuteUntil:        mute.MuteUntil,
+		})
+	}
+	return out
+}
+
+func toBroadcastJobResponse(job *bo.BroadcastJob) *v1.BroadcastJobResponse {
+	var status v1.BroadcastJobStatus
+	switch job.Status {
+	case bo.BroadcastJobStatusRunning:
+		status = v1.BroadcastJobStatus_RUNNING
+	case bo.BroadcastJobStatusPaused:
+		status = v1.BroadcastJobStatus_PAUSED
+	case bo.BroadcastJobStatusCanceled:
+		status = v1.BroadcastJobStatus_CANCELED
+	case bo.BroadcastJobStatusCompleted:
+		status = v1.BroadcastJobStatus_COMPLETED
+	}
+	return &v1.BroadcastJobResponse{
+		JobId:          job.JobId,
+		Status:         status,
+		TotalUsers:     job.TotalUsers,
+		TotalChunks:    job.TotalChunks,
+		FinishedChunks: job.FinishedChunks,
+		SentUsers:      job.SentUsers,
+		FailedUsers:    job.FailedUsers,
+		CreatedAt:      job.CreatedAt.Unix(),
+		UpdatedAt:      job.UpdatedAt.Unix(),
+	}
+}
+
+func toPushSchedule(schedule *bo.PushSchedule) *v1.PushSchedule {
+	var status v1.PushScheduleStatus
+	switch schedule.Status {
+	case bo.PushScheduleStatusPending:
+		status = v1.PushScheduleStatus_SCHEDULE_PENDING
+	case bo.PushScheduleStatusFinished:
+		status = v1.PushScheduleStatus_SCHEDULE_FINISHED
+	case bo.PushScheduleStatusCanceled:
+		status = v1.PushScheduleStatus_SCHEDULE_CANCELED
+	}
+	return &v1.PushSchedule{
+		ScheduleId:  schedule.ScheduleId,
+		Status:      status,
+		Request:     schedule.Request,
+		NextFireAt:  schedule.NextFireAt,
+		FiredCount:  schedule.FiredCount,
+		LastFiredAt: schedule.LastFiredAt,
+		LastTaskId:  schedule.LastTaskId,
+		LastError:   schedule.LastError,
+		CreatedAt:   schedule.CreatedAt.Unix(),
+	}
+}
+
+func toHistoryMessage(message *bo.ChatMessage) *v1.HistoryMessage {
+	var status v1.ChatMessageStatus
+	switch message.Status {
+	case bo.ChatMessageStatusNormal:
+		status = v1.ChatMessageStatus_CHAT_NORMAL
+	case bo.ChatMessageStatusEdited:
+		status = v1.ChatMessageStatus_CHAT_EDITED
+	case bo.ChatMessageStatusRecalled:
+		status = v1.ChatMessageStatus_CHAT_RECALLED
+	}
+	return &v1.HistoryMessage{
+		MsgId:       message.MsgId,
+		FromUserId:  message.FromUserId,
+		Content:     message.Content,
+		ContentType: im_v1.ContentType(message.ContentType),
+		Timestamp:   message.Timestamp,
+		SentAt:      message.SentAt,
+		Status:      status,
+		RevisedBy:   message.RevisedBy,
+		RevisedAt:   message.RevisedAt,
+		Seq:         message.Seq,
+	}
+}
+
+// toTemplateContents 转换多语言内容，相同语言保留后出现的一条
+func toTemplateContents(contents []*v1.PushTemplateContent) map[string]string {
+	result := make(map[string]string, len(contents))
+	for _, content := range contents {
+		result[content.Locale] = content.Content
+	}
+	return result
+}
+
+func toPushTemplate(template *bo.PushTemplate) *v1.PushTemplate {
+	locales := make([]string, 0, len(template.Contents))
+	for locale := range template.Contents {
+		locales = append(locales, locale)
+	}
+	slices.Sort(locales)
+	contents := make([]*v1.PushTemplateContent, 0, len(locales))
+	for _, locale := range locales {
+		contents = append(contents, &v1.PushTemplateContent{
+			Locale:  locale,
+			Content: template.Contents[locale],
+		})
+	}
+	return &v1.PushTemplate{
+		TemplateId:    template.TemplateId,
+		Name:          template.Name,
+		Variables:     template.Variables,
+		DefaultLocale: template.DefaultLocale,
+		Contents:      contents,
+		CreatedAt:     template.CreatedAt.Unix(),
+		UpdatedAt:     template.UpdatedAt.Unix(),
+	}
+}
+
+func toDeliveryFunnelCounts(counts *bo.FunnelCounts) *v1.DeliveryFunnelCounts {
+	return &v1.DeliveryFunnelCounts{
+		Targeted:        counts.Targeted,
+		Sent:            counts.Sent,
+		ArchivedOffline: counts.ArchivedOffline,
+		Delivered:       counts.Delivered,
+		Read:            counts.Read,
+	}
+}
+
+func toReportSchema(schema *bo.ReportSchema) *v1.ReportSchema {
+	fields := make([]*v1.ReportField, 0, len(schema.Fields))
+	for _, field := range schema.Fields {
+		fields = append(fields, &v1.ReportField{
+			Name:     field.Name,
+			Type:     v1.ReportFieldType(field.Type),
+			Required: field.Required,
+		})
+	}
+	return &v1.ReportSchema{
+		Name:                  schema.Name,
+		Version:               schema.Version,
+		Fields:                fields,
+		AllowAdditionalFields: schema.AllowAdditionalFields,
+		CreatedAt:             schema.CreatedAt.Unix(),
+	}
+}
+
+func toWebhook(subscription *bo.WebhookSubscription) *v1.Webhook {
+	eventTypes := make([]string, 0, len(subscription.EventTypes))
+	for _, eventType := range subscription.EventTypes {
+		eventTypes = append(eventTypes, string(eventType))
+	}
+	return &v1.Webhook{
+		WebhookId:  subscription.WebhookId,
+		Url:        subscription.URL,
+		EventTypes: eventTypes,
+		BotId:      subscription.BotId,
+		CreatedAt:  subscription.CreatedAt.Unix(),
+	}
+}
+
+func toUserAttributes(attributes *bo.UserAttributes) *v1.UserAttributes {
+	return &v1.UserAttributes{
+		UserId:       attributes.UserId,
+		Platform:     attributes.Platform,
+		Locale:       attributes.Locale,
+		RegisteredAt: attributes.RegisteredAt,
+		Tags:         attributes.Tags,
+	}
+}
+
+func (s *LogicService) toAttachmentUpload(upload *bo.AttachmentUpload) *v1.AttachmentUpload {
+	resp := &v1.AttachmentUpload{
+		UploadId:       upload.UploadId,
+		Status:         v1.AttachmentUploadStatus_UPLOAD_PENDING,
+		ChunkSize:      upload.ChunkSize,
+		ChunkCount:     int32(upload.ChunkCount),
+		UploadedChunks: make([]int32, 0, len(upload.UploadedChunks)),
+	}
+	if !upload.ExpiresAt.IsZero() {
+		resp.ExpiresAt = upload.ExpiresAt.Unix()
+	}
+	for _, index := range upload.UploadedChunks {
+		resp.UploadedChunks = append(resp.UploadedChunks, int32(index))
+	}
+	if upload.Status == bo.AttachmentUploadCompleted {
+		resp.Status = v1.AttachmentUploadStatus_UPLOAD_COMPLETED
+		if upload.Attachment != nil {
+			downloadURL, expires := s.attachments.DownloadURL(upload.Attachment.AttachmentId)
+			resp.Attachment = toAttachment(upload.Attachment, downloadURL, expires)
+		}
+	}
+	return resp
+}
+
+func toAttachment(attachment *bo.Attachment, downloadURL string, expires int64) *v1.Attachment {
+	return &v1.Attachment{
+		AttachmentId: attachment.AttachmentId,
+		FileName:     attachment.FileName,
+		MimeType:     attachment.MimeType,
+		Size:         attachment.Size,
+		Sha256:       attachment.Sha256,
+		CreatedAt:    attachment.CreatedAt.Unix(),
+		DownloadUrl:  downloadURL,
+		UrlExpiresAt: expires,
+	}
+}
@@ -28,17 +28,20 @@ func wireApp(bootstrap *conf.Bootstrap, logger log.Logger) (*kratos.App, func(),
 	}
 	messageRepo := data.NewOfflineMessageRepo(dataData, logger)
 	offlineUsecase := biz.NewOfflineUsecase(messageRepo, logger)
-	offlineService := service.NewOfflineService(offlineUsecase, logger)
+	offlinePurger, cleanup2 := biz.NewOfflinePurger(messageRepo, bootstrap, logger)
+	offlineService := service.NewOfflineService(offlineUsecase, offlinePurger, logger)
 	grpcServer := server.NewGRPCServer(bootstrap, offlineService, logger)
 	httpServer := server.NewHTTPServer(bootstrap, offlineService, logger)
 	client, err := data.NewEtcdClient(bootstrap)
 	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	registrar := data.NewRegistry(client)
 	app := newApp(logger, grpcServer, httpServer, registrar)
 	return app, func() {
+		cleanup2()
 		cleanup()
 	}, nil
 }
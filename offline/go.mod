@@ -10,8 +10,8 @@ require (
 	github.com/google/wire v0.6.0
 	github.com/prometheus/client_golang v1.23.0
 	github.com/redis/go-redis/v9 v9.14.1
-	github.com/xinghe903/chatify/api v0.0.0-beta003
-	github.com/xinghe903/chatify/pkg v0.0.0-beta003
+	github.com/xinghe903/chatify/api v0.0.0-beta004
+	github.com/xinghe903/chatify/pkg v0.0.0-beta004
 	go.etcd.io/etcd/client/v3 v3.6.5
 	go.uber.org/automaxprocs v1.5.1
 	google.golang.org/protobuf v1.36.9
@@ -71,7 +71,7 @@ require (
 	go.uber.org/multierr v1.11.0 // indirect
 	go.uber.org/zap v1.27.0 // indirect
 	golang.org/x/crypto v0.43.0 // indirect
-	golang.org/x/net v0.45.0 // indirect
+	golang.org/x/net v0.46.0 // indirect
 	golang.org/x/sync v0.17.0 // indirect
 	golang.org/x/sys v0.37.0 // indirect
 	golang.org/x/text v0.30.0 // indirect
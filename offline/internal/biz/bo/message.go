@@ -1,7 +1,13 @@
 package bo
 
+import "time"
+
 const (
 	BatchArchiveSize int = 1000 // 一次批量归档消息大小最大限制
+	// DefaultPurgeInterval 过期消息默认清理间隔
+	DefaultPurgeInterval = time.Minute
+	// DefaultPurgeBatchSize 每次删除的过期消息默认条数
+	DefaultPurgeBatchSize = 1000
 )
 
 // 消息状态枚举
@@ -14,6 +20,8 @@ const (
 	MessageStatusArchived MessageStatus = "archived"
 	// MessageStatusDelivered 已送达
 	MessageStatusDelivered MessageStatus = "delivered"
+	// MessageStatusRecalled 送达前已被撤回
+	MessageStatusRecalled MessageStatus = "recalled"
 )
 
 // OfflineMessage 离线消息业务对象
@@ -29,6 +37,12 @@ type OfflineMessage struct {
 	Timestamp   int64         `json:"timestamp"`
 	ExpireTime  int64         `json:"expire_time"`
 	ContentID   string        `json:"content_id"`
+	GroupID     string        `json:"group_id"`
+	Seq         int64         `json:"seq"`
+	ContentType int32         `json:"content_type"` // 聊天消息的内容类型
+	Mentioned   bool          `json:"mentioned"`    // 接收者在群聊消息中被@
+	Silent      bool          `json:"silent"`       // 接收者开启了免打扰，客户端不提醒
+	Priority    int32         `json:"priority"`     // 投递优先级
 	TaskID      string        `json:"task_id"`
 	Status      MessageStatus `json:"status"`
 	Description string        `json:"description"`
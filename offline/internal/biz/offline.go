@@ -18,6 +18,11 @@ type MessageRepo interface {
 	ArchiveMessages(ctx context.Context, messages []*bo.OfflineMessage) error
 	GetOfflineMessagesByUserID(ctx context.Context, userID string, lastMessageId string) ([]*bo.OfflineMessage, error)
 	MarkMessagesAsDelivered(ctx context.Context, messageIDs []string) error
+	// ReviseMessages 修改指定内容ID下仍待投递的离线消息，返回修改的条数
+	// recalled为true时标记为已撤回并清空内容，否则替换为新的内容
+	ReviseMessages(ctx context.Context, contentID string, recalled bool, content []byte) (int64, error)
+	// PurgeExpiredMessages 删除最多limit条在now之前过期的消息，返回删除的条数
+	PurgeExpiredMessages(ctx context.Context, now int64, limit int) (int64, error)
 }
 
 // OfflineUsecase 离线消息业务逻辑
@@ -60,6 +65,12 @@ func (uc *OfflineUsecase) ArchiveMessages(ctx context.Context, taskId string, me
 			Timestamp:   msg.Timestamp,
 			ExpireTime:  msg.ExpireTime,
 			ContentID:   msg.ContentId,
+			GroupID:     msg.GroupId,
+			Seq:         msg.Seq,
+			ContentType: int32(msg.ContentType),
+			Mentioned:   msg.Mentioned,
+			Silent:      msg.Silent,
+			Priority:    int32(msg.Priority),
 			TaskID:      taskId,
 			Status:      bo.MessageStatusPending,
 			Description: "archived offline message",
@@ -111,3 +122,17 @@ func (uc *OfflineUsecase) MarkMessagesAsDelivered(ctx context.Context, messageID
 	uc.log.WithContext(ctx).Debugf("Successfully marked %d messages as delivered", len(messageIDs))
 	return nil
 }
+
+// ReviseMessages 撤回或编辑尚未投递的离线消息，保证离线用户上线后看不到原始内容
+func (uc *OfflineUsecase) ReviseMessages(ctx context.Context, contentID string, recalled bool, content []byte) (int64, error) {
+	if contentID == "" {
+		return 0, nil
+	}
+	affected, err := uc.messageRepo.ReviseMessages(ctx, contentID, recalled, content)
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("Failed to revise offline messages. contentID=%s, error=%v", contentID, err)
+		return 0, v1.ErrorReviseMessageFailed("data revise offline message failed")
+	}
+	uc.log.WithContext(ctx).Debugf("Revised %d offline messages. contentID=%s, recalled=%v", affected, contentID, recalled)
+	return affected, nil
+}
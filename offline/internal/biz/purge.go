@@ -0,0 +1,72 @@
+package biz
+
+import (
+	"context"
+	"time"
+
+	"github.com/xinghe903/chatify/offline/internal/biz/bo"
+	"github.com/xinghe903/chatify/offline/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// OfflinePurger 定期删除已过期的离线消息
+// 删除操作是幂等的，多个实例同时运行时不需要选主
+type OfflinePurger struct {
+	messageRepo MessageRepo
+	log         *log.Helper
+	interval    time.Duration
+	batchSize   int
+}
+
+// NewOfflinePurger 创建并启动过期消息清理任务
+func NewOfflinePurger(messageRepo MessageRepo, c *conf.Bootstrap, logger log.Logger) (*OfflinePurger, func()) {
+	p := &OfflinePurger{
+		messageRepo: messageRepo,
+		log:         log.NewHelper(logger),
+		interval:    c.GetPurge().GetInterval().AsDuration(),
+		batchSize:   int(c.GetPurge().GetBatchSize()),
+	}
+	if p.interval <= 0 {
+		p.interval = bo.DefaultPurgeInterval
+	}
+	if p.batchSize <= 0 {
+		p.batchSize = bo.DefaultPurgeBatchSize
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	go p.run(ctx)
+	return p, cancel
+}
+
+func (p *OfflinePurger) run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.purge(ctx)
+		}
+	}
+}
+
+// purge 按批删除过期消息，直到没有过期消息或任务停止
+func (p *OfflinePurger) purge(ctx context.Context) {
+	now := time.Now().Unix()
+	var total int64
+	for ctx.Err() == nil {
+		affected, err := p.messageRepo.PurgeExpiredMessages(ctx, now, p.batchSize)
+		if err != nil {
+			p.log.WithContext(ctx).Errorf("failed to purge expired offline messages: %v", err)
+			break
+		}
+		total += affected
+		if affected < int64(p.batchSize) {
+			break
+		}
+	}
+	if total > 0 {
+		p.log.WithContext(ctx).Infof("Purged %d expired offline messages", total)
+	}
+}
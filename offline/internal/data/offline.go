@@ -68,6 +68,7 @@ func (r *OfflineMessageRepo) GetOfflineMessagesByUserID(ctx context.Context, use
 		Where("to_user_id = ?", userID).
 		Where("status = ?", po.MessageStatusPending).
 		Where("msg_id > ?", lastMessageId).
+		Where("expire_time = 0 OR expire_time > ?", time.Now().Unix()).
 		Order("msg_id ASC").
 		Limit(bo.BatchArchiveSize)
 
@@ -100,3 +101,45 @@ func (r *OfflineMessageRepo) MarkMessagesAsDelivered(ctx context.Context, messag
 	r.log.WithContext(ctx).Infof("Marked %d messages as delivered", result.RowsAffected)
 	return nil
 }
+
+// ReviseMessages 修改待投递的离线消息
+func (r *OfflineMessageRepo) ReviseMessages(ctx context.Context, contentID string, recalled bool, content []byte) (int64, error) {
+	updates := map[string]interface{}{
+		"content":    content,
+		"updated_at": time.Now(),
+	}
+	if recalled {
+		updates["content"] = nil
+		updates["status"] = po.MessageStatusRecalled
+		updates["description"] = "recalled before delivery"
+	}
+	result := r.data.db.WithContext(ctx).
+		Model(&po.OfflineMessage{}).
+		Where("content_id = ? AND status = ?", contentID, po.MessageStatusPending).
+		Updates(updates)
+	if result.Error != nil {
+		return 0, errors.Join(result.Error, errors.New("failed to revise offline messages"))
+	}
+	return result.RowsAffected, nil
+}
+
+// PurgeExpiredMessages 先按过期时间索引查出一批ID再删除，避免单条DELETE锁住大量行
+func (r *OfflineMessageRepo) PurgeExpiredMessages(ctx context.Context, now int64, limit int) (int64, error) {
+	var ids []string
+	err := r.data.db.WithContext(ctx).
+		Model(&po.OfflineMessage{}).
+		Where("expire_time > 0 AND expire_time <= ?", now).
+		Limit(limit).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return 0, errors.Join(err, errors.New("failed to query expired offline messages"))
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result := r.data.db.WithContext(ctx).Where("id IN ?", ids).Delete(&po.OfflineMessage{})
+	if result.Error != nil {
+		return 0, errors.Join(result.Error, errors.New("failed to purge expired offline messages"))
+	}
+	return result.RowsAffected, nil
+}
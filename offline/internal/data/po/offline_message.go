@@ -18,6 +18,8 @@ const (
 	MessageStatusArchived MessageStatus = "archived"
 	// MessageStatusDelivered 已送达
 	MessageStatusDelivered MessageStatus = "delivered"
+	// MessageStatusRecalled 已撤回
+	MessageStatusRecalled MessageStatus = "recalled"
 )
 
 // MessageStatus 消息状态类型
@@ -37,6 +39,12 @@ type OfflineMessage struct {
 	Timestamp   int64         `json:"timestamp" gorm:"index:idx_timestamp"`
 	ExpireTime  int64         `json:"expire_time" gorm:"index:idx_expire_time"`
 	ContentID   string        `json:"content_id" gorm:"index:idx_content_id"`
+	GroupID     string        `json:"group_id" gorm:"type:varchar(64)"`
+	Seq         int64         `json:"seq"`
+	ContentType int32         `json:"content_type"` // 聊天消息的内容类型，升级前归档的消息为0
+	Mentioned   bool          `json:"mentioned"`    // 接收者在群聊消息中被@
+	Silent      bool          `json:"silent"`       // 接收者开启了免打扰，客户端不提醒
+	Priority    int32         `json:"priority"`     // 投递优先级
 	TaskID      string        `json:"task_id" gorm:"index:idx_task_id"`
 	Status      MessageStatus `json:"status" gorm:"type:varchar(20);index:idx_status"`
 	Description string        `json:"description" gorm:"type:varchar(255)"`
@@ -56,6 +64,12 @@ func NewOfflineMessageFromBo(boMsg *bo.OfflineMessage) *OfflineMessage {
 		Timestamp:   boMsg.Timestamp,
 		ExpireTime:  boMsg.ExpireTime,
 		ContentID:   boMsg.ContentID,
+		GroupID:     boMsg.GroupID,
+		Seq:         boMsg.Seq,
+		ContentType: boMsg.ContentType,
+		Mentioned:   boMsg.Mentioned,
+		Silent:      boMsg.Silent,
+		Priority:    boMsg.Priority,
 		TaskID:      boMsg.TaskID,
 		Status:      MessageStatus(boMsg.Status),
 		Description: boMsg.Description,
@@ -88,6 +102,12 @@ func (om *OfflineMessage) ToBo() *bo.OfflineMessage {
 		Timestamp:   om.Timestamp,
 		ExpireTime:  om.ExpireTime,
 		ContentID:   om.ContentID,
+		GroupID:     om.GroupID,
+		Seq:         om.Seq,
+		ContentType: om.ContentType,
+		Mentioned:   om.Mentioned,
+		Silent:      om.Silent,
+		Priority:    om.Priority,
 		TaskID:      om.TaskID,
 		Status:      bo.MessageStatus(om.Status),
 		Description: om.Description,
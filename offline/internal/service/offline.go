@@ -16,15 +16,17 @@ import (
 type OfflineService struct {
 	v1.UnimplementedOfflineServiceServer
 
-	uc  *biz.OfflineUsecase
-	log *log.Helper
+	uc     *biz.OfflineUsecase
+	purger *biz.OfflinePurger
+	log    *log.Helper
 }
 
 // NewOfflineService 创建离线消息服务实例
-func NewOfflineService(uc *biz.OfflineUsecase, logger log.Logger) *OfflineService {
+func NewOfflineService(uc *biz.OfflineUsecase, purger *biz.OfflinePurger, logger log.Logger) *OfflineService {
 	return &OfflineService{
-		uc:  uc,
-		log: log.NewHelper(logger),
+		uc:     uc,
+		purger: purger,
+		log:    log.NewHelper(logger),
 	}
 }
 
@@ -61,6 +63,15 @@ func (s *OfflineService) RetrieveOfflineMessages(ctx context.Context, in *v1.Ret
 			TargetType:  im_v1.TargetType(msg.TargetType),
 			ToUserId:    msg.ToUserID,
 			Content:     msg.Content,
+			Timestamp:   msg.Timestamp,
+			ExpireTime:  msg.ExpireTime,
+			ContentId:   msg.ContentID,
+			GroupId:     msg.GroupID,
+			Seq:         msg.Seq,
+			ContentType: im_v1.ContentType(msg.ContentType),
+			Mentioned:   msg.Mentioned,
+			Silent:      msg.Silent,
+			Priority:    im_v1.Priority(msg.Priority),
 		}
 	}
 	s.log.WithContext(ctx).Debugf("RetrieveOfflineMessages request processed successfully. userId=%s, lastMessageId=%s, messageCount=%d", in.UserId, in.LastMessageId, len(messages))
@@ -75,3 +86,12 @@ func (s *OfflineService) AcknowledgeMessages(ctx context.Context, in *v1.AckRequ
 	}
 	return &v1.AckResponse{}, nil
 }
+
+// ReviseMessages 撤回或编辑尚未送达的离线消息
+func (s *OfflineService) ReviseMessages(ctx context.Context, in *v1.ReviseRequest) (*v1.ReviseResponse, error) {
+	affected, err := s.uc.ReviseMessages(ctx, in.ContentId, in.Recalled, in.Content)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.ReviseResponse{Affected: affected}, nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/xinghe903/chatify/offline/internal/biz"
+	"github.com/xinghe903/chatify/offline/internal/biz/bo"
+	"github.com/xinghe903/chatify/offline/internal/data/po"
+
+	im_v1 "github.com/xinghe903/chatify/api/im/v1"
+	v1 "github.com/xinghe903/chatify/api/offline/v1"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// memoryRepo 按po保存离线消息的内存仓库，覆盖bo和po之间的转换
+type memoryRepo struct {
+	biz.MessageRepo
+	rows []*po.OfflineMessage
+}
+
+func (r *memoryRepo) ArchiveMessages(_ context.Context, messages []*bo.OfflineMessage) error {
+	for _, message := range messages {
+		r.rows = append(r.rows, po.NewOfflineMessageFromBo(message))
+	}
+	return nil
+}
+
+func (r *memoryRepo) GetOfflineMessagesByUserID(_ context.Context, userID string, _ string) ([]*bo.OfflineMessage, error) {
+	var messages []*bo.OfflineMessage
+	for _, row := range r.rows {
+		if row.ToUserID == userID {
+			messages = append(messages, row.ToBo())
+		}
+	}
+	return messages, nil
+}
+
+// TestArchiveAndRetrieveKeepsDeliveryFields 离线归档后取回的消息保留内容类型等下发字段
+func TestArchiveAndRetrieveKeepsDeliveryFields(t *testing.T) {
+	logger := log.NewStdLogger(io.Discard)
+	s := NewOfflineService(biz.NewOfflineUsecase(&memoryRepo{}, logger), nil, logger)
+	ctx := context.Background()
+	archived := &im_v1.BaseMessage{
+		MsgId:       "m1",
+		MessageType: im_v1.MessageType_CHAT,
+		FromUserId:  "alice",
+		TargetType:  im_v1.TargetType_GROUP,
+		ToUserId:    "bob",
+		Content:     []byte(`{"image":{"attachment_id":"a1"}}`),
+		ContentId:   "c1",
+		GroupId:     "g1",
+		Seq:         7,
+		ContentType: im_v1.ContentType_IMAGE,
+		Mentioned:   true,
+		Silent:      true,
+		Priority:    im_v1.Priority_PRIORITY_LOW,
+	}
+	if _, err := s.ArchiveMessages(ctx, &v1.ArchiveRequest{TaskId: "t1", Message: []*im_v1.BaseMessage{archived}}); err != nil {
+		t.Fatalf("ArchiveMessages() error = %v", err)
+	}
+	resp, err := s.RetrieveOfflineMessages(ctx, &v1.RetrieveRequest{UserId: "bob"})
+	if err != nil {
+		t.Fatalf("RetrieveOfflineMessages() error = %v", err)
+	}
+	if len(resp.Message) != 1 {
+		t.Fatalf("retrieved %d messages, want 1", len(resp.Message))
+	}
+	got := resp.Message[0]
+	if got.ContentType != archived.ContentType {
+		t.Fatalf("content type = %v, want %v", got.ContentType, archived.ContentType)
+	}
+	if !got.Mentioned {
+		t.Fatalf("mentioned = false, want true")
+	}
+	if !got.Silent {
+		t.Fatalf("silent = false, want true")
+	}
+	if got.Priority != archived.Priority {
+		t.Fatalf("priority = %v, want %v", got.Priority, archived.Priority)
+	}
+	if got.MsgId != archived.MsgId || got.Seq != archived.Seq || got.GroupId != archived.GroupId {
+		t.Fatalf("retrieved message = %v, want %v", got, archived)
+	}
+}
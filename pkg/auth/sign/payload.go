@@ -0,0 +1,24 @@
+package sign
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// SignPayload 生成请求体签名，用于webhook等服务端主动发出的请求
+// 待签名内容为 "{timestamp}.{payload}"，timestamp 单位毫秒
+// 返回: 十六进制编码的HMAC-SHA256签名
+func SignPayload(secretKey string, timestamp int64, payload []byte) string {
+	h := hmac.New(sha256.New, []byte(secretKey))
+	h.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	h.Write([]byte("."))
+	h.Write(payload)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// VerifyPayload 验证请求体签名，接收方还需要自行校验timestamp是否在有效期内
+func VerifyPayload(secretKey string, timestamp int64, payload []byte, signature string) bool {
+	return hmac.Equal([]byte(signature), []byte(SignPayload(secretKey, timestamp, payload)))
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"strconv"
 	"sync"
 	"time"
 
@@ -18,6 +19,8 @@ var (
 	ErrInvalidSign    = errors.New("invalid signature")
 	ErrRequestExpired = errors.New("request expired")
 	ErrRequestRepeat  = errors.New("replay attack detected")
+	// ErrStateUnsupported 自定义缓存没有实现StateCache，不支持保存请求的处理状态
+	ErrStateUnsupported = errors.New("cache does not support request state")
 )
 
 // Cache 缓存接口，定义了缓存操作的基本方法
@@ -29,6 +32,23 @@ type Cache interface {
 	Set(ctx context.Context, key string, value string, expiration time.Duration) error
 }
 
+// StateCache 在Cache的基础上支持原子占用、读取和删除，用于保存请求的处理状态
+// RedisCache和MemoryCache都实现了该接口，自定义缓存实现后可以使用ReplayChecker的Claim等方法
+type StateCache interface {
+	Cache
+	// SetNX 键不存在时设置键值对，返回是否设置成功
+	SetNX(ctx context.Context, key string, value string, expiration time.Duration) (bool, error)
+	// Get 获取键的值，第二个返回值表示键是否存在
+	Get(ctx context.Context, key string) (string, bool, error)
+	// Delete 删除键
+	Delete(ctx context.Context, key string) error
+}
+
+var (
+	_ StateCache = (*RedisCache)(nil)
+	_ StateCache = (*MemoryCache)(nil)
+)
+
 // RedisCache Redis缓存实现
 // 使用Redis作为缓存存储
 type RedisCache struct {
@@ -99,7 +119,13 @@ func (mc *MemoryCache) Exists(ctx context.Context, key string) (bool, error) {
 func (mc *MemoryCache) Set(ctx context.Context, key string, value string, expiration time.Duration) error {
 	mc.mutex.Lock()
 	defer mc.mutex.Unlock()
+	mc.set(key, value, expiration)
+	return nil
+}
 
+// set 设置键值对
+// 注意：调用此方法前必须获取写锁
+func (mc *MemoryCache) set(key string, value string, expiration time.Duration) {
 	// 检查是否已达到最大存储数量，并且要添加的是新键
 	if _, exists := mc.items[key]; !exists && len(mc.items) >= mc.maxSize {
 		// 使用FIFO策略删除最旧的键
@@ -124,10 +150,45 @@ func (mc *MemoryCache) Set(ctx context.Context, key string, value string, expira
 		// 如果过期时间为0或负值，则移除过期时间设置
 		delete(mc.expiration, key)
 	}
+}
 
+// SetNX 键不存在或已过期时设置键值对
+func (mc *MemoryCache) SetNX(ctx context.Context, key string, value string, expiration time.Duration) (bool, error) {
+	mc.mutex.Lock()
+	defer mc.mutex.Unlock()
+	if _, exists := mc.items[key]; exists && !mc.expired(key) {
+		return false, nil
+	}
+	mc.set(key, value, expiration)
+	return true, nil
+}
+
+// Get 获取键的值，已过期的键视为不存在
+func (mc *MemoryCache) Get(ctx context.Context, key string) (string, bool, error) {
+	mc.mutex.RLock()
+	defer mc.mutex.RUnlock()
+	value, exists := mc.items[key]
+	if !exists || mc.expired(key) {
+		return "", false, nil
+	}
+	return value, true, nil
+}
+
+// Delete 删除键
+func (mc *MemoryCache) Delete(ctx context.Context, key string) error {
+	mc.mutex.Lock()
+	defer mc.mutex.Unlock()
+	mc.removeKey(key)
 	return nil
 }
 
+// expired 判断键是否已过期
+// 注意：调用此方法前必须获取读锁或写锁
+func (mc *MemoryCache) expired(key string) bool {
+	expiration, ok := mc.expiration[key]
+	return ok && time.Now().After(expiration)
+}
+
 // removeKey 从缓存中删除指定的键
 // 注意：调用此方法前必须获取写锁
 func (mc *MemoryCache) removeKey(key string) {
@@ -156,6 +217,28 @@ func (rc *RedisCache) Set(ctx context.Context, key string, value string, expirat
 	return err
 }
 
+// SetNX 键不存在时设置键值对
+func (rc *RedisCache) SetNX(ctx context.Context, key string, value string, expiration time.Duration) (bool, error) {
+	return rc.client.SetNX(ctx, key, value, expiration).Result()
+}
+
+// Get 获取键的值
+func (rc *RedisCache) Get(ctx context.Context, key string) (string, bool, error) {
+	value, err := rc.client.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return value, true, nil
+}
+
+// Delete 删除键
+func (rc *RedisCache) Delete(ctx context.Context, key string) error {
+	return rc.client.Del(ctx, key).Err()
+}
+
 // SignParam 签名参数结构体
 // 用于请求的签名和防重放攻击校验
 type SignParam struct {
@@ -265,7 +348,7 @@ func NewReplayCheckerWithCache(cache Cache, expireTime, timeWindow int) *ReplayC
 // 返回: 十六进制编码的签名字符串
 func GenerateSignature(requestID string, timestamp int64, secretKey string) string {
 	// 构建待签名字符串
-	message := requestID + "_" + string(timestamp)
+	message := requestID + "_" + strconv.FormatInt(timestamp, 10)
 
 	// 使用HMAC-SHA256生成签名
 	h := hmac.New(sha256.New, []byte(secretKey))
@@ -293,6 +376,16 @@ func VerifySignature(signParam *SignParam, secretKey string) bool {
 // requestID: 请求ID
 // 返回: 是否为重放请求，错误信息
 func (rc *ReplayChecker) CheckReplay(ctx context.Context, requestID string) (bool, error) {
+	// 支持原子占用的缓存避免并发的相同请求都通过检查
+	if _, ok := rc.cache.(StateCache); ok {
+		claimed, err := rc.Claim(ctx, requestID, "1", 0)
+		if err != nil {
+			return false, err
+		}
+		// 请求ID已存在，说明是重放请求
+		return !claimed, nil
+	}
+
 	// 构建缓存键
 	key := formatReplayCheckKey(requestID)
 
@@ -317,6 +410,43 @@ func (rc *ReplayChecker) CheckReplay(ctx context.Context, requestID string) (boo
 	return false, nil
 }
 
+// Claim 原子地占用请求ID并保存value，请求ID已存在时返回false
+// 并发的相同请求只有一个能占用成功，ttl为占用时长，小于等于0时使用过期时间
+// 缓存没有实现StateCache时返回ErrStateUnsupported，Load和Forget相同
+func (rc *ReplayChecker) Claim(ctx context.Context, requestID string, value string, ttl time.Duration) (bool, error) {
+	cache, ok := rc.cache.(StateCache)
+	if !ok {
+		return false, ErrStateUnsupported
+	}
+	if ttl <= 0 {
+		ttl = time.Duration(rc.expireTime) * time.Second
+	}
+	return cache.SetNX(ctx, formatReplayCheckKey(requestID), value, ttl)
+}
+
+// Load 读取请求ID保存的值，第二个返回值表示请求ID是否存在
+func (rc *ReplayChecker) Load(ctx context.Context, requestID string) (string, bool, error) {
+	cache, ok := rc.cache.(StateCache)
+	if !ok {
+		return "", false, ErrStateUnsupported
+	}
+	return cache.Get(ctx, formatReplayCheckKey(requestID))
+}
+
+// Store 更新请求ID保存的值，例如保存请求的处理结果，过期时间重新计算
+func (rc *ReplayChecker) Store(ctx context.Context, requestID string, value string) error {
+	return rc.cache.Set(ctx, formatReplayCheckKey(requestID), value, time.Duration(rc.expireTime)*time.Second)
+}
+
+// Forget 删除请求ID，请求处理失败后允许客户端使用相同的请求ID重试
+func (rc *ReplayChecker) Forget(ctx context.Context, requestID string) error {
+	cache, ok := rc.cache.(StateCache)
+	if !ok {
+		return ErrStateUnsupported
+	}
+	return cache.Delete(ctx, formatReplayCheckKey(requestID))
+}
+
 // CheckAndMark 检查消息ID是否已处理，并标记为已处理（兼容原有API）
 // func (rc *ReplayChecker) CheckAndMark(ctx context.Context, messageID string) bool {
 // 	if messageID == "" {
@@ -333,22 +463,31 @@ func (rc *ReplayChecker) CheckReplay(ctx context.Context, requestID string) (boo
 // 	return !isReplay
 // }
 
-// ValidateRequest 验证请求是否有效（包括签名验证、时间窗口验证和防重放验证）
-// ctx: 上下文
-// signParam: 签名参数
-// secretKey: 密钥
-// 返回: 是否验证通过，错误信息
-func (rc *ReplayChecker) ValidateRequest(ctx context.Context, signParam *SignParam, secretKey string) error {
+// VerifyRequest 验证签名和时间窗口，不做防重放验证
+// 时间戳早于或晚于当前时间超过时间窗口都视为过期，避免客户端用未来的时间戳延长签名有效期
+func (rc *ReplayChecker) VerifyRequest(signParam *SignParam, secretKey string) error {
 	// 1. 验证签名
 	if !VerifySignature(signParam, secretKey) {
 		return ErrInvalidSign
 	}
 
 	// 2. 验证时间窗口
-	now := time.Now().UnixNano() / int64(time.Millisecond)
-	if now-signParam.Timestamp > rc.timeWindow {
+	diff := time.Now().UnixMilli() - signParam.Timestamp
+	if diff > rc.timeWindow || -diff > rc.timeWindow {
 		return ErrRequestExpired
 	}
+	return nil
+}
+
+// ValidateRequest 验证请求是否有效（包括签名验证、时间窗口验证和防重放验证）
+// ctx: 上下文
+// signParam: 签名参数
+// secretKey: 密钥
+// 返回: 是否验证通过，错误信息
+func (rc *ReplayChecker) ValidateRequest(ctx context.Context, signParam *SignParam, secretKey string) error {
+	if err := rc.VerifyRequest(signParam, secretKey); err != nil {
+		return err
+	}
 
 	// 3. 防重放验证
 	isReplay, err := rc.CheckReplay(ctx, signParam.RequestID)
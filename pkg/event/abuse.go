@@ -0,0 +1,14 @@
+// Package event 定义服务之间通过Kafka传递的事件，生产者和消费者共用同一份结构
+package event
+
+// TopicUserAbuse logic服务上报的用户滥用事件，由auth服务消费
+const TopicUserAbuse = "user_abuse"
+
+// UserAbuse 用户滥用事件，auth服务消费后锁定账号
+type UserAbuse struct {
+	UserId     string `json:"user_id"`
+	Reason     string `json:"reason"`
+	Strikes    int64  `json:"strikes"`     // 统计周期内被禁言的次数
+	MutedUntil int64  `json:"muted_until"` // 本次禁言截止时间（单位: 秒）
+	OccurredAt int64  `json:"occurred_at"` // 单位: 秒
+}
@@ -0,0 +1,33 @@
+package event
+
+// TopicDelivery 送达漏斗事件，由push和logic上报，logic消费后按内容汇总计数
+const TopicDelivery = "delivery_event"
+
+// SystemContentIdPrefix 系统推送和广播的content_id前缀，只有系统推送参与漏斗统计
+const SystemContentIdPrefix = "content"
+
+// FunnelStage 送达漏斗阶段
+type FunnelStage string
+
+const (
+	FunnelStageTargeted        FunnelStage = "targeted"         // logic提交给push
+	FunnelStageSent            FunnelStage = "sent"             // push发送到在线连接
+	FunnelStageArchivedOffline FunnelStage = "archived_offline" // 接收者离线时归档
+	FunnelStageDelivered       FunnelStage = "delivered"        // 客户端送达回执
+	FunnelStageRead            FunnelStage = "read"             // 客户端已读回执
+)
+
+// Delivery 漏斗事件，push按内容汇总计数，回执事件每个用户一条
+type Delivery struct {
+	ContentId string      `json:"content_id"`
+	Stage     FunnelStage `json:"stage"`
+	Count     int64       `json:"count"`
+	UserId    string      `json:"user_id,omitempty"` // 回执用户，用于去重
+	Timestamp int64       `json:"timestamp"`         // 单位: 秒
+}
+
+// DeliveryBatch 一条Kafka消息携带的漏斗事件，event_id用于消费去重
+type DeliveryBatch struct {
+	EventId string      `json:"event_id"`
+	Events  []*Delivery `json:"events"`
+}
@@ -0,0 +1,279 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// 重试和死信消息的头部，原始主题和位置只在第一次失败时写入
+// 重试主题和死信队列由订阅同一主题的消费者组共用，HeaderConsumerGroup标记处理失败的组，其他组跳过这些消息
+const (
+	HeaderOriginalTopic     = "x-original-topic"
+	HeaderOriginalPartition = "x-original-partition"
+	HeaderOriginalOffset    = "x-original-offset"
+	HeaderConsumerGroup     = "x-consumer-group"
+	HeaderRetryCount        = "x-retry-count"
+	HeaderRetryAt           = "x-retry-at"  // 单位: 毫秒
+	HeaderFailedAt          = "x-failed-at" // 单位: 毫秒
+	HeaderError             = "x-error"
+	HeaderReplayed          = "x-replayed"
+
+	retryTopicInfix        = ".retry."
+	deadLetterSuffix       = ".dlq"
+	defaultMaxRetries      = 3
+	defaultInitialBackoff  = time.Second
+	defaultMaxBackoff      = 30 * time.Second
+	maxErrorHeaderLength   = 1024
+	retryProduceMaxRetries = 3
+)
+
+// RetryTopic 第attempt次重试的主题
+func RetryTopic(topic string, attempt int) string {
+	return topic + retryTopicInfix + strconv.Itoa(attempt)
+}
+
+// DeadLetterTopic 主题对应的死信队列
+func DeadLetterTopic(topic string) string {
+	return topic + deadLetterSuffix
+}
+
+// parseRetryTopic 从重试主题解析原始主题和重试次数，非重试主题返回0
+func parseRetryTopic(topic string) (string, int) {
+	index := strings.LastIndex(topic, retryTopicInfix)
+	if index < 0 {
+		return topic, 0
+	}
+	attempt, err := strconv.Atoi(topic[index+len(retryTopicInfix):])
+	if err != nil || attempt <= 0 {
+		return topic, 0
+	}
+	return topic[:index], attempt
+}
+
+// RetryPolicy 单个主题的重试策略，用于覆盖默认策略
+type RetryPolicy struct {
+	Topic             string
+	MaxRetries        int           // 最大重试次数，小于等于0表示不重试
+	InitialBackoff    time.Duration // 第一次重试的延迟，之后每次翻倍，为0时使用默认值
+	MaxBackoff        time.Duration // 重试延迟上限，为0时使用默认值
+	DisableDeadLetter bool          // 重试耗尽后直接丢弃，不投递到死信队列
+}
+
+// backoff 第attempt次重试的延迟，按指数增长并受上限约束
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.InitialBackoff
+	for i := 1; i < attempt && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, p.MaxBackoff)
+}
+
+// RetrierConfig 重试器配置
+type RetrierConfig struct {
+	Brokers  []string
+	GroupId  string
+	Policies []*RetryPolicy // 按主题覆盖默认策略，未订阅的主题忽略
+	// Ignored 匹配的错误视为已处理，不重试也不进入死信队列，例如重复消息
+	Ignored error
+	// NonRetryable 匹配的错误不重试，直接进入死信队列
+	NonRetryable error
+}
+
+// Retrier 把处理失败的消息投递到下一级重试主题或死信队列
+type Retrier struct {
+	producer     sarama.SyncProducer
+	groupId      string
+	ignored      error
+	nonRetryable error
+	policies     map[string]*RetryPolicy
+	log          *log.Helper
+}
+
+// NewRetrier 创建重试器，topics为消费者订阅的原始主题，未配置策略的主题使用默认策略
+func NewRetrier(c *RetrierConfig, logger log.Logger, topics ...string) (*Retrier, error) {
+	config := sarama.NewConfig()
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Retry.Max = retryProduceMaxRetries
+	config.Producer.Return.Successes = true
+	// 保持原始key的分区路由
+	config.Producer.Partitioner = sarama.NewHashPartitioner
+	producer, err := sarama.NewSyncProducer(c.Brokers, config)
+	if err != nil {
+		return nil, errors.Join(err, errors.New("failed to create retry producer"))
+	}
+	return NewRetrierWithProducer(producer, c, logger, topics...), nil
+}
+
+// NewRetrierWithProducer 使用已有的生产者创建重试器，Close时关闭生产者
+func NewRetrierWithProducer(producer sarama.SyncProducer, c *RetrierConfig, logger log.Logger, topics ...string) *Retrier {
+	retrier := &Retrier{
+		producer:     producer,
+		groupId:      c.GroupId,
+		ignored:      c.Ignored,
+		nonRetryable: c.NonRetryable,
+		policies:     make(map[string]*RetryPolicy, len(topics)),
+		log:          log.NewHelper(logger),
+	}
+	for _, topic := range topics {
+		retrier.policies[topic] = &RetryPolicy{
+			Topic:          topic,
+			MaxRetries:     defaultMaxRetries,
+			InitialBackoff: defaultInitialBackoff,
+			MaxBackoff:     defaultMaxBackoff,
+		}
+	}
+	for _, rc := range c.Policies {
+		policy, ok := retrier.policies[rc.Topic]
+		if !ok {
+			continue
+		}
+		policy.MaxRetries = max(rc.MaxRetries, 0)
+		if rc.InitialBackoff > 0 {
+			policy.InitialBackoff = rc.InitialBackoff
+		}
+		if rc.MaxBackoff > 0 {
+			policy.MaxBackoff = rc.MaxBackoff
+		}
+		policy.MaxBackoff = max(policy.MaxBackoff, policy.InitialBackoff)
+		policy.DisableDeadLetter = rc.DisableDeadLetter
+	}
+	return retrier
+}
+
+// Disable 主题处理失败时不重试也不进入死信队列，直接丢弃，Topics不再包含它的重试主题
+func (r *Retrier) Disable(topic string) {
+	r.policies[topic] = &RetryPolicy{Topic: topic, DisableDeadLetter: true}
+}
+
+// Topics 消费者需要订阅的全部主题，包括各级重试主题
+func (r *Retrier) Topics() []string {
+	topics := make([]string, 0, len(r.policies))
+	for topic, policy := range r.policies {
+		topics = append(topics, topic)
+		for attempt := 1; attempt <= policy.MaxRetries; attempt++ {
+			topics = append(topics, RetryTopic(topic, attempt))
+		}
+	}
+	return topics
+}
+
+// Owns 消息没有标记消费者组或标记的是当前组时返回true
+// 重试消息和从死信队列重放的消息只由处理失败的组再次处理，其他组应直接提交位移跳过
+func (r *Retrier) Owns(message *sarama.ConsumerMessage) bool {
+	group := header(message, HeaderConsumerGroup)
+	return group == "" || group == r.groupId
+}
+
+// Wait 重试消息等待到计划时间再处理，会话结束时返回false
+// 同一重试主题的延迟相同，分区内按顺序等待不会互相阻塞
+func (r *Retrier) Wait(ctx context.Context, message *sarama.ConsumerMessage) bool {
+	retryAt, err := strconv.ParseInt(header(message, HeaderRetryAt), 10, 64)
+	if err != nil {
+		return true
+	}
+	delay := time.Until(time.UnixMilli(retryAt))
+	if delay <= 0 {
+		return true
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Fail 处理失败的消息，不可重试的错误和重试耗尽的消息直接进入死信队列，只返回转发失败的错误
+func (r *Retrier) Fail(ctx context.Context, message *sarama.ConsumerMessage, cause error) error {
+	if r.ignored != nil && errors.Is(cause, r.ignored) {
+		return nil
+	}
+	topic, attempt := parseRetryTopic(message.Topic)
+	policy, ok := r.policies[topic]
+	if !ok {
+		return fmt.Errorf("no retry policy for topic %s", topic)
+	}
+	retryable := r.nonRetryable == nil || !errors.Is(cause, r.nonRetryable)
+	next := &sarama.ProducerMessage{
+		Key:     sarama.ByteEncoder(message.Key),
+		Value:   sarama.ByteEncoder(message.Value),
+		Headers: failureHeaders(message, r.groupId, cause),
+	}
+	if retryable && attempt < policy.MaxRetries {
+		retryAt := time.Now().Add(policy.backoff(attempt + 1))
+		next.Topic = RetryTopic(topic, attempt+1)
+		next.Headers = setHeader(next.Headers, HeaderRetryCount, strconv.Itoa(attempt+1))
+		next.Headers = setHeader(next.Headers, HeaderRetryAt, strconv.FormatInt(retryAt.UnixMilli(), 10))
+	} else if !policy.DisableDeadLetter {
+		next.Topic = DeadLetterTopic(topic)
+		next.Headers = setHeader(next.Headers, HeaderRetryCount, strconv.Itoa(attempt))
+	} else {
+		r.log.WithContext(ctx).Warnf("drop failed message. topic=%s, partition=%d, offset=%d, error=%v",
+			message.Topic, message.Partition, message.Offset, cause)
+		return nil
+	}
+	if _, _, err := r.producer.SendMessage(next); err != nil {
+		return errors.Join(err, fmt.Errorf("failed to send message to %s", next.Topic))
+	}
+	r.log.WithContext(ctx).Infof("Forward failed message. from=%s, to=%s, offset=%d, error=%v",
+		message.Topic, next.Topic, message.Offset, cause)
+	return nil
+}
+
+func (r *Retrier) Close() error {
+	if r.producer == nil {
+		return nil
+	}
+	return r.producer.Close()
+}
+
+// failureHeaders 保留原消息头部并写入失败信息，第一次失败时记录原始位置
+func failureHeaders(message *sarama.ConsumerMessage, groupId string, cause error) []sarama.RecordHeader {
+	headers := make([]sarama.RecordHeader, 0, len(message.Headers)+8)
+	for _, h := range message.Headers {
+		if h != nil {
+			headers = append(headers, *h)
+		}
+	}
+	if header(message, HeaderOriginalTopic) == "" {
+		headers = setHeader(headers, HeaderOriginalTopic, message.Topic)
+		headers = setHeader(headers, HeaderOriginalPartition, strconv.Itoa(int(message.Partition)))
+		headers = setHeader(headers, HeaderOriginalOffset, strconv.FormatInt(message.Offset, 10))
+	}
+	errMsg := cause.Error()
+	if len(errMsg) > maxErrorHeaderLength {
+		errMsg = errMsg[:maxErrorHeaderLength]
+	}
+	headers = setHeader(headers, HeaderConsumerGroup, groupId)
+	headers = setHeader(headers, HeaderFailedAt, strconv.FormatInt(time.Now().UnixMilli(), 10))
+	headers = setHeader(headers, HeaderError, errMsg)
+	return headers
+}
+
+func header(message *sarama.ConsumerMessage, key string) string {
+	for _, h := range message.Headers {
+		if h != nil && string(h.Key) == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func setHeader(headers []sarama.RecordHeader, key, value string) []sarama.RecordHeader {
+	for i := range headers {
+		if string(headers[i].Key) == key {
+			headers[i].Value = []byte(value)
+			return headers
+		}
+	}
+	return append(headers, sarama.RecordHeader{Key: []byte(key), Value: []byte(value)})
+}
@@ -40,16 +40,33 @@ func wireApp(bootstrap *conf.Bootstrap, logger log.Logger) (*kratos.App, func(),
 		return nil, nil, err
 	}
 	offlineRepo, cleanup3 := data.NewOfflineClient(bootstrap, logger, discovery)
-	push := biz.NewPush(logger, sessionRepo, messageRepo, accessNodeManager, offlineRepo)
-	consumer, cleanup4 := data.NewKafkaConsumer(bootstrap, logger)
+	deliveryEventRepo, cleanup4, err := data.NewKafkaProducer(bootstrap, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	pushMetrics, err := data.NewPushMetrics()
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	push, cleanup5 := biz.NewPush(logger, sessionRepo, messageRepo, accessNodeManager, offlineRepo, deliveryEventRepo, pushMetrics, bootstrap)
+	consumer, cleanup6 := data.NewKafkaConsumer(bootstrap, logger)
 	messageDedupRepo := data.NewMessageDedupRepo(dataData, logger)
-	userStateHandler, cleanup5 := biz.NewUserStateHandler(logger, offlineRepo, accessNodeManager, consumer, messageDedupRepo)
+	userStateHandler, cleanup7 := biz.NewUserStateHandler(logger, offlineRepo, accessNodeManager, consumer, messageDedupRepo, deliveryEventRepo)
 	pushService := service.NewPushService(push, logger, userStateHandler)
 	grpcServer := server.NewGRPCServer(bootstrap, pushService, logger)
 	httpServer := server.NewHTTPServer(bootstrap, pushService, logger)
 	registrar := data.NewRegistry(client)
 	app := newApp(logger, grpcServer, httpServer, registrar)
 	return app, func() {
+		cleanup7()
+		cleanup6()
 		cleanup5()
 		cleanup4()
 		cleanup3()
@@ -0,0 +1,20 @@
+package bo
+
+import "github.com/xinghe903/chatify/pkg/event"
+
+// SystemContentIdPrefix 系统推送和广播的content_id前缀，只有系统推送参与漏斗统计
+const SystemContentIdPrefix = event.SystemContentIdPrefix
+
+// FunnelStage 送达漏斗阶段，push只上报发送和离线归档两个阶段
+type FunnelStage = event.FunnelStage
+
+const (
+	FunnelStageSent            = event.FunnelStageSent
+	FunnelStageArchivedOffline = event.FunnelStageArchivedOffline
+)
+
+// DeliveryEvent 漏斗事件，与logic消费的格式一致
+type DeliveryEvent = event.Delivery
+
+// DeliveryEventBatch 一条Kafka消息携带的漏斗事件，event_id用于消费去重
+type DeliveryEventBatch = event.DeliveryBatch
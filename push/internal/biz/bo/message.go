@@ -15,6 +15,8 @@ const (
 	MessageStatusPending MessageStatus = "pending"
 	// MessageStatusSent 发送成功 - 消息已通过任一方式成功送达
 	MessageStatusSent MessageStatus = "sent"
+	// MessageStatusExpired 已过期 - 投递前已超过过期时间，不再发送
+	MessageStatusExpired MessageStatus = "expired"
 )
 
 // Message 消息业务对象
@@ -32,6 +34,12 @@ type Message struct {
 	Timestamp   int64         `json:"timestamp"`
 	ExpireTime  int64         `json:"expire_time"`
 	ContentID   string        `json:"content_id"`
+	GroupID     string        `json:"group_id"`
+	Seq         int64         `json:"seq"`
+	ContentType int32         `json:"content_type"` // 聊天消息的内容类型
+	Mentioned   bool          `json:"mentioned"`    // 接收者在群聊消息中被@
+	Silent      bool          `json:"silent"`       // 接收者开启了免打扰，客户端不提醒
+	Priority    int32         `json:"priority"`     // 投递优先级
 	TaskID      string        `json:"task_id"`
 	Status      MessageStatus `json:"status"`
 	Description string        `json:"description"`
@@ -50,6 +58,12 @@ func NewMessage(msg *im_v1.BaseMessage) *Message {
 		Timestamp:   msg.Timestamp,
 		ExpireTime:  msg.ExpireTime,
 		ContentID:   msg.ContentId,
+		GroupID:     msg.GroupId,
+		Seq:         msg.Seq,
+		ContentType: int32(msg.ContentType),
+		Mentioned:   msg.Mentioned,
+		Silent:      msg.Silent,
+		Priority:    int32(msg.Priority),
 	}
 }
 
@@ -64,5 +78,16 @@ func (m *Message) ToBaseMessage() *im_v1.BaseMessage {
 		Timestamp:   m.Timestamp,
 		ExpireTime:  m.ExpireTime,
 		ContentId:   m.ContentID,
+		GroupId:     m.GroupID,
+		Seq:         m.Seq,
+		ContentType: im_v1.ContentType(m.ContentType),
+		Mentioned:   m.Mentioned,
+		Silent:      m.Silent,
+		Priority:    im_v1.Priority(m.Priority),
 	}
 }
+
+// IsExpired 判断过期时间是否已到，expireTime为0表示永不过期
+func IsExpired(expireTime, now int64) bool {
+	return expireTime > 0 && expireTime <= now
+}
@@ -0,0 +1,66 @@
+package bo
+
+import im_v1 "github.com/xinghe903/chatify/api/im/v1"
+
+// 与logic的系统推送消息类型一致
+const (
+	MessageTypeNotice im_v1.MessageType = 100 // 公告消息
+	MessageTypeInform im_v1.MessageType = 101 // 通知消息
+)
+
+// Priority 推送队列优先级，数值越小优先级越高
+type Priority int
+
+const (
+	PriorityHigh Priority = iota
+	PriorityNormal
+	PriorityLow
+)
+
+// Priorities 全部优先级，按优先级从高到低排列
+var Priorities = []Priority{PriorityHigh, PriorityNormal, PriorityLow}
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityHigh:
+		return "high"
+	case PriorityNormal:
+		return "normal"
+	default:
+		return "low"
+	}
+}
+
+// PriorityOf 消息的推送优先级，未指定时按消息类型推断，兼容未设置优先级的调用方
+// 接收者免打扰或处于勿扰时段的静默消息不需要及时提醒，总是使用低优先级
+func PriorityOf(msg *im_v1.BaseMessage) Priority {
+	if msg.Silent {
+		return PriorityLow
+	}
+	switch msg.Priority {
+	case im_v1.Priority_PRIORITY_HIGH:
+		return PriorityHigh
+	case im_v1.Priority_PRIORITY_NORMAL:
+		return PriorityNormal
+	case im_v1.Priority_PRIORITY_LOW:
+		return PriorityLow
+	}
+	switch msg.MessageType {
+	case im_v1.MessageType_CHAT, im_v1.MessageType_CONTROL, MessageTypeInform:
+		return PriorityHigh
+	case MessageTypeNotice:
+		return PriorityLow
+	default:
+		return PriorityNormal
+	}
+}
+
+// GroupByPriority 按优先级拆分消息，保持每个优先级内的原有顺序
+func GroupByPriority(messages []*im_v1.BaseMessage) [][]*im_v1.BaseMessage {
+	groups := make([][]*im_v1.BaseMessage, len(Priorities))
+	for _, msg := range messages {
+		priority := PriorityOf(msg)
+		groups[priority] = append(groups[priority], msg)
+	}
+	return groups
+}
@@ -0,0 +1,245 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/xinghe903/chatify/push/internal/biz/bo"
+	"github.com/xinghe903/chatify/push/internal/conf"
+
+	im_v1 "github.com/xinghe903/chatify/api/im/v1"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// ErrQueueBusy 队列已满且等待入队超时
+var ErrQueueBusy = errors.New("push queue busy")
+
+// 推送队列的默认配置
+const (
+	defaultLaneQueueSize      = 1024
+	defaultSharedWorkers      = 4
+	defaultLaneEnqueueTimeout = time.Second
+)
+
+// 各优先级默认的独立worker数和共享worker调度权重
+var (
+	defaultLaneWorkers = []int{8, 4, 2}
+	defaultLaneWeights = []int{6, 3, 1}
+)
+
+// PushMetrics 推送队列指标
+type PushMetrics interface {
+	// ObserveQueueWait 记录任务从入队到开始处理的等待时间
+	ObserveQueueWait(ctx context.Context, priority bo.Priority, d time.Duration)
+	// ObserveDelivery 记录一次推送请求从收到到处理完成的耗时和消息数
+	ObserveDelivery(ctx context.Context, priority bo.Priority, d time.Duration, messages int)
+}
+
+// pushJob 一次推送请求中同一优先级的消息
+type pushJob struct {
+	ctx        context.Context
+	taskID     string
+	messages   []*im_v1.BaseMessage
+	priority   bo.Priority
+	enqueuedAt time.Time
+	done       chan error
+}
+
+type pushLane struct {
+	queue   chan *pushJob
+	slots   chan struct{} // 入队前预留的队列位置，任务出队时释放，保证预留成功后入队不会阻塞
+	workers int
+	weight  int
+	current int // 平滑加权轮询的当前权重
+}
+
+// pushLanes 按优先级分队列处理推送任务
+// 每个队列有只处理本队列的worker，保证批量推送积压时高优先级仍有处理能力；
+// 共享worker按平滑加权轮询从有积压的队列中取任务
+type pushLanes struct {
+	log            *log.Helper
+	lanes          []*pushLane
+	sharedWorkers  int
+	enqueueTimeout time.Duration
+	metrics        PushMetrics
+	handle         func(ctx context.Context, taskID string, messages []*im_v1.BaseMessage) error
+	mu             sync.Mutex
+}
+
+func newPushLanes(
+	c *conf.PushLanes,
+	logger *log.Helper,
+	metrics PushMetrics,
+	handle func(ctx context.Context, taskID string, messages []*im_v1.BaseMessage) error,
+) *pushLanes {
+	l := &pushLanes{
+		log:            logger,
+		sharedWorkers:  defaultSharedWorkers,
+		enqueueTimeout: defaultLaneEnqueueTimeout,
+		metrics:        metrics,
+		handle:         handle,
+	}
+	if c.GetSharedWorkers() > 0 {
+		l.sharedWorkers = int(c.GetSharedWorkers())
+	}
+	if c.GetEnqueueTimeout() != nil {
+		l.enqueueTimeout = c.GetEnqueueTimeout().AsDuration()
+	}
+	for _, priority := range bo.Priorities {
+		var lc *conf.PushLanes_Lane
+		switch priority {
+		case bo.PriorityHigh:
+			lc = c.GetHigh()
+		case bo.PriorityNormal:
+			lc = c.GetNormal()
+		default:
+			lc = c.GetLow()
+		}
+		lane := &pushLane{workers: defaultLaneWorkers[priority], weight: defaultLaneWeights[priority]}
+		queueSize := defaultLaneQueueSize
+		if lc.GetQueueSize() > 0 {
+			queueSize = int(lc.GetQueueSize())
+		}
+		if lc.GetWorkers() > 0 {
+			lane.workers = int(lc.GetWorkers())
+		}
+		if lc.GetWeight() > 0 {
+			lane.weight = int(lc.GetWeight())
+		}
+		lane.queue = make(chan *pushJob, queueSize)
+		lane.slots = make(chan struct{}, queueSize)
+		l.lanes = append(l.lanes, lane)
+	}
+	return l
+}
+
+// start 启动各队列的独立worker和共享worker
+func (l *pushLanes) start(ctx context.Context) {
+	for _, lane := range l.lanes {
+		for range lane.workers {
+			go l.laneWorker(ctx, lane)
+		}
+	}
+	for range l.sharedWorkers {
+		go l.sharedWorker(ctx)
+	}
+}
+
+// submit 先为一次推送请求的各优先级任务预留队列位置，全部预留成功后再一起入队
+// 任一队列已满且在enqueueTimeout内没有空位时释放已预留的位置，所有任务都不入队
+func (l *pushLanes) submit(ctx context.Context, jobs []*pushJob) error {
+	timer := time.NewTimer(l.enqueueTimeout)
+	defer timer.Stop()
+	for i, job := range jobs {
+		if err := l.reserve(ctx, l.lanes[job.priority], timer.C); err != nil {
+			for _, reserved := range jobs[:i] {
+				<-l.lanes[reserved.priority].slots
+			}
+			return fmt.Errorf("%w. priority=%s", err, job.priority)
+		}
+	}
+	now := time.Now()
+	for _, job := range jobs {
+		job.enqueuedAt = now
+		job.done = make(chan error, 1)
+		l.lanes[job.priority].queue <- job
+	}
+	return nil
+}
+
+// reserve 预留一个队列位置，队列已满时等待到timeout
+func (l *pushLanes) reserve(ctx context.Context, lane *pushLane, timeout <-chan time.Time) error {
+	select {
+	case lane.slots <- struct{}{}:
+		return nil
+	default:
+	}
+	select {
+	case lane.slots <- struct{}{}:
+		return nil
+	case <-timeout:
+		return ErrQueueBusy
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *pushLanes) laneWorker(ctx context.Context, lane *pushLane) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-lane.queue:
+			l.run(job)
+		}
+	}
+}
+
+func (l *pushLanes) sharedWorker(ctx context.Context) {
+	high, normal, low := l.lanes[bo.PriorityHigh], l.lanes[bo.PriorityNormal], l.lanes[bo.PriorityLow]
+	for {
+		if job := l.next(); job != nil {
+			l.run(job)
+			continue
+		}
+		// 所有队列都为空时等待任意队列的新任务
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-high.queue:
+			l.run(job)
+		case job := <-normal.queue:
+			l.run(job)
+		case job := <-low.queue:
+			l.run(job)
+		}
+	}
+}
+
+// next 按平滑加权轮询选择有积压的队列并取出一个任务，没有积压时返回nil
+func (l *pushLanes) next() *pushJob {
+	l.mu.Lock()
+	var selected *pushLane
+	total := 0
+	for _, lane := range l.lanes {
+		if len(lane.queue) == 0 {
+			continue
+		}
+		lane.current += lane.weight
+		total += lane.weight
+		if selected == nil || lane.current > selected.current {
+			selected = lane
+		}
+	}
+	if selected != nil {
+		selected.current -= total
+	}
+	l.mu.Unlock()
+	if selected == nil {
+		return nil
+	}
+	select {
+	case job := <-selected.queue:
+		return job
+	default:
+		// 任务已被该队列的独立worker取走
+		return nil
+	}
+}
+
+// run 处理一个任务，调用方已放弃等待的任务不再处理
+func (l *pushLanes) run(job *pushJob) {
+	<-l.lanes[job.priority].slots
+	if err := job.ctx.Err(); err != nil {
+		l.log.WithContext(job.ctx).Warnf("drop push job canceled in queue. taskID=%s, priority=%s, messageCount=%d",
+			job.taskID, job.priority, len(job.messages))
+		job.done <- err
+		return
+	}
+	l.metrics.ObserveQueueWait(job.ctx, job.priority, time.Since(job.enqueuedAt))
+	job.done <- l.handle(job.ctx, job.taskID, job.messages)
+}
@@ -3,9 +3,11 @@ package biz
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/xinghe903/chatify/push/internal/biz/bo"
+	"github.com/xinghe903/chatify/push/internal/conf"
 
 	access_v1 "github.com/xinghe903/chatify/api/access/v1"
 	im_v1 "github.com/xinghe903/chatify/api/im/v1"
@@ -30,6 +32,7 @@ var (
 	ErrPendingSessionStatus = errors.New("session status error")
 	ErrPendingUserOffline   = errors.New("user offline")
 	ErrPendingUserInvalid   = errors.New("user invalid")
+	ErrPendingExpired       = errors.New("message expired")
 )
 
 type SessionRepo interface {
@@ -52,6 +55,11 @@ type OfflineRepo interface {
 	AcknowledgeMessages(ctx context.Context, userId string, messageIds []string) error
 }
 
+// DeliveryEventRepo 送达漏斗事件上报接口
+type DeliveryEventRepo interface {
+	SendDeliveryEvents(ctx context.Context, events []*bo.DeliveryEvent) error
+}
+
 type AccessNodeManager interface {
 	SendToUser(ctx context.Context, connectId string, messages []*im_v1.BaseMessage) ([]string, error)
 	Close() error
@@ -63,29 +71,74 @@ type Push struct {
 	messageRepo MessageRepo
 	manager     AccessNodeManager
 	offlineRepo OfflineRepo
+	eventRepo   DeliveryEventRepo
+	metrics     PushMetrics
+	lanes       *pushLanes
 }
 
-// NewPush
+// NewPush 创建推送业务实例，并启动按优先级划分的推送队列
 func NewPush(logger log.Logger,
 	session SessionRepo,
 	message MessageRepo,
 	manager AccessNodeManager,
 	offline OfflineRepo,
-) *Push {
-	return &Push{
+	eventRepo DeliveryEventRepo,
+	metrics PushMetrics,
+	c *conf.Bootstrap,
+) (*Push, func()) {
+	p := &Push{
 		log:         log.NewHelper(logger),
 		session:     session,
 		messageRepo: message,
 		manager:     manager,
 		offlineRepo: offline,
+		eventRepo:   eventRepo,
+		metrics:     metrics,
 	}
+	p.lanes = newPushLanes(c.GetPushLanes(), p.log, metrics, p.deliver)
+	ctx, cancel := context.WithCancelCause(context.TODO())
+	p.lanes.start(ctx)
+	return p, func() { cancel(errors.New("push lanes context canceled")) }
 }
 
 // PushToUser 推送消息到用户
+// 消息按优先级拆分后进入对应的队列，等待全部处理完成后返回
 func (p *Push) PushToUser(ctx context.Context, taskID string, messages []*im_v1.BaseMessage) error {
 	if len(messages) > bo.MaxMessageCount {
 		return v1.ErrorTooManyMessages("message count=%d, but max is %d", len(messages), bo.MaxMessageCount)
 	}
+	start := time.Now()
+	jobs := make([]*pushJob, 0, len(bo.Priorities))
+	for priority, group := range bo.GroupByPriority(messages) {
+		if len(group) == 0 {
+			continue
+		}
+		jobs = append(jobs, &pushJob{ctx: ctx, taskID: taskID, messages: group, priority: bo.Priority(priority)})
+	}
+	// 各优先级的任务一起入队，任一队列繁忙时都不入队，避免部分消息已推送时返回繁忙导致调用方重复推送
+	if err := p.lanes.submit(ctx, jobs); err != nil {
+		p.log.WithContext(ctx).Errorf("failed to enqueue push jobs. taskID=%s, error=%v", taskID, err)
+		return v1.ErrorPushQueueBusy("push queue is busy: %v", err)
+	}
+	var firstErr error
+	for _, job := range jobs {
+		var err error
+		select {
+		case err = <-job.done:
+		case <-ctx.Done():
+			// 已开始处理的任务会继续执行完成
+			return ctx.Err()
+		}
+		p.metrics.ObserveDelivery(ctx, job.priority, time.Since(start), len(job.messages))
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// deliver 保存、投递和归档一个队列任务中的消息
+func (p *Push) deliver(ctx context.Context, taskID string, messages []*im_v1.BaseMessage) error {
 	// 创建数据库消息
 	if err := p.saveMessages(ctx, taskID, messages); err != nil {
 		p.log.WithContext(ctx).Errorf("failed to save messages. taskID=%s, error=%s", taskID, err.Error())
@@ -110,7 +163,8 @@ func (p *Push) PushToUser(ctx context.Context, taskID string, messages []*im_v1.
 		p.log.WithContext(ctx).Errorf("failed to update message status. taskID=%s, error=%s", taskID, err.Error())
 		return v1.ErrorUpdateMessageStatusFailed("data update message status failed")
 	}
-	p.log.WithContext(ctx).Debugf("PushToUser completed. taskID=%s, messageCount=%d, successCount=%d", taskID, len(messages), len(successMsgIDs))
+	p.reportDeliveryEvents(ctx, msgSendMask, messages)
+	p.log.WithContext(ctx).Debugf("Push job completed. taskID=%s, messageCount=%d, successCount=%d", taskID, len(messages), len(successMsgIDs))
 	return nil
 }
 
@@ -146,8 +200,15 @@ func (p *Push) groupMessageByConnectId(ctx context.Context,
 ) map[string]*access_v1.PushMessageRequest {
 	// 创建一个map，用于按access服务实例ID进行分组
 	accessMessageGroups := make(map[string]*access_v1.PushMessageRequest)
+	now := time.Now().Unix()
 	// 遍历所有消息，进行分组处理
 	for _, msg := range messages {
+		// 过期消息不再发送，也不归档为离线消息
+		if bo.IsExpired(msg.ExpireTime, now) {
+			msgSendMask[msg.MsgId] = ErrPendingExpired
+			p.log.WithContext(ctx).Debugf("ignore expired message msg_id=%s, expire_time=%d", msg.MsgId, msg.ExpireTime)
+			continue
+		}
 		// 忽略空的用户ID
 		if msg.ToUserId == "" {
 			msgSendMask[msg.MsgId] = ErrPendingUserInvalid
@@ -220,7 +281,8 @@ func (p *Push) archiveOfflineMessages(ctx context.Context,
 	}
 	offlineMsgIds := make([]string, 0, len(messages))
 	for id, e := range msgSendMask {
-		if errors.Is(e, ErrPendingUserOffline) {
+		// 瞬时消息（如正在输入）离线后没有意义，不做归档
+		if errors.Is(e, ErrPendingUserOffline) && !id2Msg[id].Transient {
 			offlineMsgIds = append(offlineMsgIds, id)
 		}
 	}
@@ -234,6 +296,12 @@ func (p *Push) archiveOfflineMessages(ctx context.Context,
 			MsgID:       id,
 			Content:     msg.Content,
 			ContentID:   msg.ContentId,
+			GroupID:     msg.GroupId,
+			Seq:         msg.Seq,
+			ContentType: int32(msg.ContentType),
+			Mentioned:   msg.Mentioned,
+			Silent:      msg.Silent,
+			Priority:    int32(msg.Priority),
 			TaskID:      taskID,
 			Timestamp:   msg.Timestamp,
 			ExpireTime:  msg.ExpireTime,
@@ -264,6 +332,9 @@ func (p *Push) updateMessageStatus(ctx context.Context, msgSendMask map[string]e
 			msg.Status = bo.MessageStatusPending
 			msg.Description = reason.Error()
 		}
+		if errors.Is(reason, ErrPendingExpired) {
+			msg.Status = bo.MessageStatusExpired
+		}
 		boMessages = append(boMessages, msg)
 	}
 	if err := p.messageRepo.UpdateMessageStatus(ctx, boMessages); err != nil {
@@ -271,3 +342,39 @@ func (p *Push) updateMessageStatus(ctx context.Context, msgSendMask map[string]e
 	}
 	return nil
 }
+
+// reportDeliveryEvents 按内容汇总发送成功和离线归档的消息数，上报送达漏斗
+func (p *Push) reportDeliveryEvents(ctx context.Context, msgSendMask map[string]error, messages []*im_v1.BaseMessage) {
+	sent := make(map[string]int64)
+	archived := make(map[string]int64)
+	for _, msg := range messages {
+		if !strings.HasPrefix(msg.ContentId, bo.SystemContentIdPrefix) {
+			continue
+		}
+		e := msgSendMask[msg.MsgId]
+		if e == nil {
+			sent[msg.ContentId]++
+		} else if errors.Is(e, ErrPendingUserOffline) && !msg.Transient {
+			archived[msg.ContentId]++
+		}
+	}
+	now := time.Now().Unix()
+	events := make([]*bo.DeliveryEvent, 0, len(sent)+len(archived))
+	for contentId, count := range sent {
+		events = append(events, &bo.DeliveryEvent{ContentId: contentId, Stage: bo.FunnelStageSent, Count: count, Timestamp: now})
+	}
+	for contentId, count := range archived {
+		events = append(events, &bo.DeliveryEvent{ContentId: contentId, Stage: bo.FunnelStageArchivedOffline, Count: count, Timestamp: now})
+	}
+	sendDeliveryEvents(ctx, p.eventRepo, p.log, events)
+}
+
+// sendDeliveryEvents 发送漏斗事件，统计数据丢失不影响消息投递，失败时只记录日志
+func sendDeliveryEvents(ctx context.Context, repo DeliveryEventRepo, logger *log.Helper, events []*bo.DeliveryEvent) {
+	if len(events) == 0 {
+		return
+	}
+	if err := repo.SendDeliveryEvents(ctx, events); err != nil {
+		logger.WithContext(ctx).Warnf("failed to send delivery events. size=%d, error=%v", len(events), err)
+	}
+}
@@ -0,0 +1,58 @@
+package biz
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/xinghe903/chatify/push/internal/biz/bo"
+
+	im_v1 "github.com/xinghe903/chatify/api/im/v1"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/proto"
+)
+
+// archivingOfflineRepo 记录归档的离线消息
+type archivingOfflineRepo struct {
+	OfflineRepo
+	archived []*bo.Message
+}
+
+func (r *archivingOfflineRepo) ArchiveMessages(_ context.Context, _ string, messages []*bo.Message) error {
+	r.archived = append(r.archived, messages...)
+	return nil
+}
+
+// TestArchiveOfflineMessagesKeepsDeliveryFields 归档的离线消息还原后与原消息的下发字段一致
+func TestArchiveOfflineMessagesKeepsDeliveryFields(t *testing.T) {
+	repo := &archivingOfflineRepo{}
+	p := &Push{log: log.NewHelper(log.NewStdLogger(io.Discard)), offlineRepo: repo}
+	msg := &im_v1.BaseMessage{
+		MsgId:       "m1",
+		MessageType: im_v1.MessageType_CHAT,
+		FromUserId:  "alice",
+		TargetType:  im_v1.TargetType_GROUP,
+		ToUserId:    "bob",
+		Content:     []byte(`{"image":{"attachment_id":"a1"}}`),
+		Timestamp:   1000,
+		ExpireTime:  2000,
+		ContentId:   "c1",
+		GroupId:     "g1",
+		Seq:         7,
+		ContentType: im_v1.ContentType_IMAGE,
+		Mentioned:   true,
+		Silent:      true,
+		Priority:    im_v1.Priority_PRIORITY_LOW,
+	}
+	mask := map[string]error{msg.MsgId: ErrPendingUserOffline}
+	if err := p.archiveOfflineMessages(context.Background(), "t1", mask, []*im_v1.BaseMessage{msg}); err != nil {
+		t.Fatalf("archiveOfflineMessages() error = %v", err)
+	}
+	if len(repo.archived) != 1 {
+		t.Fatalf("archived %d messages, want 1", len(repo.archived))
+	}
+	if got := repo.archived[0].ToBaseMessage(); !proto.Equal(got, msg) {
+		t.Fatalf("archived message = %v, want %v", got, msg)
+	}
+}
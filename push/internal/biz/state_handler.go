@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/xinghe903/chatify/push/internal/biz/bo"
 
@@ -16,6 +18,8 @@ import (
 var (
 	ErrUserStateInvalid = errors.New("user state invalid")
 	ErrMessageDuplicate = errors.New("message already processed")
+	// ErrNonRetryable 消息格式或内容错误，不需要重试
+	ErrNonRetryable = errors.New("non-retryable message")
 )
 
 type MessageHandler func(ctx context.Context, key string, value []byte) error
@@ -37,6 +41,7 @@ type UserStateHandler struct {
 	manager     AccessNodeManager
 	consumer    Consumer
 	dedupRepo   MessageDedupRepo
+	eventRepo   DeliveryEventRepo
 }
 
 func NewUserStateHandler(
@@ -45,6 +50,7 @@ func NewUserStateHandler(
 	manager AccessNodeManager,
 	consumer Consumer,
 	dedupRepo MessageDedupRepo,
+	eventRepo DeliveryEventRepo,
 ) (*UserStateHandler, func()) {
 	handle := &UserStateHandler{
 		log:         log.NewHelper(logger),
@@ -52,6 +58,7 @@ func NewUserStateHandler(
 		manager:     manager,
 		consumer:    consumer,
 		dedupRepo:   dedupRepo,
+		eventRepo:   eventRepo,
 	}
 	ctx, cancel := context.WithCancelCause(context.TODO())
 	handle.consumer.Start(ctx, handle.Handle())
@@ -63,7 +70,7 @@ func (h *UserStateHandler) Handle() MessageHandler {
 		var userState bo.UserStateMessage
 		if err := json.Unmarshal(value, &userState); err != nil {
 			h.log.WithContext(ctx).Errorf("consumer kafka message json unmarshal error: %v", err)
-			return err
+			return errors.Join(ErrNonRetryable, err)
 		}
 
 		// 处理消息
@@ -73,13 +80,14 @@ func (h *UserStateHandler) Handle() MessageHandler {
 		case bo.UserStateOffline:
 			return h.UserOffline(ctx, &userState)
 		default:
-			return ErrUserStateInvalid
+			return errors.Join(ErrNonRetryable, ErrUserStateInvalid)
 		}
 	}
 }
 
 func (h *UserStateHandler) UserOnline(ctx context.Context, userState *bo.UserStateMessage) error {
 	msgSize := 0
+	lastId := ""
 	var messagesToSend []*im_v1.BaseMessage
 	fn := func(latestId string) error {
 		messages, err := h.offlineRepo.RetrieveOfflineMessages(ctx, userState.UserID, latestId)
@@ -88,9 +96,20 @@ func (h *UserStateHandler) UserOnline(ctx context.Context, userState *bo.UserSta
 			return offline_v1.ErrorGetOfflineMessageFailed("data get offline message failed")
 		}
 		msgSize = len(messages)
+		if msgSize > 0 {
+			lastId = messages[msgSize-1].MsgID
+		}
+		now := time.Now().Unix()
 		for _, message := range messages {
+			// 离线服务已排除过期消息，这里再次检查查询之后刚过期的消息
+			if bo.IsExpired(message.ExpireTime, now) {
+				continue
+			}
 			messagesToSend = append(messagesToSend, message.ToBaseMessage())
 		}
+		if len(messagesToSend) == 0 {
+			return nil
+		}
 		successIds, err := h.manager.SendToUser(ctx, userState.ConnectionId, messagesToSend)
 		if err != nil {
 			h.log.WithContext(ctx).Errorf("failed to send message to access node. userID=%s, error=%s",
@@ -102,6 +121,7 @@ func (h *UserStateHandler) UserOnline(ctx context.Context, userState *bo.UserSta
 				userState.UserID, err.Error())
 			return offline_v1.ErrorMarkMessageAsDeliveredFailed("data acknowledge message failed")
 		}
+		h.reportSent(ctx, messagesToSend, successIds)
 		return nil
 	}
 	latestId := ""
@@ -113,12 +133,32 @@ func (h *UserStateHandler) UserOnline(ctx context.Context, userState *bo.UserSta
 		if msgSize != bo.MaxMessageCount {
 			break
 		}
-		latestId = messagesToSend[len(messagesToSend)-1].MsgId
+		latestId = lastId
 		messagesToSend = []*im_v1.BaseMessage{}
 	}
 	return nil
 }
 
+// reportSent 按内容汇总补发成功的离线消息数，上报送达漏斗
+func (h *UserStateHandler) reportSent(ctx context.Context, messages []*im_v1.BaseMessage, successIds []string) {
+	success := make(map[string]struct{}, len(successIds))
+	for _, id := range successIds {
+		success[id] = struct{}{}
+	}
+	sent := make(map[string]int64)
+	for _, msg := range messages {
+		if _, ok := success[msg.MsgId]; ok && strings.HasPrefix(msg.ContentId, bo.SystemContentIdPrefix) {
+			sent[msg.ContentId]++
+		}
+	}
+	now := time.Now().Unix()
+	events := make([]*bo.DeliveryEvent, 0, len(sent))
+	for contentId, count := range sent {
+		events = append(events, &bo.DeliveryEvent{ContentId: contentId, Stage: bo.FunnelStageSent, Count: count, Timestamp: now})
+	}
+	sendDeliveryEvents(ctx, h.eventRepo, h.log, events)
+}
+
 func (h *UserStateHandler) UserOffline(ctx context.Context, userState *bo.UserStateMessage) error {
 	return nil
 }
@@ -30,6 +30,8 @@ var ProviderSet = wire.NewSet(
 	NewOfflineClient,
 	NewKafkaConsumer,
 	NewMessageDedupRepo,
+	NewKafkaProducer,
+	NewPushMetrics,
 )
 
 // Data 数据层主结构
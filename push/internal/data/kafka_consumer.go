@@ -7,6 +7,8 @@ import (
 	"github.com/xinghe903/chatify/push/internal/biz"
 	"github.com/xinghe903/chatify/push/internal/conf"
 
+	"github.com/xinghe903/chatify/pkg/kafka"
+
 	"github.com/go-kratos/kratos/v2/log"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
@@ -22,6 +24,7 @@ var _ biz.Consumer = (*kafkaConsumer)(nil)
 
 type kafkaConsumer struct {
 	consumerGroup sarama.ConsumerGroup
+	retrier       *kafka.Retrier
 	log           *log.Helper
 	topics        []string
 }
@@ -44,15 +47,23 @@ func NewKafkaConsumer(c *conf.Bootstrap, logger log.Logger) (biz.Consumer, func(
 	if err != nil {
 		panic("创建消费者组失败" + err.Error())
 	}
+	retrier, err := newRetrier(kconf, kconf.GroupId, logger, KafkaTopicUserState)
+	if err != nil {
+		panic("创建重试生产者失败" + err.Error())
+	}
 	cleanup := func() {
 		if err := consumerGroup.Close(); err != nil {
 			logg.Errorf("关闭消费者组失败: %v", err)
 		}
+		if err := retrier.Close(); err != nil {
+			logg.Errorf("关闭重试生产者失败: %v", err)
+		}
 	}
 	return &kafkaConsumer{
 		consumerGroup: consumerGroup,
+		retrier:       retrier,
 		log:           logg,
-		topics:        []string{KafkaTopicUserState},
+		topics:        retrier.Topics(),
 	}, cleanup
 }
 
@@ -62,6 +73,7 @@ func (k *kafkaConsumer) Start(ctx context.Context, handler biz.MessageHandler) {
 	go func() {
 		handler := consumerGroupHandler{
 			handler: handler,
+			retrier: k.retrier,
 			log:     k.log,
 		}
 		for {
@@ -85,6 +97,7 @@ func (k *kafkaConsumer) Start(ctx context.Context, handler biz.MessageHandler) {
 type consumerGroupHandler struct {
 	log     *log.Helper
 	handler biz.MessageHandler
+	retrier *kafka.Retrier
 }
 
 // Setup 在消费者加入组后、开始消费前调用
@@ -98,9 +111,18 @@ func (h consumerGroupHandler) Cleanup(_ sarama.ConsumerGroupSession) error {
 }
 
 // ConsumeClaim 处理每个分区的消息
+// 失败的消息转发到重试主题或死信队列后才提交位移
 func (h consumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
 	ctx := context.Background()
 	for message := range claim.Messages() {
+		if !h.retrier.Owns(message) {
+			// 其他消费者组的重试或重放消息
+			session.MarkMessage(message, "")
+			continue
+		}
+		if !h.retrier.Wait(session.Context(), message) {
+			return nil
+		}
 		// 创建 trace 上下文
 		carrier := make(propagation.HeaderCarrier)
 		for _, h := range message.Headers {
@@ -122,6 +144,11 @@ func (h consumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession,
 		)
 		if err := h.handler(ctxWithTrace, string(message.Key), message.Value); err != nil {
 			h.log.WithContext(ctxWithTrace).Errorf("处理消息失败: %v", err)
+			if ferr := h.retrier.Fail(ctxWithTrace, message, err); ferr != nil {
+				h.log.WithContext(ctxWithTrace).Errorf("转发失败消息失败: %v", ferr)
+				span.End()
+				return ferr
+			}
 		}
 		// 手动提交位移（可选，也可以设置自动提交）
 		session.MarkMessage(message, "")
@@ -129,3 +156,24 @@ func (h consumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession,
 	}
 	return nil
 }
+
+// newRetrier 按配置创建失败消息重试器，重复消息视为已处理，不可重试的错误直接进入死信队列
+func newRetrier(kconf *conf.Data_Kafka, groupId string, logger log.Logger, topics ...string) (*kafka.Retrier, error) {
+	policies := make([]*kafka.RetryPolicy, 0, len(kconf.GetConsumerRetries()))
+	for _, rc := range kconf.GetConsumerRetries() {
+		policies = append(policies, &kafka.RetryPolicy{
+			Topic:             rc.Topic,
+			MaxRetries:        int(rc.MaxRetries),
+			InitialBackoff:    rc.GetInitialBackoff().AsDuration(),
+			MaxBackoff:        rc.GetMaxBackoff().AsDuration(),
+			DisableDeadLetter: rc.DisableDeadLetter,
+		})
+	}
+	return kafka.NewRetrier(&kafka.RetrierConfig{
+		Brokers:      kconf.Brokers,
+		GroupId:      groupId,
+		Policies:     policies,
+		Ignored:      biz.ErrMessageDuplicate,
+		NonRetryable: biz.ErrNonRetryable,
+	}, logger, topics...)
+}
@@ -0,0 +1,93 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/xinghe903/chatify/push/internal/biz"
+	"github.com/xinghe903/chatify/push/internal/biz/bo"
+	"github.com/xinghe903/chatify/push/internal/conf"
+
+	"github.com/xinghe903/chatify/pkg/auth"
+	"github.com/xinghe903/chatify/pkg/event"
+
+	"github.com/IBM/sarama"
+	"github.com/go-kratos/kratos/v2/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const (
+	KafkaTopicDeliveryEvent = event.TopicDelivery
+)
+
+var _ biz.DeliveryEventRepo = (*kafkaProducer)(nil)
+
+// kafkaProducer 发送送达漏斗事件
+type kafkaProducer struct {
+	producer  sarama.SyncProducer
+	log       *log.Helper
+	sonyFlake *auth.Sonyflake
+}
+
+// NewKafkaProducer 创建漏斗事件生产者
+func NewKafkaProducer(c *conf.Bootstrap, logger log.Logger) (biz.DeliveryEventRepo, func(), error) {
+	kconf := c.Data.Kafka
+	config := sarama.NewConfig()
+	config.Producer.RequiredAcks = sarama.WaitForLocal
+	config.Producer.Retry.Max = int(kconf.RetryCount)
+	config.Producer.Return.Successes = true
+	config.Producer.Partitioner = sarama.NewHashPartitioner
+	if kconf.Timeout != nil {
+		config.Producer.Timeout = kconf.Timeout.AsDuration()
+	}
+	producer, err := sarama.NewSyncProducer(kconf.Brokers, config)
+	if err != nil {
+		return nil, nil, errors.Join(err, errors.New("failed to create kafka producer"))
+	}
+	p := &kafkaProducer{
+		producer:  producer,
+		log:       log.NewHelper(logger),
+		sonyFlake: auth.NewSonyflake(),
+	}
+	cleanup := func() {
+		if err := producer.Close(); err != nil {
+			p.log.Errorf("failed to close kafka producer: %v", err)
+		}
+	}
+	return p, cleanup, nil
+}
+
+// SendDeliveryEvents 把漏斗事件打包为一条消息发送到Kafka
+func (p *kafkaProducer) SendDeliveryEvents(ctx context.Context, events []*bo.DeliveryEvent) error {
+	eventId, err := p.sonyFlake.GenerateBase62()
+	if err != nil {
+		return fmt.Errorf("generate event ID error: %w", err)
+	}
+	data, err := json.Marshal(&bo.DeliveryEventBatch{EventId: eventId, Events: events})
+	if err != nil {
+		return fmt.Errorf("marshal delivery events error: %w", err)
+	}
+	// 注入链路追踪信息
+	headers := make([]sarama.RecordHeader, 0)
+	carrier := make(propagation.HeaderCarrier)
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	for k, v := range carrier {
+		if len(v) > 0 {
+			headers = append(headers, sarama.RecordHeader{Key: []byte(k), Value: []byte(v[0])})
+		}
+	}
+	message := &sarama.ProducerMessage{
+		Topic: KafkaTopicDeliveryEvent,
+		// 同一内容的事件写入同一分区
+		Key:     sarama.StringEncoder(events[0].ContentId),
+		Value:   sarama.ByteEncoder(data),
+		Headers: headers,
+	}
+	if _, _, err = p.producer.SendMessage(message); err != nil {
+		return errors.Join(err, errors.New("failed to send delivery events"))
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/xinghe903/chatify/push/internal/biz"
+	"github.com/xinghe903/chatify/push/internal/biz/bo"
+
+	"github.com/xinghe903/chatify/pkg/monitoring"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var _ biz.PushMetrics = (*pushMetrics)(nil)
+
+// pushMetrics 按优先级统计推送队列的等待时间和处理耗时
+type pushMetrics struct {
+	queueWait metric.Float64Histogram
+	delivery  metric.Float64Histogram
+	messages  metric.Int64Counter
+}
+
+// NewPushMetrics 创建推送队列指标，未初始化Prometheus时使用全局的MeterProvider
+func NewPushMetrics() (biz.PushMetrics, error) {
+	meter := monitoring.Meter
+	if meter == nil {
+		meter = otel.Meter("push")
+	}
+	queueWait, err := meter.Float64Histogram("push_queue_wait_seconds",
+		metric.WithUnit("s"),
+		metric.WithDescription("Time a push job waits in its priority queue before processing."),
+		metric.WithExplicitBucketBoundaries(0.001, 0.005, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5))
+	if err != nil {
+		return nil, err
+	}
+	delivery, err := meter.Float64Histogram("push_delivery_seconds",
+		metric.WithUnit("s"),
+		metric.WithDescription("Time from receiving a push request to finishing its messages of one priority."),
+		metric.WithExplicitBucketBoundaries(0.005, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10))
+	if err != nil {
+		return nil, err
+	}
+	messages, err := meter.Int64Counter("push_messages_total",
+		metric.WithDescription("Messages processed by the push queues."))
+	if err != nil {
+		return nil, err
+	}
+	return &pushMetrics{queueWait: queueWait, delivery: delivery, messages: messages}, nil
+}
+
+// ObserveQueueWait 记录队列等待时间
+func (m *pushMetrics) ObserveQueueWait(ctx context.Context, priority bo.Priority, d time.Duration) {
+	m.queueWait.Record(ctx, d.Seconds(), metric.WithAttributes(attribute.String("priority", priority.String())))
+}
+
+// ObserveDelivery 记录处理耗时和消息数
+func (m *pushMetrics) ObserveDelivery(ctx context.Context, priority bo.Priority, d time.Duration, messages int) {
+	attrs := metric.WithAttributes(attribute.String("priority", priority.String()))
+	m.delivery.Record(ctx, d.Seconds(), attrs)
+	m.messages.Add(ctx, int64(messages), attrs)
+}